@@ -61,15 +61,13 @@ func (bc *BanCmd) RequiresRoom() {}
 func (bc *BanCmd) Run() error {
 	action := fmt.Sprintf("ban player \"%s\"", bc.player)
 	if !util.ConfirmationPrompt(action) {
-		fmt.Println("Operation cancelled. No action taken.")
-		return nil
+		return bc.PrintCancelled()
 	}
 	if err := bc.Client.BanPlayer(bc.player); err != nil {
 		return err
 	}
 
-	fmt.Printf("You've banned \"%s\".\n", bc.player)
-	return nil
+	return bc.PrintMessage(fmt.Sprintf("You've banned \"%s\".", bc.player))
 }
 
 func (bc *BanCmd) buildCommand() *cobra.Command {
@@ -61,15 +61,13 @@ func (kc *KickCmd) RequiresRoom() {}
 func (kc *KickCmd) Run() error {
 	action := fmt.Sprintf("kick player \"%s\" from the game", kc.player)
 	if !util.ConfirmationPrompt(action) {
-		fmt.Println("Operation cancelled. No action taken.")
-		return nil
+		return kc.PrintCancelled()
 	}
 	if err := kc.Client.KickPlayer(kc.player); err != nil {
 		return err
 	}
 
-	fmt.Printf("You've kicked \"%s\" from the game.\n", kc.player)
-	return nil
+	return kc.PrintMessage(fmt.Sprintf("You've kicked \"%s\" from the game.", kc.player))
 }
 
 func (kc *KickCmd) buildCommand() *cobra.Command {
@@ -0,0 +1,88 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pavelhadzhiev/story-builder/cmd"
+	"github.com/spf13/cobra"
+)
+
+// MuteCmd is a wrapper for the story-builder mute command
+type MuteCmd struct {
+	*cmd.Context
+
+	player   string
+	duration time.Duration
+}
+
+// Command builds and returns a cobra command that will be added to the root command
+func (mc *MuteCmd) Command() *cobra.Command {
+	result := mc.buildCommand()
+
+	return result
+}
+
+// Validate makes sure all required arguments are legal and are provided
+func (mc *MuteCmd) Validate(args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("requires a single arg")
+	}
+	if mc.duration < 0 || (mc.duration > 0 && mc.duration < time.Second) {
+		return fmt.Errorf("the mute duration must be at least a second")
+	}
+
+	mc.player = args[0]
+	return nil
+}
+
+// RequiresConnection makes sure that the configured server is valid and online before executing the command logic
+func (mc *MuteCmd) RequiresConnection() *cmd.Context {
+	return mc.Context
+}
+
+// RequiresAuthorization marks the command to require the configuration to have a user logged in.
+func (mc *MuteCmd) RequiresAuthorization() {}
+
+// RequiresRoom marks the command to require the configuration to have a user logged in.
+func (mc *MuteCmd) RequiresRoom() {}
+
+// Run is used to build the RunE function for the cobra command
+func (mc *MuteCmd) Run() error {
+	if err := mc.Client.MutePlayer(mc.player, int(mc.duration/time.Second)); err != nil {
+		return err
+	}
+
+	if mc.duration > 0 {
+		return mc.PrintMessage(fmt.Sprintf("You've muted \"%s\" for %v.", mc.player, mc.duration))
+	}
+	return mc.PrintMessage(fmt.Sprintf("You've muted \"%s\". Use the unmute command to lift the mute.", mc.player))
+}
+
+func (mc *MuteCmd) buildCommand() *cobra.Command {
+	var muteCmd = &cobra.Command{
+		Use:     "mute [player]",
+		Short:   "An admin command that mutes the player provided as argument.",
+		Long:    `An admin command that mutes the player provided as argument in the current room. Muted players stay in the room and can watch, but their turns are skipped and they can't post in the chat or trigger votes. Use the --duration flag to lift the mute automatically, e.g. --duration 10m. Admins can't be muted. Returns error if you don't have admin access for the room.`,
+		PreRunE: cmd.PreRunE(mc),
+		RunE:    cmd.RunE(mc),
+	}
+
+	muteCmd.Flags().DurationVarP(&mc.duration, "duration", "d", 0, "how long the mute lasts, e.g. 30s or 10m. Default is until the player is unmuted")
+
+	return muteCmd
+}
@@ -61,15 +61,13 @@ func (pc *PromoteCmd) RequiresRoom() {}
 func (pc *PromoteCmd) Run() error {
 	action := fmt.Sprintf("promote user \"%s\" to admin", pc.user)
 	if !util.ConfirmationPrompt(action) {
-		fmt.Println("Operation cancelled. No action taken.")
-		return nil
+		return pc.PrintCancelled()
 	}
 	if err := pc.Client.PromoteAdmin(pc.user); err != nil {
 		return err
 	}
 
-	fmt.Printf("You've promoted \"%s\" to admin.\n", pc.user)
-	return nil
+	return pc.PrintMessage(fmt.Sprintf("You've promoted \"%s\" to admin.", pc.user))
 }
 
 func (pc *PromoteCmd) buildCommand() *cobra.Command {
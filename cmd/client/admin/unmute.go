@@ -0,0 +1,78 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"fmt"
+
+	"github.com/pavelhadzhiev/story-builder/cmd"
+	"github.com/spf13/cobra"
+)
+
+// UnmuteCmd is a wrapper for the story-builder unmute command
+type UnmuteCmd struct {
+	*cmd.Context
+
+	player string
+}
+
+// Command builds and returns a cobra command that will be added to the root command
+func (uc *UnmuteCmd) Command() *cobra.Command {
+	result := uc.buildCommand()
+
+	return result
+}
+
+// Validate makes sure all required arguments are legal and are provided
+func (uc *UnmuteCmd) Validate(args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("requires a single arg")
+	}
+
+	uc.player = args[0]
+	return nil
+}
+
+// RequiresConnection makes sure that the configured server is valid and online before executing the command logic
+func (uc *UnmuteCmd) RequiresConnection() *cmd.Context {
+	return uc.Context
+}
+
+// RequiresAuthorization marks the command to require the configuration to have a user logged in.
+func (uc *UnmuteCmd) RequiresAuthorization() {}
+
+// RequiresRoom marks the command to require the configuration to have a user logged in.
+func (uc *UnmuteCmd) RequiresRoom() {}
+
+// Run is used to build the RunE function for the cobra command
+func (uc *UnmuteCmd) Run() error {
+	if err := uc.Client.UnmutePlayer(uc.player); err != nil {
+		return err
+	}
+
+	return uc.PrintMessage(fmt.Sprintf("You've unmuted \"%s\".", uc.player))
+}
+
+func (uc *UnmuteCmd) buildCommand() *cobra.Command {
+	var unmuteCmd = &cobra.Command{
+		Use:     "unmute [player]",
+		Short:   "An admin command that lifts the mute of the player provided as argument.",
+		Long:    `An admin command that lifts the mute of the player provided as argument in the current room. Returns error if the player is not muted or you don't have admin access for the room.`,
+		PreRunE: cmd.PreRunE(uc),
+		RunE:    cmd.RunE(uc),
+	}
+
+	return unmuteCmd
+}
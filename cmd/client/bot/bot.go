@@ -0,0 +1,44 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bot
+
+import (
+	"github.com/pavelhadzhiev/story-builder/cmd"
+	"github.com/spf13/cobra"
+)
+
+// BotCmd is a wrapper for the story-builder bot command group
+type BotCmd struct {
+	*cmd.Context
+}
+
+// Command builds and returns a cobra command that will be added to the root command
+func (bc *BotCmd) Command() *cobra.Command {
+	result := bc.buildCommand()
+
+	return result
+}
+
+func (bc *BotCmd) buildCommand() *cobra.Command {
+	var botCmd = &cobra.Command{
+		Use:   "bot",
+		Short: "Manages bot players.",
+		Long:  `Manages bot players, which play through the regular client API. Bots are useful to fill empty seats and for load testing.`,
+	}
+
+	botCmd.AddCommand((&RunBotCmd{Context: bc.Context}).Command())
+
+	return botCmd
+}
@@ -0,0 +1,174 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bot
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/pavelhadzhiev/story-builder/cmd"
+	"github.com/pavelhadzhiev/story-builder/pkg/bot"
+	"github.com/pavelhadzhiev/story-builder/pkg/client"
+	"github.com/pavelhadzhiev/story-builder/pkg/config"
+	"github.com/pavelhadzhiev/story-builder/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+// RunBotCmd is a wrapper for the story-builder bot run command
+type RunBotCmd struct {
+	*cmd.Context
+
+	room     string
+	strategy string
+	name     string
+	password string
+	count    int
+}
+
+// Command builds and returns a cobra command that will be added to the root command
+func (rbc *RunBotCmd) Command() *cobra.Command {
+	result := rbc.buildCommand()
+
+	return result
+}
+
+// Validate makes sure all required arguments are legal and are provided
+func (rbc *RunBotCmd) Validate(args []string) error {
+	if len(args) != 0 {
+		return errors.New("requires no args")
+	}
+	if rbc.room == "" {
+		return errors.New("requires a room to play in")
+	}
+	if rbc.count < 1 {
+		return errors.New("requires at least one bot")
+	}
+	if _, err := bot.NewStrategy(rbc.strategy); err != nil {
+		return err
+	}
+	if rbc.name == "" {
+		rbc.name = strings.ToLower(rbc.strategy) + "-bot"
+	}
+	return nil
+}
+
+// RequiresConnection makes sure that the configured server is valid and online before executing the command logic
+func (rbc *RunBotCmd) RequiresConnection() *cmd.Context {
+	return rbc.Context
+}
+
+// Run is used to build the RunE function for the cobra command
+func (rbc *RunBotCmd) Run() error {
+	cfg, err := rbc.Configurator.Load()
+	if err != nil {
+		return err
+	}
+	if rbc.password == "" {
+		if rbc.password, err = util.ReadPassword(); err != nil {
+			return err
+		}
+	}
+
+	bots := make([]*bot.Bot, 0, rbc.count)
+	clients := make([]*client.SBClient, 0, rbc.count)
+	defer func() {
+		for _, botClient := range clients {
+			botClient.LeaveRoom(rbc.room)
+			botClient.Logout()
+		}
+	}()
+	for index := 1; index <= rbc.count; index++ {
+		name := rbc.name
+		if rbc.count > 1 {
+			name = fmt.Sprintf("%s-%d", rbc.name, index)
+		}
+
+		botClient := client.NewSBClient(&config.SBConfiguration{
+			URL:           cfg.URL,
+			Authorization: "Basic " + base64.StdEncoding.EncodeToString([]byte(name+":"+rbc.password)),
+			Room:          rbc.room,
+
+			CAFile:             cfg.CAFile,
+			InsecureSkipVerify: cfg.InsecureSkipVerify,
+		})
+		if err := signIn(botClient); err != nil {
+			return fmt.Errorf("bot \"%s\" cannot sign in: %v", name, err)
+		}
+		clients = append(clients, botClient)
+		if err := botClient.JoinRoom(rbc.room); err != nil {
+			return fmt.Errorf("bot \"%s\" cannot join the room: %v", name, err)
+		}
+
+		strategy, _ := bot.NewStrategy(rbc.strategy)
+		sbBot := bot.NewBot(botClient, name, strategy)
+		sbBot.Output = rbc.Progress()
+		bots = append(bots, sbBot)
+	}
+
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	for _, sbBot := range bots {
+		wg.Add(1)
+		go func(sbBot *bot.Bot) {
+			defer wg.Done()
+			sbBot.Run(stop)
+		}(sbBot)
+	}
+
+	fmt.Fprintf(rbc.Progress(), "%d bot(s) playing in room \"%s\" with the %s strategy. Press Ctrl+C to stop...\n", len(bots), rbc.room, rbc.strategy)
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt, syscall.SIGTERM)
+	<-interrupt
+	close(stop)
+	wg.Wait()
+
+	return rbc.PrintMessage("Bots stopped and left the room.")
+}
+
+// signIn logs the bot in, registering it first if it doesn't have an account yet.
+func signIn(botClient *client.SBClient) error {
+	loginErr := botClient.Login()
+	if loginErr == nil || errors.Is(loginErr, client.ErrAlreadyLoggedIn) {
+		return nil
+	}
+	if err := botClient.Register(); err != nil {
+		return loginErr
+	}
+	return nil
+}
+
+func (rbc *RunBotCmd) buildCommand() *cobra.Command {
+	var runBotCmd = &cobra.Command{
+		Use:     "run",
+		Short:   "Runs bot players in a room.",
+		Long:    `Runs bot players in the provided room until interrupted. Bots sign in with the provided name and password, registering if they don't have an account yet. They ready up in lobbies and write an entry whenever it's their turn, following the game's max length and constraints. Available strategies are "markov", which generates entries from a Markov chain trained on the room's previous stories, and "canned", which picks from a list of phrases. Bots run fully offline.`,
+		PreRunE: cmd.PreRunE(rbc),
+		RunE:    cmd.RunE(rbc),
+	}
+
+	runBotCmd.Flags().StringVar(&rbc.room, "room", "", "the room for the bots to play in")
+	runBotCmd.Flags().StringVarP(&rbc.strategy, "strategy", "s", "markov", "the strategy of the bots - "+strings.Join(bot.StrategyNames(), " or "))
+	runBotCmd.Flags().StringVarP(&rbc.name, "name", "n", "", "the username of the bot, suffixed with a number when running several. Default is \"<strategy>-bot\"")
+	runBotCmd.Flags().StringVarP(&rbc.password, "password", "p", "", "the password of the bot accounts")
+	runBotCmd.Flags().IntVar(&rbc.count, "count", 1, "the number of bots to run")
+
+	return runBotCmd
+}
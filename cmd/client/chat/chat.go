@@ -0,0 +1,141 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chat
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/pavelhadzhiev/story-builder/cmd"
+	"github.com/spf13/cobra"
+)
+
+// ChatCmd is a wrapper for the story-builder chat command
+type ChatCmd struct {
+	*cmd.Context
+
+	since    int
+	follow   bool
+	interval time.Duration
+}
+
+// Command builds and returns a cobra command that will be added to the root command
+func (cc *ChatCmd) Command() *cobra.Command {
+	result := cc.buildCommand()
+
+	return result
+}
+
+// Validate makes sure all required arguments are legal and are provided
+func (cc *ChatCmd) Validate(args []string) error {
+	if len(args) != 0 {
+		return errors.New("requires no args")
+	}
+	if cc.since < 0 {
+		return errors.New("the --since flag can't be negative")
+	}
+	if cc.interval <= 0 {
+		return errors.New("the --interval flag must be positive")
+	}
+	return nil
+}
+
+// RequiresConnection makes sure that the configured server is valid and online before executing the command logic
+func (cc *ChatCmd) RequiresConnection() *cmd.Context {
+	return cc.Context
+}
+
+// RequiresAuthorization marks the command to require the configuration to have a user logged in.
+func (cc *ChatCmd) RequiresAuthorization() {}
+
+// RequiresRoom marks the command to require the configuration to have a user logged in.
+func (cc *ChatCmd) RequiresRoom() {}
+
+// Run is used to build the RunE function for the cobra command
+func (cc *ChatCmd) Run() error {
+	if !cc.follow {
+		messages, err := cc.Client.GetMessages(cc.since)
+		if err != nil {
+			return err
+		}
+		table := &cmd.Table{Headers: []string{"ID", "TIME", "AUTHOR", "TEXT"}}
+		for _, message := range messages {
+			table.AddRow(message.ID, message.Time.Local().Format("15:04:05"), message.Author, message.Text)
+		}
+		return cc.Print(messages, table, func() {
+			if len(messages) == 0 {
+				fmt.Println("No new messages in the room chat.")
+			}
+			for _, message := range messages {
+				fmt.Println(message)
+			}
+		})
+	}
+
+	cursor, err := cc.printMessages(cc.since)
+	if err != nil {
+		return err
+	}
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt, syscall.SIGTERM)
+	ticker := time.NewTicker(cc.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-interrupt:
+			return nil
+		case <-ticker.C:
+			if cursor, err = cc.printMessages(cursor); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// printMessages prints the messages posted after the provided cursor one by one and returns the ID of the last one as the new cursor.
+func (cc *ChatCmd) printMessages(cursor int) (int, error) {
+	messages, err := cc.Client.GetMessages(cursor)
+	if err != nil {
+		return cursor, err
+	}
+	for _, message := range messages {
+		if err := cc.PrintItem(message, func() { fmt.Println(message) }); err != nil {
+			return cursor, err
+		}
+		cursor = message.ID
+	}
+	return cursor, nil
+}
+
+func (cc *ChatCmd) buildCommand() *cobra.Command {
+	var chatCmd = &cobra.Command{
+		Use:     "chat",
+		Short:   "Prints the chat of the joined room.",
+		Long:    `Prints the chat of the joined room. The room keeps its last 200 messages. Use the --since flag with the ID of a message to print only the messages after it, or the --follow flag to keep printing new messages as they arrive until interrupted.`,
+		PreRunE: cmd.PreRunE(cc),
+		RunE:    cmd.RunE(cc),
+	}
+
+	chatCmd.Flags().IntVar(&cc.since, "since", 0, "print only the messages after the message with this ID")
+	chatCmd.Flags().BoolVarP(&cc.follow, "follow", "f", false, "keep printing new messages until interrupted")
+	chatCmd.Flags().DurationVar(&cc.interval, "interval", 2*time.Second, "how often to check for new messages when following")
+
+	return chatCmd
+}
@@ -0,0 +1,78 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chat
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pavelhadzhiev/story-builder/cmd"
+	"github.com/spf13/cobra"
+)
+
+// SayCmd is a wrapper for the story-builder say command
+type SayCmd struct {
+	*cmd.Context
+
+	message string
+}
+
+// Command builds and returns a cobra command that will be added to the root command
+func (sc *SayCmd) Command() *cobra.Command {
+	result := sc.buildCommand()
+
+	return result
+}
+
+// Validate makes sure all required arguments are legal and are provided
+func (sc *SayCmd) Validate(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("requires a message")
+	}
+
+	sc.message = strings.Join(args, " ")
+	return nil
+}
+
+// RequiresConnection makes sure that the configured server is valid and online before executing the command logic
+func (sc *SayCmd) RequiresConnection() *cmd.Context {
+	return sc.Context
+}
+
+// RequiresAuthorization marks the command to require the configuration to have a user logged in.
+func (sc *SayCmd) RequiresAuthorization() {}
+
+// RequiresRoom marks the command to require the configuration to have a user logged in.
+func (sc *SayCmd) RequiresRoom() {}
+
+// Run is used to build the RunE function for the cobra command
+func (sc *SayCmd) Run() error {
+	if _, err := sc.Client.SendMessage(sc.message); err != nil {
+		return err
+	}
+
+	return sc.PrintMessage("You've successfully sent your message.", "You can use the chat command to read the room chat.")
+}
+
+func (sc *SayCmd) buildCommand() *cobra.Command {
+	var sayCmd = &cobra.Command{
+		Use:     "say [message]",
+		Short:   "Sends a message in the chat of the joined room.",
+		Long:    `Sends a message in the chat of the joined room. The chat is separate from the story and is seen only by players in the room. Messages can be up to 280 symbols long. If you are muted in the room, returns error.`,
+		PreRunE: cmd.PreRunE(sc),
+		RunE:    cmd.RunE(sc),
+	}
+	return sayCmd
+}
@@ -0,0 +1,72 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"github.com/pavelhadzhiev/story-builder/cmd"
+	"github.com/pavelhadzhiev/story-builder/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+// DeleteAccountCmd is a wrapper for the story-builder delete-account command
+type DeleteAccountCmd struct {
+	*cmd.Context
+}
+
+// Command builds and returns a cobra command that will be added to the root command
+func (dac *DeleteAccountCmd) Command() *cobra.Command {
+	result := dac.buildCommand()
+
+	return result
+}
+
+// RequiresConnection makes sure that the configured server is valid and online before executing the command logic
+func (dac *DeleteAccountCmd) RequiresConnection() *cmd.Context {
+	return dac.Context
+}
+
+// RequiresAuthorization marks the command to require the configuration to have a user logged in.
+func (dac *DeleteAccountCmd) RequiresAuthorization() {}
+
+// Run is used to build the RunE function for the cobra command
+func (dac *DeleteAccountCmd) Run() error {
+	if !util.ConfirmationPrompt("permanently delete your account") {
+		return dac.PrintCancelled()
+	}
+
+	cfg, err := dac.Configurator.Load()
+	if err != nil {
+		return err
+	}
+	if err := dac.Client.DeleteAccount(); err != nil {
+		return err
+	}
+	cfg.Authorization = ""
+	cfg.Room = ""
+	dac.Configurator.Save(cfg)
+
+	return dac.PrintMessage("Your account has been deleted. Goodbye!")
+}
+
+func (dac *DeleteAccountCmd) buildCommand() *cobra.Command {
+	var deleteAccountCmd = &cobra.Command{
+		Use:     "delete-account",
+		Short:   "Deletes the account of the logged in user.",
+		Long:    `Deletes the account of the logged in user. You are logged out and removed from all rooms, and your entries in the stories are no longer attributed to you. This can't be undone.`,
+		PreRunE: cmd.PreRunE(dac),
+		RunE:    cmd.RunE(dac),
+	}
+	return deleteAccountCmd
+}
@@ -62,9 +62,7 @@ func (aec *AddEntryCmd) Run() error {
 		return err
 	}
 
-	fmt.Println("You've successfully submitted your entry.")
-	fmt.Println("You can use the get-game command to check the story status.")
-	return nil
+	return aec.PrintMessage("You've successfully submitted your entry.", "You can use the get-game command to check the story status.")
 }
 
 func (aec *AddEntryCmd) buildCommand() *cobra.Command {
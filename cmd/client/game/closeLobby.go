@@ -0,0 +1,67 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package game
+
+import (
+	"github.com/pavelhadzhiev/story-builder/cmd"
+	"github.com/pavelhadzhiev/story-builder/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+// CloseLobbyCmd is a wrapper for the story-builder close-lobby command
+type CloseLobbyCmd struct {
+	*cmd.Context
+}
+
+// Command builds and returns a cobra command that will be added to the root command
+func (clc *CloseLobbyCmd) Command() *cobra.Command {
+	result := clc.buildCommand()
+
+	return result
+}
+
+// RequiresConnection makes sure that the configured server is valid and online before executing the command logic
+func (clc *CloseLobbyCmd) RequiresConnection() *cmd.Context {
+	return clc.Context
+}
+
+// RequiresAuthorization marks the command to require the configuration to have a user logged in.
+func (clc *CloseLobbyCmd) RequiresAuthorization() {}
+
+// RequiresRoom marks the command to require the configuration to have a user logged in.
+func (clc *CloseLobbyCmd) RequiresRoom() {}
+
+// Run is used to build the RunE function for the cobra command
+func (clc *CloseLobbyCmd) Run() error {
+	if !util.ConfirmationPrompt("close the lobby without starting the game") {
+		return clc.PrintCancelled()
+	}
+	if err := clc.Client.CloseLobby(); err != nil {
+		return err
+	}
+
+	return clc.PrintMessage("You've successfully closed the lobby.")
+}
+
+func (clc *CloseLobbyCmd) buildCommand() *cobra.Command {
+	var closeLobbyCmd = &cobra.Command{
+		Use:     "close-lobby",
+		Short:   "Closes the open lobby without starting the game.",
+		Long:    `Closes the open lobby in the joined room without starting the game. Requires admin access. Returns error if there isn't an open lobby.`,
+		PreRunE: cmd.PreRunE(clc),
+		RunE:    cmd.RunE(clc),
+	}
+	return closeLobbyCmd
+}
@@ -70,15 +70,13 @@ func (egc *EndGameCmd) RequiresRoom() {}
 func (egc *EndGameCmd) Run() error {
 	action := fmt.Sprintf("end game in room after %d moves", egc.entriesCount)
 	if !util.ConfirmationPrompt(action) {
-		fmt.Println("Operation cancelled. No action taken.")
-		return nil
+		return egc.PrintCancelled()
 	}
 	if err := egc.Client.EndGame(egc.entriesCount); err != nil {
 		return err
 	}
 
-	fmt.Println("You've successfully triggered a game end.")
-	return nil
+	return egc.PrintMessage("You've successfully triggered a game end.")
 }
 
 func (egc *EndGameCmd) buildCommand() *cobra.Command {
@@ -0,0 +1,107 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package game
+
+import (
+	"fmt"
+
+	"github.com/pavelhadzhiev/story-builder/cmd"
+	"github.com/pavelhadzhiev/story-builder/pkg/api/game"
+	"github.com/spf13/cobra"
+)
+
+// ForkGameCmd is a wrapper for the story-builder fork command
+type ForkGameCmd struct {
+	*cmd.Context
+
+	gameID       int
+	at           int
+	timeLimit    int
+	maxLength    int
+	entriesCount int
+
+	requiredWords []string
+	bannedLetters string
+	rhyme         bool
+}
+
+// Command builds and returns a cobra command that will be added to the root command
+func (fgc *ForkGameCmd) Command() *cobra.Command {
+	result := fgc.buildCommand()
+
+	return result
+}
+
+// Validate makes sure all required arguments are legal and are provided
+func (fgc *ForkGameCmd) Validate(args []string) error {
+	if len(args) != 0 {
+		return fmt.Errorf("requires no args")
+	}
+	if fgc.at <= 0 {
+		return fmt.Errorf("requires the --at flag to be a positive entry number")
+	}
+	if fgc.gameID < 0 {
+		return fmt.Errorf("the --game flag can't be negative")
+	}
+	return nil
+}
+
+// RequiresConnection makes sure that the configured server is valid and online before executing the command logic
+func (fgc *ForkGameCmd) RequiresConnection() *cmd.Context {
+	return fgc.Context
+}
+
+// RequiresAuthorization marks the command to require the configuration to have a user logged in.
+func (fgc *ForkGameCmd) RequiresAuthorization() {}
+
+// RequiresRoom marks the command to require the configuration to have a user logged in.
+func (fgc *ForkGameCmd) RequiresRoom() {}
+
+// Run is used to build the RunE function for the cobra command
+func (fgc *ForkGameCmd) Run() error {
+	constraints, err := game.NewConstraints(fgc.requiredWords, fgc.bannedLetters, fgc.rhyme)
+	if err != nil {
+		return err
+	}
+
+	if err := fgc.Client.ForkGame(fgc.gameID, fgc.at, fgc.timeLimit, fgc.maxLength, fgc.entriesCount, constraints); err != nil {
+		return err
+	}
+
+	return fgc.PrintMessage(fmt.Sprintf("You've successfully forked the story at entry %d.", fgc.at), "You can use the get-game and add-entry commands to play.")
+}
+
+func (fgc *ForkGameCmd) buildCommand() *cobra.Command {
+	var forkGameCmd = &cobra.Command{
+		Use:     "fork",
+		Aliases: []string{"fg"},
+		Short:   "Starts a game in the joined room that continues a finished story from one of its entries.",
+		Long:    `Starts a game in the joined room that continues a finished story from one of its entries. The new story starts with the entries of the finished one, up to and including the entry provided with the --at flag (counting from 1). By default the last finished game is forked - use the --game flag to fork an older one by its ID (see story-tree). Requires admin access. If a game is already running, returns error. Supports the same game settings as the start-game command, except for prompts and teams.`,
+		PreRunE: cmd.PreRunE(fgc),
+		RunE:    cmd.RunE(fgc),
+	}
+
+	forkGameCmd.Flags().IntVar(&fgc.at, "at", 0, "the number of the last entry to keep from the forked story")
+	forkGameCmd.Flags().IntVarP(&fgc.gameID, "game", "g", 0, "the ID of the game to fork. Default is the last finished game")
+	forkGameCmd.Flags().IntVarP(&fgc.timeLimit, "time", "t", 60, "the time limit to complete a turn in seconds")
+	forkGameCmd.Flags().IntVarP(&fgc.maxLength, "length", "l", 100, "the max length for an entry in symbols")
+	forkGameCmd.Flags().IntVarP(&fgc.entriesCount, "entires", "e", 0, "the amount of entries that will be played out before the game ends")
+
+	forkGameCmd.Flags().StringSliceVarP(&fgc.requiredWords, "required-words", "w", nil, "words that entries must contain - one per entry, rotating through the list")
+	forkGameCmd.Flags().StringVarP(&fgc.bannedLetters, "banned-letters", "b", "", "letters that entries must not use")
+	forkGameCmd.Flags().BoolVarP(&fgc.rhyme, "rhyme", "r", false, "require the last word of each entry to rhyme with the last word of the previous one")
+
+	return forkGameCmd
+}
@@ -24,6 +24,8 @@ import (
 // GetGameCmd is a wrapper for the story-builder story command
 type GetGameCmd struct {
 	*cmd.Context
+
+	id int
 }
 
 // Command builds and returns a cobra command that will be added to the root command
@@ -47,11 +49,15 @@ func (ggc *GetGameCmd) RequiresRoom() {}
 // Run is used to build the RunE function for the cobra command
 func (ggc *GetGameCmd) Run() error {
 	game, err := ggc.Client.GetGame()
+	if ggc.id != 0 {
+		game, err = ggc.Client.GetGameByID(ggc.id)
+	}
 	if err != nil {
 		return err
 	}
-	fmt.Println(game)
-	return nil
+	return ggc.Print(game, nil, func() {
+		fmt.Println(game)
+	})
 }
 
 func (ggc *GetGameCmd) buildCommand() *cobra.Command {
@@ -59,9 +65,12 @@ func (ggc *GetGameCmd) buildCommand() *cobra.Command {
 		Use:     "get-game",
 		Aliases: []string{"game"},
 		Short:   "Prints the status of the current or last played game.",
-		Long:    `Prints the status of the current or last played game. If no game has ever been started in the room, returns error.`,
+		Long:    `Prints the status of the current or last played game. If no game has ever been started in the room, returns error. Use the --id flag to print an older game of the room (see story-tree).`,
 		PreRunE: cmd.PreRunE(ggc),
 		RunE:    cmd.RunE(ggc),
 	}
+
+	getGameCmd.Flags().IntVar(&ggc.id, "id", 0, "the ID of an older game to print")
+
 	return getGameCmd
 }
@@ -0,0 +1,64 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package game
+
+import (
+	"github.com/pavelhadzhiev/story-builder/cmd"
+	"github.com/spf13/cobra"
+)
+
+// PauseGameCmd is a wrapper for the story-builder pause-game command
+type PauseGameCmd struct {
+	*cmd.Context
+}
+
+// Command builds and returns a cobra command that will be added to the root command
+func (pgc *PauseGameCmd) Command() *cobra.Command {
+	result := pgc.buildCommand()
+
+	return result
+}
+
+// RequiresConnection makes sure that the configured server is valid and online before executing the command logic
+func (pgc *PauseGameCmd) RequiresConnection() *cmd.Context {
+	return pgc.Context
+}
+
+// RequiresAuthorization marks the command to require the configuration to have a user logged in.
+func (pgc *PauseGameCmd) RequiresAuthorization() {}
+
+// RequiresRoom marks the command to require the configuration to have a user logged in.
+func (pgc *PauseGameCmd) RequiresRoom() {}
+
+// Run is used to build the RunE function for the cobra command
+func (pgc *PauseGameCmd) Run() error {
+	if err := pgc.Client.PauseGame(); err != nil {
+		return err
+	}
+
+	return pgc.PrintMessage("You've successfully paused the game.", "You can use the resume-game command to continue playing.")
+}
+
+func (pgc *PauseGameCmd) buildCommand() *cobra.Command {
+	var pauseGameCmd = &cobra.Command{
+		Use:     "pause-game",
+		Aliases: []string{"pause"},
+		Short:   "Pauses the game in the joined room.",
+		Long:    `Pauses the game in the joined room. Requires admin access. The turn timer and any ongoing vote are frozen and no entries are accepted until the game is resumed. Returns error if a game is not running or is already paused.`,
+		PreRunE: cmd.PreRunE(pgc),
+		RunE:    cmd.RunE(pgc),
+	}
+	return pauseGameCmd
+}
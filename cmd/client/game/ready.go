@@ -0,0 +1,63 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package game
+
+import (
+	"github.com/pavelhadzhiev/story-builder/cmd"
+	"github.com/spf13/cobra"
+)
+
+// ReadyCmd is a wrapper for the story-builder ready command
+type ReadyCmd struct {
+	*cmd.Context
+}
+
+// Command builds and returns a cobra command that will be added to the root command
+func (rc *ReadyCmd) Command() *cobra.Command {
+	result := rc.buildCommand()
+
+	return result
+}
+
+// RequiresConnection makes sure that the configured server is valid and online before executing the command logic
+func (rc *ReadyCmd) RequiresConnection() *cmd.Context {
+	return rc.Context
+}
+
+// RequiresAuthorization marks the command to require the configuration to have a user logged in.
+func (rc *ReadyCmd) RequiresAuthorization() {}
+
+// RequiresRoom marks the command to require the configuration to have a user logged in.
+func (rc *ReadyCmd) RequiresRoom() {}
+
+// Run is used to build the RunE function for the cobra command
+func (rc *ReadyCmd) Run() error {
+	if err := rc.Client.Ready(); err != nil {
+		return err
+	}
+
+	return rc.PrintMessage("You're ready! You will take part in the game once it starts.", "You can use the get-game command to check the lobby status.")
+}
+
+func (rc *ReadyCmd) buildCommand() *cobra.Command {
+	var readyCmd = &cobra.Command{
+		Use:     "ready",
+		Short:   "Marks you as ready in the open lobby.",
+		Long:    `Marks you as ready in the open lobby of the joined room. Only ready players take part in the game once it starts. Returns error if there isn't an open lobby or you are already ready.`,
+		PreRunE: cmd.PreRunE(rc),
+		RunE:    cmd.RunE(rc),
+	}
+	return readyCmd
+}
@@ -0,0 +1,64 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package game
+
+import (
+	"github.com/pavelhadzhiev/story-builder/cmd"
+	"github.com/spf13/cobra"
+)
+
+// ResumeGameCmd is a wrapper for the story-builder resume-game command
+type ResumeGameCmd struct {
+	*cmd.Context
+}
+
+// Command builds and returns a cobra command that will be added to the root command
+func (rgc *ResumeGameCmd) Command() *cobra.Command {
+	result := rgc.buildCommand()
+
+	return result
+}
+
+// RequiresConnection makes sure that the configured server is valid and online before executing the command logic
+func (rgc *ResumeGameCmd) RequiresConnection() *cmd.Context {
+	return rgc.Context
+}
+
+// RequiresAuthorization marks the command to require the configuration to have a user logged in.
+func (rgc *ResumeGameCmd) RequiresAuthorization() {}
+
+// RequiresRoom marks the command to require the configuration to have a user logged in.
+func (rgc *ResumeGameCmd) RequiresRoom() {}
+
+// Run is used to build the RunE function for the cobra command
+func (rgc *ResumeGameCmd) Run() error {
+	if err := rgc.Client.ResumeGame(); err != nil {
+		return err
+	}
+
+	return rgc.PrintMessage("You've successfully resumed the game.", "You can use the get-game and add-entry commands to play.")
+}
+
+func (rgc *ResumeGameCmd) buildCommand() *cobra.Command {
+	var resumeGameCmd = &cobra.Command{
+		Use:     "resume-game",
+		Aliases: []string{"resume"},
+		Short:   "Resumes the paused game in the joined room.",
+		Long:    `Resumes the paused game in the joined room. Requires admin access. The turn timer and any ongoing vote continue from where they were frozen. Returns error if a game is not running or is not paused.`,
+		PreRunE: cmd.PreRunE(rgc),
+		RunE:    cmd.RunE(rgc),
+	}
+	return resumeGameCmd
+}
@@ -15,9 +15,10 @@
 package game
 
 import (
-	"fmt"
+	"errors"
 
 	"github.com/pavelhadzhiev/story-builder/cmd"
+	"github.com/pavelhadzhiev/story-builder/pkg/api/game"
 	"github.com/spf13/cobra"
 )
 
@@ -28,6 +29,17 @@ type StartGameCmd struct {
 	timeLimit    int
 	maxLength    int
 	entriesCount int
+	prompt       string
+
+	requiredWords []string
+	bannedLetters string
+	rhyme         bool
+
+	teams string
+
+	lobby     bool
+	quorum    float64
+	countdown int
 }
 
 // Command builds and returns a cobra command that will be added to the root command
@@ -50,13 +62,35 @@ func (sgc *StartGameCmd) RequiresRoom() {}
 
 // Run is used to build the RunE function for the cobra command
 func (sgc *StartGameCmd) Run() error {
-	if err := sgc.Client.StartGame(sgc.timeLimit, sgc.maxLength, sgc.entriesCount); err != nil {
+	constraints, err := game.NewConstraints(sgc.requiredWords, sgc.bannedLetters, sgc.rhyme)
+	if err != nil {
 		return err
 	}
 
-	fmt.Println("You've successfully started a game.")
-	fmt.Println("You can use the get-game and add-entry commands to play.")
-	return nil
+	if sgc.teams != "" {
+		if sgc.lobby {
+			return errors.New("team games can't be started from a lobby")
+		}
+		if err := sgc.Client.StartTeamGame(sgc.teams, sgc.timeLimit, sgc.maxLength, sgc.entriesCount, sgc.prompt, constraints); err != nil {
+			return err
+		}
+
+		return sgc.PrintMessage("You've successfully started a team game.", "Every team builds its own story - use the get-game and add-entry commands to play.")
+	}
+
+	if sgc.lobby {
+		if err := sgc.Client.OpenLobby(sgc.timeLimit, sgc.maxLength, sgc.entriesCount, sgc.quorum, sgc.countdown, sgc.prompt, constraints); err != nil {
+			return err
+		}
+
+		return sgc.PrintMessage("You've successfully opened a lobby. The game will start once enough players are ready.", "Players can use the ready command to join the game.")
+	}
+
+	if err := sgc.Client.StartGame(sgc.timeLimit, sgc.maxLength, sgc.entriesCount, sgc.prompt, constraints); err != nil {
+		return err
+	}
+
+	return sgc.PrintMessage("You've successfully started a game.", "You can use the get-game and add-entry commands to play.")
 }
 
 func (sgc *StartGameCmd) buildCommand() *cobra.Command {
@@ -64,7 +98,7 @@ func (sgc *StartGameCmd) buildCommand() *cobra.Command {
 		Use:     "start-game",
 		Aliases: []string{"sg", "start"},
 		Short:   "Starts a game in the joined room.",
-		Long:    `Starts a game in the joined room. Requires admin access. If a game is already started, returns error. Supports configurations of time limit per turn and max length of entries. Default values are 60 seconds and 100 symbols. If you don't want to use any of these features, pass 0 with the according flag.`,
+		Long:    `Starts a game in the joined room. Requires admin access. If a game is already started, returns error. Supports configurations of time limit per turn and max length of entries. Default values are 60 seconds and 100 symbols. If you don't want to use any of these features, pass 0 with the according flag. Use the --lobby flag to open a ready-check lobby instead of starting right away - only players that run the ready command take part in the game, which starts once everyone (or the --quorum part of the room) is ready or the --countdown expires. Use the --prompt flag to open the story with a prompt from the server's prompt library - pass "random" or the ID of a prompt (see list-prompts). Constraint challenges can be enabled with the --required-words, --banned-letters and --rhyme flags - every entry that breaks them is rejected. Use the --teams flag to start a team game, in which every team builds its own story and a final vote picks the winner - pass the number of teams to split the room into, or the teams themselves, e.g. "red=alice,bob;blue=carol,dave".`,
 		PreRunE: cmd.PreRunE(sgc),
 		RunE:    cmd.RunE(sgc),
 	}
@@ -72,6 +106,17 @@ func (sgc *StartGameCmd) buildCommand() *cobra.Command {
 	startGameCmd.Flags().IntVarP(&sgc.timeLimit, "time", "t", 60, "the time limit to complete a turn in seconds")
 	startGameCmd.Flags().IntVarP(&sgc.maxLength, "length", "l", 100, "the max length for an entry in symbols")
 	startGameCmd.Flags().IntVarP(&sgc.entriesCount, "entires", "e", 0, "the amount of entries that will be played out before the game ends")
+	startGameCmd.Flags().StringVarP(&sgc.prompt, "prompt", "p", "", "seed the story with a prompt - \"random\" or a prompt ID")
+
+	startGameCmd.Flags().StringSliceVarP(&sgc.requiredWords, "required-words", "w", nil, "words that entries must contain - one per entry, rotating through the list")
+	startGameCmd.Flags().StringVarP(&sgc.bannedLetters, "banned-letters", "b", "", "letters that entries must not use")
+	startGameCmd.Flags().BoolVarP(&sgc.rhyme, "rhyme", "r", false, "require the last word of each entry to rhyme with the last word of the previous one")
+
+	startGameCmd.Flags().StringVar(&sgc.teams, "teams", "", "start a team game - the number of teams or the teams in the format \"name=player1,player2;name2=player3\"")
+
+	startGameCmd.Flags().BoolVar(&sgc.lobby, "lobby", false, "open a ready-check lobby instead of starting the game right away")
+	startGameCmd.Flags().Float64VarP(&sgc.quorum, "quorum", "q", 0, "the part of the players in the room (between 0 and 1) that must be ready for the game to start. Default is everyone")
+	startGameCmd.Flags().IntVarP(&sgc.countdown, "countdown", "d", 0, "the time in seconds after which the game starts with whoever is ready")
 
 	return startGameCmd
 }
@@ -0,0 +1,81 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package game
+
+import (
+	"fmt"
+
+	"github.com/pavelhadzhiev/story-builder/cmd"
+	"github.com/pavelhadzhiev/story-builder/pkg/api/game"
+	"github.com/spf13/cobra"
+)
+
+// StoryTreeCmd is a wrapper for the story-builder story-tree command
+type StoryTreeCmd struct {
+	*cmd.Context
+}
+
+// Command builds and returns a cobra command that will be added to the root command
+func (stc *StoryTreeCmd) Command() *cobra.Command {
+	result := stc.buildCommand()
+
+	return result
+}
+
+// RequiresConnection makes sure that the configured server is valid and online before executing the command logic
+func (stc *StoryTreeCmd) RequiresConnection() *cmd.Context {
+	return stc.Context
+}
+
+// RequiresAuthorization marks the command to require the configuration to have a user logged in.
+func (stc *StoryTreeCmd) RequiresAuthorization() {}
+
+// RequiresRoom marks the command to require the configuration to have a user logged in.
+func (stc *StoryTreeCmd) RequiresRoom() {}
+
+// Run is used to build the RunE function for the cobra command
+func (stc *StoryTreeCmd) Run() error {
+	summaries, err := stc.Client.GetStoryTree()
+	if err != nil {
+		return err
+	}
+	table := &cmd.Table{Headers: []string{"ID", "FORKED FROM", "ENTRIES", "FINISHED", "OPENING"}}
+	for _, summary := range summaries {
+		forkedFrom := ""
+		if summary.ForkedFrom != nil {
+			forkedFrom = fmt.Sprintf("#%d at entry %d", summary.ForkedFrom.GameID, summary.ForkedFrom.At)
+		}
+		table.AddRow(summary.ID, forkedFrom, summary.Entries, summary.Finished, summary.Opening)
+	}
+	return stc.Print(summaries, table, func() {
+		if len(summaries) == 0 {
+			fmt.Println("No games have been played in the room yet.")
+			return
+		}
+		fmt.Print(game.FormatStoryTree(summaries))
+	})
+}
+
+func (stc *StoryTreeCmd) buildCommand() *cobra.Command {
+	var storyTreeCmd = &cobra.Command{
+		Use:     "story-tree",
+		Aliases: []string{"tree"},
+		Short:   "Prints the games played in the joined room as a tree of forked stories.",
+		Long:    `Prints the games played in the joined room as a tree of forked stories. Every game is shown with its ID, so it can be viewed with get-game --id or forked with fork --game.`,
+		PreRunE: cmd.PreRunE(stc),
+		RunE:    cmd.RunE(stc),
+	}
+	return storyTreeCmd
+}
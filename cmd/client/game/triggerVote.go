@@ -18,6 +18,7 @@ import (
 	"fmt"
 
 	"github.com/pavelhadzhiev/story-builder/cmd"
+	"github.com/pavelhadzhiev/story-builder/pkg/api/game"
 	"github.com/pavelhadzhiev/story-builder/pkg/util"
 	"github.com/spf13/cobra"
 )
@@ -26,6 +27,7 @@ import (
 type TriggerVoteCmd struct {
 	*cmd.Context
 
+	kind         string
 	playerToKick string
 }
 
@@ -38,11 +40,24 @@ func (tvc *TriggerVoteCmd) Command() *cobra.Command {
 
 // Validate makes sure all required arguments are legal and are provided
 func (tvc *TriggerVoteCmd) Validate(args []string) error {
-	if len(args) != 1 {
-		return fmt.Errorf("requires a single arg")
+	kind, err := game.ParseVoteKind(tvc.kind)
+	if err != nil {
+		return err
+	}
+	tvc.kind = string(kind)
+
+	if kind == game.KickVote {
+		if len(args) != 1 {
+			return fmt.Errorf("requires a single arg")
+		}
+		tvc.playerToKick = args[0]
+		return nil
 	}
 
-	tvc.playerToKick = args[0]
+	if len(args) != 0 {
+		return fmt.Errorf("requires no args for a vote of kind \"%s\"", kind)
+	}
+	tvc.playerToKick = ""
 	return nil
 }
 
@@ -59,28 +74,41 @@ func (tvc *TriggerVoteCmd) RequiresRoom() {}
 
 // Run is used to build the RunE function for the cobra command
 func (tvc *TriggerVoteCmd) Run() error {
-	action := fmt.Sprintf("trigger a vote to kick player \"%s\" from the game", tvc.playerToKick)
+	vote := &game.Vote{Kind: game.VoteKind(tvc.kind), Target: tvc.playerToKick}
+	action := fmt.Sprintf("trigger a vote to %s", voteActionDescription(vote))
 	if !util.ConfirmationPrompt(action) {
-		fmt.Println("Operation cancelled. No action taken.")
-		return nil
+		return tvc.PrintCancelled()
 	}
-	if err := tvc.Client.TriggerVoteKick(tvc.playerToKick); err != nil {
+	if err := tvc.Client.TriggerVote(tvc.kind, tvc.playerToKick); err != nil {
 		return err
 	}
 
-	fmt.Println("You've successfully triggered a vote to kick \"" + tvc.playerToKick + "\".")
-	fmt.Println("You can use the get-game command to check the vote status.")
-	return nil
+	return tvc.PrintMessage(fmt.Sprintf("You've successfully triggered a vote to %s.", voteActionDescription(vote)), "You can use the get-game command to check the vote status.")
 }
 
 func (tvc *TriggerVoteCmd) buildCommand() *cobra.Command {
 	var triggerVoteCmd = &cobra.Command{
 		Use:     "trigger-vote [player-to-kick]",
 		Aliases: []string{"tv"},
-		Short:   "Triggers a democratic vote to kick the provided player from the game.",
-		Long:    `Triggers a democratic vote to kick the provided player from the game. Returns error if a game is not running, the player is not in the game or there is currently an ongoing vote.`,
+		Short:   "Triggers a democratic vote in the game.",
+		Long:    `Triggers a democratic vote in the game. By default the vote is to kick the provided player from the game. Use the --kind flag to instead vote to skip the current turn ("skip"), end the game now ("end") or revert the last entry ("revert") - these kinds take no args. Several votes can run at once. Returns error if a game is not running, the player is not in the game or there is currently an ongoing vote for the same thing.`,
 		PreRunE: cmd.PreRunE(tvc),
 		RunE:    cmd.RunE(tvc),
 	}
+
+	triggerVoteCmd.Flags().StringVarP(&tvc.kind, "kind", "k", string(game.KickVote), "the kind of vote to trigger - kick, skip, end or revert")
+
 	return triggerVoteCmd
 }
+
+// voteActionDescription describes the vote action for the confirmation prompt and output, before the server has resolved the vote target.
+func voteActionDescription(vote *game.Vote) string {
+	switch vote.Kind {
+	case game.SkipVote:
+		return "skip the current turn"
+	case game.RevertVote:
+		return "revert the last entry"
+	default:
+		return vote.Description()
+	}
+}
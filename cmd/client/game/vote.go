@@ -16,6 +16,7 @@ package game
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/pavelhadzhiev/story-builder/cmd"
 	"github.com/pavelhadzhiev/story-builder/pkg/util"
@@ -25,6 +26,10 @@ import (
 // VoteCmd is a wrapper for the story-builder submit vote command
 type VoteCmd struct {
 	*cmd.Context
+
+	voteID  int
+	against bool
+	team    string
 }
 
 // Command builds and returns a cobra command that will be added to the root command
@@ -34,6 +39,31 @@ func (vc *VoteCmd) Command() *cobra.Command {
 	return result
 }
 
+// Validate makes sure all required arguments are legal and are provided
+func (vc *VoteCmd) Validate(args []string) error {
+	if len(args) > 1 {
+		return fmt.Errorf("requires a single arg or no args")
+	}
+
+	if vc.team != "" {
+		if len(args) != 0 || vc.against {
+			return fmt.Errorf("the --team flag can't be combined with a vote id or the --no flag")
+		}
+		return nil
+	}
+
+	if len(args) == 1 {
+		voteID, err := strconv.Atoi(args[0])
+		if err != nil || voteID <= 0 {
+			return fmt.Errorf("provided vote id \"%s\" is not valid", args[0])
+		}
+		vc.voteID = voteID
+	} else {
+		vc.voteID = 0 // Vote for the only ongoing vote in case an id is not provided
+	}
+	return nil
+}
+
 // RequiresConnection makes sure that the configured server is valid and online before executing the command logic
 func (vc *VoteCmd) RequiresConnection() *cmd.Context {
 	return vc.Context
@@ -47,27 +77,51 @@ func (vc *VoteCmd) RequiresRoom() {}
 
 // Run is used to build the RunE function for the cobra command
 func (vc *VoteCmd) Run() error {
-	action := "submit your approval of the currently running vote"
+	if vc.team != "" {
+		return vc.voteForTeam()
+	}
+
+	choice := "approval"
+	if vc.against {
+		choice = "disapproval"
+	}
+	action := fmt.Sprintf("submit your %s of the currently running vote", choice)
+	if vc.voteID != 0 {
+		action = fmt.Sprintf("submit your %s of vote #%d", choice, vc.voteID)
+	}
 	if !util.ConfirmationPrompt(action) {
-		fmt.Println("Operation cancelled. No action taken.")
-		return nil
+		return vc.PrintCancelled()
 	}
-	if err := vc.Client.SubmitVote(); err != nil {
+	if err := vc.Client.CastVote(vc.voteID, !vc.against); err != nil {
 		return err
 	}
 
-	fmt.Println("You've successfully cast your vote.")
-	fmt.Println("You can use the get-game command to check the vote status.")
-	return nil
+	return vc.PrintMessage("You've successfully cast your vote.", "You can use the get-game command to check the vote status.")
+}
+
+func (vc *VoteCmd) voteForTeam() error {
+	action := fmt.Sprintf("vote for the story of team \"%s\"", vc.team)
+	if !util.ConfirmationPrompt(action) {
+		return vc.PrintCancelled()
+	}
+	if err := vc.Client.VoteForTeam(vc.team); err != nil {
+		return err
+	}
+
+	return vc.PrintMessage("You've successfully cast your vote for the winning story.", "You can use the get-game command to check the results.")
 }
 
 func (vc *VoteCmd) buildCommand() *cobra.Command {
 	var submitVoteCmd = &cobra.Command{
-		Use:     "vote",
+		Use:     "vote [vote-id]",
 		Short:   "Submits your approval of the ongoing voting.",
-		Long:    `Submits your approval of the ongoing voting. Returns error if a game is not running or there is no currently ongoing vote.`,
+		Long:    `Submits your approval of the ongoing voting. If there are several ongoing votes, the id of the one to vote for must be provided - you can find it with the get-game command. Use the --no flag to vote against instead. Once all stories of a team game are finished, use the --team flag to vote for the winning story. Returns error if a game is not running or there is no such ongoing vote.`,
 		PreRunE: cmd.PreRunE(vc),
 		RunE:    cmd.RunE(vc),
 	}
+
+	submitVoteCmd.Flags().BoolVarP(&vc.against, "no", "n", false, "vote against instead of in favour")
+	submitVoteCmd.Flags().StringVar(&vc.team, "team", "", "vote for the story of the provided team in the final vote of a team game")
+
 	return submitVoteCmd
 }
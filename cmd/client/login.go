@@ -71,14 +71,14 @@ func (lc *LoginCmd) Run() error {
 	cfg.Authorization = "Basic " + base64.StdEncoding.EncodeToString([]byte(lc.username+":"+lc.password))
 	lc.Configurator.Save(cfg)
 
-	if err := client.NewSBClient(cfg).Login(); err != nil {
+	lc.Client = client.NewSBClient(cfg)
+	if err := lc.Client.Login(); err != nil {
 		cfg.Authorization = ""
 		lc.Configurator.Save(cfg)
 		return err
 	}
 
-	fmt.Printf("You've logged in successfully! Welcome back, %s.\n", lc.username)
-	return nil
+	return lc.PrintMessage(fmt.Sprintf("You've logged in successfully! Welcome back, %s.", lc.username))
 }
 
 func (lc *LoginCmd) buildCommand() *cobra.Command {
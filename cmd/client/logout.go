@@ -15,8 +15,6 @@
 package client
 
 import (
-	"fmt"
-
 	"github.com/pavelhadzhiev/story-builder/cmd"
 	"github.com/spf13/cobra"
 )
@@ -57,8 +55,7 @@ func (lc *LogoutCmd) Run() error {
 	cfg.Authorization = ""
 	lc.Configurator.Save(cfg)
 
-	fmt.Println("You've logged out successfully. See you soon!")
-	return nil
+	return lc.PrintMessage("You've logged out successfully. See you soon!")
 }
 
 func (lc *LogoutCmd) buildCommand() *cobra.Command {
@@ -0,0 +1,99 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+
+	"github.com/pavelhadzhiev/story-builder/cmd"
+	"github.com/pavelhadzhiev/story-builder/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+// PasswdCmd is a wrapper for the story-builder passwd command
+type PasswdCmd struct {
+	*cmd.Context
+
+	oldPassword string
+	newPassword string
+}
+
+// Command builds and returns a cobra command that will be added to the root command
+func (pc *PasswdCmd) Command() *cobra.Command {
+	result := pc.buildCommand()
+
+	return result
+}
+
+// RequiresConnection makes sure that the configured server is valid and online before executing the command logic
+func (pc *PasswdCmd) RequiresConnection() *cmd.Context {
+	return pc.Context
+}
+
+// RequiresAuthorization marks the command to require the configuration to have a user logged in.
+func (pc *PasswdCmd) RequiresAuthorization() {}
+
+// Run is used to build the RunE function for the cobra command
+func (pc *PasswdCmd) Run() error {
+	cfg, err := pc.Configurator.Load()
+	if err != nil {
+		return err
+	}
+	username, err := util.ExtractUsernameFromAuthorizationHeader(cfg.Authorization)
+	if err != nil {
+		return err
+	}
+	if pc.oldPassword == "" {
+		fmt.Fprintln(os.Stderr, "Enter your current password.")
+		password, err := util.ReadPassword()
+		if err != nil {
+			return err
+		}
+		pc.oldPassword = password
+	}
+	if pc.newPassword == "" {
+		fmt.Fprintln(os.Stderr, "Enter your new password.")
+		password, err := util.ReadPassword()
+		if err != nil {
+			return err
+		}
+		pc.newPassword = password
+	}
+	if err := pc.Client.ChangePassword(pc.oldPassword, pc.newPassword); err != nil {
+		return err
+	}
+
+	cfg.Authorization = "Basic " + base64.StdEncoding.EncodeToString([]byte(username+":"+pc.newPassword))
+	pc.Configurator.Save(cfg)
+
+	return pc.PrintMessage("Your password has been changed.")
+}
+
+func (pc *PasswdCmd) buildCommand() *cobra.Command {
+	var passwdCmd = &cobra.Command{
+		Use:     "passwd",
+		Short:   "Changes the password of the logged in user.",
+		Long:    `Changes the password of the logged in user. The command will prompt you to enter your current password and the new one, unless they are passed with the --old-password and --password flags. The request is rejected if the current password is wrong.`,
+		PreRunE: cmd.PreRunE(pc),
+		RunE:    cmd.RunE(pc),
+	}
+
+	passwdCmd.Flags().StringVar(&pc.oldPassword, "old-password", "", "current password of the user")
+	passwdCmd.Flags().StringVarP(&pc.newPassword, "password", "p", "", "new password of the user")
+
+	return passwdCmd
+}
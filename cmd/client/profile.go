@@ -0,0 +1,135 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"fmt"
+
+	"github.com/pavelhadzhiev/story-builder/cmd"
+	"github.com/pavelhadzhiev/story-builder/pkg/util"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// ProfileCmd is a wrapper for the story-builder profile command
+type ProfileCmd struct {
+	*cmd.Context
+
+	username      string
+	displayName   string
+	bio           string
+	email         string
+	turnReminders bool
+
+	flags *pflag.FlagSet
+}
+
+// Command builds and returns a cobra command that will be added to the root command
+func (pc *ProfileCmd) Command() *cobra.Command {
+	result := pc.buildCommand()
+
+	return result
+}
+
+// Validate makes sure all required arguments are legal and are provided
+func (pc *ProfileCmd) Validate(args []string) error {
+	if len(args) > 1 {
+		return fmt.Errorf("requires a single arg or no args")
+	}
+	if len(args) == 1 {
+		if pc.updatesProfile() {
+			return fmt.Errorf("only your own profile can be updated")
+		}
+		pc.username = args[0]
+	}
+	return nil
+}
+
+// RequiresConnection makes sure that the configured server is valid and online before executing the command logic
+func (pc *ProfileCmd) RequiresConnection() *cmd.Context {
+	return pc.Context
+}
+
+// RequiresAuthorization marks the command to require the configuration to have a user logged in.
+func (pc *ProfileCmd) RequiresAuthorization() {}
+
+// Run is used to build the RunE function for the cobra command
+func (pc *ProfileCmd) Run() error {
+	if pc.username == "" {
+		cfg, err := pc.Configurator.Load()
+		if err != nil {
+			return err
+		}
+		if pc.username, err = util.ExtractUsernameFromAuthorizationHeader(cfg.Authorization); err != nil {
+			return err
+		}
+	}
+
+	profile, err := pc.Client.GetProfile(pc.username)
+	if err != nil {
+		return err
+	}
+	if pc.updatesProfile() {
+		if pc.flags.Changed("display-name") {
+			profile.DisplayName = pc.displayName
+		}
+		if pc.flags.Changed("bio") {
+			profile.Bio = pc.bio
+		}
+		if pc.flags.Changed("email") {
+			profile.Email = pc.email
+			if pc.email == "" {
+				profile.TurnReminders = false
+			}
+		}
+		if pc.flags.Changed("turn-reminders") {
+			profile.TurnReminders = pc.turnReminders
+		}
+		if profile, err = pc.Client.UpdateProfile(profile); err != nil {
+			return err
+		}
+	}
+
+	return pc.Print(profile, nil, func() {
+		if pc.updatesProfile() {
+			fmt.Println("Your profile has been updated.")
+		}
+		fmt.Print(profile)
+	})
+}
+
+// updatesProfile returns true if any of the flags that update the profile is set.
+func (pc *ProfileCmd) updatesProfile() bool {
+	return pc.flags.Changed("display-name") || pc.flags.Changed("bio") || pc.flags.Changed("email") || pc.flags.Changed("turn-reminders")
+}
+
+func (pc *ProfileCmd) buildCommand() *cobra.Command {
+	var profileCmd = &cobra.Command{
+		Use:   "profile [user]",
+		Short: "Prints or updates the profile of a user.",
+		Long: `Prints the profile of the user provided as argument, or your own profile if no argument is provided. Use the --display-name and --bio flags to update your profile - pass an empty value to clear them.
+Your email address and turn reminders are visible only to you. Use --email and --turn-reminders to get an email when it's your turn, and --turn-reminders=false to unsubscribe.`,
+		PreRunE: cmd.PreRunE(pc),
+		RunE:    cmd.RunE(pc),
+	}
+
+	profileCmd.Flags().StringVarP(&pc.displayName, "display-name", "n", "", "your display name")
+	profileCmd.Flags().StringVarP(&pc.bio, "bio", "b", "", "a few words about yourself")
+	profileCmd.Flags().StringVar(&pc.email, "email", "", "your email address, visible only to you")
+	profileCmd.Flags().BoolVar(&pc.turnReminders, "turn-reminders", false, "email me when it's my turn, if the server supports it")
+	pc.flags = profileCmd.Flags()
+
+	return profileCmd
+}
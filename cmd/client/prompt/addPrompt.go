@@ -0,0 +1,93 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prompt
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/pavelhadzhiev/story-builder/cmd"
+	"github.com/spf13/cobra"
+)
+
+// AddPromptCmd is a wrapper for the story-builder add-prompt command
+type AddPromptCmd struct {
+	*cmd.Context
+
+	text       string
+	serverWide bool
+}
+
+// Command builds and returns a cobra command that will be added to the root command
+func (apc *AddPromptCmd) Command() *cobra.Command {
+	result := apc.buildCommand()
+
+	return result
+}
+
+// Validate makes sure all required arguments are legal and are provided
+func (apc *AddPromptCmd) Validate(args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("requires a single arg")
+	}
+
+	apc.text = args[0]
+	return nil
+}
+
+// RequiresConnection makes sure that the configured server is valid and online before executing the command logic
+func (apc *AddPromptCmd) RequiresConnection() *cmd.Context {
+	return apc.Context
+}
+
+// RequiresAuthorization marks the command to require the configuration to have a user logged in.
+func (apc *AddPromptCmd) RequiresAuthorization() {}
+
+// Run is used to build the RunE function for the cobra command
+func (apc *AddPromptCmd) Run() error {
+	roomName := ""
+	if !apc.serverWide {
+		cfg, err := apc.Configurator.Load()
+		if err != nil {
+			return err
+		}
+		if cfg.Room == "" {
+			return errors.New("user is not in a room. Join one or use the --server-wide flag")
+		}
+		roomName = cfg.Room
+	}
+
+	prompt, err := apc.Client.AddPrompt(roomName, apc.text)
+	if err != nil {
+		return err
+	}
+
+	return apc.PrintMessage(fmt.Sprintf("You've successfully added prompt #%d.", prompt.ID))
+}
+
+func (apc *AddPromptCmd) buildCommand() *cobra.Command {
+	var addPromptCmd = &cobra.Command{
+		Use:     "add-prompt [text]",
+		Aliases: []string{"ap"},
+		Short:   "Adds a prompt to the prompt library of the joined room.",
+		Long:    `Adds a prompt to the prompt library of the joined room. Requires admin access. Server admins can use the --server-wide flag to add a prompt that is available in every room.`,
+		PreRunE: cmd.PreRunE(apc),
+		RunE:    cmd.RunE(apc),
+	}
+
+	addPromptCmd.Flags().BoolVar(&apc.serverWide, "server-wide", false, "make the prompt available in every room")
+
+	return addPromptCmd
+}
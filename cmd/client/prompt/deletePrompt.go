@@ -0,0 +1,86 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prompt
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/pavelhadzhiev/story-builder/cmd"
+	"github.com/pavelhadzhiev/story-builder/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+// DeletePromptCmd is a wrapper for the story-builder delete-prompt command
+type DeletePromptCmd struct {
+	*cmd.Context
+
+	id int
+}
+
+// Command builds and returns a cobra command that will be added to the root command
+func (dpc *DeletePromptCmd) Command() *cobra.Command {
+	result := dpc.buildCommand()
+
+	return result
+}
+
+// Validate makes sure all required arguments are legal and are provided
+func (dpc *DeletePromptCmd) Validate(args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("requires a single arg")
+	}
+
+	id, err := strconv.Atoi(args[0])
+	if err != nil || id <= 0 {
+		return fmt.Errorf("illegal prompt ID \"%s\"", args[0])
+	}
+	dpc.id = id
+	return nil
+}
+
+// RequiresConnection makes sure that the configured server is valid and online before executing the command logic
+func (dpc *DeletePromptCmd) RequiresConnection() *cmd.Context {
+	return dpc.Context
+}
+
+// RequiresAuthorization marks the command to require the configuration to have a user logged in.
+func (dpc *DeletePromptCmd) RequiresAuthorization() {}
+
+// Run is used to build the RunE function for the cobra command
+func (dpc *DeletePromptCmd) Run() error {
+	action := fmt.Sprintf("delete prompt #%d", dpc.id)
+	if !util.ConfirmationPrompt(action) {
+		return dpc.PrintCancelled()
+	}
+	if err := dpc.Client.DeletePrompt(dpc.id); err != nil {
+		return err
+	}
+
+	return dpc.PrintMessage(fmt.Sprintf("You've successfully deleted prompt #%d.", dpc.id))
+}
+
+func (dpc *DeletePromptCmd) buildCommand() *cobra.Command {
+	var deletePromptCmd = &cobra.Command{
+		Use:     "delete-prompt [id]",
+		Aliases: []string{"dp"},
+		Short:   "Deletes the prompt with the provided ID.",
+		Long:    `Deletes the prompt with the provided ID from the prompt library. Requires admin access in the prompt's room.`,
+		PreRunE: cmd.PreRunE(dpc),
+		RunE:    cmd.RunE(dpc),
+	}
+
+	return deletePromptCmd
+}
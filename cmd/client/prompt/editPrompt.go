@@ -0,0 +1,83 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prompt
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/pavelhadzhiev/story-builder/cmd"
+	"github.com/spf13/cobra"
+)
+
+// EditPromptCmd is a wrapper for the story-builder edit-prompt command
+type EditPromptCmd struct {
+	*cmd.Context
+
+	id   int
+	text string
+}
+
+// Command builds and returns a cobra command that will be added to the root command
+func (epc *EditPromptCmd) Command() *cobra.Command {
+	result := epc.buildCommand()
+
+	return result
+}
+
+// Validate makes sure all required arguments are legal and are provided
+func (epc *EditPromptCmd) Validate(args []string) error {
+	if len(args) != 2 {
+		return fmt.Errorf("requires two args - prompt ID and text")
+	}
+
+	id, err := strconv.Atoi(args[0])
+	if err != nil || id <= 0 {
+		return fmt.Errorf("illegal prompt ID \"%s\"", args[0])
+	}
+	epc.id = id
+	epc.text = args[1]
+	return nil
+}
+
+// RequiresConnection makes sure that the configured server is valid and online before executing the command logic
+func (epc *EditPromptCmd) RequiresConnection() *cmd.Context {
+	return epc.Context
+}
+
+// RequiresAuthorization marks the command to require the configuration to have a user logged in.
+func (epc *EditPromptCmd) RequiresAuthorization() {}
+
+// Run is used to build the RunE function for the cobra command
+func (epc *EditPromptCmd) Run() error {
+	if err := epc.Client.EditPrompt(epc.id, epc.text); err != nil {
+		return err
+	}
+
+	return epc.PrintMessage(fmt.Sprintf("You've successfully edited prompt #%d.", epc.id))
+}
+
+func (epc *EditPromptCmd) buildCommand() *cobra.Command {
+	var editPromptCmd = &cobra.Command{
+		Use:     "edit-prompt [id] [text]",
+		Aliases: []string{"ep"},
+		Short:   "Replaces the text of the prompt with the provided ID.",
+		Long:    `Replaces the text of the prompt with the provided ID. Requires admin access in the prompt's room.`,
+		PreRunE: cmd.PreRunE(epc),
+		RunE:    cmd.RunE(epc),
+	}
+
+	return editPromptCmd
+}
@@ -0,0 +1,82 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prompt
+
+import (
+	"fmt"
+
+	"github.com/pavelhadzhiev/story-builder/cmd"
+	"github.com/spf13/cobra"
+)
+
+// ListPromptsCmd is a wrapper for the story-builder list-prompts command
+type ListPromptsCmd struct {
+	*cmd.Context
+}
+
+// Command builds and returns a cobra command that will be added to the root command
+func (lpc *ListPromptsCmd) Command() *cobra.Command {
+	result := lpc.buildCommand()
+
+	return result
+}
+
+// RequiresConnection makes sure that the configured server is valid and online before executing the command logic
+func (lpc *ListPromptsCmd) RequiresConnection() *cmd.Context {
+	return lpc.Context
+}
+
+// RequiresAuthorization marks the command to require the configuration to have a user logged in.
+func (lpc *ListPromptsCmd) RequiresAuthorization() {}
+
+// Run is used to build the RunE function for the cobra command
+func (lpc *ListPromptsCmd) Run() error {
+	cfg, err := lpc.Configurator.Load()
+	if err != nil {
+		return err
+	}
+
+	prompts, err := lpc.Client.GetPrompts(cfg.Room)
+	if err != nil {
+		return err
+	}
+
+	table := &cmd.Table{Headers: []string{"ID", "ROOM", "AUTHOR", "TEXT"}}
+	for _, prompt := range prompts {
+		table.AddRow(prompt.ID, prompt.Room, prompt.Author, prompt.Text)
+	}
+	return lpc.Print(prompts, table, func() {
+		if len(prompts) == 0 {
+			fmt.Println("There are no prompts available yet. Admins can add some using the add-prompt command.")
+			return
+		}
+		fmt.Println("Available prompts are:")
+		for _, prompt := range prompts {
+			fmt.Println(prompt)
+		}
+	})
+}
+
+func (lpc *ListPromptsCmd) buildCommand() *cobra.Command {
+	var listPromptsCmd = &cobra.Command{
+		Use:     "list-prompts",
+		Aliases: []string{"prompts"},
+		Short:   "Lists the prompts available in the joined room.",
+		Long:    `Lists the server-wide prompts, along with the prompts of the joined room. If you're not in a room, only server-wide prompts are listed. Use a prompt's ID with the --prompt flag of the start-game command.`,
+		PreRunE: cmd.PreRunE(lpc),
+		RunE:    cmd.RunE(lpc),
+	}
+	return listPromptsCmd
+}
@@ -61,6 +61,9 @@ func (rc *RegisterCmd) Run() error {
 		}
 		rc.username = username
 	}
+	if err := util.ValidateUsername(rc.username); err != nil {
+		return err
+	}
 	if rc.password == "" {
 		password, err := util.ReadPassword()
 		if err != nil {
@@ -71,21 +74,21 @@ func (rc *RegisterCmd) Run() error {
 	cfg.Authorization = "Basic " + base64.StdEncoding.EncodeToString([]byte(rc.username+":"+rc.password))
 	rc.Configurator.Save(cfg)
 
-	if err := client.NewSBClient(cfg).Register(); err != nil {
+	rc.Client = client.NewSBClient(cfg)
+	if err := rc.Client.Register(); err != nil {
 		cfg.Authorization = ""
 		rc.Configurator.Save(cfg)
 		return err
 	}
 
-	fmt.Printf("You've registered successfully! Welcome, %s.\n", rc.username)
-	return nil
+	return rc.PrintMessage(fmt.Sprintf("You've registered successfully! Welcome, %s.", rc.username))
 }
 
 func (rc *RegisterCmd) buildCommand() *cobra.Command {
 	var registerCmd = &cobra.Command{
 		Use:     "register",
 		Short:   "Registers a non-existing user with the provided username and password.",
-		Long:    `Registers a non-existing user with the provided username and password. If the user does exists, the request is rejected. Requires a valid connection to a server, a username and a password. If any of these are missing a sufficient error message is provided. To connect to a server check the connect command.`,
+		Long:    `Registers a non-existing user with the provided username and password. If the user does exists, the request is rejected. Usernames are between 3 and 32 symbols long and can only contain letters, digits, "-", "_" and ".". Requires a valid connection to a server, a username and a password. If any of these are missing a sufficient error message is provided. To connect to a server check the connect command.`,
 		PreRunE: cmd.PreRunE(rc),
 		RunE:    cmd.RunE(rc),
 	}
@@ -72,8 +72,7 @@ func (crc *CreateRoomCmd) Run() error {
 		return err
 	}
 
-	fmt.Printf("Room \"%s\" was successfully created by \"%s\".\n", crc.name, user)
-	return nil
+	return crc.PrintMessage(fmt.Sprintf("Room \"%s\" was successfully created by \"%s\".", crc.name, user))
 }
 
 func (crc *CreateRoomCmd) buildCommand() *cobra.Command {
@@ -81,7 +80,7 @@ func (crc *CreateRoomCmd) buildCommand() *cobra.Command {
 		Use:     "create-room [name]",
 		Aliases: []string{"cr"},
 		Short:   "Creates a game room with the provided name.",
-		Long:    `Creates a game room with the provided name. Returns an error if a room with this name already exists.`,
+		Long:    `Creates a game room with the provided name. Returns an error if a room with this name already exists. Room names are up to 64 symbols long and can only contain letters, digits, spaces, "-", "_" and ".".`,
 		PreRunE: cmd.PreRunE(crc),
 		RunE:    cmd.RunE(crc),
 	}
@@ -63,15 +63,13 @@ func (drc *DeleteRoomCmd) Run() error {
 
 	action := fmt.Sprintf("delete room \"%s\"", drc.name)
 	if !util.ConfirmationPrompt(action) {
-		fmt.Println("Operation cancelled. No action taken.")
-		return nil
+		return drc.PrintCancelled()
 	}
 	if err := drc.Client.DeleteRoom(drc.name); err != nil {
 		return err
 	}
 
-	fmt.Printf("You've successfully deleted room \"%s\".\n", drc.name)
-	return nil
+	return drc.PrintMessage(fmt.Sprintf("You've successfully deleted room \"%s\".", drc.name))
 }
 
 func (drc *DeleteRoomCmd) buildCommand() *cobra.Command {
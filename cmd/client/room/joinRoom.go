@@ -73,8 +73,7 @@ func (jrc *JoinRoomCmd) Run() error {
 		return err
 	}
 
-	fmt.Printf("You've successfully joined room \"%s\".\n", jrc.name)
-	return nil
+	return jrc.PrintMessage(fmt.Sprintf("You've successfully joined room \"%s\".", jrc.name))
 }
 
 func (jrc *JoinRoomCmd) buildCommand() *cobra.Command {
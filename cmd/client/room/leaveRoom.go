@@ -58,14 +58,12 @@ func (lrc *LeaveRoomCmd) Run() error {
 	}
 
 	if err := lrc.Client.LeaveRoom(roomName); err != nil {
-		fmt.Printf("Something went wrong: %s\n", err)
-		fmt.Println("This could've been caused by a problem with the server or you could've been banned.")
-		fmt.Println("However, your configuration has been adjusted, so you've technically \"left\".")
-	} else {
-		fmt.Printf("You've successfully left room \"%s\".\n", roomName)
+		lrc.Warn("something went wrong: %s", err)
+		lrc.Warn("this could've been caused by a problem with the server or you could've been banned.")
+		return lrc.PrintMessage("Your configuration has been adjusted, so you've technically \"left\".")
 	}
 
-	return nil
+	return lrc.PrintMessage(fmt.Sprintf("You've successfully left room \"%s\".", roomName))
 }
 
 func (lrc *LeaveRoomCmd) buildCommand() *cobra.Command {
@@ -16,6 +16,7 @@ package room
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/pavelhadzhiev/story-builder/cmd"
 	"github.com/spf13/cobra"
@@ -48,11 +49,16 @@ func (lrc *ListRoomsCmd) Run() error {
 		return err
 	}
 
-	fmt.Println("All rooms in the current server are:")
+	table := &cmd.Table{Headers: []string{"NAME", "CREATOR", "ONLINE", "ADMINS"}}
 	for _, room := range rooms {
-		fmt.Println(room)
+		table.AddRow(room.Name, room.Creator, strings.Join(room.Online, ","), strings.Join(room.Admins, ","))
 	}
-	return nil
+	return lrc.Print(rooms, table, func() {
+		fmt.Println("All rooms in the current server are:")
+		for _, room := range rooms {
+			fmt.Println(room)
+		}
+	})
 }
 
 func (lrc *ListRoomsCmd) buildCommand() *cobra.Command {
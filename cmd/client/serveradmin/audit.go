@@ -0,0 +1,108 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serveradmin
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pavelhadzhiev/story-builder/cmd"
+	"github.com/pavelhadzhiev/story-builder/pkg/api/audit"
+	"github.com/spf13/cobra"
+)
+
+// AuditCmd is a wrapper for the story-builder server-admin audit command
+type AuditCmd struct {
+	*cmd.Context
+
+	filter audit.Filter
+	since  string
+}
+
+// Command builds and returns a cobra command that will be added to the root command
+func (ac *AuditCmd) Command() *cobra.Command {
+	result := ac.buildCommand()
+
+	return result
+}
+
+// Validate makes sure all required arguments are legal and are provided
+func (ac *AuditCmd) Validate(args []string) error {
+	if len(args) != 0 {
+		return fmt.Errorf("doesn't take args")
+	}
+	if ac.filter.Limit < 0 {
+		return fmt.Errorf("--limit must not be negative")
+	}
+	if ac.since != "" {
+		if duration, err := time.ParseDuration(ac.since); err == nil {
+			ac.filter.Since = time.Now().Add(-duration)
+		} else if sinceTime, err := time.Parse(time.RFC3339, ac.since); err == nil {
+			ac.filter.Since = sinceTime
+		} else {
+			return fmt.Errorf("--since must be a duration, such as \"24h\", or a time in RFC 3339 format, such as \"2019-06-01T15:00:00Z\"")
+		}
+	}
+	return nil
+}
+
+// RequiresConnection makes sure that the configured server is valid and online before executing the command logic
+func (ac *AuditCmd) RequiresConnection() *cmd.Context {
+	return ac.Context
+}
+
+// RequiresAuthorization marks the command to require the configuration to have a user logged in.
+func (ac *AuditCmd) RequiresAuthorization() {}
+
+// Run is used to build the RunE function for the cobra command
+func (ac *AuditCmd) Run() error {
+	entries, err := ac.Client.GetAuditLog(ac.filter)
+	if err != nil {
+		return err
+	}
+
+	table := &cmd.Table{Headers: []string{"ID", "TIME", "ACTION", "ACTOR", "TARGET", "ROOM", "DETAILS"}}
+	for _, entry := range entries {
+		table.AddRow(entry.ID, entry.Time.Local().Format("2006-01-02 15:04:05"), entry.Action, entry.Actor, entry.Target, entry.Room, entry.Details)
+	}
+	return ac.Print(entries, table, func() {
+		if len(entries) == 0 {
+			fmt.Println("There are no matching entries in the audit log.")
+			return
+		}
+		for _, entry := range entries {
+			fmt.Println(entry)
+		}
+	})
+}
+
+func (ac *AuditCmd) buildCommand() *cobra.Command {
+	var auditCmd = &cobra.Command{
+		Use:   "audit",
+		Short: "Shows the audit log of the server.",
+		Long: `Shows the audit log of the server, oldest first. It records security-relevant actions - logins, failed logins, creation and deletion of rooms, promotions, bans, kicks, redactions and vote outcomes.
+Use the flags to filter the entries.`,
+		PreRunE: cmd.PreRunE(ac),
+		RunE:    cmd.RunE(ac),
+	}
+
+	auditCmd.Flags().StringVar(&ac.filter.Actor, "actor", "", "Show only the actions of this user")
+	auditCmd.Flags().StringVar(&ac.filter.Action, "action", "", `Show only actions of this kind, e.g. "login_failed" or "room_ban"`)
+	auditCmd.Flags().StringVarP(&ac.filter.Room, "room", "r", "", "Show only the actions in this room")
+	auditCmd.Flags().StringVar(&ac.since, "since", "", `Show only the actions since this time (RFC 3339) or in this last period, e.g. "24h"`)
+	auditCmd.Flags().IntVarP(&ac.filter.Limit, "limit", "n", 50, "Show only this many of the newest matching entries. 0 shows all of them")
+
+	return auditCmd
+}
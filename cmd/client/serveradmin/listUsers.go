@@ -0,0 +1,73 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serveradmin
+
+import (
+	"fmt"
+
+	"github.com/pavelhadzhiev/story-builder/cmd"
+	"github.com/spf13/cobra"
+)
+
+// ListUsersCmd is a wrapper for the story-builder server-admin list-users command
+type ListUsersCmd struct {
+	*cmd.Context
+}
+
+// Command builds and returns a cobra command that will be added to the root command
+func (luc *ListUsersCmd) Command() *cobra.Command {
+	result := luc.buildCommand()
+
+	return result
+}
+
+// RequiresConnection makes sure that the configured server is valid and online before executing the command logic
+func (luc *ListUsersCmd) RequiresConnection() *cmd.Context {
+	return luc.Context
+}
+
+// RequiresAuthorization marks the command to require the configuration to have a user logged in.
+func (luc *ListUsersCmd) RequiresAuthorization() {}
+
+// Run is used to build the RunE function for the cobra command
+func (luc *ListUsersCmd) Run() error {
+	users, err := luc.Client.GetUsers()
+	if err != nil {
+		return err
+	}
+
+	table := &cmd.Table{Headers: []string{"USERNAME", "ROLE", "ONLINE", "DISABLED", "BANNED"}}
+	for _, user := range users {
+		table.AddRow(user.Username, user.Role, user.Online, user.Disabled, user.Banned)
+	}
+	return luc.Print(users, table, func() {
+		fmt.Printf("There are %d users on the server:\n", len(users))
+		for _, user := range users {
+			fmt.Println(user)
+		}
+	})
+}
+
+func (luc *ListUsersCmd) buildCommand() *cobra.Command {
+	var listUsersCmd = &cobra.Command{
+		Use:     "list-users",
+		Aliases: []string{"users"},
+		Short:   "Lists all users of the server.",
+		Long:    `Lists all users of the server, showing which of them are server admins, online, disabled or banned.`,
+		PreRunE: cmd.PreRunE(luc),
+		RunE:    cmd.RunE(luc),
+	}
+	return listUsersCmd
+}
@@ -0,0 +1,88 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serveradmin
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/pavelhadzhiev/story-builder/cmd"
+	"github.com/pavelhadzhiev/story-builder/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+// ResetPasswordCmd is a wrapper for the story-builder server-admin reset-password command
+type ResetPasswordCmd struct {
+	*cmd.Context
+
+	username    string
+	newPassword string
+}
+
+// Command builds and returns a cobra command that will be added to the root command
+func (rpc *ResetPasswordCmd) Command() *cobra.Command {
+	result := rpc.buildCommand()
+
+	return result
+}
+
+// Validate makes sure all required arguments are legal and are provided
+func (rpc *ResetPasswordCmd) Validate(args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("requires a single arg")
+	}
+
+	rpc.username = args[0]
+	return nil
+}
+
+// RequiresConnection makes sure that the configured server is valid and online before executing the command logic
+func (rpc *ResetPasswordCmd) RequiresConnection() *cmd.Context {
+	return rpc.Context
+}
+
+// RequiresAuthorization marks the command to require the configuration to have a user logged in.
+func (rpc *ResetPasswordCmd) RequiresAuthorization() {}
+
+// Run is used to build the RunE function for the cobra command
+func (rpc *ResetPasswordCmd) Run() error {
+	if rpc.newPassword == "" {
+		fmt.Fprintf(os.Stderr, "Enter the new password of \"%s\".\n", rpc.username)
+		password, err := util.ReadPassword()
+		if err != nil {
+			return err
+		}
+		rpc.newPassword = password
+	}
+	if err := rpc.Client.ResetPassword(rpc.username, rpc.newPassword); err != nil {
+		return err
+	}
+
+	return rpc.PrintMessage(fmt.Sprintf("You've reset the password of \"%s\".", rpc.username))
+}
+
+func (rpc *ResetPasswordCmd) buildCommand() *cobra.Command {
+	var resetPasswordCmd = &cobra.Command{
+		Use:     "reset-password [user]",
+		Short:   "Resets the password of the user provided as argument.",
+		Long:    `Resets the password of the user provided as argument. The command will prompt you to enter the new password, unless it's passed with the --password flag. Requires server admin access.`,
+		PreRunE: cmd.PreRunE(rpc),
+		RunE:    cmd.RunE(rpc),
+	}
+
+	resetPasswordCmd.Flags().StringVarP(&rpc.newPassword, "password", "p", "", "the new password of the user")
+
+	return resetPasswordCmd
+}
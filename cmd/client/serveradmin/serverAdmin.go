@@ -0,0 +1,103 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serveradmin
+
+import (
+	"github.com/pavelhadzhiev/story-builder/cmd"
+	"github.com/pavelhadzhiev/story-builder/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+// ServerAdminCmd is a wrapper for the story-builder server-admin command group
+type ServerAdminCmd struct {
+	*cmd.Context
+}
+
+// Command builds and returns a cobra command that will be added to the root command
+func (sac *ServerAdminCmd) Command() *cobra.Command {
+	result := sac.buildCommand()
+
+	return result
+}
+
+func (sac *ServerAdminCmd) buildCommand() *cobra.Command {
+	var serverAdminCmd = &cobra.Command{
+		Use:     "server-admin",
+		Aliases: []string{"sa"},
+		Short:   "Manages the users and rooms of the server.",
+		Long:    `Manages the users and rooms of the server. All commands in the group require the logged in user to be a server admin. The first server admins are set with the --server-admin flag of the host command.`,
+	}
+
+	serverAdminCmd.AddCommand(
+		(&ListUsersCmd{Context: sac.Context}).Command(),
+		(&AuditCmd{Context: sac.Context}).Command(),
+		(&UserActionCmd{
+			Context: sac.Context,
+			use:     "disable",
+			short:   "Disables the account of the user provided as argument.",
+			long:    `Disables the account of the user provided as argument. The user is logged out, removed from all rooms and can't log in until the account is enabled again.`,
+			confirm: true,
+			action:  (*client.SBClient).DisableUser,
+			done:    "You've disabled the account of \"%s\".",
+		}).Command(),
+		(&UserActionCmd{
+			Context: sac.Context,
+			use:     "enable",
+			short:   "Enables the disabled account of the user provided as argument.",
+			long:    `Enables the disabled account of the user provided as argument, so the user can log in again.`,
+			action:  (*client.SBClient).EnableUser,
+			done:    "You've enabled the account of \"%s\".",
+		}).Command(),
+		(&UserActionCmd{
+			Context: sac.Context,
+			use:     "ban",
+			short:   "Bans the user provided as argument from all rooms of the server.",
+			long:    `Bans the user provided as argument from all rooms of the server. The user is removed from the rooms they're in and can't join or create rooms until the ban is lifted.`,
+			confirm: true,
+			action:  (*client.SBClient).BanUserFromServer,
+			done:    "You've banned \"%s\" from the server.",
+		}).Command(),
+		(&UserActionCmd{
+			Context: sac.Context,
+			use:     "unban",
+			short:   "Lifts the server-wide ban of the user provided as argument.",
+			long:    `Lifts the server-wide ban of the user provided as argument, so the user can join and create rooms again.`,
+			action:  (*client.SBClient).UnbanUserFromServer,
+			done:    "You've lifted the server-wide ban of \"%s\".",
+		}).Command(),
+		(&UserActionCmd{
+			Context: sac.Context,
+			use:     "promote",
+			short:   "Gives the server admin role to the user provided as argument.",
+			long:    `Gives the server admin role to the user provided as argument.`,
+			confirm: true,
+			action:  (*client.SBClient).PromoteServerAdmin,
+			done:    "You've promoted \"%s\" to server admin.",
+		}).Command(),
+		(&UserActionCmd{
+			Context: sac.Context,
+			use:     "demote",
+			short:   "Takes the server admin role from the user provided as argument.",
+			long:    `Takes the server admin role from the user provided as argument.`,
+			confirm: true,
+			action:  (*client.SBClient).DemoteServerAdmin,
+			done:    "\"%s\" is no longer a server admin.",
+		}).Command(),
+		(&ResetPasswordCmd{Context: sac.Context}).Command(),
+		(&DeleteRoomCmd{Context: sac.Context}).Command(),
+	)
+
+	return serverAdminCmd
+}
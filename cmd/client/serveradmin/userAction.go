@@ -0,0 +1,89 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serveradmin
+
+import (
+	"fmt"
+
+	"github.com/pavelhadzhiev/story-builder/cmd"
+	"github.com/pavelhadzhiev/story-builder/pkg/client"
+	"github.com/pavelhadzhiev/story-builder/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+// UserActionCmd is a wrapper for the story-builder server-admin commands that take a single action against a user, e.g. disable or ban
+type UserActionCmd struct {
+	*cmd.Context
+
+	use     string
+	short   string
+	long    string
+	confirm bool
+	action  func(client *client.SBClient, username string) error
+	done    string
+
+	username string
+}
+
+// Command builds and returns a cobra command that will be added to the root command
+func (uac *UserActionCmd) Command() *cobra.Command {
+	result := uac.buildCommand()
+
+	return result
+}
+
+// Validate makes sure all required arguments are legal and are provided
+func (uac *UserActionCmd) Validate(args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("requires a single arg")
+	}
+
+	uac.username = args[0]
+	return nil
+}
+
+// RequiresConnection makes sure that the configured server is valid and online before executing the command logic
+func (uac *UserActionCmd) RequiresConnection() *cmd.Context {
+	return uac.Context
+}
+
+// RequiresAuthorization marks the command to require the configuration to have a user logged in.
+func (uac *UserActionCmd) RequiresAuthorization() {}
+
+// Run is used to build the RunE function for the cobra command
+func (uac *UserActionCmd) Run() error {
+	if uac.confirm {
+		action := fmt.Sprintf("%s user \"%s\"", uac.use, uac.username)
+		if !util.ConfirmationPrompt(action) {
+			return uac.PrintCancelled()
+		}
+	}
+	if err := uac.action(uac.Client, uac.username); err != nil {
+		return err
+	}
+
+	return uac.PrintMessage(fmt.Sprintf(uac.done, uac.username))
+}
+
+func (uac *UserActionCmd) buildCommand() *cobra.Command {
+	var userActionCmd = &cobra.Command{
+		Use:     uac.use + " [user]",
+		Short:   uac.short,
+		Long:    uac.long + " Requires server admin access.",
+		PreRunE: cmd.PreRunE(uac),
+		RunE:    cmd.RunE(uac),
+	}
+	return userActionCmd
+}
@@ -0,0 +1,92 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pavelhadzhiev/story-builder/cmd"
+	"github.com/pavelhadzhiev/story-builder/pkg/api/webhooks"
+	"github.com/spf13/cobra"
+)
+
+// AddWebhookCmd is a wrapper for the story-builder webhook add command
+type AddWebhookCmd struct {
+	*cmd.Context
+
+	url    string
+	events []string
+	secret string
+}
+
+// Command builds and returns a cobra command that will be added to the root command
+func (awc *AddWebhookCmd) Command() *cobra.Command {
+	result := awc.buildCommand()
+
+	return result
+}
+
+// Validate makes sure all required arguments are legal and are provided
+func (awc *AddWebhookCmd) Validate(args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("requires a single arg")
+	}
+
+	awc.url = args[0]
+	return webhooks.Webhook{URL: awc.url, Events: awc.events}.Validate()
+}
+
+// RequiresConnection makes sure that the configured server is valid and online before executing the command logic
+func (awc *AddWebhookCmd) RequiresConnection() *cmd.Context {
+	return awc.Context
+}
+
+// RequiresAuthorization marks the command to require the configuration to have a user logged in.
+func (awc *AddWebhookCmd) RequiresAuthorization() {}
+
+// RequiresRoom marks the command to require the configuration to have a user logged in.
+func (awc *AddWebhookCmd) RequiresRoom() {}
+
+// Run is used to build the RunE function for the cobra command
+func (awc *AddWebhookCmd) Run() error {
+	webhook, err := awc.Client.AddWebhook(awc.url, awc.events, awc.secret)
+	if err != nil {
+		return err
+	}
+
+	return awc.Print(webhook, nil, func() {
+		fmt.Printf("You've successfully added webhook #%d.\n", webhook.ID)
+		if awc.secret == "" {
+			fmt.Printf("Its secret is \"%s\". Keep it safe - it won't be shown again.\n", webhook.Secret)
+		}
+		fmt.Printf("Verify deliveries with the HMAC-SHA256 of their body, keyed with the secret, in the %s header.\n", webhooks.SignatureHeader)
+	})
+}
+
+func (awc *AddWebhookCmd) buildCommand() *cobra.Command {
+	var addWebhookCmd = &cobra.Command{
+		Use:     "add [url]",
+		Short:   "Adds a webhook to the joined room.",
+		Long:    `Adds a webhook to the joined room, which posts the events of the room to the provided URL. Use the --events flag to pick the events, out of "` + strings.Join(webhooks.Events, `", "`) + `". The secret that signs the payloads is generated by the server, unless you provide one with the --secret flag. Requires admin access.`,
+		PreRunE: cmd.PreRunE(awc),
+		RunE:    cmd.RunE(awc),
+	}
+
+	addWebhookCmd.Flags().StringSliceVarP(&awc.events, "events", "e", nil, "comma-separated events to subscribe to (default all events)")
+	addWebhookCmd.Flags().StringVar(&awc.secret, "secret", "", "secret to sign the payloads with (default generated by the server)")
+
+	return addWebhookCmd
+}
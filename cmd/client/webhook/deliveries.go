@@ -0,0 +1,100 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/pavelhadzhiev/story-builder/cmd"
+	"github.com/spf13/cobra"
+)
+
+// DeliveriesCmd is a wrapper for the story-builder webhook deliveries command
+type DeliveriesCmd struct {
+	*cmd.Context
+
+	id int
+}
+
+// Command builds and returns a cobra command that will be added to the root command
+func (dc *DeliveriesCmd) Command() *cobra.Command {
+	result := dc.buildCommand()
+
+	return result
+}
+
+// Validate makes sure all required arguments are legal and are provided
+func (dc *DeliveriesCmd) Validate(args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("requires a single arg")
+	}
+
+	id, err := strconv.Atoi(args[0])
+	if err != nil || id <= 0 {
+		return fmt.Errorf("illegal webhook ID \"%s\"", args[0])
+	}
+	dc.id = id
+	return nil
+}
+
+// RequiresConnection makes sure that the configured server is valid and online before executing the command logic
+func (dc *DeliveriesCmd) RequiresConnection() *cmd.Context {
+	return dc.Context
+}
+
+// RequiresAuthorization marks the command to require the configuration to have a user logged in.
+func (dc *DeliveriesCmd) RequiresAuthorization() {}
+
+// RequiresRoom marks the command to require the configuration to have a user logged in.
+func (dc *DeliveriesCmd) RequiresRoom() {}
+
+// Run is used to build the RunE function for the cobra command
+func (dc *DeliveriesCmd) Run() error {
+	deliveries, err := dc.Client.GetWebhookDeliveries(dc.id)
+	if err != nil {
+		return err
+	}
+
+	table := &cmd.Table{Headers: []string{"ID", "TIME", "EVENT", "STATUS", "ATTEMPTS", "RESPONSE", "ERROR"}}
+	for _, delivery := range deliveries {
+		response := ""
+		if delivery.StatusCode != 0 {
+			response = strconv.Itoa(delivery.StatusCode)
+		}
+		table.AddRow(delivery.ID, delivery.Time.Local().Format("2006-01-02 15:04:05"), delivery.Event, delivery.Status, delivery.Attempts, response, delivery.Error)
+	}
+	return dc.Print(deliveries, table, func() {
+		if len(deliveries) == 0 {
+			fmt.Printf("Webhook #%d has no deliveries yet.\n", dc.id)
+			return
+		}
+		for _, delivery := range deliveries {
+			fmt.Println(delivery)
+		}
+	})
+}
+
+func (dc *DeliveriesCmd) buildCommand() *cobra.Command {
+	var deliveriesCmd = &cobra.Command{
+		Use:     "deliveries [id]",
+		Short:   "Prints the delivery log of the webhook with the provided ID.",
+		Long:    `Prints the delivery log of the webhook with the provided ID - the events sent to it, whether they were delivered and how many attempts it took. Failed deliveries are retried with exponential backoff. The server keeps the last 100 deliveries of every room in memory. Requires admin access.`,
+		PreRunE: cmd.PreRunE(dc),
+		RunE:    cmd.RunE(dc),
+	}
+
+	return deliveriesCmd
+}
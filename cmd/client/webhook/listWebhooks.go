@@ -0,0 +1,86 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pavelhadzhiev/story-builder/cmd"
+	"github.com/spf13/cobra"
+)
+
+// ListWebhooksCmd is a wrapper for the story-builder webhook list command
+type ListWebhooksCmd struct {
+	*cmd.Context
+}
+
+// Command builds and returns a cobra command that will be added to the root command
+func (lwc *ListWebhooksCmd) Command() *cobra.Command {
+	result := lwc.buildCommand()
+
+	return result
+}
+
+// RequiresConnection makes sure that the configured server is valid and online before executing the command logic
+func (lwc *ListWebhooksCmd) RequiresConnection() *cmd.Context {
+	return lwc.Context
+}
+
+// RequiresAuthorization marks the command to require the configuration to have a user logged in.
+func (lwc *ListWebhooksCmd) RequiresAuthorization() {}
+
+// RequiresRoom marks the command to require the configuration to have a user logged in.
+func (lwc *ListWebhooksCmd) RequiresRoom() {}
+
+// Run is used to build the RunE function for the cobra command
+func (lwc *ListWebhooksCmd) Run() error {
+	roomWebhooks, err := lwc.Client.GetWebhooks()
+	if err != nil {
+		return err
+	}
+
+	table := &cmd.Table{Headers: []string{"ID", "URL", "EVENTS", "CREATOR"}}
+	for _, webhook := range roomWebhooks {
+		events := "all"
+		if len(webhook.Events) > 0 {
+			events = strings.Join(webhook.Events, ",")
+		}
+		table.AddRow(webhook.ID, webhook.URL, events, webhook.Creator)
+	}
+	return lwc.Print(roomWebhooks, table, func() {
+		if len(roomWebhooks) == 0 {
+			fmt.Println("The room has no webhooks. Admins can add some using the webhook add command.")
+			return
+		}
+		fmt.Println("The webhooks of the room are:")
+		for _, webhook := range roomWebhooks {
+			fmt.Println(webhook)
+		}
+	})
+}
+
+func (lwc *ListWebhooksCmd) buildCommand() *cobra.Command {
+	var listWebhooksCmd = &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "Lists the webhooks of the joined room.",
+		Long:    `Lists the webhooks of the joined room with the events they subscribe to. Their secrets are never shown. Requires admin access.`,
+		PreRunE: cmd.PreRunE(lwc),
+		RunE:    cmd.RunE(lwc),
+	}
+
+	return listWebhooksCmd
+}
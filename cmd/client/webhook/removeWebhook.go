@@ -0,0 +1,84 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/pavelhadzhiev/story-builder/cmd"
+	"github.com/spf13/cobra"
+)
+
+// RemoveWebhookCmd is a wrapper for the story-builder webhook remove command
+type RemoveWebhookCmd struct {
+	*cmd.Context
+
+	id int
+}
+
+// Command builds and returns a cobra command that will be added to the root command
+func (rwc *RemoveWebhookCmd) Command() *cobra.Command {
+	result := rwc.buildCommand()
+
+	return result
+}
+
+// Validate makes sure all required arguments are legal and are provided
+func (rwc *RemoveWebhookCmd) Validate(args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("requires a single arg")
+	}
+
+	id, err := strconv.Atoi(args[0])
+	if err != nil || id <= 0 {
+		return fmt.Errorf("illegal webhook ID \"%s\"", args[0])
+	}
+	rwc.id = id
+	return nil
+}
+
+// RequiresConnection makes sure that the configured server is valid and online before executing the command logic
+func (rwc *RemoveWebhookCmd) RequiresConnection() *cmd.Context {
+	return rwc.Context
+}
+
+// RequiresAuthorization marks the command to require the configuration to have a user logged in.
+func (rwc *RemoveWebhookCmd) RequiresAuthorization() {}
+
+// RequiresRoom marks the command to require the configuration to have a user logged in.
+func (rwc *RemoveWebhookCmd) RequiresRoom() {}
+
+// Run is used to build the RunE function for the cobra command
+func (rwc *RemoveWebhookCmd) Run() error {
+	if err := rwc.Client.RemoveWebhook(rwc.id); err != nil {
+		return err
+	}
+
+	return rwc.PrintMessage(fmt.Sprintf("You've successfully removed webhook #%d.", rwc.id))
+}
+
+func (rwc *RemoveWebhookCmd) buildCommand() *cobra.Command {
+	var removeWebhookCmd = &cobra.Command{
+		Use:     "remove [id]",
+		Aliases: []string{"rm"},
+		Short:   "Removes the webhook with the provided ID from the joined room.",
+		Long:    `Removes the webhook with the provided ID from the joined room. Deliveries in progress are still attempted. Requires admin access.`,
+		PreRunE: cmd.PreRunE(rwc),
+		RunE:    cmd.RunE(rwc),
+	}
+
+	return removeWebhookCmd
+}
@@ -0,0 +1,50 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"github.com/pavelhadzhiev/story-builder/cmd"
+	"github.com/spf13/cobra"
+)
+
+// WebhookCmd is a wrapper for the story-builder webhook command group
+type WebhookCmd struct {
+	*cmd.Context
+}
+
+// Command builds and returns a cobra command that will be added to the root command
+func (wc *WebhookCmd) Command() *cobra.Command {
+	result := wc.buildCommand()
+
+	return result
+}
+
+func (wc *WebhookCmd) buildCommand() *cobra.Command {
+	var webhookCmd = &cobra.Command{
+		Use:     "webhook",
+		Aliases: []string{"webhooks"},
+		Short:   "Manages the webhooks of the joined room.",
+		Long:    `Manages the webhooks of the joined room. A webhook posts the events of the room's games - "game.started", "turn.started" and "game.finished" - to a URL as JSON, signed with HMAC-SHA256. All commands in the group require admin access.`,
+	}
+
+	webhookCmd.AddCommand(
+		(&AddWebhookCmd{Context: wc.Context}).Command(),
+		(&ListWebhooksCmd{Context: wc.Context}).Command(),
+		(&RemoveWebhookCmd{Context: wc.Context}).Command(),
+		(&DeliveriesCmd{Context: wc.Context}).Command(),
+	)
+
+	return webhookCmd
+}
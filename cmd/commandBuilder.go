@@ -57,12 +57,12 @@ type RoomCommand interface {
 }
 
 // PreRunE is used to execute some generic preparations for the command execution, depending on interfaces the command impements.
-// Set this function to the PreRunE property of a cobra command.
+// Set this function to the PreRunE property of a cobra command. The returned errors have the exit code that matches their cause.
 func PreRunE(cmd Command) func(*cobra.Command, []string) error {
 	return func(c *cobra.Command, args []string) error {
 		if valCmd, ok := cmd.(ValidatedCommand); ok {
 			if err := valCmd.Validate(args); err != nil {
-				return err
+				return withExitCode(err, ExitCodeUserError)
 			}
 		}
 
@@ -72,20 +72,20 @@ func PreRunE(cmd Command) func(*cobra.Command, []string) error {
 		if connCmd, ok := cmd.(ConnectionCommand); ok {
 			ctx := connCmd.RequiresConnection()
 			if err := ctx.Client.HealthCheck(ctx.Configurator); err != nil {
-				return fmt.Errorf("illegal configuration: %v", err)
+				return withExitCode(fmt.Errorf("illegal configuration: %v", err), clientExitCode(ctx.Client, err))
 			}
 
 			cfg, err := ctx.Configurator.Load()
 			if err != nil {
-				return err
+				return withExitCode(err, ExitCodeUserError)
 			}
 
 			if _, ok := cmd.(AuthorizedCommand); ok && cfg.Authorization == "" {
-				return errors.New("users is not logged in")
+				return withExitCode(errors.New("users is not logged in"), ExitCodeAuthError)
 			}
 
 			if _, ok := cmd.(RoomCommand); ok && cfg.Room == "" {
-				return errors.New("user is not in a room")
+				return withExitCode(errors.New("user is not in a room"), ExitCodeUserError)
 			}
 		}
 
@@ -94,8 +94,17 @@ func PreRunE(cmd Command) func(*cobra.Command, []string) error {
 }
 
 // RunE is used to set the RunE property of a cobra command.
+// Errors of commands that require a connection get their exit code from the failed request, all others are unexpected.
 func RunE(cmd Command) func(*cobra.Command, []string) error {
 	return func(c *cobra.Command, args []string) error {
-		return cmd.Run()
+		connCmd, requiresConnection := cmd.(ConnectionCommand)
+		if requiresConnection {
+			connCmd.RequiresConnection().Client.ResetLastStatus() // forget the healthcheck
+		}
+		err := cmd.Run()
+		if requiresConnection && err != nil {
+			return withExitCode(err, clientExitCode(connCmd.RequiresConnection().Client, err))
+		}
+		return withExitCode(err, ExitCodeError)
 	}
 }
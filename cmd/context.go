@@ -24,4 +24,7 @@ type Context struct {
 	Client *client.SBClient
 
 	Configurator config.SBConfigurator
+
+	// Output is the format in which the commands print their results.
+	Output OutputFormat
 }
@@ -0,0 +1,121 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/pavelhadzhiev/story-builder/pkg/client"
+)
+
+// Exit codes of the CLI. Scripts can rely on them to tell apart the reasons a command failed.
+const (
+	// ExitCodeError is returned for unexpected errors.
+	ExitCodeError = 1
+	// ExitCodeUserError is returned for invalid arguments, an invalid configuration and requests the server rejects as invalid.
+	ExitCodeUserError = 2
+	// ExitCodeAuthError is returned when the user is not logged in, can't be authenticated or isn't allowed to do what the command asks for.
+	ExitCodeAuthError = 3
+	// ExitCodeServerError is returned when the server can't be reached, fails or keeps rejecting requests because of too many requests.
+	ExitCodeServerError = 4
+)
+
+// ExitError is an error that makes the CLI exit with a specific exit code.
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func (err *ExitError) Error() string {
+	return err.Err.Error()
+}
+
+func (err *ExitError) Unwrap() error {
+	return err.Err
+}
+
+// Kind returns the name of the kind of the error, as written in the structured output formats.
+func (err *ExitError) Kind() string {
+	switch err.Code {
+	case ExitCodeUserError:
+		return "user"
+	case ExitCodeAuthError:
+		return "auth"
+	case ExitCodeServerError:
+		return "server"
+	default:
+		return "unknown"
+	}
+}
+
+// withExitCode wraps the error, unless it's nil or already has an exit code.
+func withExitCode(err error, code int) error {
+	var exitErr *ExitError
+	if err == nil || errors.As(err, &exitErr) {
+		return err
+	}
+	return &ExitError{Code: code, Err: err}
+}
+
+// clientExitCode returns the exit code for an error returned by a request of the provided client.
+func clientExitCode(sbClient *client.SBClient, err error) int {
+	switch sbClient.ErrorKind(err) {
+	case client.UserError:
+		return ExitCodeUserError
+	case client.AuthError:
+		return ExitCodeAuthError
+	case client.ServerError:
+		return ExitCodeServerError
+	default:
+		return ExitCodeError
+	}
+}
+
+// ClientError gives an error returned by a request of the provided client the exit code that matches its cause.
+// Commands that require a connection get it automatically, so only commands that make requests without one need it.
+func ClientError(sbClient *client.SBClient, err error) error {
+	return withExitCode(err, clientExitCode(sbClient, err))
+}
+
+// exitError returns the provided error with an exit code. Errors that don't have one come from cobra rejecting the arguments or flags.
+func exitError(err error) *ExitError {
+	var exitErr *ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr
+	}
+	return &ExitError{Code: ExitCodeUserError, Err: err}
+}
+
+// errorResult is how errors are written in the structured output formats.
+type errorResult struct {
+	Error    string `json:"error"`
+	Kind     string `json:"kind"`
+	ExitCode int    `json:"exitCode"`
+}
+
+// printError writes the error to the standard error in the provided output format.
+func printError(err *ExitError, format OutputFormat) {
+	result := errorResult{Error: err.Error(), Kind: err.Kind(), ExitCode: err.Code}
+	switch format {
+	case OutputJSON:
+		writeJSON(os.Stderr, result, false)
+	case OutputYAML:
+		writeYAML(os.Stderr, result)
+	default:
+		fmt.Fprintln(os.Stderr, "Error:", err)
+	}
+}
@@ -33,44 +33,64 @@ func (ic *InfoCmd) Command() *cobra.Command {
 	return result
 }
 
+// infoResult is the structured output of the info command. Empty fields are omitted.
+type infoResult struct {
+	Context string `json:"context,omitempty"`
+	Server  string `json:"server,omitempty"`
+	User    string `json:"user,omitempty"`
+	Room    string `json:"room,omitempty"`
+}
+
 // Run is used to build the RunE function for the cobra command
 func (ic *InfoCmd) Run() error {
 	cfg, err := ic.Configurator.Load()
 	if err != nil {
-		fmt.Println("You don't have a valid configuration.")
-		return nil
+		return ic.PrintMessage("You don't have a valid configuration.")
 	}
-	if cfg.URL == "" {
-		fmt.Println("You are not connected to a server.")
-		return nil
+	if insecureFiles, err := ic.Configurator.InsecureFiles(); err == nil {
+		for _, file := range insecureFiles {
+			ic.Warn("%s can be accessed by other users, restrict it with \"chmod 600 %s\"", file, file)
+		}
 	}
-	fmt.Println("Server:", cfg.URL)
-
-	if cfg.Authorization == "" {
-		fmt.Println("You are not logged in.")
-		return nil
+	result := infoResult{Server: cfg.URL, Room: cfg.Room}
+	if context, err := ic.Configurator.ActiveContext(); err == nil {
+		result.Context = context
 	}
-	user, err := util.ExtractUsernameFromAuthorizationHeader(cfg.Authorization)
-	if err != nil {
-		fmt.Println("You are not logged in.")
-		return nil
+	if cfg.Authorization != "" {
+		if user, err := util.ExtractUsernameFromAuthorizationHeader(cfg.Authorization); err == nil {
+			result.User = user
+		}
 	}
-	fmt.Println("User:", user)
 
-	if cfg.Room == "" {
-		fmt.Println("You have not joined a room.")
-		return nil
-	}
-	fmt.Println("Room:", cfg.Room)
+	return ic.Print(result, nil, func() {
+		if result.Context != "" {
+			fmt.Println("Context:", result.Context)
+		}
+		if result.Server == "" {
+			fmt.Println("You are not connected to a server.")
+			return
+		}
+		fmt.Println("Server:", result.Server)
+
+		if result.User == "" {
+			fmt.Println("You are not logged in.")
+			return
+		}
+		fmt.Println("User:", result.User)
 
-	return nil
+		if result.Room == "" {
+			fmt.Println("You have not joined a room.")
+			return
+		}
+		fmt.Println("Room:", result.Room)
+	})
 }
 
 func (ic *InfoCmd) buildCommand() *cobra.Command {
 	var infoCmd = &cobra.Command{
 		Use:     "info",
-		Short:   "Outputs information about the server, user and joined room.",
-		Long:    "Outputs information about the server, user and joined room.",
+		Short:   "Outputs information about the context, server, user and joined room.",
+		Long:    "Outputs information about the context, server, user and joined room.",
 		PreRunE: PreRunE(ic),
 		RunE:    RunE(ic),
 	}
@@ -0,0 +1,181 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// OutputFormat is the format in which commands print their results, selected with the --output flag.
+type OutputFormat string
+
+const (
+	// OutputText prints results as sentences for humans. It's the default.
+	OutputText OutputFormat = "text"
+	// OutputTable prints lists as aligned tables. Results that are not lists are printed as text.
+	OutputTable OutputFormat = "table"
+	// OutputJSON prints results as JSON.
+	OutputJSON OutputFormat = "json"
+	// OutputYAML prints results as YAML, with the same field names as JSON.
+	OutputYAML OutputFormat = "yaml"
+)
+
+// OutputFormats lists the names of all output formats.
+var OutputFormats = []string{string(OutputText), string(OutputTable), string(OutputJSON), string(OutputYAML)}
+
+// ParseOutputFormat returns the output format with the provided name.
+// Returns error if there is no such output format.
+func ParseOutputFormat(format string) (OutputFormat, error) {
+	for _, name := range OutputFormats {
+		if strings.EqualFold(format, name) {
+			return OutputFormat(name), nil
+		}
+	}
+	return OutputText, fmt.Errorf("unknown output format \"%s\", must be one of: %s", format, strings.Join(OutputFormats, ", "))
+}
+
+// Progress returns where long-running commands report their progress. It's the standard error when the results are meant for scripts,
+// so the standard output contains nothing but the results.
+func (ctx *Context) Progress() io.Writer {
+	if ctx.Output == OutputJSON || ctx.Output == OutputYAML {
+		return os.Stderr
+	}
+	return os.Stdout
+}
+
+// Table is the table form of a command's result. Cells must not contain tabs or new lines.
+type Table struct {
+	Headers []string
+	Rows    [][]string
+}
+
+// AddRow appends a row with the provided cells, which are formatted with fmt.Sprint.
+func (table *Table) AddRow(cells ...interface{}) {
+	row := make([]string, 0, len(cells))
+	for _, cell := range cells {
+		row = append(row, strings.NewReplacer("\t", " ", "\n", " ").Replace(fmt.Sprint(cell)))
+	}
+	table.Rows = append(table.Rows, row)
+}
+
+func (table *Table) write(out io.Writer) error {
+	writer := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, strings.Join(table.Headers, "\t"))
+	for _, row := range table.Rows {
+		fmt.Fprintln(writer, strings.Join(row, "\t"))
+	}
+	return writer.Flush()
+}
+
+// MessageResult is the result of commands that perform an action, rather than retrieve data.
+type MessageResult struct {
+	Message string `json:"message"`
+	// Cancelled is true if the user didn't confirm the action, so no action was taken.
+	Cancelled bool `json:"cancelled,omitempty"`
+}
+
+// Print writes the result of a command in the output format selected with the --output flag. The json and yaml formats marshal the data,
+// so its JSON field names are the schema of the command's output and must not change. The table format writes the table, if there is one.
+// The text format, and the table format without a table, call the text function, which prints the result for humans.
+// Lists are printed as empty arrays, rather than null, when they are nil.
+func (ctx *Context) Print(data interface{}, table *Table, text func()) error {
+	if value := reflect.ValueOf(data); value.Kind() == reflect.Slice && value.IsNil() {
+		data = []interface{}{}
+	}
+	switch ctx.Output {
+	case OutputJSON:
+		return writeJSON(os.Stdout, data, true)
+	case OutputYAML:
+		return writeYAML(os.Stdout, data)
+	case OutputTable:
+		if table != nil {
+			return table.write(os.Stdout)
+		}
+	}
+	text()
+	return nil
+}
+
+// PrintItem writes a single item of a stream of results, such as a followed chat. The json format writes every item on its own line and
+// the yaml format writes every item as a separate document. The other formats call the text function.
+func (ctx *Context) PrintItem(data interface{}, text func()) error {
+	switch ctx.Output {
+	case OutputJSON:
+		return writeJSON(os.Stdout, data, false)
+	case OutputYAML:
+		fmt.Fprintln(os.Stdout, "---")
+		return writeYAML(os.Stdout, data)
+	}
+	text()
+	return nil
+}
+
+// PrintMessage writes the outcome of a command that performs an action. The hints, which suggest what to do next, are printed only in
+// the text and table formats.
+func (ctx *Context) PrintMessage(message string, hints ...string) error {
+	return ctx.Print(MessageResult{Message: message}, nil, func() {
+		fmt.Println(message)
+		for _, hint := range hints {
+			fmt.Println(hint)
+		}
+	})
+}
+
+// PrintCancelled writes that the user didn't confirm the action of the command, so no action was taken.
+func (ctx *Context) PrintCancelled() error {
+	result := MessageResult{Message: "Operation cancelled. No action taken.", Cancelled: true}
+	return ctx.Print(result, nil, func() {
+		fmt.Println(result.Message)
+	})
+}
+
+// Warn writes a warning to the standard error, so it doesn't get mixed with the results of the command.
+func (ctx *Context) Warn(format string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, "Warning: "+format+"\n", args...)
+}
+
+func writeJSON(out io.Writer, data interface{}, indent bool) error {
+	encoder := json.NewEncoder(out)
+	if indent {
+		encoder.SetIndent("", "  ")
+	}
+	return encoder.Encode(data)
+}
+
+// writeYAML writes the data as YAML. The data is converted to JSON first, so it has the same field names in both formats.
+func writeYAML(out io.Writer, data interface{}) error {
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	var generic interface{}
+	if err := json.Unmarshal(jsonData, &generic); err != nil {
+		return err
+	}
+	yamlData, err := yaml.Marshal(generic)
+	if err != nil {
+		return err
+	}
+	_, err = out.Write(yamlData)
+	return err
+}
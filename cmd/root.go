@@ -24,7 +24,7 @@ import (
 
 // BuildRootCommand builds the root command for story-builder
 func BuildRootCommand(ctx *Context) *cobra.Command {
-	var cfgFile string
+	var cfgFile, contextName, output string
 
 	var rootCmd = &cobra.Command{
 		Use:   "story-builder",
@@ -32,31 +32,50 @@ func BuildRootCommand(ctx *Context) *cobra.Command {
 		Long:  `Story Builder is a chat room game. All participants take turns in adding a sentence to a story and see the results that they get. To use it you have to connect to a server and register or log in with an existing user. Then join a room and start playing. Check out the help page for available commands and what they are used for.`,
 
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			format, err := ParseOutputFormat(output)
+			if err != nil {
+				return withExitCode(err, ExitCodeUserError)
+			}
+			ctx.Output = format
 			if ctx.Configurator == nil {
-				configurator, err := viper.NewConfigurator(cfgFile)
+				configurator, err := viper.NewConfigurator(cfgFile, contextName)
 				if err != nil {
-					return err
+					return withExitCode(err, ExitCodeUserError)
 				}
 				ctx.Configurator = configurator
 			}
 			config, err := ctx.Configurator.Load()
 			if err != nil {
-				return err
+				return withExitCode(err, ExitCodeUserError)
 			}
 			ctx.Client = client.NewSBClient(config)
 			return nil
 		},
+		// Errors are printed by Execute, in the selected output format
+		SilenceErrors: true,
 	}
+	rootCmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return withExitCode(err, ExitCodeUserError)
+	})
 
 	rootCmd.PersistentFlags().StringVarP(&cfgFile, "config", "c", "", "config file (default is <homedir>/.story-builder.json)")
+	rootCmd.PersistentFlags().StringVar(&contextName, "context", "", "context to use instead of the current one")
+	rootCmd.PersistentFlags().StringVarP(&output, "output", "o", string(OutputText), "output format: text, table, json or yaml")
 
 	return rootCmd
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
+// If the command fails, the error is printed in the selected output format and the CLI exits with the exit code of the error.
 func Execute(cmd *cobra.Command) {
 	if err := cmd.Execute(); err != nil {
-		os.Exit(1)
+		format := OutputText
+		if flag := cmd.PersistentFlags().Lookup("output"); flag != nil {
+			format, _ = ParseOutputFormat(flag.Value.String())
+		}
+		exitErr := exitError(err)
+		printError(exitErr, format)
+		os.Exit(exitErr.Code)
 	}
 }
@@ -0,0 +1,110 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/pavelhadzhiev/story-builder/cmd"
+	"github.com/pavelhadzhiev/story-builder/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+// AddContextCmd is a wrapper for the story-builder context add command
+type AddContextCmd struct {
+	*cmd.Context
+
+	name               string
+	url                string
+	caFile             string
+	insecureSkipVerify bool
+	use                bool
+}
+
+// Command builds and returns a cobra command that will be added to the root command
+func (acc *AddContextCmd) Command() *cobra.Command {
+	result := acc.buildCommand()
+
+	return result
+}
+
+// Validate makes sure all required arguments are legal and are provided
+func (acc *AddContextCmd) Validate(args []string) error {
+	if len(args) != 2 {
+		return fmt.Errorf("requires exactly two args - a name and a server URL")
+	}
+	if err := validateContextName(args[0]); err != nil {
+		return err
+	}
+	parsedURL, err := url.Parse(args[1])
+	if err != nil || !parsedURL.IsAbs() || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") {
+		return fmt.Errorf("\"%s\" is not a valid server URL, use \"http://host:port\" or \"https://host:port\"", args[1])
+	}
+
+	acc.name = args[0]
+	acc.url = args[1]
+	return nil
+}
+
+// Run is used to build the RunE function for the cobra command
+func (acc *AddContextCmd) Run() error {
+	contexts, err := acc.Configurator.LoadContexts()
+	if err != nil {
+		return err
+	}
+	if contexts.Get(acc.name) != nil {
+		return fmt.Errorf("context \"%s\" already exists", acc.name)
+	}
+
+	contexts.Set(acc.name, config.SBConfiguration{URL: acc.url, CAFile: acc.caFile, InsecureSkipVerify: acc.insecureSkipVerify})
+	if acc.use || contexts.Current == "" {
+		contexts.Current = acc.name
+	}
+	if err := acc.Configurator.SaveContexts(contexts); err != nil {
+		return err
+	}
+
+	message := fmt.Sprintf("You've added context \"%s\" for \"%s\".", acc.name, acc.url)
+	if contexts.Current == acc.name {
+		return acc.PrintMessage(message, "It's now the current context. You can log in as an existing user or register as a new one.")
+	}
+	return acc.PrintMessage(message)
+}
+
+// validateContextName makes sure the provided context name can be typed in a shell without quoting.
+func validateContextName(name string) error {
+	if name == "" || strings.ContainsAny(name, " \t\n\"'") {
+		return fmt.Errorf("context name \"%s\" is not valid - it must not be empty or contain spaces or quotes", name)
+	}
+	return nil
+}
+
+func (acc *AddContextCmd) buildCommand() *cobra.Command {
+	var addContextCmd = &cobra.Command{
+		Use:     "add [name] [url]",
+		Short:   "Adds a context for the server with the provided URL.",
+		Long:    `Adds a context with the provided name for the server with the provided URL. The server isn't contacted until the context is used. For servers that use a self-signed TLS certificate, provide the certificate with the --ca-file flag.`,
+		PreRunE: cmd.PreRunE(acc),
+		RunE:    cmd.RunE(acc),
+	}
+
+	addContextCmd.Flags().StringVar(&acc.caFile, "ca-file", "", "PEM file with the certificate authority to verify the server's TLS certificate against, e.g. the self-signed certificate of the server")
+	addContextCmd.Flags().BoolVar(&acc.insecureSkipVerify, "insecure-skip-verify", false, "don't verify the server's TLS certificate. Use only for testing")
+	addContextCmd.Flags().BoolVar(&acc.use, "use", false, "make the new context the current one")
+
+	return addContextCmd
+}
@@ -16,6 +16,8 @@ package server
 
 import (
 	"fmt"
+	"net"
+	"net/url"
 
 	"github.com/pavelhadzhiev/story-builder/cmd"
 	"github.com/spf13/cobra"
@@ -27,7 +29,9 @@ import (
 type ConnectCmd struct {
 	*cmd.Context
 
-	host string
+	host               string
+	caFile             string
+	insecureSkipVerify bool
 }
 
 // Command builds and returns a cobra command that will be added to the root command
@@ -58,25 +62,45 @@ func (cc *ConnectCmd) Run() error {
 		return err
 	}
 	if cfg.URL != "" {
-		return fmt.Errorf("you are already connected to \"" + cfg.URL + "\". You have to disconnect first")
+		return fmt.Errorf("you are already connected to \"" + cfg.URL + "\". You have to disconnect first or add a context for the other server")
 	}
 	cfg.URL = cc.host
-	if err = client.NewSBClient(cfg).HealthCheck(cc.Configurator); err != nil {
-		return err
+	cfg.CAFile = cc.caFile
+	cfg.InsecureSkipVerify = cc.insecureSkipVerify
+	sbClient := client.NewSBClient(cfg)
+	if err = sbClient.HealthCheck(cc.Configurator); err != nil {
+		return cmd.ClientError(sbClient, err)
 	}
 
-	fmt.Println("You've successfully connected to \"" + cfg.URL + "\"! You can now log in as an existing user or register as a new one.")
-	return nil
+	if parsedURL, _ := url.Parse(cfg.URL); parsedURL.Scheme == "http" && !isLoopback(parsedURL.Hostname()) {
+		cc.Warn("the connection is not encrypted, so your credentials are sent in clear text. Connect with https if the server supports it.")
+	} else if cfg.InsecureSkipVerify {
+		cc.Warn("the certificate of the server is not verified. Use --ca-file instead of --insecure-skip-verify outside of testing.")
+	}
+
+	return cc.PrintMessage("You've successfully connected to \"" + cfg.URL + "\"! You can now log in as an existing user or register as a new one.")
+}
+
+// isLoopback returns true if the provided host name refers to the local machine.
+func isLoopback(hostname string) bool {
+	if hostname == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(hostname)
+	return ip != nil && ip.IsLoopback()
 }
 
 func (cc *ConnectCmd) buildCommand() *cobra.Command {
 	var connectCmd = &cobra.Command{
 		Use:     "connect [host]",
 		Short:   "Connects to a healthy server with the provided host.",
-		Long:    `Connects to a healthy server with the provided host. If the URL is invalid, or the specified server doesn't have a responing healthcheck, the request is rejected.`,
+		Long:    `Connects to a healthy server with the provided host. If the URL is invalid, or the specified server doesn't have a responing healthcheck, the request is rejected. For servers that use a self-signed TLS certificate, provide the certificate with the --ca-file flag.`,
 		PreRunE: cmd.PreRunE(cc),
 		RunE:    cmd.RunE(cc),
 	}
 
+	connectCmd.Flags().StringVar(&cc.caFile, "ca-file", "", "PEM file with the certificate authority to verify the server's TLS certificate against, e.g. the self-signed certificate of the server")
+	connectCmd.Flags().BoolVar(&cc.insecureSkipVerify, "insecure-skip-verify", false, "don't verify the server's TLS certificate. Use only for testing")
+
 	return connectCmd
 }
@@ -0,0 +1,51 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"github.com/pavelhadzhiev/story-builder/cmd"
+	"github.com/spf13/cobra"
+)
+
+// ContextCmd is a wrapper for the story-builder context command group
+type ContextCmd struct {
+	*cmd.Context
+}
+
+// Command builds and returns a cobra command that will be added to the root command
+func (cc *ContextCmd) Command() *cobra.Command {
+	result := cc.buildCommand()
+
+	return result
+}
+
+func (cc *ContextCmd) buildCommand() *cobra.Command {
+	var contextCmd = &cobra.Command{
+		Use:     "context",
+		Aliases: []string{"ctx"},
+		Short:   "Manages the contexts of the CLI.",
+		Long: `Manages the contexts of the CLI. A context is a named server profile - a server, the user logged in to it and the room they've joined.
+All commands use the current context, unless another one is selected with the --context flag.`,
+	}
+
+	contextCmd.AddCommand(
+		(&AddContextCmd{Context: cc.Context}).Command(),
+		(&UseContextCmd{Context: cc.Context}).Command(),
+		(&ListContextsCmd{Context: cc.Context}).Command(),
+		(&DeleteContextCmd{Context: cc.Context}).Command(),
+	)
+
+	return contextCmd
+}
@@ -0,0 +1,77 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"fmt"
+
+	"github.com/pavelhadzhiev/story-builder/cmd"
+	"github.com/spf13/cobra"
+)
+
+// DeleteContextCmd is a wrapper for the story-builder context delete command
+type DeleteContextCmd struct {
+	*cmd.Context
+
+	name string
+}
+
+// Command builds and returns a cobra command that will be added to the root command
+func (dcc *DeleteContextCmd) Command() *cobra.Command {
+	result := dcc.buildCommand()
+
+	return result
+}
+
+// Validate makes sure all required arguments are legal and are provided
+func (dcc *DeleteContextCmd) Validate(args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("requires a single arg")
+	}
+	dcc.name = args[0]
+	return nil
+}
+
+// Run is used to build the RunE function for the cobra command
+func (dcc *DeleteContextCmd) Run() error {
+	contexts, err := dcc.Configurator.LoadContexts()
+	if err != nil {
+		return err
+	}
+	if dcc.name == contexts.Current {
+		return fmt.Errorf("context \"%s\" is the current one. Switch to another context first", dcc.name)
+	}
+	if !contexts.Delete(dcc.name) {
+		return fmt.Errorf("context \"%s\" doesn't exist", dcc.name)
+	}
+	if err := dcc.Configurator.SaveContexts(contexts); err != nil {
+		return err
+	}
+
+	return dcc.PrintMessage(fmt.Sprintf("You've deleted context \"%s\".", dcc.name))
+}
+
+func (dcc *DeleteContextCmd) buildCommand() *cobra.Command {
+	var deleteContextCmd = &cobra.Command{
+		Use:     "delete [name]",
+		Aliases: []string{"rm"},
+		Short:   "Deletes the context with the provided name.",
+		Long:    `Deletes the context with the provided name, including its credentials. The current context can't be deleted - use disconnect to clear it instead.`,
+		PreRunE: cmd.PreRunE(dcc),
+		RunE:    cmd.RunE(dcc),
+	}
+
+	return deleteContextCmd
+}
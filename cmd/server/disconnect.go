@@ -15,8 +15,6 @@
 package server
 
 import (
-	"fmt"
-
 	"github.com/pavelhadzhiev/story-builder/cmd"
 	"github.com/spf13/cobra"
 )
@@ -55,8 +53,7 @@ func (dc *DisconnectCmd) Run() error {
 	cfg.URL = ""
 	dc.Configurator.Save(cfg)
 
-	fmt.Println("You've disconnected from the server.")
-	return nil
+	return dc.PrintMessage("You've disconnected from the server.")
 }
 
 func (dc *DisconnectCmd) buildCommand() *cobra.Command {
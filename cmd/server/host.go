@@ -15,25 +15,76 @@
 package server
 
 import (
-	"bufio"
+	"context"
 	"fmt"
+	"net"
 	"os"
+	"os/signal"
+	"path/filepath"
 	"strconv"
+	"syscall"
 
-	"github.com/pavelhadzhiev/story-builder/pkg/db"
-
+	homedir "github.com/mitchellh/go-homedir"
 	"github.com/pavelhadzhiev/story-builder/cmd"
 	"github.com/pavelhadzhiev/story-builder/pkg/api"
+	"github.com/pavelhadzhiev/story-builder/pkg/api/audit"
+	"github.com/pavelhadzhiev/story-builder/pkg/api/logging"
+	"github.com/pavelhadzhiev/story-builder/pkg/api/ratelimit"
+	"github.com/pavelhadzhiev/story-builder/pkg/api/reminders"
+	"github.com/pavelhadzhiev/story-builder/pkg/config"
+	"github.com/pavelhadzhiev/story-builder/pkg/config/viper"
+	"github.com/pavelhadzhiev/story-builder/pkg/db"
+	"github.com/pavelhadzhiev/story-builder/pkg/util"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// defaultSelfSignedCert and defaultSelfSignedKey are the files in the home directory, in which the self-signed certificate is kept, unless other files are provided.
+const (
+	defaultSelfSignedCert = ".story-builder-tls.crt"
+	defaultSelfSignedKey  = ".story-builder-tls.key"
 )
 
+// defaultSnapshot is the file in the home directory, in which the state of the server is kept between runs, unless another file is provided.
+const defaultSnapshot = ".story-builder-snapshot.json"
+
+// defaultAuditLog is the file in the home directory, to which the audit log is appended, unless another file is provided.
+const defaultAuditLog = ".story-builder-audit.log"
+
+// flagKeys maps the flags of the host command to the settings in the server configuration they override.
+var flagKeys = map[string]string{
+	"username":             "database.username",
+	"password":             "database.password",
+	"rate-limit":           "rateLimits.requestsPerSecond",
+	"rate-burst":           "rateLimits.burst",
+	"lockout-threshold":    "rateLimits.lockoutThreshold",
+	"lockout-duration":     "rateLimits.lockoutDuration",
+	"max-lockout-duration": "rateLimits.maxLockoutDuration",
+	"smtp-host":            "reminders.host",
+	"smtp-port":            "reminders.port",
+	"smtp-from":            "reminders.from",
+	"reminder-interval":    "reminders.interval",
+	"webhook-allow":        "webhooks.allowedNetworks",
+	"tls-cert":             "tls.cert",
+	"tls-key":              "tls.key",
+	"tls-self-signed":      "tls.selfSigned",
+	"drain-timeout":        "shutdown.drainTimeout",
+	"snapshot":             "shutdown.snapshotFile",
+	"no-snapshot":          "shutdown.disableSnapshot",
+	"server-admin":         "serverAdmins",
+	"log-level":            "logLevel",
+	"log-format":           "logFormat",
+	"audit-log":            "audit.file",
+	"no-audit-log":         "audit.disabled",
+}
+
 // HostCmd is a wrapper for the story-builder host command
 type HostCmd struct {
 	database *db.SBDatabase
-	username string
-	password string
 
-	port int
+	configFile string
+	config     *config.ServerConfiguration
+	flags      *pflag.FlagSet
 }
 
 // Command builds and returns a cobra command that will be added to the root command
@@ -49,56 +100,205 @@ func (hc *HostCmd) Validate(args []string) error {
 		return fmt.Errorf("requires a single arg or no args")
 	}
 
-	if hc.username == "" && hc.password == "" { // set default database user if not provided
-		hc.username = "admin"
-		hc.password = "Abcd1234"
+	flags := make(map[string]*pflag.Flag)
+	for name, key := range flagKeys {
+		flags[key] = hc.flags.Lookup(name)
+	}
+	serverConfig, err := viper.LoadServerConfiguration(hc.configFile, flags)
+	if err != nil {
+		return err
+	}
+	hc.config = serverConfig
+
+	if !hc.config.Shutdown.DisableSnapshot && hc.config.Shutdown.SnapshotFile == "" {
+		home, err := homedir.Dir()
+		if err != nil {
+			return err
+		}
+		hc.config.Shutdown.SnapshotFile = filepath.Join(home, defaultSnapshot)
+	}
+	if !hc.config.Audit.Disabled && hc.config.Audit.File == "" {
+		home, err := homedir.Dir()
+		if err != nil {
+			return err
+		}
+		hc.config.Audit.File = filepath.Join(home, defaultAuditLog)
 	}
 
-	if len(args) == 0 { // set default server port if not provided
-		hc.port = 8080
+	if len(args) == 0 { // keep the configured listen address if no port is provided
 		return nil
 	}
 
 	portString := args[0]
 	portNumber, err := strconv.Atoi(portString)
-	if err != nil || portNumber < 0 {
+	if err != nil || portNumber < 0 || portNumber > 65535 {
 		return fmt.Errorf("provided port \"%s\" is not valid", portString)
 	}
-	hc.port = portNumber
+	host, _, _ := net.SplitHostPort(hc.config.Listen)
+	hc.config.Listen = net.JoinHostPort(host, portString)
 	return nil
 }
 
 // Run is used to build the RunE function for the cobra command
 func (hc *HostCmd) Run() error {
-	hc.database = db.NewSBDatabase(hc.username, hc.password)
+	hc.database = db.NewSBDatabaseWithSettings(hc.config.Database)
 	defer hc.database.CloseDB()
 	if err := hc.database.InitializeDB(); err != nil {
 		return err
 	}
 
-	sbServer := api.NewSBServer(hc.database, hc.port)
+	logLevel, err := logging.ParseLevel(hc.config.LogLevel)
+	if err != nil {
+		return err
+	}
+	logFormat, err := logging.ParseFormat(hc.config.LogFormat)
+	if err != nil {
+		return err
+	}
+	sbServer := api.NewSBServer(hc.database, 0)
+	sbServer.SetAddress(hc.config.Listen)
+	sbServer.SetLogger(logging.NewLogger(os.Stderr, logLevel, logFormat))
+	if !hc.config.Audit.Disabled {
+		auditLog, err := audit.NewLog(hc.config.Audit.File)
+		if err != nil {
+			return fmt.Errorf("cannot open the audit log: %v", err)
+		}
+		defer auditLog.Close()
+		sbServer.SetAuditLog(auditLog)
+	}
+	sbServer.ConfigureRateLimits(hc.config.RateLimits)
+	sbServer.ConfigureWebhooks(hc.config.Webhooks)
+	if hc.config.Reminders.Enabled() {
+		sbServer.EnableTurnReminders(hc.config.Reminders)
+	}
+	sbServer.VoteSettings = hc.config.Votes
+	sbServer.GameSettings = &hc.config.Game
+	scheme := "http"
+	if hc.config.TLS.Enabled() {
+		if err := hc.prepareTLS(sbServer); err != nil {
+			return err
+		}
+		scheme = "https"
+	}
+	if err := sbServer.BootstrapServerAdmins(hc.config.ServerAdmins); err != nil {
+		return err
+	}
+	if !hc.config.Shutdown.DisableSnapshot {
+		if err := hc.restoreSnapshot(sbServer); err != nil {
+			return err
+		}
+	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signals)
+
 	sbServer.Start()
-	defer sbServer.Shutdown()
+	host, port, _ := net.SplitHostPort(hc.config.Listen)
+	if host == "" {
+		host = "localhost"
+	}
+	fmt.Printf("Server was started at %s://%s\n", scheme, net.JoinHostPort(host, port))
+	fmt.Println("Press Ctrl+C to shut down...")
 
-	fmt.Println("Server was started at http://localhost:", hc.port)
-	fmt.Println("Press ENTER to shut down...")
-	reader := bufio.NewReader(os.Stdin)
-	reader.ReadString('\n')
+	received := <-signals
+	fmt.Printf("Received %v, shutting down...\n", received)
+	drainTimeout := hc.config.Shutdown.DrainTimeout
+	ctx, cancel := context.WithTimeout(context.Background(), drainTimeout)
+	defer cancel()
+	if err := sbServer.Shutdown(ctx); err != nil {
+		fmt.Printf("Not all requests finished within %v: %v\n", drainTimeout, err)
+	}
+
+	if !hc.config.Shutdown.DisableSnapshot {
+		if err := sbServer.SaveSnapshot(hc.config.Shutdown.SnapshotFile); err != nil {
+			return fmt.Errorf("cannot save a snapshot of the server: %v", err)
+		}
+		fmt.Printf("Saved a snapshot of the server at \"%s\".\n", hc.config.Shutdown.SnapshotFile)
+	}
+	return nil
+}
 
+// restoreSnapshot restores the server from the snapshot file, if there is one, and removes the file, so a crash later on doesn't restore stale state.
+func (hc *HostCmd) restoreSnapshot(sbServer *api.SBServer) error {
+	taken, err := sbServer.LoadSnapshot(hc.config.Shutdown.SnapshotFile)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("cannot restore the snapshot at \"%s\": %v", hc.config.Shutdown.SnapshotFile, err)
+	}
+	if err := os.Remove(hc.config.Shutdown.SnapshotFile); err != nil {
+		return err
+	}
+	fmt.Printf("Restored %d rooms from the snapshot taken at %s.\n", len(sbServer.Rooms), taken.Local().Format("2006-01-02 15:04:05"))
 	return nil
 }
 
+// prepareTLS enables TLS on the server, generating a self-signed certificate first if requested and there isn't one yet.
+func (hc *HostCmd) prepareTLS(sbServer *api.SBServer) error {
+	tlsSettings := &hc.config.TLS
+	if tlsSettings.SelfSigned {
+		if tlsSettings.Cert == "" {
+			home, err := homedir.Dir()
+			if err != nil {
+				return err
+			}
+			tlsSettings.Cert = filepath.Join(home, defaultSelfSignedCert)
+			tlsSettings.Key = filepath.Join(home, defaultSelfSignedKey)
+		}
+		if _, err := os.Stat(tlsSettings.Cert); os.IsNotExist(err) {
+			hosts := []string{"localhost", "127.0.0.1", "::1"}
+			if hostname, err := os.Hostname(); err == nil {
+				hosts = append(hosts, hostname)
+			}
+			if err := util.GenerateSelfSignedCertificate(tlsSettings.Cert, tlsSettings.Key, hosts); err != nil {
+				return fmt.Errorf("cannot generate a self-signed certificate: %v", err)
+			}
+			fmt.Printf("Generated a self-signed certificate at \"%s\".\n", tlsSettings.Cert)
+		}
+		fmt.Printf("Clients have to connect with \"--ca-file %s\" to trust it.\n", tlsSettings.Cert)
+	}
+	return sbServer.EnableTLS(tlsSettings.Cert, tlsSettings.Key)
+}
+
 func (hc *HostCmd) buildCommand() *cobra.Command {
 	var serverCmd = &cobra.Command{
-		Use:     "host [port]",
-		Short:   "Hosts a server at the specified port.",
-		Long:    `Hosts a server at the specified port. If the port is invalid or the server cannot be started, a sufficient errog message is returned.`,
+		Use:   "host [port]",
+		Short: "Hosts a server at the specified port.",
+		Long: `Hosts a server at the specified port. If the port is invalid or the server cannot be started, a sufficient errog message is returned.
+The server is configured with a configuration file (--config), environment variables prefixed with SB_ and the flags below, which take precedence in reverse order.
+The server shuts down gracefully on SIGINT (Ctrl+C) or SIGTERM, saving the rooms and games in a snapshot, which is restored on the next start.`,
 		PreRunE: cmd.PreRunE(hc),
 		RunE:    cmd.RunE(hc),
 	}
 
-	serverCmd.Flags().StringVarP(&hc.username, "username", "u", "", `Username to access database with. Default value is "admin"`)
-	serverCmd.Flags().StringVarP(&hc.password, "password", "p", "", `Password to access database with. Default value is "Abcd1234"`)
+	defaults := config.DefaultServerConfiguration()
+	serverCmd.Flags().StringVarP(&hc.configFile, "config", "c", "", "Server configuration file in YAML, JSON or TOML format")
+	serverCmd.Flags().StringP("username", "u", "", `Username to access database with. Default value is "admin"`)
+	serverCmd.Flags().StringP("password", "p", "", `Password to access database with. Default value is "Abcd1234"`)
+	serverCmd.Flags().Float64("rate-limit", ratelimit.DefaultSettings.RequestsPerSecond, "Average number of requests per second allowed for every IP address and user. 0 disables rate limiting")
+	serverCmd.Flags().Int("rate-burst", ratelimit.DefaultSettings.Burst, "Number of requests every IP address and user can make at once, before the rate limit applies")
+	serverCmd.Flags().Int("lockout-threshold", ratelimit.DefaultSettings.LockoutThreshold, "Number of failed logins in a row, after which the user and the IP address are locked out. 0 disables lockouts")
+	serverCmd.Flags().Duration("lockout-duration", ratelimit.DefaultSettings.LockoutDuration, "Duration of the first lockout. It doubles with every further failed login")
+	serverCmd.Flags().Duration("max-lockout-duration", ratelimit.DefaultSettings.MaxLockoutDuration, "Longest duration of a lockout")
+	serverCmd.Flags().String("smtp-host", "", "Host of the SMTP relay that emails players when it's their turn. Turn reminders are off without it")
+	serverCmd.Flags().Int("smtp-port", reminders.DefaultSettings.Port, "Port of the SMTP relay")
+	serverCmd.Flags().String("smtp-from", "", "Sender address of the turn reminders, e.g. noreply@example.com")
+	serverCmd.Flags().Duration("reminder-interval", reminders.DefaultSettings.Interval, "Minimum time between two turn reminders to the same player in the same room")
+	serverCmd.Flags().StringSlice("webhook-allow", nil, `IP addresses and CIDR ranges that webhooks may deliver to, even though they are loopback, private or link-local, e.g. "127.0.0.1"`)
+	serverCmd.Flags().String("tls-cert", "", "PEM file with the TLS certificate of the server. Requires --tls-key")
+	serverCmd.Flags().String("tls-key", "", "PEM file with the private key of the TLS certificate")
+	serverCmd.Flags().Bool("tls-self-signed", false, `Serve HTTPS with a self-signed certificate, generated on the first run. It's saved in the --tls-cert and --tls-key files, or in the home directory if they are not provided`)
+	serverCmd.Flags().Duration("drain-timeout", defaults.Shutdown.DrainTimeout, "How long to wait for ongoing requests to finish when the server shuts down")
+	serverCmd.Flags().String("snapshot", "", `File in which the rooms and games are saved on shutdown and restored from on the next start. Default value is "<homedir>/`+defaultSnapshot+`"`)
+	serverCmd.Flags().Bool("no-snapshot", false, "Don't save or restore a snapshot of the rooms and games")
+	serverCmd.Flags().StringSlice("server-admin", nil, "Registered users to give the server admin role to. Unknown usernames are skipped")
+	serverCmd.Flags().String("log-level", defaults.LogLevel, "Log level of the server: debug, info, warn or error")
+	serverCmd.Flags().String("log-format", defaults.LogFormat, "Format of the server logs: logfmt or json")
+	serverCmd.Flags().String("audit-log", "", `File to which the audit log is appended. Default value is "<homedir>/`+defaultAuditLog+`"`)
+	serverCmd.Flags().Bool("no-audit-log", false, "Don't keep an audit log")
+	hc.flags = serverCmd.Flags()
 
 	return serverCmd
 }
@@ -0,0 +1,100 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"fmt"
+
+	"github.com/pavelhadzhiev/story-builder/cmd"
+	"github.com/pavelhadzhiev/story-builder/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+// contextResult is the structured output of the context list command. It never contains the authorization of the context.
+type contextResult struct {
+	Name    string `json:"name"`
+	Current bool   `json:"current"`
+	URL     string `json:"url,omitempty"`
+	User    string `json:"user,omitempty"`
+	Room    string `json:"room,omitempty"`
+}
+
+// ListContextsCmd is a wrapper for the story-builder context list command
+type ListContextsCmd struct {
+	*cmd.Context
+}
+
+// Command builds and returns a cobra command that will be added to the root command
+func (lcc *ListContextsCmd) Command() *cobra.Command {
+	result := lcc.buildCommand()
+
+	return result
+}
+
+// Run is used to build the RunE function for the cobra command
+func (lcc *ListContextsCmd) Run() error {
+	contexts, err := lcc.Configurator.LoadContexts()
+	if err != nil {
+		return err
+	}
+	results := make([]contextResult, 0, len(contexts.Contexts))
+	table := &cmd.Table{Headers: []string{"NAME", "CURRENT", "URL", "USER", "ROOM"}}
+	for _, context := range contexts.Contexts {
+		result := contextResult{Name: context.Name, Current: context.Name == contexts.Current, URL: context.URL, Room: context.Room}
+		if user, err := util.ExtractUsernameFromAuthorizationHeader(context.Authorization); err == nil && context.Authorization != "" {
+			result.User = user
+		}
+		results = append(results, result)
+		table.AddRow(result.Name, result.Current, result.URL, result.User, result.Room)
+	}
+
+	return lcc.Print(results, table, func() {
+		if len(results) == 0 {
+			fmt.Println("There are no contexts. Add one with \"story-builder context add\" or connect to a server.")
+			return
+		}
+		for _, result := range results {
+			marker := " "
+			if result.Current {
+				marker = "*"
+			}
+			url := result.URL
+			if url == "" {
+				url = "(not connected)"
+			}
+			line := fmt.Sprintf("%s %s\t%s", marker, result.Name, url)
+			if result.User != "" {
+				line += "\tuser: " + result.User
+			}
+			if result.Room != "" {
+				line += "\troom: " + result.Room
+			}
+			fmt.Println(line)
+		}
+	})
+}
+
+func (lcc *ListContextsCmd) buildCommand() *cobra.Command {
+	var listContextsCmd = &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "Lists all contexts.",
+		Long:    `Lists all contexts with their servers, logged in users and joined rooms. The current context is marked with "*".`,
+		PreRunE: cmd.PreRunE(lcc),
+		RunE:    cmd.RunE(lcc),
+	}
+
+	return listContextsCmd
+}
@@ -0,0 +1,67 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"fmt"
+
+	"github.com/pavelhadzhiev/story-builder/cmd"
+	"github.com/spf13/cobra"
+)
+
+// StatusCmd is a wrapper for the story-builder status command
+type StatusCmd struct {
+	*cmd.Context
+}
+
+// Command builds and returns a cobra command that will be added to the root command
+func (sc *StatusCmd) Command() *cobra.Command {
+	result := sc.buildCommand()
+
+	return result
+}
+
+// RequiresConnection makes sure that the configured server is valid and online before executing the command logic
+func (sc *StatusCmd) RequiresConnection() *cmd.Context {
+	return sc.Context
+}
+
+// Run is used to build the RunE function for the cobra command
+func (sc *StatusCmd) Run() error {
+	status, err := sc.Client.GetStatus()
+	if err != nil {
+		return err
+	}
+
+	return sc.Print(status, nil, func() {
+		fmt.Println("Version:", status.Version)
+		fmt.Println("Uptime:", status.UptimeDuration())
+		fmt.Printf("Rooms: %d (%d active games)\n", status.Rooms, status.ActiveGames)
+		fmt.Println("Online users:", status.OnlineUsers)
+		fmt.Println("Database:", status.Database)
+	})
+}
+
+func (sc *StatusCmd) buildCommand() *cobra.Command {
+	var statusCmd = &cobra.Command{
+		Use:     "status",
+		Short:   "Outputs the health report of the server.",
+		Long:    "Outputs the health report of the server - its version, uptime, number of rooms, active games and online users, and whether its database is reachable.",
+		PreRunE: cmd.PreRunE(sc),
+		RunE:    cmd.RunE(sc),
+	}
+
+	return statusCmd
+}
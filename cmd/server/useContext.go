@@ -0,0 +1,76 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"fmt"
+
+	"github.com/pavelhadzhiev/story-builder/cmd"
+	"github.com/spf13/cobra"
+)
+
+// UseContextCmd is a wrapper for the story-builder context use command
+type UseContextCmd struct {
+	*cmd.Context
+
+	name string
+}
+
+// Command builds and returns a cobra command that will be added to the root command
+func (ucc *UseContextCmd) Command() *cobra.Command {
+	result := ucc.buildCommand()
+
+	return result
+}
+
+// Validate makes sure all required arguments are legal and are provided
+func (ucc *UseContextCmd) Validate(args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("requires a single arg")
+	}
+	ucc.name = args[0]
+	return nil
+}
+
+// Run is used to build the RunE function for the cobra command
+func (ucc *UseContextCmd) Run() error {
+	contexts, err := ucc.Configurator.LoadContexts()
+	if err != nil {
+		return err
+	}
+	context := contexts.Get(ucc.name)
+	if context == nil {
+		return fmt.Errorf("context \"%s\" doesn't exist", ucc.name)
+	}
+
+	contexts.Current = ucc.name
+	if err := ucc.Configurator.SaveContexts(contexts); err != nil {
+		return err
+	}
+
+	return ucc.PrintMessage(fmt.Sprintf("You've switched to context \"%s\" for \"%s\".", ucc.name, context.URL))
+}
+
+func (ucc *UseContextCmd) buildCommand() *cobra.Command {
+	var useContextCmd = &cobra.Command{
+		Use:     "use [name]",
+		Short:   "Makes the context with the provided name the current one.",
+		Long:    `Makes the context with the provided name the current one. All commands use it from now on, unless another context is selected with the --context flag.`,
+		PreRunE: cmd.PreRunE(ucc),
+		RunE:    cmd.RunE(ucc),
+	}
+
+	return useContextCmd
+}
@@ -0,0 +1,186 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/pavelhadzhiev/story-builder/pkg/api/audit"
+	"github.com/pavelhadzhiev/story-builder/pkg/api/users"
+	"github.com/pavelhadzhiev/story-builder/pkg/util"
+)
+
+// AccountHandler is an http handler for the story builder's account management endpoint.
+// It serves /account/ for account deletion and /account/password for password changes.
+func (server *SBServer) AccountHandler(w http.ResponseWriter, r *http.Request) {
+	username, ok := server.authenticate(w, r)
+	if !ok {
+		return
+	}
+
+	switch strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/account/"), "/") {
+	case "":
+		if r.Method != http.MethodDelete {
+			w.WriteHeader(405)
+			return
+		}
+		if err := server.DeleteUser(username); err != nil {
+			w.WriteHeader(500)
+			w.Write([]byte("Database write failed."))
+			return
+		}
+		server.audit(r, audit.Entry{Action: audit.AccountDeleted, Actor: username, Target: username})
+		w.Write([]byte("Your account has been deleted. Goodbye, " + username + "."))
+	case "password":
+		if r.Method != http.MethodPut {
+			w.WriteHeader(405)
+			return
+		}
+		oldPassword, newPassword := r.Header.Get("Old-Password"), r.Header.Get("New-Password")
+		if oldPassword == "" || newPassword == "" {
+			w.WriteHeader(400)
+			w.Write([]byte("Missing Old-Password or New-Password header."))
+			return
+		}
+		if err := server.loginUser(r, username, oldPassword); err != nil {
+			if writeLockedOut(w, err) {
+				return
+			}
+			w.WriteHeader(403)
+			w.Write([]byte("The old password is wrong."))
+			return
+		}
+		if err := server.Database.UpdatePassword(username, newPassword); err != nil {
+			w.WriteHeader(500)
+			w.Write([]byte("Database write failed."))
+			return
+		}
+		w.Write([]byte("Your password has been changed."))
+	default:
+		w.WriteHeader(404)
+		w.Write([]byte("Request URL is illegal."))
+	}
+}
+
+// ProfileHandler is an http handler for the story builder's profiles endpoint.
+// Everyone can get the profile of a user at /profiles/<user>, but only the user can update it or see its private information.
+func (server *SBServer) ProfileHandler(w http.ResponseWriter, r *http.Request) {
+	username := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/profiles/"), "/")
+	if username == "" || strings.Contains(username, "/") {
+		w.WriteHeader(404)
+		w.Write([]byte("Request URL is illegal."))
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		profile, err := server.Database.GetProfile(username)
+		if err != nil {
+			w.WriteHeader(500)
+			w.Write([]byte("Database lookup failed."))
+			return
+		}
+		if profile == nil {
+			w.WriteHeader(404)
+			w.Write([]byte("User \"" + username + "\" doesn't exist."))
+			return
+		}
+		if !server.isProfileOwner(r, username) {
+			*profile = profile.Public()
+		}
+		responseBody, err := json.Marshal(profile)
+		if err != nil {
+			w.WriteHeader(500)
+			w.Write([]byte("Error during serialization of retrieved profile."))
+			return
+		}
+		w.Write(responseBody)
+	case http.MethodPut:
+		issuer, ok := server.authenticate(w, r)
+		if !ok {
+			return
+		}
+		if issuer != username {
+			w.WriteHeader(403)
+			w.Write([]byte("Users can only update their own profile."))
+			return
+		}
+
+		var profile users.Profile
+		if err := json.NewDecoder(r.Body).Decode(&profile); err != nil {
+			w.WriteHeader(400)
+			w.Write([]byte("Request body is not a valid profile."))
+			return
+		}
+		profile.Username = username
+		if err := profile.Validate(); err != nil {
+			w.WriteHeader(400)
+			w.Write([]byte(fmt.Sprintf("Profile cannot be updated: %v.", err)))
+			return
+		}
+		if err := server.Database.UpdateProfile(profile); err != nil {
+			w.WriteHeader(500)
+			w.Write([]byte("Database write failed."))
+			return
+		}
+
+		responseBody, err := json.Marshal(profile)
+		if err != nil {
+			w.WriteHeader(500)
+			w.Write([]byte("Error during serialization of updated profile."))
+			return
+		}
+		w.Write(responseBody)
+	default:
+		w.WriteHeader(405)
+	}
+}
+
+// isProfileOwner returns true if the request is authenticated as the user with the provided username. Credentials of other users are not
+// checked, so looking up their profiles doesn't count as a failed login. A lookup of your own profile with a wrong password does, like every
+// other password check, so profile lookups can't be used to guess passwords past the lockout.
+func (server *SBServer) isProfileOwner(r *http.Request, username string) bool {
+	issuer, password, err := util.ExtractCredentialsFromAuthorizationHeader(r.Header.Get("Authorization"))
+	if err != nil || issuer != username {
+		return false
+	}
+	return server.loginUser(r, issuer, password) == nil
+}
+
+// authenticate checks the credentials in the authorization header of the request against the database and returns the username.
+// If they are not valid, the user is locked out or over its rate limit, it writes the error response and returns false.
+func (server *SBServer) authenticate(w http.ResponseWriter, r *http.Request) (string, bool) {
+	username, password, err := util.ExtractCredentialsFromAuthorizationHeader(r.Header.Get("Authorization"))
+	if err != nil {
+		w.WriteHeader(400)
+		w.Write([]byte("Error during decoding of authorization header."))
+		return "", false
+	}
+	if err := server.loginUser(r, username, password); err != nil {
+		if writeLockedOut(w, err) {
+			return "", false
+		}
+		w.WriteHeader(401)
+		w.Write([]byte("Could not authenticate user."))
+		return "", false
+	}
+	if !server.allowUser(w, r, username) {
+		return "", false
+	}
+	return username, true
+}
@@ -0,0 +1,260 @@
+package api
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pavelhadzhiev/story-builder/pkg/api/game"
+	"github.com/pavelhadzhiev/story-builder/pkg/api/rooms"
+	"github.com/pavelhadzhiev/story-builder/pkg/api/users"
+	"github.com/pavelhadzhiev/story-builder/pkg/client"
+	"github.com/pavelhadzhiev/story-builder/pkg/config"
+	"github.com/pavelhadzhiev/story-builder/pkg/db/dbfakes"
+)
+
+var _ = Describe("Story Builder Account Handlers test", func() {
+	var sbClient *client.SBClient
+	var sbServer *SBServer
+	var database *dbfakes.FakeUserDatabase
+	var ts *httptest.Server
+
+	username := "username"
+	password := "password"
+	authHeader := "Basic " + base64.StdEncoding.EncodeToString([]byte(username+":"+password))
+
+	roomName := "Test Room"
+	player := "test-player"
+
+	BeforeEach(func() {
+		database = &dbfakes.FakeUserDatabase{}
+		database.LoginUserStub = func(user, pass string) error {
+			if user == username && pass == password {
+				return nil
+			}
+			return errors.New("password incorrect")
+		}
+
+		sbServer = &SBServer{
+			Database: database,
+			Rooms:    make([]rooms.Room, 0),
+			Online:   []string{username},
+		}
+	})
+
+	Describe("Handle account requests", func() {
+		BeforeEach(func() {
+			ts = httptest.NewServer(http.HandlerFunc(sbServer.AccountHandler))
+			sbClient = client.NewTestSBClient(&config.SBConfiguration{URL: ts.URL, Authorization: authHeader}, ts.Client())
+		})
+
+		AfterEach(func() {
+			ts.Close()
+		})
+
+		Context("When the password is changed with the right old password", func() {
+			It("should update the password", func() {
+				err := sbClient.ChangePassword(password, "new-password")
+
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(database.UpdatePasswordCallCount()).To(Equal(1))
+				user, newPassword := database.UpdatePasswordArgsForCall(0)
+				Expect(user).To(Equal(username))
+				Expect(newPassword).To(Equal("new-password"))
+			})
+		})
+
+		Context("When the password is changed with a wrong old password", func() {
+			It("should return error and not update the password", func() {
+				err := sbClient.ChangePassword("wrong-password", "new-password")
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("the old password is wrong"))
+				Expect(database.UpdatePasswordCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("When the credentials are wrong", func() {
+			It("should return error", func() {
+				wrongAuthHeader := "Basic " + base64.StdEncoding.EncodeToString([]byte(username+":wrong-password"))
+				sbClient = client.NewTestSBClient(&config.SBConfiguration{URL: ts.URL, Authorization: wrongAuthHeader}, ts.Client())
+
+				err := sbClient.DeleteAccount()
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("could not authenticate user"))
+				Expect(database.DeleteUserCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("When the account is deleted", func() {
+			It("should log the user out, remove it from the rooms and anonymize its entries", func() {
+				room := rooms.NewRoom(roomName, username)
+				room.Online = append(room.Online, username, player)
+				Expect(room.StartGame(username, 60, 100, 0)).To(Succeed())
+				Expect(room.AddEntry("Once upon a time", username)).To(Succeed())
+				_, err := room.PostMessage(username, "Hello!")
+				Expect(err).ShouldNot(HaveOccurred())
+				sbServer.Rooms = append(sbServer.Rooms, *room)
+
+				err = sbClient.DeleteAccount()
+
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(database.DeleteUserCallCount()).To(Equal(1))
+				Expect(database.DeleteUserArgsForCall(0)).To(Equal(username))
+				Expect(sbServer.Online).To(BeEmpty())
+
+				updatedRoom := sbServer.Rooms[0]
+				Expect(updatedRoom.IsOnline(username)).To(BeFalse())
+				Expect(updatedRoom.IsAdmin(username)).To(BeFalse())
+				Expect(updatedRoom.GetGame().Story[0].Player).To(Equal(game.DeletedPlayer))
+				Expect(updatedRoom.GetGame().Players).To(Equal([]string{player}))
+				Expect(updatedRoom.GetMessages(0)[0].Author).To(Equal(game.DeletedPlayer))
+				Expect(updatedRoom.Creator).To(BeEmpty())
+			})
+
+			It("should hand the rooms it created to another admin", func() {
+				room := rooms.NewRoom(roomName, username)
+				room.Admins = append(room.Admins, player)
+				sbServer.Rooms = append(sbServer.Rooms, *room)
+
+				err := sbClient.DeleteAccount()
+
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(sbServer.Rooms[0].Creator).To(Equal(player))
+				Expect(sbServer.Rooms[0].Admins).To(Equal([]string{player}))
+			})
+		})
+	})
+
+	Describe("Handle profile requests", func() {
+		BeforeEach(func() {
+			database.GetProfileStub = func(user string) (*users.Profile, error) {
+				if user == username || user == player {
+					return &users.Profile{Username: user, DisplayName: "Display " + user, Email: user + "@example.com", TurnReminders: true}, nil
+				}
+				return nil, nil
+			}
+
+			ts = httptest.NewServer(http.HandlerFunc(sbServer.ProfileHandler))
+			sbClient = client.NewTestSBClient(&config.SBConfiguration{URL: ts.URL, Authorization: authHeader}, ts.Client())
+		})
+
+		AfterEach(func() {
+			ts.Close()
+		})
+
+		Context("When the profile of another user is requested", func() {
+			It("should return the profile", func() {
+				profile, err := sbClient.GetProfile(player)
+
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(profile.Username).To(Equal(player))
+				Expect(profile.DisplayName).To(Equal("Display " + player))
+			})
+
+			It("should not return its private information", func() {
+				profile, err := sbClient.GetProfile(player)
+
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(profile.Email).To(BeEmpty())
+				Expect(profile.TurnReminders).To(BeFalse())
+			})
+		})
+
+		Context("When the user requests its own profile", func() {
+			It("should return its private information", func() {
+				profile, err := sbClient.GetProfile(username)
+
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(profile.Email).To(Equal(username + "@example.com"))
+				Expect(profile.TurnReminders).To(BeTrue())
+			})
+		})
+
+		Context("When the user requests its own profile with wrong credentials", func() {
+			It("should not return its private information", func() {
+				wrongAuthHeader := "Basic " + base64.StdEncoding.EncodeToString([]byte(username+":wrong"))
+				sbClient = client.NewTestSBClient(&config.SBConfiguration{URL: ts.URL, Authorization: wrongAuthHeader}, ts.Client())
+
+				profile, err := sbClient.GetProfile(username)
+
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(profile.Email).To(BeEmpty())
+			})
+		})
+
+		Context("When the user doesn't exist", func() {
+			It("should return error", func() {
+				_, err := sbClient.GetProfile("missing")
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("user \"missing\" doesn't exist"))
+			})
+		})
+
+		Context("When the user updates its profile", func() {
+			It("should save the profile", func() {
+				profile, err := sbClient.UpdateProfile(&users.Profile{Username: username, DisplayName: "The Bard", Bio: "I like dragons."})
+
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(profile.DisplayName).To(Equal("The Bard"))
+				Expect(database.UpdateProfileCallCount()).To(Equal(1))
+				Expect(database.UpdateProfileArgsForCall(0)).To(Equal(users.Profile{Username: username, DisplayName: "The Bard", Bio: "I like dragons."}))
+			})
+		})
+
+		Context("When the bio is too long", func() {
+			It("should return error and not save the profile", func() {
+				_, err := sbClient.UpdateProfile(&users.Profile{Username: username, Bio: strings.Repeat("a", users.MaxBioLength+1)})
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("bio is longer than"))
+				Expect(database.UpdateProfileCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("When the user turns on turn reminders", func() {
+			It("should save the email address and the setting", func() {
+				_, err := sbClient.UpdateProfile(&users.Profile{Username: username, Email: "bard@example.com", TurnReminders: true})
+
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(database.UpdateProfileArgsForCall(0)).To(Equal(users.Profile{Username: username, Email: "bard@example.com", TurnReminders: true}))
+			})
+		})
+
+		Context("When the email address is not valid", func() {
+			It("should return error and not save the profile", func() {
+				_, err := sbClient.UpdateProfile(&users.Profile{Username: username, Email: "Bard <bard@example.com>"})
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("is not a valid email address"))
+				Expect(database.UpdateProfileCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("When turn reminders are turned on without an email address", func() {
+			It("should return error and not save the profile", func() {
+				_, err := sbClient.UpdateProfile(&users.Profile{Username: username, TurnReminders: true})
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("turn reminders require an email address"))
+				Expect(database.UpdateProfileCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("When the user updates the profile of another user", func() {
+			It("should return error and not save the profile", func() {
+				_, err := sbClient.UpdateProfile(&users.Profile{Username: player, DisplayName: "Hacked"})
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("users can only update their own profile"))
+				Expect(database.UpdateProfileCallCount()).To(Equal(0))
+			})
+		})
+	})
+})
@@ -15,9 +15,13 @@
 package api
 
 import (
+	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/pavelhadzhiev/story-builder/pkg/api/audit"
 	"github.com/pavelhadzhiev/story-builder/pkg/util"
 )
 
@@ -43,10 +47,8 @@ func (server *SBServer) BanHandler(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		issuer, err := util.ExtractUsernameFromAuthorizationHeader(r.Header.Get("Authorization"))
-		if err != nil {
-			w.WriteHeader(500)
-			w.Write([]byte("Error during decoding of authorization header."))
+		issuer, ok := server.authenticate(w, r)
+		if !ok {
 			return
 		}
 
@@ -73,6 +75,7 @@ func (server *SBServer) BanHandler(w http.ResponseWriter, r *http.Request) {
 		}
 
 		room.GetGame().Kick(playerToBan)
+		server.audit(r, audit.Entry{Action: audit.RoomBan, Actor: issuer, Target: playerToBan, Room: roomName})
 		w.Write([]byte("Player \"" + playerToBan + "\" has been banned from room \"" + roomName + "\"."))
 		return
 	default:
@@ -110,10 +113,8 @@ func (server *SBServer) KickHandler(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		issuer, err := util.ExtractUsernameFromAuthorizationHeader(r.Header.Get("Authorization"))
-		if err != nil {
-			w.WriteHeader(500)
-			w.Write([]byte("Error during decoding of authorization header."))
+		issuer, ok := server.authenticate(w, r)
+		if !ok {
 			return
 		}
 
@@ -144,6 +145,7 @@ func (server *SBServer) KickHandler(w http.ResponseWriter, r *http.Request) {
 		}
 
 		game.Kick(playerToKick)
+		server.audit(r, audit.Entry{Action: audit.Kick, Actor: issuer, Target: playerToKick, Room: roomName})
 		w.Write([]byte("Player \"" + playerToKick + "\" has been kicked from the game in room \"" + roomName + "\"."))
 		return
 	default:
@@ -184,10 +186,8 @@ func (server *SBServer) PromoteAdminHandler(w http.ResponseWriter, r *http.Reque
 			return
 		}
 
-		issuer, err := util.ExtractUsernameFromAuthorizationHeader(r.Header.Get("Authorization"))
-		if err != nil {
-			w.WriteHeader(500)
-			w.Write([]byte("Error during decoding of authorization header."))
+		issuer, ok := server.authenticate(w, r)
+		if !ok {
 			return
 		}
 		if err := room.PromoteAdmin(userToPromote, issuer); err != nil {
@@ -196,9 +196,95 @@ func (server *SBServer) PromoteAdminHandler(w http.ResponseWriter, r *http.Reque
 			return
 		}
 
+		server.audit(r, audit.Entry{Action: audit.RoomAdminAdded, Actor: issuer, Target: userToPromote, Room: roomName})
 		w.Write([]byte("User \"" + userToPromote + "\" has been promoted to admin in room \"" + roomName + "\"."))
 	default:
 		w.WriteHeader(405)
 		return
 	}
 }
+
+// MuteHandler is an http handler for the story builder's admin API
+func (server *SBServer) MuteHandler(w http.ResponseWriter, r *http.Request) {
+	urlSuffix := strings.TrimPrefix(r.URL.Path, "/admin/mute/")
+	urlSuffixSplit := strings.Split(urlSuffix, "/")
+
+	if len(urlSuffixSplit) == 1 || len(urlSuffixSplit) > 3 || (len(urlSuffixSplit) == 3 && urlSuffixSplit[2] != "") {
+		w.WriteHeader(400)
+		w.Write([]byte("Request URL is illegal."))
+		return
+	}
+	roomName := urlSuffixSplit[0]
+	player := urlSuffixSplit[1]
+
+	room, err := server.GetRoom(roomName)
+	if err != nil {
+		w.WriteHeader(404)
+		w.Write([]byte("Room \"" + roomName + "\" doesn't exist."))
+		return
+	}
+
+	issuer, err := util.ExtractUsernameFromAuthorizationHeader(r.Header.Get("Authorization"))
+	if err != nil {
+		w.WriteHeader(500)
+		w.Write([]byte("Error during decoding of authorization header."))
+		return
+	}
+	if !room.IsAdmin(issuer) || !room.IsOnline(issuer) {
+		w.WriteHeader(403)
+		w.Write([]byte("You don't have admin access for room \"" + roomName + "\"."))
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPost:
+		var duration int
+		if durationString := r.Header.Get("Mute-Duration"); durationString != "" {
+			duration, err = strconv.Atoi(durationString)
+			if err != nil || duration < 0 {
+				w.WriteHeader(400)
+				w.Write([]byte("Illegal Mute-Duration header value."))
+				return
+			}
+		}
+
+		if userExists, err := server.Database.UserExists(player); err != nil {
+			w.WriteHeader(500)
+			w.Write([]byte("Database lookup failed."))
+			return
+		} else if !userExists {
+			w.WriteHeader(404)
+			w.Write([]byte("User \"" + player + "\" doesn't exist."))
+			return
+		}
+
+		if err := room.MutePlayer(player, issuer, time.Duration(duration)*time.Second); err != nil {
+			w.WriteHeader(400)
+			w.Write([]byte(fmt.Sprintf("Player cannot be muted: %v.", err)))
+			return
+		}
+
+		if duration > 0 {
+			w.Write([]byte(fmt.Sprintf("Player \"%s\" has been muted in room \"%s\" for %d seconds.", player, roomName, duration)))
+			return
+		}
+		w.Write([]byte("Player \"" + player + "\" has been muted in room \"" + roomName + "\"."))
+	case http.MethodDelete:
+		if !room.IsMuted(player) {
+			w.WriteHeader(409)
+			w.Write([]byte("User is not muted in \"" + roomName + "\". No action will be taken."))
+			return
+		}
+
+		if err := room.UnmutePlayer(player, issuer); err != nil {
+			w.WriteHeader(400)
+			w.Write([]byte(fmt.Sprintf("Player cannot be unmuted: %v.", err)))
+			return
+		}
+
+		w.Write([]byte("Player \"" + player + "\" has been unmuted in room \"" + roomName + "\"."))
+	default:
+		w.WriteHeader(405)
+		return
+	}
+}
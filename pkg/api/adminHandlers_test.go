@@ -9,6 +9,7 @@ import (
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/pavelhadzhiev/story-builder/pkg/api/game"
 	"github.com/pavelhadzhiev/story-builder/pkg/api/rooms"
 	"github.com/pavelhadzhiev/story-builder/pkg/client"
 	"github.com/pavelhadzhiev/story-builder/pkg/config"
@@ -96,6 +97,18 @@ var _ = Describe("Story Builder Admin Handlers test", func() {
 			})
 		})
 
+		Context("When the password of the issuer is wrong", func() {
+			It("should not ban the player and return error", func() {
+				database.LoginUserReturns(errors.New("wrong password"))
+
+				err := sbClient.BanPlayer(player)
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("could not authenticate user"))
+				Expect(sbServer.Rooms[0].Banned).To(BeEmpty())
+			})
+		})
+
 		Context("When issuer is not an admin", func() {
 			It("should return error", func() {
 				sbServer.Rooms[0].Admins[0] = ""
@@ -375,4 +388,107 @@ var _ = Describe("Story Builder Admin Handlers test", func() {
 			})
 		})
 	})
+
+	Describe("Handle mute request", func() {
+		BeforeEach(func() {
+			ts = httptest.NewServer(http.HandlerFunc(sbServer.MuteHandler))
+
+			clientConfig = &config.SBConfiguration{URL: ts.URL, Authorization: authHeader, Room: roomName}
+			sbClient = client.NewTestSBClient(clientConfig, ts.Client())
+		})
+
+		Context("When request is valid", func() {
+			It("player should be muted and skipped in the turn rotation", func() {
+				err := sbClient.MutePlayer(player, 0)
+
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(sbServer.Rooms[0].IsMuted(player)).To(BeTrue())
+				Expect(sbServer.Rooms[0].GetGame().Turn).To(Equal(username))
+				Expect(sbServer.Rooms[0].GetGame().Players).To(ContainElement(player))
+			})
+
+			It("muted player should not be able to post in the chat", func() {
+				sbClient.MutePlayer(player, 0)
+
+				_, err := sbServer.Rooms[0].PostMessage(player, "Hello?")
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(Equal("user is muted"))
+			})
+
+			It("muted player should not be able to trigger votes", func() {
+				sbClient.MutePlayer(player, 0)
+
+				_, err := sbServer.Rooms[0].GetGame().TriggerVote(player, game.EndVote, "", 0.75, 60)
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(Equal("muted players can't trigger votes"))
+			})
+
+			It("mute should expire after the provided duration", func() {
+				err := sbClient.MutePlayer(player, 1)
+
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(sbServer.Rooms[0].IsMuted(player)).To(BeTrue())
+				Eventually(func() bool { return sbServer.Rooms[0].IsMuted(player) }, "2s").Should(BeFalse())
+				Expect(sbServer.Rooms[0].GetGame().IsMuted(player)).To(BeFalse())
+			})
+		})
+
+		Context("When the player is an admin", func() {
+			It("should return error", func() {
+				database.UserExistsReturns(true, nil)
+				database.UserExistsStub = nil
+
+				err := sbClient.MutePlayer(username, 0)
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("admins can't be muted"))
+			})
+		})
+
+		Context("When the player doesn't exist", func() {
+			It("should return error", func() {
+				err := sbClient.MutePlayer("nonexistent", 0)
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("User \"nonexistent\" doesn't exist."))
+			})
+		})
+
+		Context("When the issuer is not an admin", func() {
+			It("should return error", func() {
+				clientConfig.Authorization = "Basic " + base64.StdEncoding.EncodeToString([]byte(player+":"+password))
+				sbClient = client.NewTestSBClient(clientConfig, ts.Client())
+
+				err := sbClient.MutePlayer(username, 0)
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("does not have permissions to mute"))
+			})
+		})
+
+		Describe("Specifically unmute request", func() {
+			Context("When the player is muted", func() {
+				It("should lift the mute", func() {
+					sbServer.Rooms[0].MutePlayer(player, username, 0)
+
+					err := sbClient.UnmutePlayer(player)
+
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(sbServer.Rooms[0].IsMuted(player)).To(BeFalse())
+					Expect(sbServer.Rooms[0].GetGame().IsMuted(player)).To(BeFalse())
+				})
+			})
+
+			Context("When the player is not muted", func() {
+				It("should return error", func() {
+					err := sbClient.UnmutePlayer(player)
+
+					Expect(err).Should(HaveOccurred())
+					Expect(err.Error()).To(Equal("player is not muted"))
+				})
+			})
+		})
+	})
 })
@@ -0,0 +1,222 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Actions recorded in the audit log.
+const (
+	Login              = "login"
+	LoginFailed        = "login_failed"
+	RoomCreated        = "room_created"
+	RoomDeleted        = "room_deleted"
+	RoomAdminAdded     = "room_admin_promoted"
+	RoomBan            = "room_ban"
+	Kick               = "kick"
+	EntryRedacted      = "entry_redacted"
+	VotePassed         = "vote_passed"
+	VoteFailed         = "vote_failed"
+	ServerAdminAdded   = "server_admin_promoted"
+	ServerAdminRemoved = "server_admin_demoted"
+	ServerBan          = "server_ban"
+	ServerUnban        = "server_unban"
+	UserDisabled       = "user_disabled"
+	UserEnabled        = "user_enabled"
+	PasswordReset      = "password_reset"
+	AccountDeleted     = "account_deleted"
+	WebhookAdded       = "webhook_added"
+	WebhookRemoved     = "webhook_removed"
+)
+
+// Entry is a security-relevant action, recorded in the audit log.
+type Entry struct {
+	ID        int       `json:"id"`
+	Time      time.Time `json:"time"`
+	Action    string    `json:"action"`
+	Actor     string    `json:"actor,omitempty"`
+	Target    string    `json:"target,omitempty"`
+	Room      string    `json:"room,omitempty"`
+	Details   string    `json:"details,omitempty"`
+	IP        string    `json:"ip,omitempty"`
+	RequestID string    `json:"requestId,omitempty"`
+}
+
+func (entry Entry) String() string {
+	entryString := fmt.Sprintf("#%d [%s] %s", entry.ID, entry.Time.Local().Format("2006-01-02 15:04:05"), entry.Action)
+	if entry.Actor != "" {
+		entryString += fmt.Sprintf(" by \"%s\"", entry.Actor)
+	}
+	if entry.Target != "" {
+		entryString += fmt.Sprintf(" on \"%s\"", entry.Target)
+	}
+	if entry.Room != "" {
+		entryString += fmt.Sprintf(" in room \"%s\"", entry.Room)
+	}
+	if entry.Details != "" {
+		entryString += " (" + entry.Details + ")"
+	}
+	if entry.IP != "" {
+		entryString += " from " + entry.IP
+	}
+	return entryString
+}
+
+// Filter selects entries of the audit log. Empty fields match every entry.
+type Filter struct {
+	Actor  string
+	Action string
+	Room   string
+	Since  time.Time
+	// Limit keeps only the newest entries that match. Zero means no limit.
+	Limit int
+}
+
+// Matches returns true if the entry passes the filter. Actors, actions and rooms are compared case-insensitively.
+func (filter Filter) Matches(entry Entry) bool {
+	if filter.Actor != "" && !strings.EqualFold(filter.Actor, entry.Actor) {
+		return false
+	}
+	if filter.Action != "" && !strings.EqualFold(filter.Action, entry.Action) {
+		return false
+	}
+	if filter.Room != "" && !strings.EqualFold(filter.Room, entry.Room) {
+		return false
+	}
+	return filter.Since.IsZero() || !entry.Time.Before(filter.Since)
+}
+
+// Log is an append-only audit log. Entries are written to a file as JSON lines, or kept in memory if the log has no file.
+// It's safe for concurrent use.
+type Log struct {
+	mutex  sync.Mutex
+	path   string
+	file   *os.File
+	memory []Entry
+	lastID int
+}
+
+// NewLog opens the audit log in the provided file, creating it (readable by the owner only) if it doesn't exist. New entries are appended to it.
+// Returns error if the file can't be opened or holds something else than audit entries.
+func NewLog(path string) (*Log, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, err
+	}
+	log := &Log{path: path, file: file}
+	lastID := 0
+	if err := log.scan(func(entry Entry) { lastID = entry.ID }); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("cannot read audit log \"%s\": %v", path, err)
+	}
+	log.lastID = lastID
+	return log, nil
+}
+
+// NewMemoryLog returns an audit log that keeps its entries in memory. They are lost when the server stops.
+func NewMemoryLog() *Log {
+	return &Log{memory: make([]Entry, 0)}
+}
+
+// Record appends the entry to the log, giving it the next ID and the current time, unless it has one already, and returns it.
+func (log *Log) Record(entry Entry) (Entry, error) {
+	log.mutex.Lock()
+	defer log.mutex.Unlock()
+
+	log.lastID++
+	entry.ID = log.lastID
+	if entry.Time.IsZero() {
+		entry.Time = time.Now()
+	}
+	if log.file == nil {
+		log.memory = append(log.memory, entry)
+		return entry, nil
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return entry, err
+	}
+	if _, err := log.file.Write(append(line, '\n')); err != nil {
+		return entry, err
+	}
+	return entry, nil
+}
+
+// Query returns the entries that pass the filter, oldest first.
+// Returns error if the file of the log can't be read.
+func (log *Log) Query(filter Filter) ([]Entry, error) {
+	log.mutex.Lock()
+	defer log.mutex.Unlock()
+
+	entries := make([]Entry, 0)
+	collect := func(entry Entry) {
+		if filter.Matches(entry) {
+			entries = append(entries, entry)
+		}
+	}
+	if log.file == nil {
+		for _, entry := range log.memory {
+			collect(entry)
+		}
+	} else if err := log.scan(collect); err != nil {
+		return nil, err
+	}
+
+	if filter.Limit > 0 && len(entries) > filter.Limit {
+		entries = entries[len(entries)-filter.Limit:]
+	}
+	return entries, nil
+}
+
+// Close closes the file of the log.
+func (log *Log) Close() error {
+	if log.file == nil {
+		return nil
+	}
+	return log.file.Close()
+}
+
+// scan reads the file of the log from the start, passing every entry to the provided function.
+func (log *Log) scan(handle func(entry Entry)) error {
+	file, err := os.Open(log.path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		if len(strings.TrimSpace(scanner.Text())) == 0 {
+			continue
+		}
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return fmt.Errorf("line %d is not an audit entry: %v", lineNumber, err)
+		}
+		handle(entry)
+	}
+	return scanner.Err()
+}
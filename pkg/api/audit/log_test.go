@@ -0,0 +1,110 @@
+package audit
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFileLog(t *testing.T) {
+	dir, err := ioutil.TempDir("", "story-builder-audit")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "audit.log")
+
+	log, err := NewLog(path)
+	if err != nil {
+		t.Fatalf("opening a new audit log should succeed: %v", err)
+	}
+	if _, err := log.Record(Entry{Action: Login, Actor: "alice"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := log.Record(Entry{Action: RoomCreated, Actor: "alice", Room: "tavern"}); err != nil {
+		t.Fatal(err)
+	}
+	log.Close()
+
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0600 {
+		t.Errorf("the audit log should be readable by its owner only, got %v", info.Mode())
+	}
+
+	log, err = NewLog(path)
+	if err != nil {
+		t.Fatalf("reopening the audit log should succeed: %v", err)
+	}
+	defer log.Close()
+	entry, err := log.Record(Entry{Action: LoginFailed, Actor: "bob"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry.ID != 3 {
+		t.Errorf("a reopened audit log should continue the IDs, got %d", entry.ID)
+	}
+
+	entries, err := log.Query(Filter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 3 || entries[0].Action != Login || entries[2].Actor != "bob" {
+		t.Errorf("the audit log should keep all entries in order, got %v", entries)
+	}
+}
+
+func TestCorruptedLog(t *testing.T) {
+	file, err := ioutil.TempFile("", "story-builder-audit")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(file.Name())
+	file.WriteString("not an entry\n")
+	file.Close()
+
+	if _, err := NewLog(file.Name()); err == nil {
+		t.Error("opening a file that doesn't hold audit entries should return error")
+	}
+}
+
+func TestQuery(t *testing.T) {
+	log := NewMemoryLog()
+	start := time.Now()
+	log.Record(Entry{Action: Login, Actor: "alice", Time: start.Add(-time.Hour)})
+	log.Record(Entry{Action: RoomBan, Actor: "alice", Target: "bob", Room: "tavern"})
+	log.Record(Entry{Action: Kick, Actor: "carol", Target: "bob", Room: "tavern"})
+	log.Record(Entry{Action: RoomBan, Actor: "alice", Target: "dave", Room: "library"})
+
+	tests := []struct {
+		filter   Filter
+		expected []int
+	}{
+		{Filter{}, []int{1, 2, 3, 4}},
+		{Filter{Actor: "alice"}, []int{1, 2, 4}},
+		{Filter{Action: RoomBan}, []int{2, 4}},
+		{Filter{Room: "tavern"}, []int{2, 3}},
+		{Filter{Since: start}, []int{2, 3, 4}},
+		{Filter{Actor: "alice", Limit: 2}, []int{2, 4}},
+	}
+	for _, test := range tests {
+		entries, err := log.Query(test.filter)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids := make([]int, 0, len(entries))
+		for _, entry := range entries {
+			ids = append(ids, entry.ID)
+		}
+		if len(ids) != len(test.expected) {
+			t.Errorf("filter %+v: expected entries %v, got %v", test.filter, test.expected, ids)
+			continue
+		}
+		for index := range ids {
+			if ids[index] != test.expected[index] {
+				t.Errorf("filter %+v: expected entries %v, got %v", test.filter, test.expected, ids)
+				break
+			}
+		}
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/pavelhadzhiev/story-builder/pkg/api/audit"
 	"github.com/pavelhadzhiev/story-builder/pkg/util"
 )
 
@@ -36,6 +37,11 @@ func (server *SBServer) RegistrationHandler(w http.ResponseWriter, r *http.Reque
 			w.Write([]byte(fmt.Sprintf("%v", err)))
 			return
 		}
+		if err := util.ValidateUsername(username); err != nil {
+			w.WriteHeader(400)
+			w.Write([]byte(fmt.Sprintf("Username is not valid: %v.", err)))
+			return
+		}
 
 		if usernameTaken, err := server.Database.UserExists(username); err != nil {
 			w.WriteHeader(500)
@@ -51,7 +57,6 @@ func (server *SBServer) RegistrationHandler(w http.ResponseWriter, r *http.Reque
 			w.Write([]byte("Database write failed."))
 			return
 		}
-
 		server.Online = append(server.Online, username)
 		w.Write([]byte("Successfully registered! Welcome, " + username + "."))
 	default:
@@ -75,11 +80,26 @@ func (server *SBServer) LoginHandler(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		if err := server.Database.LoginUser(username, password); err != nil {
+		if err := server.loginUser(r, username, password); err != nil {
+			if writeLockedOut(w, err) {
+				return
+			}
 			w.WriteHeader(401)
 			w.Write([]byte("Could not authenticate user."))
 			return
 		}
+		if !server.allowUser(w, r, username) {
+			return
+		}
+		if user, err := server.Database.GetUser(username); err != nil {
+			w.WriteHeader(500)
+			w.Write([]byte("Database lookup failed."))
+			return
+		} else if user != nil && user.Disabled {
+			w.WriteHeader(403)
+			w.Write([]byte("This account has been disabled by a server admin."))
+			return
+		}
 		for _, user := range server.Online {
 			if user == username {
 				w.WriteHeader(409)
@@ -89,6 +109,7 @@ func (server *SBServer) LoginHandler(w http.ResponseWriter, r *http.Request) {
 		}
 
 		server.Online = append(server.Online, username)
+		server.audit(r, audit.Entry{Action: audit.Login, Actor: username})
 		w.Write([]byte("Successfully logged in! Welcome back, " + username + "."))
 	default:
 		w.WriteHeader(405)
@@ -111,7 +132,10 @@ func (server *SBServer) LogoutHandler(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		if err := server.Database.LoginUser(username, password); err != nil {
+		if err := server.loginUser(r, username, password); err != nil {
+			if writeLockedOut(w, err) {
+				return
+			}
 			w.WriteHeader(401)
 			w.Write([]byte("Could not authenticate user."))
 			return
@@ -58,6 +58,36 @@ var _ = Describe("Story Builder Admin Handlers test", func() {
 			})
 		})
 
+		Context("When username breaks the naming policy", func() {
+			It("should return error and not register the user", func() {
+				for _, illegal := range []string{"ab", "system", "ADMIN", "foo/bar", "with space"} {
+					illegalAuthHeader := "Basic " + base64.StdEncoding.EncodeToString([]byte(illegal+":"+password))
+					sbClient = client.NewTestSBClient(&config.SBConfiguration{URL: ts.URL, Authorization: illegalAuthHeader}, ts.Client())
+
+					err := sbClient.Register()
+
+					Expect(err).Should(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("Username is not valid"))
+				}
+				Expect(database.RegisterUserCallCount()).To(Equal(0))
+				Expect(sbServer.Online).To(BeEmpty())
+			})
+		})
+
+		Context("When the password contains a colon", func() {
+			It("should register the user with the whole password", func() {
+				colonAuthHeader := "Basic " + base64.StdEncoding.EncodeToString([]byte(username+":pass:word"))
+				sbClient = client.NewTestSBClient(&config.SBConfiguration{URL: ts.URL, Authorization: colonAuthHeader}, ts.Client())
+
+				err := sbClient.Register()
+
+				Expect(err).ShouldNot(HaveOccurred())
+				registeredUsername, registeredPassword := database.RegisterUserArgsForCall(0)
+				Expect(registeredUsername).To(Equal(username))
+				Expect(registeredPassword).To(Equal("pass:word"))
+			})
+		})
+
 		Context("When username is taken", func() {
 			It("should return error", func() {
 				database.UserExistsReturns(true, nil)
@@ -0,0 +1,110 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/pavelhadzhiev/story-builder/pkg/api/rooms"
+	"github.com/pavelhadzhiev/story-builder/pkg/util"
+)
+
+// ChatHandler is an http handler for the story builder's room chat API
+func (server *SBServer) ChatHandler(w http.ResponseWriter, r *http.Request) {
+	urlSuffix := strings.TrimPrefix(r.URL.Path, "/chat/")
+	urlSuffixSplit := strings.Split(urlSuffix, "/")
+	if len(urlSuffixSplit) > 2 || (len(urlSuffixSplit) == 2 && urlSuffixSplit[1] != "") {
+		w.WriteHeader(400)
+		w.Write([]byte("Room name is illegal."))
+		return
+	}
+	roomName := urlSuffixSplit[0]
+
+	issuer, err := util.ExtractUsernameFromAuthorizationHeader(r.Header.Get("Authorization"))
+	if err != nil {
+		w.WriteHeader(500)
+		w.Write([]byte("Error during decoding of authorization header."))
+		return
+	}
+
+	room, err := server.GetRoom(roomName)
+	if err != nil {
+		w.WriteHeader(404)
+		w.Write([]byte("Room \"" + roomName + "\" doesn't exist."))
+		return
+	}
+	if !room.IsOnline(issuer) {
+		w.WriteHeader(403)
+		w.Write([]byte("The chat is only available to users in the room."))
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		var since int
+		if sinceString := r.URL.Query().Get("since"); sinceString != "" {
+			since, err = strconv.Atoi(sinceString)
+			if err != nil || since < 0 {
+				w.WriteHeader(400)
+				w.Write([]byte("Illegal since cursor."))
+				return
+			}
+		}
+
+		responseBody, err := json.Marshal(room.GetMessages(since))
+		if err != nil {
+			w.WriteHeader(500)
+			w.Write([]byte("Error during serialization of chat messages."))
+			return
+		}
+		w.Write(responseBody)
+	case http.MethodPost:
+		if room.IsMuted(issuer) {
+			w.WriteHeader(403)
+			w.Write([]byte("You are muted in this room."))
+			return
+		}
+
+		var message = &rooms.Message{}
+		defer r.Body.Close()
+		if err := json.NewDecoder(r.Body).Decode(message); err != nil {
+			w.WriteHeader(400)
+			w.Write([]byte("Message must have a text."))
+			return
+		}
+		posted, err := room.PostMessage(issuer, message.Text)
+		if err != nil {
+			w.WriteHeader(400)
+			w.Write([]byte(fmt.Sprintf("Message cannot be posted: %v.", err)))
+			return
+		}
+
+		responseBody, err := json.Marshal(posted)
+		if err != nil {
+			w.WriteHeader(500)
+			w.Write([]byte("Error during serialization of posted message."))
+			return
+		}
+		w.WriteHeader(201)
+		w.Write(responseBody)
+	default:
+		w.WriteHeader(405)
+		return
+	}
+}
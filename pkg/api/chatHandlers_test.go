@@ -0,0 +1,151 @@
+package api
+
+import (
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pavelhadzhiev/story-builder/pkg/api/rooms"
+	"github.com/pavelhadzhiev/story-builder/pkg/client"
+	"github.com/pavelhadzhiev/story-builder/pkg/config"
+	"github.com/pavelhadzhiev/story-builder/pkg/db/dbfakes"
+)
+
+var _ = Describe("Story Builder Chat Handlers test", func() {
+	var sbClient *client.SBClient
+	var clientConfig *config.SBConfiguration
+	var sbServer *SBServer
+	var room *rooms.Room
+	var ts *httptest.Server
+
+	username := "username"
+	password := "password"
+	authHeader := "Basic " + base64.StdEncoding.EncodeToString([]byte(username+":"+password))
+
+	roomName := "Test Room"
+	player := "test-player"
+
+	BeforeEach(func() {
+		room = rooms.NewRoom(roomName, username)
+		room.Online = append(room.Online, username, player)
+
+		sbServer = &SBServer{
+			Database: &dbfakes.FakeUserDatabase{},
+			Rooms:    make([]rooms.Room, 0),
+			Online:   make([]string, 0),
+		}
+		sbServer.Rooms = append(sbServer.Rooms, *room)
+
+		ts = httptest.NewServer(http.HandlerFunc(sbServer.ChatHandler))
+		clientConfig = &config.SBConfiguration{URL: ts.URL, Authorization: authHeader, Room: roomName}
+		sbClient = client.NewTestSBClient(clientConfig, ts.Client())
+	})
+
+	Describe("Handle send message requests", func() {
+		Context("When request is valid", func() {
+			It("should post the message on behalf of the user", func() {
+				message, err := sbClient.SendMessage("Let's make it a mystery!")
+
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(message.ID).To(Equal(1))
+				Expect(message.Author).To(Equal(username))
+				Expect(sbServer.Rooms[0].GetMessages(0)).To(HaveLen(1))
+			})
+		})
+
+		Context("When the message is empty", func() {
+			It("should return error", func() {
+				_, err := sbClient.SendMessage("   ")
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("message is empty"))
+			})
+		})
+
+		Context("When the message is too long", func() {
+			It("should return error", func() {
+				_, err := sbClient.SendMessage(strings.Repeat("a", rooms.MaxMessageLength+1))
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("message is longer than 280 symbols"))
+			})
+		})
+
+		Context("When the user is not in the room", func() {
+			It("should return error", func() {
+				sbServer.Rooms[0].Online = []string{player}
+
+				_, err := sbClient.SendMessage("Hello?")
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("only available to users in the room"))
+			})
+		})
+
+		Context("When room does not exist", func() {
+			It("should return error", func() {
+				clientConfig.Room = "Other Room"
+
+				_, err := sbClient.SendMessage("Hello?")
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("doesn't exist"))
+			})
+		})
+	})
+
+	Describe("Handle get messages requests", func() {
+		BeforeEach(func() {
+			sbServer.Rooms[0].PostMessage(player, "first")
+			sbServer.Rooms[0].PostMessage(username, "second")
+			sbServer.Rooms[0].PostMessage(player, "third")
+		})
+
+		Context("When no cursor is provided", func() {
+			It("should return all messages", func() {
+				messages, err := sbClient.GetMessages(0)
+
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(messages).To(HaveLen(3))
+				Expect(messages[0].Text).To(Equal("first"))
+			})
+		})
+
+		Context("When a cursor is provided", func() {
+			It("should return only the messages after it", func() {
+				messages, err := sbClient.GetMessages(2)
+
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(messages).To(HaveLen(1))
+				Expect(messages[0].Text).To(Equal("third"))
+				Expect(messages[0].Author).To(Equal(player))
+			})
+		})
+
+		Context("When the user is not in the room", func() {
+			It("should return error", func() {
+				sbServer.Rooms[0].Online = []string{player}
+
+				_, err := sbClient.GetMessages(0)
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("requires user to be joined in the room"))
+			})
+		})
+	})
+
+	Context("When request is a wrong method type", func() {
+		It("should return 405", func() {
+			req, _ := http.NewRequest(http.MethodPut, ts.URL+"/chat/"+roomName, nil)
+			req.Header.Add("Authorization", authHeader)
+
+			resp, err := ts.Client().Do(req)
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(resp.StatusCode).To(Equal(http.StatusMethodNotAllowed))
+		})
+	})
+})
@@ -0,0 +1,218 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package game
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Validator is a rule that every entry of a game must satisfy. New kinds of constraints are added by implementing it and enabling it through Constraints.
+type Validator interface {
+	// Name returns a short name of the rule, used to explain rejections.
+	Name() string
+	// Validate returns an error, explaining why the entry breaks the rule, or nil if the entry is valid for the provided story so far.
+	Validate(entry string, story []Entry) error
+}
+
+// Constraints holds the optional constraint challenges of a game, configured when it starts.
+type Constraints struct {
+	RequiredWords []string `json:"requiredWords,omitempty"`
+	BannedLetters string   `json:"bannedLetters,omitempty"`
+	Rhyme         bool     `json:"rhyme,omitempty"`
+}
+
+// NewConstraints creates constraints from the provided settings, normalizing them to lower case.
+// Returns error if the banned letters are not letters or if a required word can't be written without them.
+func NewConstraints(requiredWords []string, bannedLetters string, rhyme bool) (*Constraints, error) {
+	constraints := &Constraints{Rhyme: rhyme}
+	for _, letter := range strings.ToLower(bannedLetters) {
+		if !unicode.IsLetter(letter) {
+			return nil, fmt.Errorf("banned letter \"%c\" is not a letter", letter)
+		}
+		if !strings.ContainsRune(constraints.BannedLetters, letter) {
+			constraints.BannedLetters += string(letter)
+		}
+	}
+	for _, word := range requiredWords {
+		word = strings.ToLower(strings.TrimSpace(word))
+		if word == "" {
+			continue
+		}
+		if strings.ContainsAny(word, constraints.BannedLetters) {
+			return nil, fmt.Errorf("required word \"%s\" contains a banned letter", word)
+		}
+		constraints.RequiredWords = append(constraints.RequiredWords, word)
+	}
+	return constraints, nil
+}
+
+// IsEmpty returns true if no constraint is enabled.
+func (constraints *Constraints) IsEmpty() bool {
+	return constraints == nil || (len(constraints.RequiredWords) == 0 && constraints.BannedLetters == "" && !constraints.Rhyme)
+}
+
+// Validators returns the validators of all enabled constraints.
+func (constraints *Constraints) Validators() []Validator {
+	validators := make([]Validator, 0)
+	if constraints == nil {
+		return validators
+	}
+	if len(constraints.RequiredWords) > 0 {
+		validators = append(validators, requiredWordValidator{words: constraints.RequiredWords})
+	}
+	if constraints.BannedLetters != "" {
+		validators = append(validators, lipogramValidator{letters: constraints.BannedLetters})
+	}
+	if constraints.Rhyme {
+		validators = append(validators, rhymeValidator{})
+	}
+	return validators
+}
+
+// NextRequiredWord returns the word that the next entry of the provided story must contain or an empty string if there isn't one.
+func (constraints *Constraints) NextRequiredWord(story []Entry) string {
+	if constraints == nil || len(constraints.RequiredWords) == 0 {
+		return ""
+	}
+	return requiredWordValidator{words: constraints.RequiredWords}.next(story)
+}
+
+func (constraints *Constraints) String() (constraintsString string) {
+	if len(constraints.RequiredWords) > 0 {
+		constraintsString += "Required words (one per entry, in turn): " + strings.Join(constraints.RequiredWords, ", ") + "\n"
+	}
+	if constraints.BannedLetters != "" {
+		constraintsString += "Banned letters: " + strings.Join(strings.Split(constraints.BannedLetters, ""), ", ") + "\n"
+	}
+	if constraints.Rhyme {
+		constraintsString += "Each entry must end with a word that rhymes with the end of the previous one.\n"
+	}
+	return
+}
+
+// requiredWordValidator requires each entry to contain a word from a list, rotating through the list with every entry.
+type requiredWordValidator struct {
+	words []string
+}
+
+func (validator requiredWordValidator) Name() string {
+	return "required word"
+}
+
+func (validator requiredWordValidator) Validate(entry string, story []Entry) error {
+	required := validator.next(story)
+	for _, word := range splitWords(entry) {
+		if word == required {
+			return nil
+		}
+	}
+	return fmt.Errorf("the entry must contain the word \"%s\"", required)
+}
+
+// next returns the required word for the entry after the provided story. Entries by the system, such as prompts, are not counted.
+func (validator requiredWordValidator) next(story []Entry) string {
+	played := 0
+	for _, entry := range story {
+		if entry.Player != SystemPlayer {
+			played++
+		}
+	}
+	return validator.words[played%len(validator.words)]
+}
+
+// lipogramValidator forbids the usage of some letters.
+type lipogramValidator struct {
+	letters string
+}
+
+func (validator lipogramValidator) Name() string {
+	return "lipogram"
+}
+
+func (validator lipogramValidator) Validate(entry string, story []Entry) error {
+	for _, letter := range strings.ToLower(entry) {
+		if strings.ContainsRune(validator.letters, letter) {
+			return fmt.Errorf("the entry uses the banned letter \"%c\"", letter)
+		}
+	}
+	return nil
+}
+
+// rhymeValidator requires the last word of each entry to rhyme with the last word of the previous one.
+type rhymeValidator struct{}
+
+func (validator rhymeValidator) Name() string {
+	return "rhyme"
+}
+
+func (validator rhymeValidator) Validate(entry string, story []Entry) error {
+	if len(story) == 0 {
+		return nil
+	}
+	previous := lastWord(story[len(story)-1].Text)
+	if previous == "" {
+		return nil
+	}
+	current := lastWord(entry)
+	if current == "" {
+		return errors.New("the entry must end with a word")
+	}
+	if rhymePart(current) != rhymePart(previous) {
+		return fmt.Errorf("the last word \"%s\" doesn't rhyme with \"%s\"", current, previous)
+	}
+	return nil
+}
+
+// splitWords returns the lower case words of the provided text, ignoring punctuation.
+func splitWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '\''
+	})
+}
+
+func lastWord(text string) string {
+	words := splitWords(text)
+	if len(words) == 0 {
+		return ""
+	}
+	return strings.Trim(words[len(words)-1], "'")
+}
+
+// rhymePart returns the ending of a word that has to match for two words to rhyme. It is a simple suffix heuristic -
+// the ending starts from the last group of vowels, skipping a silent "e" at the end, and "y" is treated as "i".
+func rhymePart(word string) string {
+	word = strings.ReplaceAll(word, "y", "i")
+	isVowel := func(r byte) bool { return strings.IndexByte("aeiou", r) >= 0 }
+
+	end := len(word)
+	if end > 2 && word[end-1] == 'e' && !isVowel(word[end-2]) {
+		end-- // silent e, e.g. "time"
+	}
+
+	start := end - 1
+	for start >= 0 && !isVowel(word[start]) {
+		start--
+	}
+	if start < 0 {
+		return word
+	}
+	for start > 0 && isVowel(word[start-1]) {
+		start--
+	}
+	return word[start:]
+}
@@ -16,6 +16,12 @@ package game
 
 import "fmt"
 
+// SystemPlayer is the name to which entries that are not written by players, such as story prompts, are attributed.
+const SystemPlayer = "system"
+
+// DeletedPlayer is the name to which the entries of players who deleted their accounts are attributed.
+const DeletedPlayer = "[deleted]"
+
 // Entry represents a single player's turn in the story builder game
 type Entry struct {
 	Text   string `json:"text"`
@@ -0,0 +1,81 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package game
+
+// EventKind is a kind of transition of a game.
+type EventKind string
+
+const (
+	// GameStarted is the transition of a game from not running to running, including the start of a game from its lobby.
+	GameStarted EventKind = "game.started"
+	// TurnStarted is the transition to the turn of the next player. Team games have one for every team.
+	TurnStarted EventKind = "turn.started"
+	// GameFinished is the transition of a game to finished, after which its story no longer changes.
+	GameFinished EventKind = "game.finished"
+)
+
+// Event is a transition of a game, which the event listener of the game is notified about.
+type Event struct {
+	Kind EventKind
+	Game *Game
+	// Player is the player whose turn has started, for TurnStarted events.
+	Player string
+	// Team is the team of the player whose turn has started, for TurnStarted events of team games.
+	Team string
+}
+
+// SetEventListener makes the game call the provided function on every transition - see EventKind. The function is called from the
+// goroutine that makes the transition, which may be the one of a request or of a timer, so it must not block.
+func (game *Game) SetEventListener(listener func(event Event)) {
+	game.eventListener = listener
+}
+
+// AnnounceStart notifies the event listener that the game has started and whose turns it is. Games that start from their lobby
+// announce their start on their own.
+func (game *Game) AnnounceStart() {
+	game.notify(Event{Kind: GameStarted})
+	game.announceTurns()
+}
+
+// announceTurns notifies the event listener about the current turn, or the current turn of every team.
+func (game *Game) announceTurns() {
+	if game.IsTeamGame() {
+		for _, team := range game.Teams {
+			if team.Turn != "" {
+				game.notify(Event{Kind: TurnStarted, Player: team.Turn, Team: team.Name})
+			}
+		}
+		return
+	}
+	if game.Turn != "" {
+		game.notify(Event{Kind: TurnStarted, Player: game.Turn})
+	}
+}
+
+// finish finishes the game and notifies the event listener, unless the game is already finished.
+func (game *Game) finish() {
+	if game.Finished {
+		return
+	}
+	game.Finished = true
+	game.notify(Event{Kind: GameFinished})
+}
+
+func (game *Game) notify(event Event) {
+	if game.eventListener != nil {
+		event.Game = game
+		game.eventListener(event)
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package game
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Fork records where a game's story was forked from - the ID of the parent game and the number of its entries that were kept.
+type Fork struct {
+	GameID int `json:"gameId"`
+	At     int `json:"at"`
+}
+
+// Summary is a short description of a game, used to browse the story tree of a room.
+type Summary struct {
+	ID         int    `json:"id"`
+	ForkedFrom *Fork  `json:"forkedFrom,omitempty"`
+	Entries    int    `json:"entries"`
+	Finished   bool   `json:"finished,omitempty"`
+	Opening    string `json:"opening,omitempty"`
+}
+
+func (summary Summary) String() string {
+	status := "running"
+	if summary.Finished {
+		status = "finished"
+	}
+	summaryString := fmt.Sprintf("#%d (%d entries, %s)", summary.ID, summary.Entries, status)
+	if summary.ForkedFrom != nil {
+		summaryString += fmt.Sprintf(" forked at entry %d", summary.ForkedFrom.At)
+	}
+	if summary.Opening != "" {
+		summaryString += ": " + summary.Opening
+	}
+	return summaryString
+}
+
+// ForkGame starts a new game whose story continues the first entries of the provided parent game, up to and including entry number "at" (counting from 1).
+// The other settings are the same as for StartGame. Returns error if the parent game is not finished, is a team game or doesn't have that many entries.
+func ForkGame(parent *Game, at int, initiator string, players []string, timeLimit, maxLength, entriesCount int) (*Game, error) {
+	if !parent.Finished {
+		return nil, errors.New("only finished games can be forked")
+	}
+	if parent.IsTeamGame() {
+		return nil, errors.New("team games can't be forked")
+	}
+	if at < 1 || at > len(parent.Story) {
+		return nil, fmt.Errorf("the fork point must be between 1 and %d", len(parent.Story))
+	}
+
+	game := StartGame(initiator, players, timeLimit, maxLength, entriesCount)
+	game.Story = append(game.Story, parent.Story[:at]...)
+	game.ForkedFrom = &Fork{GameID: parent.ID, At: at}
+	return game, nil
+}
+
+// Summarize returns a short description of the game for the story tree.
+func (game *Game) Summarize() Summary {
+	summary := Summary{ID: game.ID, ForkedFrom: game.ForkedFrom, Entries: len(game.Story), Finished: game.Finished}
+	if len(game.Story) > 0 {
+		summary.Opening = game.Story[0].Text
+	}
+	return summary
+}
+
+// FormatStoryTree renders the provided game summaries as a tree, with forked games nested under the games they were forked from.
+// Games whose parent is not among the summaries are shown at the top level.
+func FormatStoryTree(summaries []Summary) string {
+	known := make(map[int]bool)
+	for _, summary := range summaries {
+		known[summary.ID] = true
+	}
+	children := make(map[int][]Summary)
+	for _, summary := range summaries {
+		parent := 0
+		if summary.ForkedFrom != nil && known[summary.ForkedFrom.GameID] {
+			parent = summary.ForkedFrom.GameID
+		}
+		children[parent] = append(children[parent], summary)
+	}
+
+	var tree strings.Builder
+	var render func(parent int, depth int)
+	render = func(parent int, depth int) {
+		nodes := children[parent]
+		sort.Slice(nodes, func(i, j int) bool { return nodes[i].ID < nodes[j].ID })
+		for _, node := range nodes {
+			tree.WriteString(strings.Repeat("    ", depth))
+			if depth > 0 {
+				tree.WriteString("└── ")
+			}
+			tree.WriteString(node.String() + "\n")
+			render(node.ID, depth+1)
+		}
+	}
+	render(0, 0)
+	return tree.String()
+}
@@ -21,31 +21,73 @@ import (
 	"strings"
 	"time"
 
+	"github.com/pavelhadzhiev/story-builder/pkg/metrics"
 	"github.com/pavelhadzhiev/story-builder/pkg/util"
 )
 
 // Game represents a story builder game. It holds a
 type Game struct {
-	Turn        string    `json:"turn,omitempty"`
-	Story       []Entry   `json:"story,omitempty"`
-	Players     []string  `json:"players,omitempty"`
-	Finished    bool      `json:"finished,omitempty"`
-	TimeLeft    int       `json:"timeLeft,omitempty"`
-	MaxLength   int       `json:"maxLength,omitempty"`
-	MaxEntries  int       `json:"maxEntries,omitempty"`
-	EntriesLeft int       `json:"entriesLeft,omitempty"`
-	VoteKick    *VoteKick `json:"votekick,omiempty"`
+	ID          int      `json:"id,omitempty"`
+	Turn        string   `json:"turn,omitempty"`
+	Story       []Entry  `json:"story,omitempty"`
+	Players     []string `json:"players,omitempty"`
+	Finished    bool     `json:"finished,omitempty"`
+	TimeLeft    int      `json:"timeLeft,omitempty"`
+	MaxLength   int      `json:"maxLength,omitempty"`
+	MaxEntries  int      `json:"maxEntries,omitempty"`
+	EntriesLeft int      `json:"entriesLeft,omitempty"`
+	Votes       []*Vote  `json:"votes,omitempty"`
+	Paused      bool     `json:"paused,omitempty"`
+	Lobby       *Lobby   `json:"lobby,omitempty"`
+
+	Constraints *Constraints `json:"constraints,omitempty"`
+
+	Teams     []*Team    `json:"teams,omitempty"`
+	FinalVote *FinalVote `json:"finalVote,omitempty"`
+	Winner    string     `json:"winner,omitempty"`
+
+	ForkedFrom *Fork `json:"forkedFrom,omitempty"`
+
+	Muted map[string]time.Time `json:"muted,omitempty"`
 
 	playerTurn int
 	timeLimit  int
+	lastVoteID int
+	stopped    bool
+
+	voteListener  func(vote *Vote, passed bool)
+	eventListener func(event Event)
 }
 
 func (game *Game) String() string {
 	gameString := "\n"
-	if game.VoteKick != nil {
-		gameString += "ATTENTION: There is a kick vote going on!\n"
-		gameString += game.VoteKick.String()
-		gameString += "\nYou can cast your vote using the vote command.\n\n"
+	if game.Lobby != nil {
+		gameString += "The game is waiting in the lobby. Use the ready command to join it!\n"
+		gameString += game.Lobby.String()
+		return gameString
+	}
+
+	if len(game.Votes) > 0 {
+		gameString += "ATTENTION: There are votes going on!\n"
+		for _, vote := range game.Votes {
+			gameString += vote.String() + "\n"
+		}
+		gameString += "You can cast your vote using the vote command.\n\n"
+	}
+
+	if game.Paused {
+		gameString += "ATTENTION: The game is paused! Turn and vote timers are frozen until an admin resumes it.\n\n"
+	}
+
+	if game.ForkedFrom != nil {
+		gameString += fmt.Sprintf("This story was forked from game #%d at entry %d.\n\n", game.ForkedFrom.GameID, game.ForkedFrom.At)
+	}
+
+	if game.IsTeamGame() {
+		if game.Constraints != nil {
+			gameString += game.Constraints.String() + "\n"
+		}
+		return gameString + game.teamsString()
 	}
 
 	gameString += "Players in the game: "
@@ -53,6 +95,9 @@ func (game *Game) String() string {
 		gameString += player + ", "
 	}
 	gameString = strings.TrimSuffix(gameString, ", ")
+	if muted := game.mutedPlayers(); len(muted) > 0 {
+		gameString += "\nMuted players: " + strings.Join(muted, ", ")
+	}
 
 	gameString += "\n--------------------------------\n"
 	for _, entry := range game.Story {
@@ -67,6 +112,12 @@ func (game *Game) String() string {
 		if game.MaxLength != 0 {
 			gameString += fmt.Sprintf("Max length: %d symbols\n", game.MaxLength)
 		}
+		if game.Constraints != nil {
+			gameString += game.Constraints.String()
+			if word := game.Constraints.NextRequiredWord(game.Story); word != "" {
+				gameString += fmt.Sprintf("Required word for the next entry: \"%s\"\n", word)
+			}
+		}
 		if game.TimeLeft != 0 {
 			gameString += fmt.Sprintf("Time left: %d seconds\n", game.TimeLeft)
 		}
@@ -82,6 +133,23 @@ func (game *Game) String() string {
 	return gameString
 }
 
+// Settings are the settings of new games, used when the player starting a game doesn't provide them.
+type Settings struct {
+	// TimeLimit is the time (in seconds) each player has for a turn. Zero disables the time limit.
+	TimeLimit int `json:"timeLimit"`
+	// MaxLength is the maximum number of symbols in an entry. Zero disables the limit.
+	MaxLength int `json:"maxLength"`
+	// EntriesCount is the number of entries after which the game finishes. Zero means the game runs until it's ended.
+	EntriesCount int `json:"entriesCount"`
+}
+
+// DefaultSettings are the settings of new games, unless the server is configured otherwise.
+var DefaultSettings = Settings{
+	TimeLimit:    60,
+	MaxLength:    100,
+	EntriesCount: 0,
+}
+
 // StartGame creates a game, initializing all required structures and arrays, with the provided players and initiator.
 // Supports configuration of time limit for turns (in seconds) and max length of entries (in symbols). Pass 0 if you don't want any of these features.
 func StartGame(initiator string, players []string, timeLimit, maxLength, entriesCount int) *Game {
@@ -103,7 +171,7 @@ func StartGame(initiator string, players []string, timeLimit, maxLength, entries
 		MaxLength:   maxLength,
 		MaxEntries:  entriesCount,
 		EntriesLeft: entriesCount,
-		VoteKick:    nil,
+		Votes:       make([]*Vote, 0),
 
 		playerTurn: 1,
 		timeLimit:  timeLimit,
@@ -118,73 +186,243 @@ func StartGame(initiator string, players []string, timeLimit, maxLength, entries
 
 // AddEntry sets the game to end after the next turn.
 func (game *Game) AddEntry(entry string, issuer string) error {
+	if game.Lobby != nil {
+		return errors.New("invalid entry - the game has not started yet")
+	}
+	if game.Paused {
+		return errors.New("invalid entry - the game is paused")
+	}
+	if game.IsTeamGame() {
+		return game.addTeamEntry(entry, issuer)
+	}
 	if issuer != game.Turn {
 		return errors.New("invalid entry - not this player's turn")
 	}
-	if game.MaxLength > 0 && len(entry) > game.MaxLength {
-		return fmt.Errorf("invalid entry - entry is above max length (%v)", game.MaxLength)
+	if err := game.validateEntry(entry, game.Story); err != nil {
+		return err
 	}
 
 	game.Story = append(game.Story, Entry{Text: entry, Player: issuer})
-	game.setNextTurn()
-
+	metrics.EntriesSubmitted.Inc()
 	if game.MaxEntries != 0 {
 		game.EntriesLeft--
 		if game.EntriesLeft <= 0 {
-			game.Finished = true
+			game.finish()
 		}
 	}
+	game.setNextTurn()
 	return nil
 }
 
+// validateEntry checks the entry against the max length and constraints of the game, using the provided story as the story so far.
+func (game *Game) validateEntry(entry string, story []Entry) error {
+	if game.MaxLength > 0 && len(entry) > game.MaxLength {
+		return fmt.Errorf("invalid entry - entry is above max length (%v)", game.MaxLength)
+	}
+	for _, validator := range game.Constraints.Validators() {
+		if err := validator.Validate(entry, story); err != nil {
+			return fmt.Errorf("invalid entry - breaks the %s rule: %v", validator.Name(), err)
+		}
+	}
+	return nil
+}
+
+// SeedStory opens the story with the provided prompt, attributing it to the system. The prompt doesn't count as a turn or towards the entries limit.
+func (game *Game) SeedStory(prompt string) {
+	game.Story = append([]Entry{{Text: prompt, Player: SystemPlayer}}, game.Story...)
+	for _, team := range game.Teams {
+		team.Story = append([]Entry{{Text: prompt, Player: SystemPlayer}}, team.Story...)
+	}
+}
+
+// SetConstraints enables the provided constraint challenges, which every following entry must satisfy. Pass nil to disable them.
+func (game *Game) SetConstraints(constraints *Constraints) {
+	if constraints.IsEmpty() {
+		constraints = nil
+	}
+	game.Constraints = constraints
+}
+
 // EndGame sets the left entries count to one, meaning the next move will finish the story.
 func (game *Game) EndGame(entries int) {
 	game.MaxEntries = entries
 	game.EntriesLeft = entries
+	for _, team := range game.Teams {
+		if !team.Done {
+			team.EntriesLeft = entries
+		}
+	}
 }
 
-// TriggerVoteKick starts a vote to kick a player. It requires an issuer on whose behalf the vote is triggered.
-// Parameters of the campaign are the acceptance ratio (a number between 0 and 1, indicating what part of the players must submit a vote in order to kick the player)
-// and a time limit (how many seconds before the campaign is considered unsuccessful)
-// Return error if there is already a running vote or if the player to be kicked is not in the game.
-func (game *Game) TriggerVoteKick(issuer, playerToKick string, acceptanceRatio float64, timeLimit int) error {
+// Pause freezes the turn timer and any ongoing vote. While paused, entries and votes are rejected.
+// Returns error if the game is finished or already paused.
+func (game *Game) Pause() error {
 	if game.Finished {
 		return errors.New("there is no running game")
 	}
-	if game.VoteKick != nil {
-		return fmt.Errorf("there is an ongoing vote to kick player \"%s\"", game.VoteKick.Player)
+	if game.Lobby != nil {
+		return errors.New("the game has not started yet")
 	}
-	for _, player := range game.Players {
-		if player == playerToKick {
-			voteTreshold := int(math.Ceil(float64(len(game.Players)) * acceptanceRatio))
-			game.VoteKick = NewVoteKick(issuer, playerToKick, voteTreshold, timeLimit)
-			go game.monitorVote()
-			return nil
-		}
+	if game.Paused {
+		return errors.New("the game is already paused")
 	}
-	return fmt.Errorf("player \"%s\" is not in the game", playerToKick)
+	game.Paused = true
+	return nil
 }
 
-// Vote submits a vote on behalf of the provided voter to the current campaign.
-// Returns errors if the issuer has already voted or he's not part of the game or if there is no ongoing vote at all.
-func (game *Game) Vote(voter string) error {
+// Resume unfreezes a paused game, letting the turn timer and any ongoing vote continue from where they were stopped.
+// Returns error if the game is finished or not paused.
+func (game *Game) Resume() error {
 	if game.Finished {
 		return errors.New("there is no running game")
 	}
-	if game.VoteKick == nil {
-		return errors.New("there is no ongoing vote")
+	if !game.Paused {
+		return errors.New("the game is not paused")
 	}
-	for _, player := range game.Players {
-		if player == voter {
-			if !game.VoteKick.hasVoted(voter) {
-				game.VoteKick.voted = append(game.VoteKick.voted, player)
-				game.VoteKick.Count++
-				return nil
+	game.Paused = false
+	return nil
+}
+
+// TriggerVote starts a vote of the provided kind. It requires an issuer on whose behalf the vote is triggered.
+// The target is the player to kick for kick votes and is ignored for other kinds - skip votes target the current turn and revert votes target the last entry.
+// Parameters of the campaign are the acceptance ratio (a number between 0 and 1, indicating what part of the players must vote in favour in order for the vote to pass)
+// and a time limit (how many seconds before the campaign is considered unsuccessful). Several votes can run at once, as long as they are not for the same thing.
+// Return error if there is already a running vote for the same thing or if the vote target doesn't exist.
+func (game *Game) TriggerVote(issuer string, kind VoteKind, target string, acceptanceRatio float64, timeLimit int) (*Vote, error) {
+	if game.Finished {
+		return nil, errors.New("there is no running game")
+	}
+	if game.Paused {
+		return nil, errors.New("the game is paused")
+	}
+	if game.Lobby != nil {
+		return nil, errors.New("the game has not started yet")
+	}
+
+	if game.IsMuted(issuer) {
+		return nil, errors.New("muted players can't trigger votes")
+	}
+
+	if game.IsTeamGame() && (kind == SkipVote || kind == RevertVote) {
+		return nil, fmt.Errorf("%s votes are not available in team games", kind)
+	}
+
+	entryIndex := -1
+	switch kind {
+	case KickVote:
+		if !game.isPlayer(target) {
+			return nil, fmt.Errorf("player \"%s\" is not in the game", target)
+		}
+	case SkipVote:
+		target = game.Turn
+	case EndVote:
+		target = ""
+	case RevertVote:
+		if len(game.Story) == 0 || game.Story[len(game.Story)-1].Player == SystemPlayer {
+			return nil, errors.New("there are no entries to revert")
+		}
+		entryIndex = len(game.Story) - 1
+		target = game.Story[entryIndex].Text
+	default:
+		return nil, fmt.Errorf("unknown vote kind \"%s\"", kind)
+	}
+
+	if vote := game.FindOngoingVote(kind, target); vote != nil {
+		return nil, fmt.Errorf("there is an ongoing vote to %s", vote.Description())
+	}
+
+	game.lastVoteID++
+	voteTreshold := int(math.Ceil(float64(len(game.Players)) * acceptanceRatio))
+	vote := NewVote(game.lastVoteID, kind, issuer, target, voteTreshold, timeLimit)
+	vote.entryIndex = entryIndex
+	game.Votes = append(game.Votes, vote)
+	if kind == KickVote {
+		metrics.VoteKicksTriggered.Inc()
+	}
+	go game.monitorVote(vote)
+	return vote, nil
+}
+
+// TriggerVoteKick starts a vote to kick a player. It is a shorthand for TriggerVote with the kick vote kind.
+// Return error if there is already a running vote to kick this player or if the player to be kicked is not in the game.
+func (game *Game) TriggerVoteKick(issuer, playerToKick string, acceptanceRatio float64, timeLimit int) error {
+	_, err := game.TriggerVote(issuer, KickVote, playerToKick, acceptanceRatio, timeLimit)
+	return err
+}
+
+// FindOngoingVote returns the ongoing vote of the provided kind and target or nil if there isn't one.
+// Like in TriggerVote, the target is only taken into account for kick votes. Revert votes are matched by the index of the last entry,
+// as several entries can have the same text.
+func (game *Game) FindOngoingVote(kind VoteKind, target string) *Vote {
+	switch kind {
+	case SkipVote:
+		target = game.Turn
+	case EndVote:
+		target = ""
+	case RevertVote:
+		for _, vote := range game.Votes {
+			if vote.Kind == RevertVote && vote.entryIndex == len(game.Story)-1 {
+				return vote
 			}
-			return fmt.Errorf("player \"%s\" has already voted for this vote", voter)
 		}
+		return nil
+	}
+	for _, vote := range game.Votes {
+		if vote.Kind == kind && vote.Target == target {
+			return vote
+		}
+	}
+	return nil
+}
+
+// GetVote returns the ongoing vote with the provided ID. If the ID is 0, the only ongoing vote is returned.
+// Returns error if there is no such vote, or if the ID is 0 and there are several ongoing votes.
+func (game *Game) GetVote(voteID int) (*Vote, error) {
+	if len(game.Votes) == 0 {
+		return nil, errors.New("there is no ongoing vote")
+	}
+	if voteID == 0 {
+		if len(game.Votes) > 1 {
+			return nil, errors.New("there are several ongoing votes, specify which one to vote for")
+		}
+		return game.Votes[0], nil
+	}
+	for _, vote := range game.Votes {
+		if vote.ID == voteID {
+			return vote, nil
+		}
+	}
+	return nil, fmt.Errorf("there is no ongoing vote with id %d", voteID)
+}
+
+// Vote submits a vote in favour or against on behalf of the provided voter to the campaign with the provided ID.
+// If the ID is 0, the vote is submitted to the only ongoing campaign.
+// Returns errors if the issuer has already voted or he's not part of the game or if there is no such ongoing vote.
+func (game *Game) Vote(voter string, voteID int, inFavour bool) error {
+	if game.Finished {
+		return errors.New("there is no running game")
 	}
-	return fmt.Errorf("player \"%s\" cannot vote as he's not part of the game", voter)
+	if game.Paused {
+		return errors.New("the game is paused")
+	}
+	vote, err := game.GetVote(voteID)
+	if err != nil {
+		return err
+	}
+	if !game.isPlayer(voter) {
+		return fmt.Errorf("player \"%s\" cannot vote as he's not part of the game", voter)
+	}
+	if vote.hasVoted(voter) {
+		return fmt.Errorf("player \"%s\" has already voted for this vote", voter)
+	}
+
+	vote.voted = append(vote.voted, voter)
+	if inFavour {
+		vote.Count++
+	} else {
+		vote.Against++
+	}
+	return nil
 }
 
 // Kick kicks a player from the game immediately, iterating player turn if necessary.
@@ -193,7 +431,9 @@ func (game *Game) Kick(toRemove string) error {
 	for index, player := range game.Players {
 		if player == toRemove {
 			game.Players = util.DeleteFromSlice(game.Players, index)
-			if player == game.Turn {
+			if game.IsTeamGame() {
+				game.kickFromTeam(player)
+			} else if player == game.Turn {
 				game.setNextTurn()
 			}
 			return nil
@@ -202,43 +442,159 @@ func (game *Game) Kick(toRemove string) error {
 	return fmt.Errorf("player \"%s\" is not part of the game", toRemove)
 }
 
+// AnonymizePlayer attributes all entries of the provided player, including the ones in team stories, to DeletedPlayer.
+func (game *Game) AnonymizePlayer(player string) {
+	anonymizeEntries(game.Story, player)
+	for _, team := range game.Teams {
+		anonymizeEntries(team.Story, player)
+	}
+}
+
+func anonymizeEntries(story []Entry, player string) {
+	for index := range story {
+		if story[index].Player == player {
+			story[index].Player = DeletedPlayer
+		}
+	}
+}
+
 func (game *Game) monitorTime() {
-	for !game.Finished {
-		game.TimeLeft--
-		if game.TimeLeft <= 0 {
-			game.setNextTurn()
+	for !game.Finished && !game.stopped && game.FinalVote == nil {
+		if !game.Paused {
+			if game.IsTeamGame() {
+				game.monitorTeamTurns()
+			} else {
+				game.TimeLeft--
+				if game.TimeLeft <= 0 {
+					metrics.TurnTimeouts.Inc()
+					game.setNextTurn()
+				}
+			}
 		}
 		time.Sleep(1 * time.Second)
 	}
 }
 
+// setNextTurn passes the turn to the next player and notifies the event listener, unless the game is finished. The game finishes if
+// there are no players left.
 func (game *Game) setNextTurn() {
-	game.playerTurn++
-	if game.playerTurn > len(game.Players) {
-		game.playerTurn = 1
-	}
-
 	if len(game.Players) > 0 {
+		game.playerTurn = nextTurn(game.Players, game.playerTurn, game.IsMuted)
 		game.Turn = game.Players[game.playerTurn-1]
 		game.TimeLeft = game.timeLimit
+		if !game.Finished {
+			game.notify(Event{Kind: TurnStarted, Player: game.Turn})
+		}
 	} else {
-		game.Finished = true
 		game.Turn = ""
+		game.finish()
 	}
 }
 
-func (game *Game) monitorVote() {
-	for game.VoteKick != nil {
-		if game.VoteKick.Count >= game.VoteKick.Treshold {
-			game.Kick(game.VoteKick.Player)
-			game.VoteKick = nil
+func (game *Game) monitorVote(vote *Vote) {
+	for !game.Finished && !game.stopped && game.hasVote(vote) {
+		if vote.Count >= vote.Treshold {
+			game.removeVote(vote)
+			game.notifyVoteResolved(vote, true)
+			game.applyVote(vote)
+			return
+		}
+		if vote.Against > len(game.Players)-vote.Treshold || game.isObsolete(vote) {
+			game.removeVote(vote)
+			game.notifyVoteResolved(vote, false)
 			return
 		}
 		time.Sleep(1 * time.Second)
-		game.VoteKick.TimeLeft--
-		if game.VoteKick.TimeLeft <= 0 {
-			game.VoteKick = nil
+		if game.Paused || game.stopped {
+			continue
+		}
+		vote.TimeLeft--
+		if vote.TimeLeft <= 0 {
+			game.removeVote(vote)
+			game.notifyVoteResolved(vote, false)
+			return
+		}
+	}
+}
+
+// SetVoteListener makes the game call the provided function whenever a vote passes or fails. Votes that become obsolete fail.
+// The function is called from the goroutine that monitors the vote, before the action of a passed vote is applied.
+func (game *Game) SetVoteListener(listener func(vote *Vote, passed bool)) {
+	game.voteListener = listener
+}
+
+func (game *Game) notifyVoteResolved(vote *Vote, passed bool) {
+	if game.voteListener != nil {
+		game.voteListener(vote, passed)
+	}
+}
+
+// applyVote executes the action of a vote that has passed.
+func (game *Game) applyVote(vote *Vote) {
+	switch vote.Kind {
+	case KickVote:
+		metrics.VoteKicksPassed.Inc()
+		game.Kick(vote.Target)
+	case SkipVote:
+		if game.Turn == vote.Target {
+			game.setNextTurn()
+		}
+	case EndVote:
+		if game.IsTeamGame() {
+			game.finishTeamStories()
 			return
 		}
+		game.Turn = ""
+		game.finish()
+	case RevertVote:
+		if !game.isObsolete(vote) {
+			game.Story = game.Story[:vote.entryIndex]
+			if game.MaxEntries != 0 {
+				game.EntriesLeft++
+			}
+		}
+	}
+}
+
+// isObsolete returns true if the vote's target no longer exists, so the vote can't have any effect.
+func (game *Game) isObsolete(vote *Vote) bool {
+	switch vote.Kind {
+	case KickVote:
+		return !game.isPlayer(vote.Target)
+	case SkipVote:
+		return game.Turn != vote.Target
+	case RevertVote:
+		// Only the last entry can be reverted, so the story stays continuous.
+		return vote.entryIndex != len(game.Story)-1
+	default:
+		return false
+	}
+}
+
+func (game *Game) hasVote(vote *Vote) bool {
+	for _, ongoing := range game.Votes {
+		if ongoing == vote {
+			return true
+		}
+	}
+	return false
+}
+
+func (game *Game) removeVote(vote *Vote) {
+	votes := make([]*Vote, 0, len(game.Votes))
+	for _, ongoing := range game.Votes {
+		if ongoing != vote {
+			votes = append(votes, ongoing)
+		}
+	}
+	game.Votes = votes
+}
+
+func (game *Game) isPlayer(player string) bool {
+	for _, p := range game.Players {
+		if p == player {
+			return true
+		}
 	}
+	return false
 }
@@ -1,10 +1,13 @@
 package game
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/pavelhadzhiev/story-builder/pkg/metrics"
 )
 
 const initiator = "initiator"
@@ -37,7 +40,7 @@ func TestStartGame(t *testing.T) {
 		t.Error("max entries is not set right")
 	}
 
-	if game.Story == nil || game.Finished || game.VoteKick != nil {
+	if game.Story == nil || game.Finished || game.Votes == nil || len(game.Votes) != 0 {
 		t.Error("fields are not initialized")
 	}
 }
@@ -147,23 +150,23 @@ func TestTriggerVoteKick(t *testing.T) {
 		t.Error("trigger votekick should pass with no error")
 	}
 
-	if game.VoteKick.Issuer != initiator {
+	if game.Votes[0].Issuer != initiator {
 		t.Error("issuer is not set right")
 	}
 
-	if game.VoteKick.Count != 0 {
+	if game.Votes[0].Count != 0 {
 		t.Error("count is not started from 0")
 	}
 
-	if game.VoteKick.Treshold != 2 {
+	if game.Votes[0].Treshold != 2 {
 		t.Error("threshold is not calculated correctly")
 	}
 
-	if game.VoteKick.Player != otherPlayer {
+	if game.Votes[0].Target != otherPlayer {
 		t.Error("player to kick is not set right")
 	}
 
-	if game.VoteKick.TimeLeft != 60 {
+	if game.Votes[0].TimeLeft != 60 {
 		t.Error("time left is not set right")
 	}
 }
@@ -182,7 +185,7 @@ func TestTriggerVoteKickOnAFinishedGame(t *testing.T) {
 		t.Error("wrong type of error is returned")
 	}
 
-	if game.VoteKick != nil {
+	if len(game.Votes) != 0 {
 		t.Error("vote kick was triggered with an illegal request")
 	}
 }
@@ -201,7 +204,7 @@ func TestTriggerVoteKickOnANonExistingPlayer(t *testing.T) {
 		t.Error("wrong type of error is returned")
 	}
 
-	if game.VoteKick != nil {
+	if len(game.Votes) != 0 {
 		t.Error("vote kick was triggered with an illegal request")
 	}
 }
@@ -210,17 +213,17 @@ func TestTriggerVoteKickWithAnOngoingVote(t *testing.T) {
 	game := StartGame(initiator, []string{initiator, otherPlayer, "third player"}, timeLimit, maxLength, entriesCount)
 
 	game.TriggerVoteKick(initiator, otherPlayer, 0.65, 60)
-	err := game.TriggerVoteKick(otherPlayer, initiator, 0.65, 60)
+	err := game.TriggerVoteKick("third player", otherPlayer, 0.65, 60)
 
 	if err == nil {
 		t.Error("trigger votekick while another vote is running should return error")
 	}
 
-	if err.Error() != fmt.Sprintf("there is an ongoing vote to kick player \"%s\"", game.VoteKick.Player) {
+	if err.Error() != fmt.Sprintf("there is an ongoing vote to kick player \"%s\"", game.Votes[0].Target) {
 		t.Error("wrong type of error is returned")
 	}
 
-	if game.VoteKick == nil {
+	if len(game.Votes) == 0 {
 		t.Error("vote kick should still be running after second trigger failed")
 	}
 }
@@ -231,7 +234,7 @@ func TestTriggerVoteKickEndsAfterTimeLimit(t *testing.T) {
 	game.TriggerVoteKick(otherPlayer, initiator, 0.65, 1)
 
 	time.Sleep(time.Millisecond * 1100)
-	if game.VoteKick != nil {
+	if len(game.Votes) != 0 {
 		t.Error("vote kick should have ended after time limit")
 	}
 }
@@ -240,17 +243,17 @@ func TestVote(t *testing.T) {
 	game := StartGame(initiator, []string{initiator, otherPlayer, "third player"}, timeLimit, maxLength, entriesCount)
 	game.TriggerVoteKick(initiator, otherPlayer, 0.65, 60)
 
-	err := game.Vote(initiator)
+	err := game.Vote(initiator, 0, true)
 
 	if err != nil {
 		t.Error("vote should not should return error")
 	}
 
-	if game.VoteKick.Count != 1 {
+	if game.Votes[0].Count != 1 {
 		t.Error("vote should have been counter")
 	}
 
-	if game.VoteKick.voted[0] != initiator {
+	if game.Votes[0].voted[0] != initiator {
 		t.Error("voter should have been set in the voted list")
 	}
 }
@@ -261,7 +264,7 @@ func TestVoteOnAFinishedGame(t *testing.T) {
 	game.TriggerVoteKick(initiator, otherPlayer, 0.65, 60)
 	game.Finished = true
 
-	err := game.Vote(initiator)
+	err := game.Vote(initiator, 0, true)
 
 	if err == nil {
 		t.Error("voting on a finished game should return error")
@@ -275,7 +278,7 @@ func TestVoteOnAFinishedGame(t *testing.T) {
 func TestVoteWithNoTriggeredVoteKick(t *testing.T) {
 	game := StartGame(initiator, []string{initiator, otherPlayer, "third player"}, timeLimit, maxLength, entriesCount)
 
-	err := game.Vote(initiator)
+	err := game.Vote(initiator, 0, true)
 
 	if err == nil {
 		t.Error("voting without an ongoing votekick should return error")
@@ -292,7 +295,7 @@ func TestVoteFromAPlayerOutsideTheGame(t *testing.T) {
 	game.TriggerVoteKick(initiator, otherPlayer, 0.65, 60)
 	missingPlayer := "no-such-player"
 
-	err := game.Vote(missingPlayer)
+	err := game.Vote(missingPlayer, 0, true)
 
 	if err == nil {
 		t.Error("voting with a player not in the game should return error")
@@ -307,8 +310,8 @@ func TestVoteMoreThanOnce(t *testing.T) {
 	game := StartGame(initiator, []string{initiator, otherPlayer, "third player"}, timeLimit, maxLength, entriesCount)
 
 	game.TriggerVoteKick(initiator, otherPlayer, 0.65, 60)
-	game.Vote(initiator)
-	err := game.Vote(initiator)
+	game.Vote(initiator, 0, true)
+	err := game.Vote(initiator, 0, true)
 
 	if err == nil {
 		t.Error("voting with a player not in the game should return error")
@@ -325,8 +328,8 @@ func TestVoteKickOnMeetingThreshold(t *testing.T) {
 
 	game.TriggerVoteKick(otherPlayer, initiator, 0.65, 60)
 
-	game.Vote(otherPlayer)
-	game.Vote(thirdPlayer)
+	game.Vote(otherPlayer, 0, true)
+	game.Vote(thirdPlayer, 0, true)
 
 	time.Sleep(1100 * time.Millisecond) // have to wait for thread to kick player
 
@@ -344,6 +347,177 @@ func TestVoteKickOnMeetingThreshold(t *testing.T) {
 	}
 }
 
+func TestSeveralIndependentVotes(t *testing.T) {
+	game := StartGame(initiator, []string{initiator, otherPlayer, "third player"}, timeLimit, maxLength, entriesCount)
+
+	if err := game.TriggerVoteKick(initiator, otherPlayer, 0.65, 60); err != nil {
+		t.Error("trigger votekick should pass with no error")
+	}
+	if _, err := game.TriggerVote(otherPlayer, EndVote, "", 0.75, 60); err != nil {
+		t.Error("trigger end vote should pass with no error")
+	}
+
+	if len(game.Votes) != 2 || game.Votes[0].ID == game.Votes[1].ID {
+		t.Error("both votes should be running with distinct ids")
+	}
+
+	err := game.Vote(initiator, 0, true)
+	if err == nil || err.Error() != "there are several ongoing votes, specify which one to vote for" {
+		t.Error("voting without an id while several votes are running should return error")
+	}
+
+	if err := game.Vote(initiator, game.Votes[1].ID, true); err != nil {
+		t.Error("vote with an id should pass with no error")
+	}
+	if game.Votes[1].Count != 1 || game.Votes[0].Count != 0 {
+		t.Error("vote should have been counted only for the chosen campaign")
+	}
+
+	err = game.Vote(initiator, 42, true)
+	if err == nil || err.Error() != "there is no ongoing vote with id 42" {
+		t.Error("voting for a missing vote id should return error")
+	}
+}
+
+func TestVoteAgainstFailsVoteOnceTresholdIsUnreachable(t *testing.T) {
+	thirdPlayer := "third player"
+	game := StartGame(initiator, []string{initiator, otherPlayer, thirdPlayer}, timeLimit, maxLength, entriesCount)
+	game.TriggerVoteKick(initiator, otherPlayer, 0.65, 60)
+
+	game.Vote(otherPlayer, 0, false)
+	if game.Votes[0].Against != 1 {
+		t.Error("vote against should have been counted")
+	}
+	game.Vote(thirdPlayer, 0, false)
+
+	time.Sleep(1100 * time.Millisecond)
+	if len(game.Votes) != 0 {
+		t.Error("vote should have failed once its treshold became unreachable")
+	}
+	if len(game.Players) != 3 {
+		t.Error("failed vote kick should not have removed the player")
+	}
+}
+
+func TestSkipVote(t *testing.T) {
+	game := StartGame(initiator, []string{initiator, otherPlayer}, timeLimit, maxLength, entriesCount)
+
+	vote, err := game.TriggerVote(otherPlayer, SkipVote, "", 0.5, 60)
+	if err != nil {
+		t.Error("trigger skip vote should pass with no error")
+	}
+	if vote.Target != initiator {
+		t.Error("skip vote should target the current turn")
+	}
+
+	game.Vote(otherPlayer, vote.ID, true)
+	time.Sleep(1100 * time.Millisecond)
+
+	if game.Turn != otherPlayer {
+		t.Error("the turn was not skipped after the vote passed")
+	}
+}
+
+func TestEndVote(t *testing.T) {
+	game := StartGame(initiator, []string{initiator, otherPlayer}, timeLimit, maxLength, entriesCount)
+
+	vote, _ := game.TriggerVote(initiator, EndVote, "", 1, 60)
+	game.Vote(initiator, vote.ID, true)
+	game.Vote(otherPlayer, vote.ID, true)
+	time.Sleep(1100 * time.Millisecond)
+
+	if !game.Finished {
+		t.Error("the game should have finished after the vote passed")
+	}
+}
+
+func TestRevertVote(t *testing.T) {
+	game := StartGame(initiator, []string{initiator, otherPlayer}, timeLimit, maxLength, 5)
+
+	if _, err := game.TriggerVote(initiator, RevertVote, "", 0.5, 60); err == nil || err.Error() != "there are no entries to revert" {
+		t.Error("reverting an empty story should return error")
+	}
+
+	game.AddEntry(entry, initiator)
+	vote, err := game.TriggerVote(otherPlayer, RevertVote, "", 0.5, 60)
+	if err != nil {
+		t.Error("trigger revert vote should pass with no error")
+	}
+	if vote.Target != entry {
+		t.Error("revert vote should target the last entry")
+	}
+
+	game.Vote(otherPlayer, vote.ID, true)
+	time.Sleep(1100 * time.Millisecond)
+
+	if len(game.Story) != 0 {
+		t.Error("the entry was not reverted after the vote passed")
+	}
+	if game.EntriesLeft != 5 {
+		t.Error("the reverted entry should not count towards the entries limit")
+	}
+}
+
+func TestRevertVotesTargetEntriesByIndex(t *testing.T) {
+	game := StartGame(initiator, []string{initiator, otherPlayer}, timeLimit, maxLength, 0)
+
+	game.AddEntry(entry, initiator)
+	first, err := game.TriggerVote(otherPlayer, RevertVote, "", 1, 60)
+	if err != nil {
+		t.Fatalf("trigger revert vote should pass with no error, got %v", err)
+	}
+	game.AddEntry(entry, otherPlayer)
+	if !game.isObsolete(first) {
+		t.Error("the vote for the first entry should be obsolete once it's no longer the last entry")
+	}
+	second, err := game.TriggerVote(initiator, RevertVote, "", 1, 60)
+	if err != nil {
+		t.Fatalf("a revert vote for another entry with the same text should pass with no error, got %v", err)
+	}
+	if _, err := game.TriggerVote(initiator, RevertVote, "", 1, 60); err == nil {
+		t.Error("a second revert vote for the same entry should return error")
+	}
+
+	game.applyVote(first)
+	if len(game.Story) != 2 {
+		t.Errorf("an entry from the middle of the story should not be reverted, got %v", game.Story)
+	}
+
+	game.applyVote(second)
+	if len(game.Story) != 1 || game.Story[0].Player != initiator {
+		t.Errorf("only the last entry should be reverted, got %v", game.Story)
+	}
+	game.Finished = true
+}
+
+func TestSeedStory(t *testing.T) {
+	prompt := "It was a dark and stormy night."
+	game := StartGame(initiator, []string{initiator, otherPlayer}, timeLimit, maxLength, 2)
+
+	game.SeedStory(prompt)
+
+	if len(game.Story) != 1 || game.Story[0].Text != prompt || game.Story[0].Player != SystemPlayer {
+		t.Error("the story was not seeded with the prompt")
+	}
+	if game.Turn != initiator || game.EntriesLeft != 2 {
+		t.Error("the prompt should not count as a turn")
+	}
+
+	if _, err := game.TriggerVote(initiator, RevertVote, "", 0.5, 60); err == nil || err.Error() != "there are no entries to revert" {
+		t.Error("the prompt should not be revertable")
+	}
+}
+
+func TestParseVoteKind(t *testing.T) {
+	if kind, err := ParseVoteKind("Skip"); err != nil || kind != SkipVote {
+		t.Error("vote kind was not parsed correctly")
+	}
+
+	if _, err := ParseVoteKind("no-such-kind"); err == nil {
+		t.Error("parsing an unknown vote kind should return error")
+	}
+}
+
 func TestTurnsAreSwappedCorrectlyOnTimeRunningOut(t *testing.T) {
 	game := StartGame(initiator, []string{initiator, otherPlayer}, 1, maxLength, entriesCount)
 
@@ -436,7 +610,7 @@ func TestGameStringMethodOnAGameWithVote(t *testing.T) {
 		!strings.Contains(gameStr, fmt.Sprintf(`Next turn: Player "%s"`, initiator)) ||
 		!strings.Contains(gameStr, fmt.Sprintf("Max length: %d symbols", maxLength)) ||
 		!strings.Contains(gameStr, "Time left:") ||
-		!strings.Contains(gameStr, fmt.Sprintf(game.VoteKick.String())) {
+		!strings.Contains(gameStr, game.Votes[0].String()) {
 		t.Error("string method missed some output")
 	}
 }
@@ -458,3 +632,607 @@ func TestGameStringWithAStory(t *testing.T) {
 		t.Error("string method missed some output")
 	}
 }
+
+func TestPauseGame(t *testing.T) {
+	game := StartGame(initiator, []string{initiator, otherPlayer}, timeLimit, maxLength, entriesCount)
+
+	if err := game.Pause(); err != nil {
+		t.Error("pause should pass with no error")
+	}
+
+	if !game.Paused {
+		t.Error("game should be paused")
+	}
+
+	err := game.Pause()
+	if err == nil || err.Error() != "the game is already paused" {
+		t.Error("pausing a paused game should return error")
+	}
+}
+
+func TestPauseFinishedGame(t *testing.T) {
+	game := StartGame(initiator, []string{initiator, otherPlayer}, timeLimit, maxLength, entriesCount)
+	game.Finished = true
+
+	err := game.Pause()
+	if err == nil || err.Error() != "there is no running game" {
+		t.Error("pausing a finished game should return error")
+	}
+}
+
+func TestResumeGame(t *testing.T) {
+	game := StartGame(initiator, []string{initiator, otherPlayer}, timeLimit, maxLength, entriesCount)
+
+	err := game.Resume()
+	if err == nil || err.Error() != "the game is not paused" {
+		t.Error("resuming a game that is not paused should return error")
+	}
+
+	game.Pause()
+	if err := game.Resume(); err != nil {
+		t.Error("resume should pass with no error")
+	}
+
+	if game.Paused {
+		t.Error("game should not be paused")
+	}
+}
+
+func TestAddEntryWhilePaused(t *testing.T) {
+	game := StartGame(initiator, []string{initiator, otherPlayer}, timeLimit, maxLength, entriesCount)
+	game.Pause()
+
+	err := game.AddEntry(entry, initiator)
+
+	if err == nil || err.Error() != "invalid entry - the game is paused" {
+		t.Error("add entry on a paused game should return error")
+	}
+
+	if len(game.Story) != 0 {
+		t.Error("story length is not 0 after attempting to add an entry to a paused game")
+	}
+}
+
+func TestPausedGameFreezesTurnAndVoteTimers(t *testing.T) {
+	game := StartGame(initiator, []string{initiator, otherPlayer, "third player"}, 1, maxLength, entriesCount)
+	game.TriggerVoteKick(initiator, otherPlayer, 0.65, 1)
+	game.Pause()
+
+	time.Sleep(2100 * time.Millisecond)
+	if game.Turn != initiator {
+		t.Error("the turn changed while the game was paused")
+	}
+
+	if len(game.Votes) == 0 {
+		t.Error("the vote ended while the game was paused")
+	}
+
+	if err := game.Vote(otherPlayer, 0, true); err == nil || err.Error() != "the game is paused" {
+		t.Error("voting on a paused game should return error")
+	}
+}
+
+func TestGameStringMethodOnAPausedGame(t *testing.T) {
+	game := StartGame(initiator, []string{initiator, otherPlayer}, timeLimit, maxLength, entriesCount)
+	game.Pause()
+
+	if !strings.Contains(game.String(), "ATTENTION: The game is paused!") {
+		t.Error("string method missed some output")
+	}
+}
+
+func TestOpenLobby(t *testing.T) {
+	game := OpenLobby(initiator, timeLimit, maxLength, entriesCount, 0, 0)
+
+	if game.Lobby == nil || game.Lobby.Initiator != initiator {
+		t.Error("lobby is not set right")
+	}
+
+	if len(game.Lobby.Ready) != 1 || game.Lobby.Ready[0] != initiator {
+		t.Error("initiator should be ready from the start")
+	}
+
+	if game.Turn != "" || len(game.Players) != 0 || game.Finished {
+		t.Error("game should not have started yet")
+	}
+
+	if err := game.AddEntry(entry, initiator); err == nil || err.Error() != "invalid entry - the game has not started yet" {
+		t.Error("add entry in a lobby should return error")
+	}
+}
+
+func TestLobbyStartsOnceEveryoneIsReady(t *testing.T) {
+	online := []string{initiator, otherPlayer}
+	game := OpenLobby(initiator, timeLimit, maxLength, entriesCount, 0, 0)
+
+	if err := game.SetReady(otherPlayer, online); err != nil {
+		t.Error("set ready should pass with no error")
+	}
+
+	if game.Lobby != nil {
+		t.Error("lobby should be closed once everyone is ready")
+	}
+
+	if game.Turn != initiator || len(game.Players) != 2 || game.Players[0] != initiator {
+		t.Error("game should have started with the initiator first")
+	}
+
+	if err := game.SetReady(otherPlayer, online); err == nil || err.Error() != "the game is not in a lobby" {
+		t.Error("set ready on a started game should return error")
+	}
+}
+
+func TestLobbyStartsOnceQuorumIsReadyExcludingTheRest(t *testing.T) {
+	thirdPlayer := "third player"
+	online := []string{initiator, otherPlayer, thirdPlayer, "afk player"}
+	game := OpenLobby(initiator, timeLimit, maxLength, entriesCount, 0.75, 0)
+
+	game.SetReady(otherPlayer, online)
+	if game.Lobby == nil {
+		t.Error("lobby should stay open before the quorum is met")
+	}
+
+	err := game.SetReady(otherPlayer, online)
+	if err == nil || err.Error() != fmt.Sprintf("player \"%s\" is already ready", otherPlayer) {
+		t.Error("setting ready twice should return error")
+	}
+
+	game.SetReady(thirdPlayer, online)
+	if game.Lobby != nil {
+		t.Error("lobby should be closed once the quorum is met")
+	}
+
+	if len(game.Players) != 3 {
+		t.Error("players that are not ready should be excluded from the game")
+	}
+}
+
+func TestLobbyStartsOnceCountdownExpires(t *testing.T) {
+	game := OpenLobby(initiator, timeLimit, maxLength, entriesCount, 0, 1)
+
+	time.Sleep(1100 * time.Millisecond)
+	if game.Lobby != nil {
+		t.Error("lobby should be closed once the countdown expires")
+	}
+
+	if game.Turn != initiator || len(game.Players) != 1 {
+		t.Error("game should have started with the ready players only")
+	}
+}
+
+func TestLobbyForgetsPlayersThatLeave(t *testing.T) {
+	thirdPlayer := "third player"
+	online := []string{initiator, otherPlayer, thirdPlayer}
+	game := OpenLobby(initiator, timeLimit, maxLength, entriesCount, 0, 0)
+	game.SetReady(otherPlayer, online)
+
+	online = []string{initiator, thirdPlayer}
+	game.LeaveLobby(otherPlayer, online)
+	if game.Lobby == nil || len(game.Lobby.Ready) != 1 || game.Lobby.Ready[0] != initiator {
+		t.Errorf("the ready state of the player that left should be removed, got %v", game.Lobby)
+	}
+
+	game.SetReady(otherPlayer, append(online, otherPlayer))
+	online = []string{initiator, otherPlayer}
+	game.LeaveLobby(thirdPlayer, online)
+	if game.Lobby != nil {
+		t.Error("lobby should be closed once everyone left is ready")
+	}
+	if len(game.Players) != 2 || game.Players[1] != otherPlayer {
+		t.Errorf("game should have started with the ready players that are left, got %v", game.Players)
+	}
+}
+
+func TestLobbyHandsTheFirstTurnOverWhenTheInitiatorLeaves(t *testing.T) {
+	thirdPlayer := "third player"
+	game := OpenLobby(initiator, timeLimit, maxLength, entriesCount, 0, 0)
+	game.SetReady(otherPlayer, []string{initiator, otherPlayer, thirdPlayer})
+
+	game.LeaveLobby(initiator, []string{otherPlayer, thirdPlayer})
+	if game.Lobby == nil || game.Lobby.Initiator != otherPlayer {
+		t.Errorf("the next ready player should become the initiator, got %v", game.Lobby)
+	}
+
+	game.SetReady(thirdPlayer, []string{otherPlayer, thirdPlayer})
+	if game.Turn != otherPlayer || len(game.Players) != 2 {
+		t.Errorf("game should have started with the new initiator first, got %v with turn %s", game.Players, game.Turn)
+	}
+}
+
+func TestLobbyWithoutReadyPlayersDoesNotStart(t *testing.T) {
+	game := OpenLobby(initiator, timeLimit, maxLength, entriesCount, 0, 0)
+
+	game.LeaveLobby(initiator, []string{})
+	if game.Lobby == nil || len(game.Players) != 0 {
+		t.Error("lobby should not start a game without players")
+	}
+}
+
+func TestGameStringMethodOnALobby(t *testing.T) {
+	game := OpenLobby(initiator, timeLimit, maxLength, entriesCount, 0.5, 30)
+
+	gameStr := game.String()
+	if !strings.Contains(gameStr, "The game is waiting in the lobby.") ||
+		!strings.Contains(gameStr, "Ready players: "+initiator) ||
+		!strings.Contains(gameStr, "50% of the players") ||
+		!strings.Contains(gameStr, "Time left until the game starts anyway: 30 seconds") {
+		t.Error("string method missed some output")
+	}
+}
+
+func TestRequiredWordConstraintRotates(t *testing.T) {
+	game := StartGame(initiator, []string{initiator, otherPlayer}, timeLimit, maxLength, entriesCount)
+	constraints, _ := NewConstraints([]string{"Dragon", "castle"}, "", false)
+	game.SetConstraints(constraints)
+
+	err := game.AddEntry("Once upon a time.", initiator)
+	if err == nil || err.Error() != "invalid entry - breaks the required word rule: the entry must contain the word \"dragon\"" {
+		t.Error("entry without the required word should be rejected")
+	}
+
+	if err := game.AddEntry("A DRAGON appeared.", initiator); err != nil {
+		t.Error("entry with the required word should pass with no error")
+	}
+
+	if err := game.AddEntry("The dragon left.", otherPlayer); err == nil {
+		t.Error("the required word should rotate with every entry")
+	}
+	if err := game.AddEntry("It flew to the castle.", otherPlayer); err != nil {
+		t.Error("entry with the next required word should pass with no error")
+	}
+}
+
+func TestLipogramConstraint(t *testing.T) {
+	game := StartGame(initiator, []string{initiator, otherPlayer}, timeLimit, maxLength, entriesCount)
+	constraints, _ := NewConstraints(nil, "E", false)
+	game.SetConstraints(constraints)
+
+	err := game.AddEntry("The end.", initiator)
+	if err == nil || err.Error() != "invalid entry - breaks the lipogram rule: the entry uses the banned letter \"e\"" {
+		t.Error("entry with a banned letter should be rejected")
+	}
+
+	if err := game.AddEntry("A cat sat on a mat.", initiator); err != nil {
+		t.Error("entry without banned letters should pass with no error")
+	}
+}
+
+func TestRhymeConstraint(t *testing.T) {
+	game := StartGame(initiator, []string{initiator, otherPlayer}, timeLimit, maxLength, entriesCount)
+	constraints, _ := NewConstraints(nil, "", true)
+	game.SetConstraints(constraints)
+
+	if err := game.AddEntry("It was a dark and stormy night.", initiator); err != nil {
+		t.Error("the first entry has nothing to rhyme with and should pass with no error")
+	}
+
+	err := game.AddEntry("The wind was howling loud.", otherPlayer)
+	if err == nil || err.Error() != "invalid entry - breaks the rhyme rule: the last word \"loud\" doesn't rhyme with \"night\"" {
+		t.Error("entry that doesn't rhyme should be rejected")
+	}
+
+	if err := game.AddEntry("Nobody slept, nobody dared to fight!", otherPlayer); err != nil {
+		t.Error("entry that rhymes should pass with no error")
+	}
+	if err := game.AddEntry("Until the break of day...", initiator); err == nil {
+		t.Error("entry that doesn't rhyme should be rejected")
+	}
+}
+
+func TestRhymePart(t *testing.T) {
+	rhymes := [][]string{{"cat", "hat"}, {"night", "light"}, {"day", "play"}, {"time", "rhyme"}, {"fly", "sky"}}
+	for _, pair := range rhymes {
+		if rhymePart(pair[0]) != rhymePart(pair[1]) {
+			t.Errorf("\"%s\" and \"%s\" should rhyme", pair[0], pair[1])
+		}
+	}
+	if rhymePart("cat") == rhymePart("dog") {
+		t.Error("\"cat\" and \"dog\" should not rhyme")
+	}
+}
+
+func TestNewConstraintsWithConflictingSettings(t *testing.T) {
+	if _, err := NewConstraints([]string{"dragon"}, "a", false); err == nil {
+		t.Error("a required word with a banned letter should return error")
+	}
+	if _, err := NewConstraints(nil, "1", false); err == nil {
+		t.Error("a banned letter that is not a letter should return error")
+	}
+}
+
+func TestParseTeams(t *testing.T) {
+	players := []string{initiator, otherPlayer, "third", "fourth"}
+
+	teams, err := ParseTeams("2", players)
+	if err != nil || len(teams) != 2 || len(teams[0].Players) != 2 || len(teams[1].Players) != 2 {
+		t.Error("players should be dealt evenly into the provided number of teams")
+	}
+
+	teams, err = ParseTeams("red="+initiator+",third;blue="+otherPlayer, players)
+	if err != nil || len(teams) != 2 || teams[0].Name != "red" || len(teams[0].Players) != 2 || teams[1].Players[0] != otherPlayer {
+		t.Error("teams should be parsed from the specification")
+	}
+
+	illegal := []string{"1", "5", "red=" + initiator, "red=" + initiator + ";blue=" + initiator, "red=" + initiator + ";blue=stranger", "red=" + initiator + ";blue="}
+	for _, spec := range illegal {
+		if _, err := ParseTeams(spec, players); err == nil {
+			t.Errorf("illegal teams \"%s\" should return error", spec)
+		}
+	}
+}
+
+func TestTeamGameAddEntry(t *testing.T) {
+	teams, _ := ParseTeams("red="+initiator+",third;blue="+otherPlayer, []string{initiator, otherPlayer, "third"})
+	game := StartTeamGame(initiator, teams, timeLimit, maxLength, 1)
+
+	if err := game.AddEntry(entry, "third"); err == nil {
+		t.Error("entry should be rejected when it's not the player's turn in his team")
+	}
+	if err := game.AddEntry(entry, initiator); err != nil {
+		t.Error("entry on the player's turn in his team should pass with no error")
+	}
+	if err := game.AddEntry("another entry", otherPlayer); err != nil {
+		t.Error("teams should play in parallel")
+	}
+
+	if len(teams[0].Story) != 1 || teams[0].Story[0].Text != entry || len(teams[1].Story) != 1 || len(game.Story) != 0 {
+		t.Error("each entry should be added to the story of the player's team")
+	}
+	if game.FinalVote == nil || game.Finished {
+		t.Error("the final vote should start once all teams finish their stories")
+	}
+}
+
+func TestTeamGameFinalVote(t *testing.T) {
+	teams, _ := ParseTeams("red="+initiator+",third;blue="+otherPlayer, []string{initiator, otherPlayer, "third"})
+	game := StartTeamGame(initiator, teams, timeLimit, maxLength, 0)
+
+	if err := game.VoteForTeam(initiator, "red"); err == nil {
+		t.Error("voting before the stories are finished should return error")
+	}
+
+	game.EndGame(1)
+	game.AddEntry(entry, initiator)
+	game.AddEntry(entry, otherPlayer)
+
+	if err := game.VoteForTeam(initiator, "green"); err == nil {
+		t.Error("voting for a non-existing team should return error")
+	}
+	game.VoteForTeam(initiator, "blue")
+	if err := game.VoteForTeam(initiator, "red"); err == nil {
+		t.Error("voting twice should return error")
+	}
+	game.VoteForTeam(otherPlayer, "blue")
+	game.VoteForTeam("third", "red")
+
+	if !game.Finished || game.Winner != "blue" {
+		t.Error("the team with the most votes should win once everyone has voted")
+	}
+	if !strings.Contains(game.String(), "Team \"blue\" wins!") {
+		t.Error("string method should show the winner")
+	}
+}
+
+func TestTeamGameKick(t *testing.T) {
+	teams, _ := ParseTeams("red="+initiator+";blue="+otherPlayer+",third", []string{initiator, otherPlayer, "third"})
+	game := StartTeamGame(initiator, teams, timeLimit, maxLength, 0)
+
+	game.Kick(otherPlayer)
+	if teams[1].Turn != "third" || len(game.Players) != 2 {
+		t.Error("kicked player should be removed from his team and the turn should pass")
+	}
+
+	if _, err := game.TriggerVote(initiator, SkipVote, "", 0.5, 30); err == nil {
+		t.Error("skip votes should not be available in team games")
+	}
+}
+
+func TestForkGame(t *testing.T) {
+	parent := StartGame(initiator, []string{initiator, otherPlayer}, timeLimit, maxLength, 3)
+	parent.ID = 1
+	parent.AddEntry("first", initiator)
+
+	if _, err := ForkGame(parent, 1, initiator, []string{initiator, otherPlayer}, timeLimit, maxLength, 0); err == nil {
+		t.Error("forking a running game should return error")
+	}
+
+	parent.AddEntry("second", otherPlayer)
+	parent.AddEntry("third", initiator)
+
+	if _, err := ForkGame(parent, 4, initiator, []string{initiator, otherPlayer}, timeLimit, maxLength, 0); err == nil {
+		t.Error("forking after the last entry should return error")
+	}
+
+	forked, err := ForkGame(parent, 2, otherPlayer, []string{initiator, otherPlayer}, timeLimit, maxLength, 0)
+	if err != nil {
+		t.Error("forking a finished game should pass with no error")
+	}
+	if len(forked.Story) != 2 || forked.Story[1].Text != "second" || forked.Turn != otherPlayer {
+		t.Error("the forked story should continue from the fork point")
+	}
+	if forked.ForkedFrom == nil || forked.ForkedFrom.GameID != 1 || forked.ForkedFrom.At != 2 {
+		t.Error("the fork point is not recorded")
+	}
+
+	forked.AddEntry("another second", otherPlayer)
+	if len(parent.Story) != 3 || parent.Story[2].Text != "third" {
+		t.Error("playing the forked game should not change the parent's story")
+	}
+}
+
+func TestFormatStoryTree(t *testing.T) {
+	summaries := []Summary{
+		{ID: 3, ForkedFrom: &Fork{GameID: 1, At: 2}, Entries: 4, Opening: "first"},
+		{ID: 1, Entries: 3, Finished: true, Opening: "first"},
+		{ID: 2, Entries: 1, Finished: true, Opening: "other"},
+		{ID: 4, ForkedFrom: &Fork{GameID: 3, At: 3}, Entries: 3, Finished: true, Opening: "first"},
+	}
+
+	expected := "#1 (3 entries, finished): first\n" +
+		"    └── #3 (4 entries, running) forked at entry 2: first\n" +
+		"        └── #4 (3 entries, finished) forked at entry 3: first\n" +
+		"#2 (1 entries, finished): other\n"
+	if tree := FormatStoryTree(summaries); tree != expected {
+		t.Errorf("story tree is not rendered correctly:\n%s", tree)
+	}
+}
+
+func TestMutedPlayerIsSkippedInTurnRotation(t *testing.T) {
+	game := StartGame(initiator, []string{initiator, otherPlayer, "third"}, timeLimit, maxLength, entriesCount)
+
+	game.Mute(otherPlayer, time.Time{})
+	game.AddEntry(entry, initiator)
+	if game.Turn != "third" {
+		t.Error("the muted player's turn should be skipped")
+	}
+
+	game.Mute("third", time.Time{})
+	if game.Turn != initiator {
+		t.Error("muting the player on turn should pass the turn")
+	}
+
+	game.Mute(initiator, time.Time{})
+	game.AddEntry(entry, initiator)
+	if game.Turn != otherPlayer {
+		t.Error("the regular rotation should be kept when everyone is muted")
+	}
+
+	game.Unmute("third")
+	game.Unmute(initiator)
+	game.setNextTurn()
+	if game.Turn != "third" {
+		t.Error("unmuted players should take turns again")
+	}
+}
+
+func TestMuteExpires(t *testing.T) {
+	game := StartGame(initiator, []string{initiator, otherPlayer}, timeLimit, maxLength, entriesCount)
+
+	game.Mute(otherPlayer, time.Now().Add(-time.Second))
+	if game.IsMuted(otherPlayer) {
+		t.Error("an expired mute should not apply")
+	}
+	if _, err := game.TriggerVote(otherPlayer, EndVote, "", 0.75, 60); err != nil {
+		t.Error("a player whose mute expired should be able to trigger votes")
+	}
+
+	game.Mute(otherPlayer, time.Now().Add(time.Minute))
+	if _, err := game.TriggerVote(otherPlayer, SkipVote, "", 0.5, 30); err == nil {
+		t.Error("muted players should not be able to trigger votes")
+	}
+	if !strings.Contains(game.String(), "Muted players: "+otherPlayer) {
+		t.Error("string method should show the muted players")
+	}
+}
+
+func TestAnonymizePlayer(t *testing.T) {
+	game := StartGame(initiator, []string{initiator, otherPlayer}, timeLimit, maxLength, entriesCount)
+	game.AddEntry(entry, initiator)
+	game.AddEntry(entry, otherPlayer)
+
+	game.AnonymizePlayer(initiator)
+	if game.Story[0].Player != DeletedPlayer || game.Story[1].Player != otherPlayer {
+		t.Error("only the entries of the anonymized player should be attributed to the deleted player")
+	}
+}
+
+func TestStopFreezesTimers(t *testing.T) {
+	game := StartGame(initiator, []string{initiator, otherPlayer}, 1, maxLength, entriesCount)
+	game.Stop()
+
+	time.Sleep(2500 * time.Millisecond)
+	if game.Turn != initiator {
+		t.Error("turn should not pass after the game is stopped")
+	}
+}
+
+func TestSnapshotRestore(t *testing.T) {
+	game := StartGame(initiator, []string{initiator, otherPlayer}, timeLimit, maxLength, entriesCount)
+	game.AddEntry(entry, initiator)
+	game.TriggerVote(initiator, KickVote, otherPlayer, 1, 60)
+	game.Vote(initiator, 1, true)
+	game.Stop()
+
+	data, err := json.Marshal(game.Snapshot())
+	if err != nil {
+		t.Fatal(err)
+	}
+	snapshot := &Snapshot{}
+	if err := json.Unmarshal(data, snapshot); err != nil {
+		t.Fatal(err)
+	}
+	restored := Restore(snapshot)
+
+	if !restored.Paused {
+		t.Error("a running game should be restored paused")
+	}
+	if restored.Turn != otherPlayer || len(restored.Story) != 1 {
+		t.Error("turn and story should be restored")
+	}
+	if err := restored.Vote(initiator, 1, true); err == nil {
+		t.Error("votes should not be accepted while the restored game is paused")
+	}
+
+	restored.Resume()
+	if err := restored.Vote(initiator, 1, true); err == nil {
+		t.Error("players who voted before the snapshot should not be able to vote again")
+	}
+	if err := restored.AddEntry(entry, otherPlayer); err != nil {
+		t.Errorf("restored game should continue with the next turn: %v", err)
+	}
+	if restored.Turn != initiator {
+		t.Error("turn rotation should continue from where it was")
+	}
+	vote, err := restored.TriggerVote(otherPlayer, EndVote, "", 1, 60)
+	if err != nil || vote.ID != 2 {
+		t.Error("vote IDs should continue from where they were")
+	}
+}
+
+func TestGameMetrics(t *testing.T) {
+	entries := metrics.EntriesSubmitted.Value()
+	triggered := metrics.VoteKicksTriggered.Value()
+	passed := metrics.VoteKicksPassed.Value()
+
+	game := StartGame(initiator, []string{initiator, otherPlayer}, timeLimit, maxLength, entriesCount)
+	game.AddEntry(entry, initiator)
+	game.AddEntry(entry, initiator) // not his turn, not counted
+	if metrics.EntriesSubmitted.Value() != entries+1 {
+		t.Error("only added entries should be counted")
+	}
+
+	game.TriggerVote(initiator, KickVote, otherPlayer, 0.5, 60)
+	game.Vote(initiator, 1, true)
+	time.Sleep(1500 * time.Millisecond)
+	if metrics.VoteKicksTriggered.Value() != triggered+1 || metrics.VoteKicksPassed.Value() != passed+1 {
+		t.Error("triggered and passed kick votes should be counted")
+	}
+}
+
+func TestGameEvents(t *testing.T) {
+	game := StartGame(initiator, []string{initiator, otherPlayer}, timeLimit, maxLength, 2)
+	events := make([]Event, 0)
+	game.SetEventListener(func(event Event) {
+		events = append(events, event)
+	})
+
+	game.AnnounceStart()
+	game.AddEntry(entry, initiator)
+	game.AddEntry(entry, initiator) // not his turn, no transition
+	game.AddEntry(entry, otherPlayer)
+
+	expected := []Event{
+		{Kind: GameStarted, Game: game},
+		{Kind: TurnStarted, Game: game, Player: initiator},
+		{Kind: TurnStarted, Game: game, Player: otherPlayer},
+		{Kind: GameFinished, Game: game},
+	}
+	if len(events) != len(expected) {
+		t.Fatalf("expected events %v, got %v", expected, events)
+	}
+	for i := range expected {
+		if events[i] != expected[i] {
+			t.Errorf("expected event %v, got %v", expected[i], events[i])
+		}
+	}
+}
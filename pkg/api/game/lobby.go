@@ -0,0 +1,172 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package game
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// Lobby represents the ready-check phase before a game starts.
+// The game starts once all players in the room are ready, once the quorum of ready players is met or once the countdown expires.
+type Lobby struct {
+	Initiator string   `json:"initiator"`
+	Ready     []string `json:"ready,omitempty"`
+	Quorum    float64  `json:"quorum,omitempty"`
+	TimeLeft  int      `json:"timeLeft,omitempty"`
+}
+
+func (lobby *Lobby) String() (lobbyString string) {
+	lobbyString = fmt.Sprintf("Opened by: \"%s\"\n", lobby.Initiator)
+	lobbyString += "Ready players: " + strings.Join(lobby.Ready, ", ") + "\n"
+	if lobby.Quorum > 0 {
+		lobbyString += fmt.Sprintf("The game starts once %d%% of the players in the room are ready.\n", int(math.Round(lobby.Quorum*100)))
+	} else {
+		lobbyString += "The game starts once all players in the room are ready.\n"
+	}
+	if lobby.TimeLeft > 0 {
+		lobbyString += fmt.Sprintf("Time left until the game starts anyway: %d seconds\n", lobby.TimeLeft)
+	}
+	return
+}
+
+// OpenLobby creates a game in its lobby phase. The initiator is ready from the start and will have the first turn.
+// The game settings are the same as for StartGame. The quorum is the part of the players in the room (a number between 0 and 1) that must be ready for the game to start - pass 0 to wait for everyone.
+// The countdown is the time (in seconds) after which the game starts with whoever is ready - pass 0 if you don't want this feature.
+func OpenLobby(initiator string, timeLimit, maxLength, entriesCount int, quorum float64, countdown int) *Game {
+	game := &Game{
+		Story:       make([]Entry, 0),
+		Players:     make([]string, 0),
+		Finished:    false,
+		MaxLength:   maxLength,
+		MaxEntries:  entriesCount,
+		EntriesLeft: entriesCount,
+		Votes:       make([]*Vote, 0),
+		Lobby: &Lobby{
+			Initiator: initiator,
+			Ready:     []string{initiator},
+			Quorum:    quorum,
+			TimeLeft:  countdown,
+		},
+
+		timeLimit: timeLimit,
+	}
+
+	if countdown > 0 {
+		go game.monitorLobby()
+	}
+
+	return game
+}
+
+// SetReady marks the provided player as ready and starts the game if enough of the provided online players are ready.
+// Returns error if the game is not in its lobby phase or the player is already ready.
+func (game *Game) SetReady(player string, online []string) error {
+	if game.Lobby == nil {
+		return errors.New("the game is not in a lobby")
+	}
+	for _, ready := range game.Lobby.Ready {
+		if ready == player {
+			return fmt.Errorf("player \"%s\" is already ready", player)
+		}
+	}
+
+	game.Lobby.Ready = append(game.Lobby.Ready, player)
+	if game.isLobbyComplete(online) {
+		game.startFromLobby()
+	}
+	return nil
+}
+
+// LeaveLobby takes back the ready state of the provided player once it leaves the room, and starts the game if enough of the provided
+// online players are ready without it. If the initiator leaves, the next ready player gets the first turn instead.
+// Does nothing if the game is not in its lobby phase.
+func (game *Game) LeaveLobby(player string, online []string) {
+	lobby := game.Lobby
+	if lobby == nil {
+		return
+	}
+	for index, ready := range lobby.Ready {
+		if ready == player {
+			lobby.Ready = append(lobby.Ready[:index], lobby.Ready[index+1:]...)
+			break
+		}
+	}
+	if lobby.Initiator == player && len(lobby.Ready) > 0 {
+		lobby.Initiator = lobby.Ready[0]
+	}
+	if game.isLobbyComplete(online) {
+		game.startFromLobby()
+	}
+}
+
+// isLobbyComplete returns true if enough of the provided online players are ready for the game to start. A lobby without ready online
+// players is never complete.
+func (game *Game) isLobbyComplete(online []string) bool {
+	readyOnline := 0
+	for _, player := range online {
+		for _, ready := range game.Lobby.Ready {
+			if ready == player {
+				readyOnline++
+				break
+			}
+		}
+	}
+
+	if readyOnline == 0 {
+		return false
+	}
+	if game.Lobby.Quorum > 0 {
+		return readyOnline >= int(math.Ceil(float64(len(online))*game.Lobby.Quorum))
+	}
+	return readyOnline >= len(online)
+}
+
+// startFromLobby closes the lobby and starts the game with the ready players only, giving the initiator the first turn.
+func (game *Game) startFromLobby() {
+	lobby := game.Lobby
+	if lobby == nil {
+		return
+	}
+
+	game.Players = append(make([]string, 0, len(lobby.Ready)), lobby.Ready...)
+	game.Turn = lobby.Initiator
+	game.TimeLeft = game.timeLimit
+	game.playerTurn = 1
+	game.Lobby = nil
+	game.AnnounceStart()
+
+	if game.timeLimit > 0 {
+		go game.monitorTime()
+	}
+}
+
+func (game *Game) monitorLobby() {
+	for game.Lobby != nil && !game.Finished && !game.stopped {
+		time.Sleep(1 * time.Second)
+		lobby := game.Lobby
+		if lobby == nil || game.Finished || game.stopped {
+			return
+		}
+		lobby.TimeLeft--
+		if lobby.TimeLeft <= 0 {
+			game.startFromLobby()
+			return
+		}
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package game
+
+import (
+	"sort"
+	"time"
+)
+
+// Mute mutes the provided player until the provided time - pass the zero time to mute until the player is unmuted.
+// Muted players stay in the game and can watch, but their turns are skipped and they can't trigger votes.
+// If it is the player's turn, the turn passes to the next player.
+func (game *Game) Mute(player string, until time.Time) {
+	if game.Muted == nil {
+		game.Muted = make(map[string]time.Time)
+	}
+	game.Muted[player] = until
+	if game.Finished || game.Lobby != nil || !game.IsMuted(player) {
+		return
+	}
+
+	if game.IsTeamGame() {
+		if team := game.TeamOf(player); team != nil && !team.Done && team.Turn == player {
+			game.setNextTeamTurn(team)
+		}
+	} else if game.Turn == player {
+		game.setNextTurn()
+	}
+}
+
+// Unmute lifts the mute of the provided player, who takes turns again from the next rotation.
+func (game *Game) Unmute(player string) {
+	delete(game.Muted, player)
+}
+
+// IsMuted returns true if the provided player is muted in the game and the mute has not expired yet.
+func (game *Game) IsMuted(player string) bool {
+	until, muted := game.Muted[player]
+	return muted && (until.IsZero() || time.Now().Before(until))
+}
+
+// mutedPlayers returns the players whose mutes have not expired yet, sorted by name.
+func (game *Game) mutedPlayers() []string {
+	players := make([]string, 0, len(game.Muted))
+	for player := range game.Muted {
+		if game.IsMuted(player) {
+			players = append(players, player)
+		}
+	}
+	sort.Strings(players)
+	return players
+}
+
+// nextTurn returns the position (counting from 1) of the player that plays after the provided position, skipping muted players.
+// If every player is muted, the regular rotation is kept, so the game doesn't get stuck.
+func nextTurn(players []string, position int, isMuted func(player string) bool) int {
+	next := position
+	for range players {
+		next++
+		if next > len(players) {
+			next = 1
+		}
+		if !isMuted(players[next-1]) {
+			return next
+		}
+	}
+
+	next = position + 1
+	if next > len(players) {
+		next = 1
+	}
+	return next
+}
@@ -0,0 +1,102 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package game
+
+// Snapshot holds a game together with the internal state it needs to continue after a server restart.
+// Snapshots are meant to be stored as JSON and turned back into games with Restore.
+type Snapshot struct {
+	Game        *Game            `json:"game"`
+	PlayerTurn  int              `json:"playerTurn,omitempty"`
+	TimeLimit   int              `json:"timeLimit,omitempty"`
+	LastVoteID  int              `json:"lastVoteId,omitempty"`
+	TeamTurns   map[string]int   `json:"teamTurns,omitempty"`
+	Voted       map[int][]string `json:"voted,omitempty"`
+	VoteEntries map[int]int      `json:"voteEntries,omitempty"`
+}
+
+// Snapshot captures the game and its internal state. Call it after Stop, so the timers don't change the game in the meantime.
+func (game *Game) Snapshot() *Snapshot {
+	snapshot := &Snapshot{
+		Game:        game,
+		PlayerTurn:  game.playerTurn,
+		TimeLimit:   game.timeLimit,
+		LastVoteID:  game.lastVoteID,
+		TeamTurns:   make(map[string]int),
+		Voted:       make(map[int][]string),
+		VoteEntries: make(map[int]int),
+	}
+	for _, team := range game.Teams {
+		snapshot.TeamTurns[team.Name] = team.playerTurn
+	}
+	for _, vote := range game.Votes {
+		snapshot.Voted[vote.ID] = vote.voted
+		snapshot.VoteEntries[vote.ID] = vote.entryIndex
+	}
+	return snapshot
+}
+
+// Restore turns the snapshot back into a game and restarts its timers.
+// A game that was being played is restored paused, so players have time to reconnect before an admin resumes it.
+func Restore(snapshot *Snapshot) *Game {
+	game := snapshot.Game
+	if game.Story == nil {
+		game.Story = make([]Entry, 0)
+	}
+	if game.Players == nil {
+		game.Players = make([]string, 0)
+	}
+	if game.Votes == nil {
+		game.Votes = make([]*Vote, 0)
+	}
+	game.playerTurn = snapshot.PlayerTurn
+	game.timeLimit = snapshot.TimeLimit
+	game.lastVoteID = snapshot.LastVoteID
+	for _, team := range game.Teams {
+		team.playerTurn = snapshot.TeamTurns[team.Name]
+	}
+	for _, vote := range game.Votes {
+		vote.voted = snapshot.Voted[vote.ID]
+		if vote.voted == nil {
+			vote.voted = make([]string, 0)
+		}
+		vote.entryIndex = snapshot.VoteEntries[vote.ID]
+	}
+
+	if game.Finished {
+		return game
+	}
+	if game.Lobby != nil {
+		if game.Lobby.TimeLeft > 0 {
+			go game.monitorLobby()
+		}
+		return game
+	}
+
+	game.Paused = true
+	if game.FinalVote != nil {
+		go game.monitorFinalVote()
+	} else if game.timeLimit > 0 {
+		go game.monitorTime()
+	}
+	for _, vote := range game.Votes {
+		go game.monitorVote(vote)
+	}
+	return game
+}
+
+// Stop stops the turn, vote and lobby timers of the game for good. It's used when the server shuts down.
+func (game *Game) Stop() {
+	game.stopped = true
+}
@@ -0,0 +1,416 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package game
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pavelhadzhiev/story-builder/pkg/metrics"
+	"github.com/pavelhadzhiev/story-builder/pkg/util"
+)
+
+// FinalVoteTimeLimit is the time (in seconds) players have to pick the winning story once all teams have finished.
+const FinalVoteTimeLimit = 60
+
+// Team is a group of players in a team game, building its own story with its own turn rotation.
+type Team struct {
+	Name        string   `json:"name"`
+	Players     []string `json:"players,omitempty"`
+	Story       []Entry  `json:"story,omitempty"`
+	Turn        string   `json:"turn,omitempty"`
+	TimeLeft    int      `json:"timeLeft,omitempty"`
+	EntriesLeft int      `json:"entriesLeft,omitempty"`
+	Done        bool     `json:"done,omitempty"`
+
+	playerTurn int
+}
+
+func (team *Team) String() string {
+	teamString := fmt.Sprintf("Team \"%s\" (%s)\n", team.Name, strings.Join(team.Players, ", "))
+	teamString += "--------------------------------\n"
+	for _, entry := range team.Story {
+		teamString += entry.String() + "\n"
+	}
+	teamString += "--------------------------------\n"
+	if team.Done {
+		teamString += "The team has finished its story.\n"
+		return teamString
+	}
+	teamString += fmt.Sprintf("Next turn: Player \"%s\"\n", team.Turn)
+	if team.TimeLeft != 0 {
+		teamString += fmt.Sprintf("Time left: %d seconds\n", team.TimeLeft)
+	}
+	return teamString
+}
+
+func (team *Team) isPlayer(player string) bool {
+	return containsPlayer(team.Players, player)
+}
+
+func containsPlayer(players []string, player string) bool {
+	for _, candidate := range players {
+		if candidate == player {
+			return true
+		}
+	}
+	return false
+}
+
+func (team *Team) setNextTurn(timeLimit int, isMuted func(player string) bool) {
+	if len(team.Players) == 0 {
+		team.Done = true
+		team.Turn = ""
+		return
+	}
+	team.playerTurn = nextTurn(team.Players, team.playerTurn, isMuted)
+	team.Turn = team.Players[team.playerTurn-1]
+	team.TimeLeft = timeLimit
+}
+
+// setNextTeamTurn passes the turn in the provided team to its next player and notifies the event listener.
+func (game *Game) setNextTeamTurn(team *Team) {
+	team.setNextTurn(game.timeLimit, game.IsMuted)
+	if team.Turn != "" && !game.Finished {
+		game.notify(Event{Kind: TurnStarted, Player: team.Turn, Team: team.Name})
+	}
+}
+
+// FinalVote is the vote that picks the winning story of a team game.
+type FinalVote struct {
+	Ballots  map[string]string `json:"ballots,omitempty"`
+	TimeLeft int               `json:"timeLeft,omitempty"`
+}
+
+// Results returns the number of votes each team received.
+func (finalVote *FinalVote) Results() map[string]int {
+	results := make(map[string]int)
+	for _, team := range finalVote.Ballots {
+		results[team]++
+	}
+	return results
+}
+
+// ParseTeams splits the provided players into teams, using the provided specification. The specification is either the number of teams,
+// in which case the players are dealt into teams named "1", "2" and so on, or a list of teams in the format "name=player1,player2;name2=player3".
+// Returns error if there are less than two teams, a team is empty or a player is in several teams or is not among the provided players.
+func ParseTeams(spec string, players []string) ([]*Team, error) {
+	teams := make([]*Team, 0)
+	if count, err := strconv.Atoi(spec); err == nil {
+		if count < 2 {
+			return nil, errors.New("there must be at least two teams")
+		}
+		if count > len(players) {
+			return nil, fmt.Errorf("there are not enough players for %d teams", count)
+		}
+		for index := 1; index <= count; index++ {
+			teams = append(teams, &Team{Name: strconv.Itoa(index), Players: make([]string, 0)})
+		}
+		for index, player := range players {
+			team := teams[index%count]
+			team.Players = append(team.Players, player)
+		}
+		return teams, nil
+	}
+
+	assigned := make(map[string]bool)
+	for _, teamSpec := range strings.Split(spec, ";") {
+		nameAndPlayers := strings.SplitN(teamSpec, "=", 2)
+		name := strings.TrimSpace(nameAndPlayers[0])
+		if len(nameAndPlayers) != 2 || name == "" {
+			return nil, fmt.Errorf("team \"%s\" must be in the format name=player1,player2", teamSpec)
+		}
+		team := &Team{Name: name, Players: make([]string, 0)}
+		for _, existing := range teams {
+			if existing.Name == name {
+				return nil, fmt.Errorf("team \"%s\" is listed more than once", name)
+			}
+		}
+		for _, player := range strings.Split(nameAndPlayers[1], ",") {
+			player = strings.TrimSpace(player)
+			if player == "" {
+				continue
+			}
+			if !containsPlayer(players, player) {
+				return nil, fmt.Errorf("player \"%s\" is not in the room", player)
+			}
+			if assigned[player] {
+				return nil, fmt.Errorf("player \"%s\" is in more than one team", player)
+			}
+			assigned[player] = true
+			team.Players = append(team.Players, player)
+		}
+		if len(team.Players) == 0 {
+			return nil, fmt.Errorf("team \"%s\" has no players", name)
+		}
+		teams = append(teams, team)
+	}
+	if len(teams) < 2 {
+		return nil, errors.New("there must be at least two teams")
+	}
+	return teams, nil
+}
+
+// StartTeamGame creates a team game, in which every team builds its own story in parallel, with its own turn rotation.
+// The settings are the same as for StartGame, with the entries count applying to each team separately. The initiator gets the first turn in his team.
+// Once all teams finish their stories, the players vote for the winning story.
+func StartTeamGame(initiator string, teams []*Team, timeLimit, maxLength, entriesCount int) *Game {
+	players := make([]string, 0)
+	for _, team := range teams {
+		for index, player := range team.Players {
+			if player == initiator { // arrange the team so that the initiator is first
+				team.Players = append([]string{initiator}, append(team.Players[:index], team.Players[index+1:]...)...)
+				break
+			}
+		}
+		team.Story = make([]Entry, 0)
+		team.Turn = team.Players[0]
+		team.TimeLeft = timeLimit
+		team.EntriesLeft = entriesCount
+		team.playerTurn = 1
+		players = append(players, team.Players...)
+	}
+
+	game := &Game{
+		Story:       make([]Entry, 0),
+		Players:     players,
+		Finished:    false,
+		TimeLeft:    timeLimit,
+		MaxLength:   maxLength,
+		MaxEntries:  entriesCount,
+		EntriesLeft: entriesCount,
+		Votes:       make([]*Vote, 0),
+		Teams:       teams,
+
+		timeLimit: timeLimit,
+	}
+
+	if timeLimit > 0 {
+		go game.monitorTime()
+	}
+
+	return game
+}
+
+// IsTeamGame returns true if the game is played in teams.
+func (game *Game) IsTeamGame() bool {
+	return len(game.Teams) > 0
+}
+
+// TeamOf returns the team of the provided player or nil if the player isn't in a team.
+func (game *Game) TeamOf(player string) *Team {
+	for _, team := range game.Teams {
+		if team.isPlayer(player) {
+			return team
+		}
+	}
+	return nil
+}
+
+// getTeam returns the team with the provided name or nil if there is no such team.
+func (game *Game) getTeam(name string) *Team {
+	for _, team := range game.Teams {
+		if team.Name == name {
+			return team
+		}
+	}
+	return nil
+}
+
+// VoteForTeam casts the provided player's vote for the winning story in the final vote of a team game.
+// Returns error if the final vote hasn't started, the team doesn't exist or the player is not part of the game or has already voted.
+func (game *Game) VoteForTeam(voter, teamName string) error {
+	if game.FinalVote == nil || game.Finished {
+		return errors.New("there is no final vote going on")
+	}
+	if game.getTeam(teamName) == nil {
+		return fmt.Errorf("team \"%s\" doesn't exist", teamName)
+	}
+	if !game.isPlayer(voter) {
+		return fmt.Errorf("player \"%s\" cannot vote as he's not part of the game", voter)
+	}
+	if _, voted := game.FinalVote.Ballots[voter]; voted {
+		return fmt.Errorf("player \"%s\" has already voted", voter)
+	}
+
+	game.FinalVote.Ballots[voter] = teamName
+	if len(game.FinalVote.Ballots) >= len(game.Players) {
+		game.concludeFinalVote()
+	}
+	return nil
+}
+
+// addTeamEntry adds the entry to the story of the issuer's team.
+func (game *Game) addTeamEntry(entry, issuer string) error {
+	team := game.TeamOf(issuer)
+	if team == nil || team.Done || issuer != team.Turn {
+		return errors.New("invalid entry - not this player's turn")
+	}
+	if err := game.validateEntry(entry, team.Story); err != nil {
+		return err
+	}
+
+	team.Story = append(team.Story, Entry{Text: entry, Player: issuer})
+	metrics.EntriesSubmitted.Inc()
+	if game.MaxEntries != 0 {
+		team.EntriesLeft--
+		if team.EntriesLeft <= 0 {
+			team.Done = true
+			team.Turn = ""
+		}
+	}
+	if !team.Done {
+		game.setNextTeamTurn(team)
+	}
+	game.checkTeamsDone()
+	return nil
+}
+
+// checkTeamsDone starts the final vote once all teams have finished their stories.
+func (game *Game) checkTeamsDone() {
+	for _, team := range game.Teams {
+		if !team.Done {
+			return
+		}
+	}
+	game.startFinalVote()
+}
+
+// finishTeamStories ends the stories of all teams immediately and starts the final vote.
+func (game *Game) finishTeamStories() {
+	for _, team := range game.Teams {
+		team.Done = true
+		team.Turn = ""
+	}
+	game.startFinalVote()
+}
+
+func (game *Game) startFinalVote() {
+	if game.FinalVote != nil || game.Finished {
+		return
+	}
+	game.FinalVote = &FinalVote{Ballots: make(map[string]string), TimeLeft: FinalVoteTimeLimit}
+	go game.monitorFinalVote()
+}
+
+func (game *Game) monitorFinalVote() {
+	for !game.Finished && !game.stopped {
+		time.Sleep(1 * time.Second)
+		if game.Paused || game.Finished || game.stopped {
+			continue
+		}
+		game.FinalVote.TimeLeft--
+		if game.FinalVote.TimeLeft <= 0 {
+			game.concludeFinalVote()
+			return
+		}
+	}
+}
+
+// concludeFinalVote finishes the game, picking the team with the most votes as the winner. There is no winner on a tie.
+func (game *Game) concludeFinalVote() {
+	if game.Finished {
+		return
+	}
+	best := 0
+	winners := make([]string, 0)
+	for team, votes := range game.FinalVote.Results() {
+		if votes > best {
+			best = votes
+			winners = []string{team}
+		} else if votes == best {
+			winners = append(winners, team)
+		}
+	}
+	if len(winners) == 1 {
+		game.Winner = winners[0]
+	}
+	game.FinalVote.TimeLeft = 0
+	game.finish()
+}
+
+// monitorTeamTurns passes the turn in every team whose player's time has run out.
+func (game *Game) monitorTeamTurns() {
+	for _, team := range game.Teams {
+		if team.Done {
+			continue
+		}
+		team.TimeLeft--
+		if team.TimeLeft <= 0 {
+			metrics.TurnTimeouts.Inc()
+			game.setNextTeamTurn(team)
+		}
+	}
+}
+
+// kickFromTeam removes the player from his team, passing the turn if it was his. A team without players is done.
+func (game *Game) kickFromTeam(player string) {
+	team := game.TeamOf(player)
+	if team == nil {
+		return
+	}
+	for index, teamPlayer := range team.Players {
+		if teamPlayer == player {
+			team.Players = util.DeleteFromSlice(team.Players, index)
+			if index < team.playerTurn {
+				team.playerTurn--
+			}
+			break
+		}
+	}
+	if team.Turn == player || len(team.Players) == 0 {
+		game.setNextTeamTurn(team)
+	}
+	game.checkTeamsDone()
+}
+
+func (game *Game) teamsString() string {
+	teamsString := ""
+	for _, team := range game.Teams {
+		teamsString += team.String() + "\n"
+	}
+
+	if game.FinalVote != nil {
+		results := game.FinalVote.Results()
+		names := make([]string, 0, len(game.Teams))
+		for _, team := range game.Teams {
+			names = append(names, team.Name)
+		}
+		sort.Strings(names)
+		teamsString += "Final vote results: "
+		for _, name := range names {
+			teamsString += fmt.Sprintf("\"%s\" - %d, ", name, results[name])
+		}
+		teamsString = strings.TrimSuffix(teamsString, ", ") + "\n"
+		if !game.Finished {
+			teamsString += fmt.Sprintf("All stories are finished! Vote for the best one using the vote command with the --team flag. Time left: %d seconds\n", game.FinalVote.TimeLeft)
+		}
+	}
+
+	if game.Finished {
+		if game.Winner != "" {
+			teamsString += fmt.Sprintf("The game has finished. Team \"%s\" wins!\n", game.Winner)
+		} else {
+			teamsString += "The game has finished in a draw.\n"
+		}
+	} else if game.MaxEntries != 0 && game.FinalVote == nil {
+		teamsString += fmt.Sprintf("Entries per team: %d\n", game.MaxEntries)
+	}
+	return teamsString
+}
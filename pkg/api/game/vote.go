@@ -14,27 +14,78 @@
 
 package game
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
-// VoteKick represents a vote to kick a player from a game.
-// If enough votes are submitted to cover the vote treshold, the player will be removed from the game.
-type VoteKick struct {
-	Player   string `json:"player,omitempty"`
-	TimeLeft int    `json:"timeLeft,omitempty"`
-	Count    int    `json:"voteCount,omitempty"`
-	Treshold int    `json:"voteTreshold,omitempty"`
-	Issuer   string `json:"issuer,omitempty"`
+// VoteKind identifies what happens to the game once a vote passes.
+type VoteKind string
 
-	voted []string
+const (
+	// KickVote removes the target player from the game.
+	KickVote VoteKind = "kick"
+	// SkipVote skips the turn of the target player.
+	SkipVote VoteKind = "skip"
+	// EndVote finishes the game immediately.
+	EndVote VoteKind = "end"
+	// RevertVote removes the last entry from the story.
+	RevertVote VoteKind = "revert"
+)
+
+// VoteSettings holds the acceptance ratio (a number between 0 and 1, indicating what part of the players must vote in favour)
+// and the time limit (in seconds) of a vote kind.
+type VoteSettings struct {
+	AcceptanceRatio float64 `json:"acceptanceRatio"`
+	TimeLimit       int     `json:"timeLimit"`
+}
+
+// DefaultVoteSettings are the settings used for each vote kind, unless the server is configured otherwise.
+var DefaultVoteSettings = map[VoteKind]VoteSettings{
+	KickVote:   {AcceptanceRatio: 0.65, TimeLimit: 60},
+	SkipVote:   {AcceptanceRatio: 0.5, TimeLimit: 30},
+	EndVote:    {AcceptanceRatio: 0.75, TimeLimit: 60},
+	RevertVote: {AcceptanceRatio: 0.5, TimeLimit: 30},
+}
+
+// ParseVoteKind returns the vote kind with the provided name.
+// Returns error if there is no such vote kind.
+func ParseVoteKind(kind string) (VoteKind, error) {
+	switch voteKind := VoteKind(strings.ToLower(kind)); voteKind {
+	case KickVote, SkipVote, EndVote, RevertVote:
+		return voteKind, nil
+	default:
+		return "", fmt.Errorf("unknown vote kind \"%s\"", kind)
+	}
 }
 
-// NewVoteKick creates a vote kick object reference for the provided player, on behalf of the issuer.
+// Vote represents a democratic vote in a game.
+// If enough votes in favour are submitted to cover the vote treshold, the vote passes and its action is applied to the game.
+// If enough votes against are submitted so that the treshold can no longer be met, or time runs out, the vote fails.
+type Vote struct {
+	ID       int      `json:"id"`
+	Kind     VoteKind `json:"kind"`
+	Target   string   `json:"target,omitempty"`
+	TimeLeft int      `json:"timeLeft,omitempty"`
+	Count    int      `json:"voteCount,omitempty"`
+	Against  int      `json:"votesAgainst,omitempty"`
+	Treshold int      `json:"voteTreshold,omitempty"`
+	Issuer   string   `json:"issuer,omitempty"`
+
+	voted      []string
+	entryIndex int
+}
+
+// NewVote creates a vote object reference of the provided kind and target, on behalf of the issuer.
 // Also requires treshold for vote to be considered a success and time limit for vote to be considered failed.
-func NewVoteKick(issuer, player string, treshold, timeleft int) *VoteKick {
-	return &VoteKick{
-		Player:   player,
+func NewVote(id int, kind VoteKind, issuer, target string, treshold, timeleft int) *Vote {
+	return &Vote{
+		ID:       id,
+		Kind:     kind,
+		Target:   target,
 		TimeLeft: timeleft,
 		Count:    0,
+		Against:  0,
 		Treshold: treshold,
 		Issuer:   issuer,
 
@@ -42,17 +93,34 @@ func NewVoteKick(issuer, player string, treshold, timeleft int) *VoteKick {
 	}
 }
 
-func (voteKick *VoteKick) String() (voteKickString string) {
-	voteKickString = fmt.Sprintf("Triggered by: \"%s\"\n", voteKick.Issuer)
-	voteKickString += fmt.Sprintf("Player to kick: \"%s\"\n", voteKick.Player)
-	voteKickString += fmt.Sprintf("Required votes: %d\n", voteKick.Treshold)
-	voteKickString += fmt.Sprintf("Votes so far: %d\n", voteKick.Count)
-	voteKickString += fmt.Sprintf("Time left until vote end: %d seconds\n", voteKick.TimeLeft)
+func (vote *Vote) String() (voteString string) {
+	voteString = fmt.Sprintf("Vote #%d: %s\n", vote.ID, vote.Description())
+	voteString += fmt.Sprintf("Triggered by: \"%s\"\n", vote.Issuer)
+	voteString += fmt.Sprintf("Required votes: %d\n", vote.Treshold)
+	voteString += fmt.Sprintf("Votes in favour so far: %d\n", vote.Count)
+	voteString += fmt.Sprintf("Votes against so far: %d\n", vote.Against)
+	voteString += fmt.Sprintf("Time left until vote end: %d seconds\n", vote.TimeLeft)
 	return
 }
 
-func (voteKick *VoteKick) hasVoted(player string) bool {
-	for _, voter := range voteKick.voted {
+// Description returns a short human readable explanation of what the vote is for.
+func (vote *Vote) Description() string {
+	switch vote.Kind {
+	case KickVote:
+		return fmt.Sprintf("kick player \"%s\"", vote.Target)
+	case SkipVote:
+		return fmt.Sprintf("skip the turn of player \"%s\"", vote.Target)
+	case EndVote:
+		return "end the game"
+	case RevertVote:
+		return fmt.Sprintf("revert the entry \"%s\"", vote.Target)
+	default:
+		return string(vote.Kind)
+	}
+}
+
+func (vote *Vote) hasVoted(player string) bool {
+	for _, voter := range vote.voted {
 		if player == voter {
 			return true
 		}
@@ -16,11 +16,14 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
 
+	"github.com/pavelhadzhiev/story-builder/pkg/api/game"
+	"github.com/pavelhadzhiev/story-builder/pkg/api/prompts"
 	"github.com/pavelhadzhiev/story-builder/pkg/util"
 )
 
@@ -43,6 +46,20 @@ func (server *SBServer) GameplayHandler(w http.ResponseWriter, r *http.Request)
 			w.Write([]byte("Room \"" + roomName + "\" doesn't exist or no games have been started."))
 			return
 		}
+		if gameIDString := r.URL.Query().Get("id"); gameIDString != "" {
+			room, _ := server.GetRoom(roomName)
+			gameID, err := strconv.Atoi(gameIDString)
+			if err != nil {
+				w.WriteHeader(400)
+				w.Write([]byte("Illegal game id."))
+				return
+			}
+			if game, err = room.GetGameByID(gameID); err != nil {
+				w.WriteHeader(404)
+				w.Write([]byte(fmt.Sprintf("Game #%d doesn't exist in room \"%s\".", gameID, roomName)))
+				return
+			}
+		}
 
 		if responseBody, err := json.Marshal(game); err == nil {
 			w.Write(responseBody)
@@ -135,7 +152,7 @@ func (server *SBServer) ManageGamesHandler(w http.ResponseWriter, r *http.Reques
 				return
 			}
 		} else {
-			timeLimit = 60 // Set default time limit, if one is not provided
+			timeLimit = server.GetGameSettings().TimeLimit // Set default time limit, if one is not provided
 		}
 
 		var maxLength int
@@ -148,7 +165,7 @@ func (server *SBServer) ManageGamesHandler(w http.ResponseWriter, r *http.Reques
 				return
 			}
 		} else {
-			maxLength = 100 // Set default max length, if one is not provided
+			maxLength = server.GetGameSettings().MaxLength // Set default max length, if one is not provided
 		}
 
 		var entriesCount int
@@ -161,15 +178,80 @@ func (server *SBServer) ManageGamesHandler(w http.ResponseWriter, r *http.Reques
 				return
 			}
 		} else {
-			entriesCount = 0 // Set default entries count, if one is not provided
+			entriesCount = server.GetGameSettings().EntriesCount // Set default entries count, if one is not provided
+		}
+
+		constraints, err := parseConstraints(r.Header)
+		if err != nil {
+			w.WriteHeader(400)
+			w.Write([]byte(fmt.Sprintf("Illegal constraints: %v.", err)))
+			return
 		}
 
-		if err := room.StartGame(issuer, timeLimit, maxLength, entriesCount); err != nil {
+		var prompt *prompts.Prompt
+		if selector := r.Header.Get("Prompt"); selector != "" {
+			prompt, err = server.ResolvePrompt(roomName, selector)
+			if err != nil {
+				w.WriteHeader(400)
+				w.Write([]byte(fmt.Sprintf("Prompt cannot be used: %v.", err)))
+				return
+			}
+		}
+
+		teamSpec := r.Header.Get("Teams")
+		if forkAtString := r.Header.Get("Fork-At"); forkAtString != "" {
+			if teamSpec != "" || prompt != nil {
+				w.WriteHeader(400)
+				w.Write([]byte("A forked game can't be played in teams or start from a prompt."))
+				return
+			}
+			forkAt, err := strconv.Atoi(forkAtString)
+			if err != nil {
+				w.WriteHeader(400)
+				w.Write([]byte("Illegal Fork-At header value."))
+				return
+			}
+			var gameID int
+			if gameIDString := r.Header.Get("Game-ID"); gameIDString != "" {
+				gameID, err = strconv.Atoi(gameIDString)
+				if err != nil || gameID <= 0 {
+					w.WriteHeader(400)
+					w.Write([]byte("Illegal Game-ID header value."))
+					return
+				}
+			}
+			if !room.IsAdmin(issuer) || !room.IsOnline(issuer) {
+				w.WriteHeader(403)
+				w.Write([]byte("Game cannot be started. Requires user to be joined and have admin access."))
+				return
+			}
+			if err := room.ForkGame(issuer, gameID, forkAt, timeLimit, maxLength, entriesCount); err != nil {
+				w.WriteHeader(400)
+				w.Write([]byte(fmt.Sprintf("Game cannot be forked: %v.", err)))
+				return
+			}
+		} else if teamSpec != "" {
+			if _, err := game.ParseTeams(teamSpec, room.Online); err != nil {
+				w.WriteHeader(400)
+				w.Write([]byte(fmt.Sprintf("Illegal teams: %v.", err)))
+				return
+			}
+			if err := room.StartTeamGame(issuer, teamSpec, timeLimit, maxLength, entriesCount); err != nil {
+				w.WriteHeader(403)
+				w.Write([]byte("Game cannot be started. Requires user to be joined and have admin access."))
+				return
+			}
+		} else if err := room.StartGame(issuer, timeLimit, maxLength, entriesCount); err != nil {
 			w.WriteHeader(403)
 			w.Write([]byte("Game cannot be started. Requires user to be joined and have admin access."))
 			return
 		}
 
+		room.GetGame().SetConstraints(constraints)
+		if prompt != nil {
+			room.GetGame().SeedStory(prompt.Text)
+		}
+
 		w.Write([]byte("Game successfully started in room \"" + roomName + "\"."))
 	case http.MethodDelete:
 		room, err := server.GetRoom(roomName)
@@ -218,6 +300,278 @@ func (server *SBServer) ManageGamesHandler(w http.ResponseWriter, r *http.Reques
 	}
 }
 
+// LobbyHandler is an http handler for the story builder's ready-check lobby API
+func (server *SBServer) LobbyHandler(w http.ResponseWriter, r *http.Request) {
+	urlSuffix := strings.TrimPrefix(r.URL.Path, "/lobby/")
+	urlSuffixSplit := strings.Split(urlSuffix, "/")
+	if len(urlSuffixSplit) > 2 || (len(urlSuffixSplit) == 2 && urlSuffixSplit[1] != "") {
+		w.WriteHeader(400)
+		w.Write([]byte("Room name is illegal."))
+		return
+	}
+	roomName := urlSuffixSplit[0]
+
+	switch r.Method {
+	case http.MethodPost:
+		room, err := server.GetRoom(roomName)
+		if err != nil {
+			w.WriteHeader(404)
+			w.Write([]byte("Room \"" + roomName + "\" doesn't exist."))
+			return
+		}
+		if game, err := server.GetGame(roomName); err == nil && !game.Finished {
+			w.WriteHeader(409)
+			w.Write([]byte("There is already a running game."))
+			return
+		}
+
+		issuer, err := util.ExtractUsernameFromAuthorizationHeader(r.Header.Get("Authorization"))
+		if err != nil {
+			w.WriteHeader(500)
+			w.Write([]byte("Error during decoding of authorization header."))
+			return
+		}
+
+		var timeLimit int
+		timeLimitString := r.Header.Get("Time-Limit")
+		if timeLimitString != "" {
+			timeLimit, err = strconv.Atoi(timeLimitString)
+			if err != nil || timeLimit < 0 {
+				w.WriteHeader(400)
+				w.Write([]byte("Illegal Time-Limit header value."))
+				return
+			}
+		} else {
+			timeLimit = server.GetGameSettings().TimeLimit // Set default time limit, if one is not provided
+		}
+
+		var maxLength int
+		maxLengthString := r.Header.Get("Max-Length")
+		if maxLengthString != "" {
+			maxLength, err = strconv.Atoi(maxLengthString)
+			if err != nil || maxLength < 0 {
+				w.WriteHeader(400)
+				w.Write([]byte("Illegal Max-Length header value."))
+				return
+			}
+		} else {
+			maxLength = server.GetGameSettings().MaxLength // Set default max length, if one is not provided
+		}
+
+		var entriesCount int
+		entriesCountString := r.Header.Get("Entries-Count")
+		if entriesCountString != "" {
+			entriesCount, err = strconv.Atoi(entriesCountString)
+			if err != nil || entriesCount < 0 {
+				w.WriteHeader(400)
+				w.Write([]byte("Illegal Entries-Count header value."))
+				return
+			}
+		} else {
+			entriesCount = server.GetGameSettings().EntriesCount // Set default entries count, if one is not provided
+		}
+
+		var quorum float64
+		quorumString := r.Header.Get("Quorum")
+		if quorumString != "" {
+			quorum, err = strconv.ParseFloat(quorumString, 64)
+			if err != nil || quorum < 0 || quorum > 1 {
+				w.WriteHeader(400)
+				w.Write([]byte("Illegal Quorum header value."))
+				return
+			}
+		}
+
+		var countdown int
+		countdownString := r.Header.Get("Countdown")
+		if countdownString != "" {
+			countdown, err = strconv.Atoi(countdownString)
+			if err != nil || countdown < 0 {
+				w.WriteHeader(400)
+				w.Write([]byte("Illegal Countdown header value."))
+				return
+			}
+		}
+
+		constraints, err := parseConstraints(r.Header)
+		if err != nil {
+			w.WriteHeader(400)
+			w.Write([]byte(fmt.Sprintf("Illegal constraints: %v.", err)))
+			return
+		}
+
+		var prompt *prompts.Prompt
+		if selector := r.Header.Get("Prompt"); selector != "" {
+			prompt, err = server.ResolvePrompt(roomName, selector)
+			if err != nil {
+				w.WriteHeader(400)
+				w.Write([]byte(fmt.Sprintf("Prompt cannot be used: %v.", err)))
+				return
+			}
+		}
+
+		if err := room.OpenLobby(issuer, timeLimit, maxLength, entriesCount, quorum, countdown); err != nil {
+			w.WriteHeader(403)
+			w.Write([]byte("Lobby cannot be opened. Requires user to be joined and have admin access."))
+			return
+		}
+
+		room.GetGame().SetConstraints(constraints)
+		if prompt != nil {
+			room.GetGame().SeedStory(prompt.Text)
+		}
+
+		w.Write([]byte("Lobby successfully opened in room \"" + roomName + "\"."))
+	case http.MethodPut:
+		room, err := server.GetRoom(roomName)
+		if err != nil {
+			w.WriteHeader(404)
+			w.Write([]byte("Room \"" + roomName + "\" doesn't exist."))
+			return
+		}
+		if game, err := server.GetGame(roomName); err != nil || game.Lobby == nil {
+			w.WriteHeader(409)
+			w.Write([]byte("There isn't an open lobby."))
+			return
+		}
+
+		issuer, err := util.ExtractUsernameFromAuthorizationHeader(r.Header.Get("Authorization"))
+		if err != nil {
+			w.WriteHeader(500)
+			w.Write([]byte("Error during decoding of authorization header."))
+			return
+		}
+
+		if !room.IsOnline(issuer) {
+			w.WriteHeader(403)
+			w.Write([]byte("User \"" + issuer + "\" is not in room \"" + roomName + "\"."))
+			return
+		}
+
+		if err := room.SetReady(issuer); err != nil {
+			w.WriteHeader(409)
+			w.Write([]byte("You are already ready."))
+			return
+		}
+
+		w.Write([]byte("You are ready to play in room \"" + roomName + "\"."))
+	case http.MethodDelete:
+		room, err := server.GetRoom(roomName)
+		if err != nil {
+			w.WriteHeader(404)
+			w.Write([]byte("Room \"" + roomName + "\" doesn't exist."))
+			return
+		}
+		if game, err := server.GetGame(roomName); err != nil || game.Lobby == nil {
+			w.WriteHeader(409)
+			w.Write([]byte("There isn't an open lobby."))
+			return
+		}
+
+		issuer, err := util.ExtractUsernameFromAuthorizationHeader(r.Header.Get("Authorization"))
+		if err != nil {
+			w.WriteHeader(500)
+			w.Write([]byte("Error during decoding of authorization header."))
+			return
+		}
+
+		if err := room.CloseLobby(issuer); err != nil {
+			w.WriteHeader(403)
+			w.Write([]byte("Lobby cannot be closed. Requires user to be joined and have admin access."))
+			return
+		}
+
+		w.Write([]byte("Lobby successfully closed in room \"" + roomName + "\"."))
+	default:
+		w.WriteHeader(405)
+		return
+	}
+}
+
+// PauseGameHandler is an http handler for the story builder's pause game API
+func (server *SBServer) PauseGameHandler(w http.ResponseWriter, r *http.Request) {
+	urlSuffix := strings.TrimPrefix(r.URL.Path, "/pause-game/")
+	urlSuffixSplit := strings.Split(urlSuffix, "/")
+	if len(urlSuffixSplit) > 2 || (len(urlSuffixSplit) == 2 && urlSuffixSplit[1] != "") {
+		w.WriteHeader(400)
+		w.Write([]byte("Room name is illegal."))
+		return
+	}
+	roomName := urlSuffixSplit[0]
+
+	switch r.Method {
+	case http.MethodPost:
+		room, err := server.GetRoom(roomName)
+		if err != nil {
+			w.WriteHeader(404)
+			w.Write([]byte("Room \"" + roomName + "\" doesn't exist."))
+			return
+		}
+		game, err := server.GetGame(roomName)
+		if err != nil || game.Finished {
+			w.WriteHeader(409)
+			w.Write([]byte("There is no running game."))
+			return
+		}
+		if game.Paused {
+			w.WriteHeader(409)
+			w.Write([]byte("The game is already paused."))
+			return
+		}
+
+		issuer, err := util.ExtractUsernameFromAuthorizationHeader(r.Header.Get("Authorization"))
+		if err != nil {
+			w.WriteHeader(500)
+			w.Write([]byte("Error during decoding of authorization header."))
+			return
+		}
+
+		if err := room.PauseGame(issuer); err != nil {
+			w.WriteHeader(403)
+			w.Write([]byte("Game cannot be paused. Requires user to be joined and have admin access."))
+			return
+		}
+
+		w.Write([]byte("Game successfully paused in room \"" + roomName + "\"."))
+	case http.MethodDelete:
+		room, err := server.GetRoom(roomName)
+		if err != nil {
+			w.WriteHeader(404)
+			w.Write([]byte("Room \"" + roomName + "\" doesn't exist."))
+			return
+		}
+		game, err := server.GetGame(roomName)
+		if err != nil || game.Finished {
+			w.WriteHeader(409)
+			w.Write([]byte("There is no running game."))
+			return
+		}
+		if !game.Paused {
+			w.WriteHeader(409)
+			w.Write([]byte("The game is not paused."))
+			return
+		}
+
+		issuer, err := util.ExtractUsernameFromAuthorizationHeader(r.Header.Get("Authorization"))
+		if err != nil {
+			w.WriteHeader(500)
+			w.Write([]byte("Error during decoding of authorization header."))
+			return
+		}
+
+		if err := room.ResumeGame(issuer); err != nil {
+			w.WriteHeader(403)
+			w.Write([]byte("Game cannot be resumed. Requires user to be joined and have admin access."))
+			return
+		}
+
+		w.Write([]byte("Game successfully resumed in room \"" + roomName + "\"."))
+	default:
+		w.WriteHeader(405)
+		return
+	}
+}
+
 // VoteHandler is an http handler for the story builder's voting API
 func (server *SBServer) VoteHandler(w http.ResponseWriter, r *http.Request) {
 	urlSuffix := strings.TrimPrefix(r.URL.Path, "/vote/")
@@ -225,24 +579,48 @@ func (server *SBServer) VoteHandler(w http.ResponseWriter, r *http.Request) {
 
 	switch r.Method {
 	case http.MethodPost:
-		if len(urlSuffixSplit) == 1 || len(urlSuffixSplit) > 3 || (len(urlSuffixSplit) == 3 && urlSuffixSplit[2] != "") {
+		if len(urlSuffixSplit) > 3 || (len(urlSuffixSplit) == 3 && urlSuffixSplit[2] != "") {
 			w.WriteHeader(400)
 			w.Write([]byte("Request URL is illegal."))
 			return
 		}
 		roomName := urlSuffixSplit[0]
-		playerToKick := urlSuffixSplit[1]
+		target := ""
+		if len(urlSuffixSplit) > 1 {
+			target = urlSuffixSplit[1]
+		}
 
-		game, err := server.GetGame(roomName)
+		kind := game.KickVote
+		if kindString := r.Header.Get("Vote-Kind"); kindString != "" {
+			var err error
+			if kind, err = game.ParseVoteKind(kindString); err != nil {
+				w.WriteHeader(400)
+				w.Write([]byte("Illegal Vote-Kind header value."))
+				return
+			}
+		}
+		if kind == game.KickVote && target == "" {
+			w.WriteHeader(400)
+			w.Write([]byte("Request URL is illegal. A vote to kick requires a player."))
+			return
+		}
+
+		sbGame, err := server.GetGame(roomName)
 		if err != nil {
 			w.WriteHeader(404)
 			w.Write([]byte("Room \"" + roomName + "\" doesn't exist or no games have been started."))
 			return
 		}
 
-		if game.VoteKick != nil {
+		if sbGame.Paused {
+			w.WriteHeader(423)
+			w.Write([]byte("The game is paused."))
+			return
+		}
+
+		if vote := sbGame.FindOngoingVote(kind, target); vote != nil {
 			w.WriteHeader(409)
-			w.Write([]byte("There is already an ongoing vote for player \"" + game.VoteKick.Player + "\"."))
+			w.Write([]byte("There is already an ongoing vote to " + vote.Description() + "."))
 			return
 		}
 
@@ -253,14 +631,16 @@ func (server *SBServer) VoteHandler(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		if err := game.TriggerVoteKick(issuer, playerToKick, 0.65, 60); err != nil {
+		settings := server.GetVoteSettings(kind)
+		vote, err := sbGame.TriggerVote(issuer, kind, target, settings.AcceptanceRatio, settings.TimeLimit)
+		if err != nil {
 			w.WriteHeader(404)
 			w.Write([]byte(err.Error()))
 			return
 		}
 
 		w.WriteHeader(202)
-		w.Write([]byte("A vote to kick player \"" + playerToKick + "\" was successfully triggered."))
+		w.Write([]byte(fmt.Sprintf("A vote to %s was successfully triggered (vote #%d).", vote.Description(), vote.ID)))
 		return
 	case http.MethodPut:
 		if len(urlSuffixSplit) > 2 || (len(urlSuffixSplit) == 2 && urlSuffixSplit[1] != "") {
@@ -270,25 +650,64 @@ func (server *SBServer) VoteHandler(w http.ResponseWriter, r *http.Request) {
 		}
 		roomName := urlSuffixSplit[0]
 
-		game, err := server.GetGame(roomName)
+		sbGame, err := server.GetGame(roomName)
 		if err != nil {
 			w.WriteHeader(404)
 			w.Write([]byte("Room \"" + roomName + "\" doesn't exist or no games have been started."))
 			return
 		}
 
-		if game.Finished {
+		if sbGame.Finished {
 			w.WriteHeader(404)
 			w.Write([]byte("There is no running game."))
 			return
 		}
 
-		if game.VoteKick == nil {
+		if sbGame.Paused {
+			w.WriteHeader(423)
+			w.Write([]byte("The game is paused."))
+			return
+		}
+
+		if len(sbGame.Votes) == 0 {
 			w.WriteHeader(404)
 			w.Write([]byte("There are no ongoing votes."))
 			return
 		}
 
+		var voteID int
+		if voteIDString := r.Header.Get("Vote-ID"); voteIDString != "" {
+			voteID, err = strconv.Atoi(voteIDString)
+			if err != nil || voteID < 0 {
+				w.WriteHeader(400)
+				w.Write([]byte("Illegal Vote-ID header value."))
+				return
+			}
+		}
+
+		inFavour := true
+		switch strings.ToLower(r.Header.Get("Vote-Choice")) {
+		case "", "yes":
+		case "no":
+			inFavour = false
+		default:
+			w.WriteHeader(400)
+			w.Write([]byte("Illegal Vote-Choice header value."))
+			return
+		}
+
+		vote, err := sbGame.GetVote(voteID)
+		if err != nil {
+			if voteID == 0 {
+				w.WriteHeader(400)
+				w.Write([]byte("There are several ongoing votes. Specify which one to vote for."))
+				return
+			}
+			w.WriteHeader(404)
+			w.Write([]byte(fmt.Sprintf("There is no ongoing vote with id %d.", voteID)))
+			return
+		}
+
 		issuer, err := util.ExtractUsernameFromAuthorizationHeader(r.Header.Get("Authorization"))
 		if err != nil {
 			w.WriteHeader(500)
@@ -297,7 +716,7 @@ func (server *SBServer) VoteHandler(w http.ResponseWriter, r *http.Request) {
 		}
 
 		inGame := false
-		for _, player := range game.Players {
+		for _, player := range sbGame.Players {
 			if player == issuer {
 				inGame = true
 				break
@@ -309,14 +728,121 @@ func (server *SBServer) VoteHandler(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		if err := game.Vote(issuer); err != nil {
+		if err := sbGame.Vote(issuer, vote.ID, inFavour); err != nil {
 			w.WriteHeader(409)
 			w.Write([]byte("You have already voted. You can only vote once."))
 			return
 		}
 
+		choice := "in favour of"
+		if !inFavour {
+			choice = "against"
+		}
 		w.WriteHeader(200)
-		w.Write([]byte("Your vote to kick player \"" + game.VoteKick.Player + "\" was accepted."))
+		w.Write([]byte("Your vote " + choice + " the vote to " + vote.Description() + " was accepted."))
+	default:
+		w.WriteHeader(405)
+		return
+	}
+}
+
+// parseConstraints builds the constraint challenges of a new game from the Required-Words (comma separated), Banned-Letters and Rhyme headers.
+func parseConstraints(header http.Header) (*game.Constraints, error) {
+	var requiredWords []string
+	if requiredWordsString := header.Get("Required-Words"); requiredWordsString != "" {
+		requiredWords = strings.Split(requiredWordsString, ",")
+	}
+
+	var rhyme bool
+	if rhymeString := header.Get("Rhyme"); rhymeString != "" {
+		var err error
+		if rhyme, err = strconv.ParseBool(rhymeString); err != nil {
+			return nil, errors.New("illegal Rhyme header value")
+		}
+	}
+
+	return game.NewConstraints(requiredWords, header.Get("Banned-Letters"), rhyme)
+}
+
+// TeamVoteHandler is an http handler for the story builder's final vote API of team games
+func (server *SBServer) TeamVoteHandler(w http.ResponseWriter, r *http.Request) {
+	urlSuffix := strings.TrimPrefix(r.URL.Path, "/team-vote/")
+	urlSuffixSplit := strings.Split(urlSuffix, "/")
+	if len(urlSuffixSplit) > 2 || (len(urlSuffixSplit) == 2 && urlSuffixSplit[1] != "") {
+		w.WriteHeader(400)
+		w.Write([]byte("Room name is illegal."))
+		return
+	}
+	roomName := urlSuffixSplit[0]
+
+	switch r.Method {
+	case http.MethodPost:
+		room, err := server.GetRoom(roomName)
+		if err != nil {
+			w.WriteHeader(404)
+			w.Write([]byte("Room \"" + roomName + "\" doesn't exist."))
+			return
+		}
+		if sbGame, err := server.GetGame(roomName); err != nil || sbGame.Finished || sbGame.FinalVote == nil {
+			w.WriteHeader(409)
+			w.Write([]byte("There is no final vote going on."))
+			return
+		}
+
+		voter, err := util.ExtractUsernameFromAuthorizationHeader(r.Header.Get("Authorization"))
+		if err != nil {
+			w.WriteHeader(500)
+			w.Write([]byte("Error during decoding of authorization header."))
+			return
+		}
+
+		team := r.Header.Get("Team")
+		if team == "" {
+			w.WriteHeader(400)
+			w.Write([]byte("Missing Team header."))
+			return
+		}
+
+		if err := room.VoteForTeam(voter, team); err != nil {
+			w.WriteHeader(403)
+			w.Write([]byte(fmt.Sprintf("There was an error while casting your vote: %v", err)))
+			return
+		}
+
+		w.Write([]byte("Vote for team \"" + team + "\" successfully cast."))
+	default:
+		w.WriteHeader(405)
+		return
+	}
+}
+
+// StoryTreeHandler is an http handler for the story builder's story tree API
+func (server *SBServer) StoryTreeHandler(w http.ResponseWriter, r *http.Request) {
+	urlSuffix := strings.TrimPrefix(r.URL.Path, "/story-tree/")
+	urlSuffixSplit := strings.Split(urlSuffix, "/")
+	if len(urlSuffixSplit) > 2 || (len(urlSuffixSplit) == 2 && urlSuffixSplit[1] != "") {
+		w.WriteHeader(400)
+		w.Write([]byte("Room name is illegal."))
+		return
+	}
+	roomName := urlSuffixSplit[0]
+
+	switch r.Method {
+	case http.MethodGet:
+		room, err := server.GetRoom(roomName)
+		if err != nil {
+			w.WriteHeader(404)
+			w.Write([]byte("Room \"" + roomName + "\" doesn't exist."))
+			return
+		}
+
+		if responseBody, err := json.Marshal(room.StoryTree()); err == nil {
+			w.Write(responseBody)
+			return
+		}
+
+		w.WriteHeader(500)
+		w.Write([]byte("Error during serialization of the story tree."))
 	default:
 		w.WriteHeader(405)
 		return
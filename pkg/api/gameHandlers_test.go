@@ -255,7 +255,7 @@ var _ = Describe("Story Builder Admin Handlers test", func() {
 				Context("And previous game is not finished", func() {
 					It("should return error", func() {
 						sbServer.Rooms[0].GetGame().Finished = false
-						err := sbClient.StartGame(timeLimit, maxLength, entriesCount)
+						err := sbClient.StartGame(timeLimit, maxLength, entriesCount, "", nil)
 
 						Expect(err).Should(HaveOccurred())
 						Expect(err.Error()).To(ContainSubstring("a game is already running in \"" + roomName + "\""))
@@ -264,7 +264,7 @@ var _ = Describe("Story Builder Admin Handlers test", func() {
 
 				Context("And previous game is finished", func() {
 					It("should start game succesffuly and not return error", func() {
-						err := sbClient.StartGame(timeLimit, maxLength, entriesCount)
+						err := sbClient.StartGame(timeLimit, maxLength, entriesCount, "", nil)
 
 						Expect(err).ShouldNot(HaveOccurred())
 						Expect(sbServer.Rooms[0].GetGame().Finished).To(BeFalse())
@@ -272,11 +272,31 @@ var _ = Describe("Story Builder Admin Handlers test", func() {
 				})
 			})
 
+			Context("When constraints are provided", func() {
+				It("should start the game with them", func() {
+					constraints := &game.Constraints{RequiredWords: []string{"dragon"}, Rhyme: true}
+					err := sbClient.StartGame(timeLimit, maxLength, entriesCount, "", constraints)
+
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(sbServer.Rooms[0].GetGame().Constraints).To(Equal(constraints))
+				})
+			})
+
+			Context("When constraints conflict", func() {
+				It("should return error", func() {
+					constraints := &game.Constraints{RequiredWords: []string{"dragon"}, BannedLetters: "a"}
+					err := sbClient.StartGame(timeLimit, maxLength, entriesCount, "", constraints)
+
+					Expect(err).Should(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("required word \"dragon\" contains a banned letter"))
+				})
+			})
+
 			Context("When room does not exist", func() {
 				It("should return error", func() {
 					sbServer.Rooms = make([]rooms.Room, 0)
 
-					err := sbClient.StartGame(timeLimit, maxLength, entriesCount)
+					err := sbClient.StartGame(timeLimit, maxLength, entriesCount, "", nil)
 
 					Expect(err).Should(HaveOccurred())
 					Expect(err.Error()).To(ContainSubstring("room \"" + roomName + "\" doesn't exist"))
@@ -287,7 +307,7 @@ var _ = Describe("Story Builder Admin Handlers test", func() {
 				It("should return error", func() {
 					sbServer.Rooms[0].Admins = make([]string, 0)
 
-					err := sbClient.StartGame(timeLimit, maxLength, entriesCount)
+					err := sbClient.StartGame(timeLimit, maxLength, entriesCount, "", nil)
 
 					Expect(err).Should(HaveOccurred())
 					Expect(err.Error()).To(ContainSubstring("cannot start game: requires admin access"))
@@ -299,7 +319,7 @@ var _ = Describe("Story Builder Admin Handlers test", func() {
 					clientConfig = &config.SBConfiguration{URL: ts.URL, Authorization: "invalid", Room: roomName}
 					sbClient = client.NewTestSBClient(clientConfig, ts.Client())
 
-					err := sbClient.StartGame(timeLimit, maxLength, entriesCount)
+					err := sbClient.StartGame(timeLimit, maxLength, entriesCount, "", nil)
 
 					Expect(err).Should(HaveOccurred())
 				})
@@ -310,7 +330,7 @@ var _ = Describe("Story Builder Admin Handlers test", func() {
 					clientConfig = &config.SBConfiguration{URL: ts.URL, Authorization: authHeader, Room: "invalid/roomname"}
 					sbClient = client.NewTestSBClient(clientConfig, ts.Client())
 
-					err := sbClient.StartGame(timeLimit, maxLength, entriesCount)
+					err := sbClient.StartGame(timeLimit, maxLength, entriesCount, "", nil)
 
 					Expect(err).Should(HaveOccurred())
 				})
@@ -398,6 +418,440 @@ var _ = Describe("Story Builder Admin Handlers test", func() {
 		})
 	})
 
+	Describe("Handle lobby requests", func() {
+		BeforeEach(func() {
+			ts = httptest.NewServer(http.HandlerFunc(sbServer.LobbyHandler))
+
+			clientConfig = &config.SBConfiguration{URL: ts.URL, Authorization: authHeader, Room: roomName}
+			sbClient = client.NewTestSBClient(clientConfig, ts.Client())
+		})
+		Describe("Specifically open lobby request", func() {
+			BeforeEach(func() {
+				sbServer.Rooms[0].GetGame().Finished = true
+			})
+			Context("When request is valid", func() {
+				It("should open the lobby and not return error", func() {
+					err := sbClient.OpenLobby(timeLimit, maxLength, entriesCount, 0.5, 0, "", nil)
+
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(sbServer.Rooms[0].GetGame().Lobby).ToNot(BeNil())
+					Expect(sbServer.Rooms[0].GetGame().Lobby.Ready).To(Equal([]string{username}))
+					Expect(sbServer.Rooms[0].GetGame().Lobby.Quorum).To(Equal(0.5))
+				})
+			})
+
+			Context("When a game is running", func() {
+				It("should return error", func() {
+					sbServer.Rooms[0].GetGame().Finished = false
+
+					err := sbClient.OpenLobby(timeLimit, maxLength, entriesCount, 0, 0, "", nil)
+
+					Expect(err).Should(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("a game is already running in \"" + roomName + "\""))
+				})
+			})
+
+			Context("When user doesn't have permissions", func() {
+				It("should return error", func() {
+					sbServer.Rooms[0].Admins = make([]string, 0)
+
+					err := sbClient.OpenLobby(timeLimit, maxLength, entriesCount, 0, 0, "", nil)
+
+					Expect(err).Should(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("cannot open lobby: requires admin access"))
+				})
+			})
+		})
+
+		Describe("Specifically ready request", func() {
+			BeforeEach(func() {
+				sbServer.Rooms[0].GetGame().Finished = true
+				sbServer.Rooms[0].OpenLobby(username, timeLimit, maxLength, entriesCount, 0, 0)
+				clientConfig = &config.SBConfiguration{URL: ts.URL, Authorization: "Basic " + base64.StdEncoding.EncodeToString([]byte(player+":"+password)), Room: roomName}
+				sbClient = client.NewTestSBClient(clientConfig, ts.Client())
+			})
+			Context("When request is valid", func() {
+				It("should start the game once everyone is ready and not return error", func() {
+					err := sbClient.Ready()
+
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(sbServer.Rooms[0].GetGame().Lobby).To(BeNil())
+					Expect(sbServer.Rooms[0].GetGame().Players).To(Equal([]string{username, player}))
+				})
+			})
+
+			Context("When user is already ready", func() {
+				It("should return error", func() {
+					sbServer.Rooms[0].Online = append(sbServer.Rooms[0].Online, "afk-player")
+					sbClient.Ready()
+
+					err := sbClient.Ready()
+
+					Expect(err).Should(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("cannot get ready: You are already ready."))
+				})
+			})
+
+			Context("When players leave the room", func() {
+				It("should forget that they were ready and start the game once the rest are ready", func() {
+					sbServer.Rooms[0].Online = append(sbServer.Rooms[0].Online, "afk-player")
+					Expect(sbClient.Ready()).To(Succeed())
+
+					Expect(sbServer.LeaveRoom(roomName, player)).To(Succeed())
+					Expect(sbServer.Rooms[0].GetGame().Lobby.Ready).To(Equal([]string{username}))
+
+					Expect(sbServer.LeaveRoom(roomName, "afk-player")).To(Succeed())
+					Expect(sbServer.Rooms[0].GetGame().Lobby).To(BeNil())
+					Expect(sbServer.Rooms[0].GetGame().Players).To(Equal([]string{username}))
+				})
+
+				It("should close the lobby once none of the ready players is left", func() {
+					Expect(sbServer.LeaveRoom(roomName, username)).To(Succeed())
+
+					err := sbClient.Ready()
+
+					Expect(err).Should(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("cannot get ready: There isn't an open lobby."))
+				})
+			})
+
+			Context("When there isn't an open lobby", func() {
+				It("should return error", func() {
+					sbServer.Rooms[0].CloseLobby(username)
+
+					err := sbClient.Ready()
+
+					Expect(err).Should(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("cannot get ready: There isn't an open lobby."))
+				})
+			})
+
+			Context("When user is not in the room", func() {
+				It("should return error", func() {
+					sbServer.Rooms[0].Online = []string{username}
+
+					err := sbClient.Ready()
+
+					Expect(err).Should(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("cannot get ready: user is not in room \"" + roomName + "\""))
+				})
+			})
+		})
+
+		Describe("Specifically close lobby request", func() {
+			BeforeEach(func() {
+				sbServer.Rooms[0].GetGame().Finished = true
+				sbServer.Rooms[0].OpenLobby(username, timeLimit, maxLength, entriesCount, 0, 0)
+			})
+			Context("When request is valid", func() {
+				It("should close the lobby and not return error", func() {
+					err := sbClient.CloseLobby()
+
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(sbServer.Rooms[0].GetGame().Lobby).To(BeNil())
+					Expect(sbServer.Rooms[0].GetGame().Finished).To(BeTrue())
+				})
+			})
+
+			Context("When user doesn't have permissions", func() {
+				It("should return error", func() {
+					sbServer.Rooms[0].Admins = make([]string, 0)
+
+					err := sbClient.CloseLobby()
+
+					Expect(err).Should(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("cannot close lobby: requires admin access"))
+				})
+			})
+		})
+
+		Context("When request is a wrong method type", func() {
+			It("should return HTTP 405 status code", func() {
+				resp, err := http.Get(ts.URL + "/lobby/" + room.Name + "/")
+
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(resp.StatusCode).To(Equal(http.StatusMethodNotAllowed))
+			})
+		})
+	})
+
+	Describe("Handle team game requests", func() {
+		BeforeEach(func() {
+			sbServer.Rooms[0].GetGame().Finished = true
+			ts = httptest.NewServer(http.HandlerFunc(sbServer.ManageGamesHandler))
+
+			clientConfig = &config.SBConfiguration{URL: ts.URL, Authorization: authHeader, Room: roomName}
+			sbClient = client.NewTestSBClient(clientConfig, ts.Client())
+		})
+
+		Context("When the teams are valid", func() {
+			It("should start a team game", func() {
+				err := sbClient.StartTeamGame("red="+username+";blue="+player, timeLimit, maxLength, entriesCount, "", nil)
+
+				Expect(err).ShouldNot(HaveOccurred())
+				teams := sbServer.Rooms[0].GetGame().Teams
+				Expect(teams).To(HaveLen(2))
+				Expect(teams[0].Name).To(Equal("red"))
+				Expect(teams[1].Players).To(Equal([]string{player}))
+			})
+		})
+
+		Context("When the teams are illegal", func() {
+			It("should return error", func() {
+				err := sbClient.StartTeamGame("red="+username+";blue=stranger", timeLimit, maxLength, entriesCount, "", nil)
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("player \"stranger\" is not in the room"))
+			})
+		})
+
+		Describe("Specifically final vote request", func() {
+			BeforeEach(func() {
+				sbServer.Rooms[0].StartTeamGame(username, "red="+username+";blue="+player, timeLimit, maxLength, 1)
+				ts = httptest.NewServer(http.HandlerFunc(sbServer.TeamVoteHandler))
+
+				clientConfig = &config.SBConfiguration{URL: ts.URL, Authorization: authHeader, Room: roomName}
+				sbClient = client.NewTestSBClient(clientConfig, ts.Client())
+			})
+
+			Context("When the stories are not finished", func() {
+				It("should return error", func() {
+					err := sbClient.VoteForTeam("red")
+
+					Expect(err).Should(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("there is no final vote going on"))
+				})
+			})
+
+			Context("When the final vote is going on", func() {
+				BeforeEach(func() {
+					sbServer.Rooms[0].AddEntry(entry, username)
+					sbServer.Rooms[0].AddEntry(entry, player)
+				})
+
+				It("should cast the vote", func() {
+					err := sbClient.VoteForTeam("blue")
+
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(sbServer.Rooms[0].GetGame().FinalVote.Ballots).To(HaveKeyWithValue(username, "blue"))
+				})
+
+				It("should return error when voting for a non-existing team", func() {
+					err := sbClient.VoteForTeam("green")
+
+					Expect(err).Should(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("team \"green\" doesn't exist"))
+				})
+			})
+		})
+	})
+
+	Describe("Handle fork game requests", func() {
+		BeforeEach(func() {
+			sbServer.Rooms[0].GetGame().Finished = true
+			sbServer.Rooms[0].StartGame(username, timeLimit, maxLength, 2)
+			sbServer.Rooms[0].AddEntry(entry, username)
+			sbServer.Rooms[0].AddEntry(entry, player)
+			ts = httptest.NewServer(http.HandlerFunc(sbServer.ManageGamesHandler))
+
+			clientConfig = &config.SBConfiguration{URL: ts.URL, Authorization: authHeader, Room: roomName}
+			sbClient = client.NewTestSBClient(clientConfig, ts.Client())
+		})
+
+		Context("When the fork point is valid", func() {
+			It("should start a game with the story up to the fork point", func() {
+				err := sbClient.ForkGame(0, 1, timeLimit, maxLength, entriesCount, nil)
+
+				Expect(err).ShouldNot(HaveOccurred())
+				forked := sbServer.Rooms[0].GetGame()
+				Expect(forked.Finished).To(BeFalse())
+				Expect(forked.Story).To(HaveLen(1))
+				Expect(forked.ForkedFrom).To(Equal(&game.Fork{GameID: 2, At: 1}))
+			})
+		})
+
+		Context("When an older game is forked by ID", func() {
+			It("should return error if the game has no entries to fork", func() {
+				err := sbClient.ForkGame(1, 1, timeLimit, maxLength, entriesCount, nil)
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("the fork point must be between 1 and 0"))
+			})
+		})
+
+		Context("When the fork point is past the end of the story", func() {
+			It("should return error", func() {
+				err := sbClient.ForkGame(0, 3, timeLimit, maxLength, entriesCount, nil)
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("the fork point must be between 1 and 2"))
+			})
+		})
+
+		Context("When the game doesn't exist", func() {
+			It("should return error", func() {
+				err := sbClient.ForkGame(7, 1, timeLimit, maxLength, entriesCount, nil)
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("there is no game with id 7"))
+			})
+		})
+
+		Context("When the user is not an admin", func() {
+			It("should return error", func() {
+				clientConfig.Authorization = "Basic " + base64.StdEncoding.EncodeToString([]byte(player+":"+password))
+				sbClient = client.NewTestSBClient(clientConfig, ts.Client())
+
+				err := sbClient.ForkGame(0, 1, timeLimit, maxLength, entriesCount, nil)
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("requires admin access"))
+			})
+		})
+
+		Describe("Specifically story tree and game history requests", func() {
+			BeforeEach(func() {
+				sbServer.Rooms[0].ForkGame(username, 0, 1, timeLimit, maxLength, entriesCount)
+			})
+
+			It("should return the summaries of all games in the room", func() {
+				ts = httptest.NewServer(http.HandlerFunc(sbServer.StoryTreeHandler))
+				sbClient = client.NewTestSBClient(&config.SBConfiguration{URL: ts.URL, Authorization: authHeader, Room: roomName}, ts.Client())
+
+				summaries, err := sbClient.GetStoryTree()
+
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(summaries).To(HaveLen(3))
+				Expect(summaries[2].ID).To(Equal(3))
+				Expect(summaries[2].ForkedFrom).To(Equal(&game.Fork{GameID: 2, At: 1}))
+			})
+
+			It("should return an archived game by its ID", func() {
+				ts = httptest.NewServer(http.HandlerFunc(sbServer.GameplayHandler))
+				sbClient = client.NewTestSBClient(&config.SBConfiguration{URL: ts.URL, Authorization: authHeader, Room: roomName}, ts.Client())
+
+				archived, err := sbClient.GetGameByID(2)
+
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(archived.Finished).To(BeTrue())
+				Expect(archived.Story).To(HaveLen(2))
+
+				_, err = sbClient.GetGameByID(7)
+				Expect(err).Should(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("Handle pause game requests", func() {
+		BeforeEach(func() {
+			ts = httptest.NewServer(http.HandlerFunc(sbServer.PauseGameHandler))
+
+			clientConfig = &config.SBConfiguration{URL: ts.URL, Authorization: authHeader, Room: roomName}
+			sbClient = client.NewTestSBClient(clientConfig, ts.Client())
+		})
+		Describe("Specifically pause game request", func() {
+			Context("When request is valid", func() {
+				It("should pause the game and not return error", func() {
+					err := sbClient.PauseGame()
+
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(sbServer.Rooms[0].GetGame().Paused).To(BeTrue())
+				})
+			})
+
+			Context("When game is already paused", func() {
+				It("should return error", func() {
+					sbServer.Rooms[0].GetGame().Paused = true
+
+					err := sbClient.PauseGame()
+
+					Expect(err).Should(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("cannot pause game: The game is already paused."))
+				})
+			})
+
+			Context("When game is finished", func() {
+				It("should return error", func() {
+					sbServer.Rooms[0].GetGame().Finished = true
+
+					err := sbClient.PauseGame()
+
+					Expect(err).Should(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("cannot pause game: There is no running game."))
+				})
+			})
+
+			Context("When room does not exist", func() {
+				It("should return error", func() {
+					sbServer.Rooms = make([]rooms.Room, 0)
+
+					err := sbClient.PauseGame()
+
+					Expect(err).Should(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("room \"" + roomName + "\" doesn't exist"))
+				})
+			})
+
+			Context("When user doesn't have permissions", func() {
+				It("should return error", func() {
+					sbServer.Rooms[0].Admins = make([]string, 0)
+
+					err := sbClient.PauseGame()
+
+					Expect(err).Should(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("cannot pause game: requires admin access"))
+					Expect(sbServer.Rooms[0].GetGame().Paused).To(BeFalse())
+				})
+			})
+		})
+
+		Describe("Specifically resume game request", func() {
+			BeforeEach(func() {
+				sbServer.Rooms[0].GetGame().Paused = true
+			})
+			Context("When request is valid", func() {
+				It("should resume the game and not return error", func() {
+					err := sbClient.ResumeGame()
+
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(sbServer.Rooms[0].GetGame().Paused).To(BeFalse())
+				})
+			})
+
+			Context("When game is not paused", func() {
+				It("should return error", func() {
+					sbServer.Rooms[0].GetGame().Paused = false
+
+					err := sbClient.ResumeGame()
+
+					Expect(err).Should(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("cannot resume game: The game is not paused."))
+				})
+			})
+
+			Context("When user doesn't have permissions", func() {
+				It("should return error", func() {
+					sbServer.Rooms[0].Admins = make([]string, 0)
+
+					err := sbClient.ResumeGame()
+
+					Expect(err).Should(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("cannot resume game: requires admin access"))
+					Expect(sbServer.Rooms[0].GetGame().Paused).To(BeTrue())
+				})
+			})
+		})
+
+		Context("When request is a wrong method type", func() {
+			It("should return HTTP 405 status code", func() {
+				resp, err := http.Get(ts.URL + "/pause-game/" + room.Name + "/")
+
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(resp.StatusCode).To(Equal(http.StatusMethodNotAllowed))
+			})
+		})
+	})
+
 	Describe("Handle vote requests", func() {
 		BeforeEach(func() {
 			ts = httptest.NewServer(http.HandlerFunc(sbServer.VoteHandler))
@@ -407,7 +861,7 @@ var _ = Describe("Story Builder Admin Handlers test", func() {
 		})
 		Describe("Specifically trigger vote request", func() {
 			BeforeEach(func() {
-				sbServer.Rooms[0].GetGame().VoteKick = nil
+				sbServer.Rooms[0].GetGame().Votes = make([]*game.Vote, 0)
 			})
 			Context("When request is valid", func() {
 				Context("And game is not finished", func() {
@@ -415,9 +869,10 @@ var _ = Describe("Story Builder Admin Handlers test", func() {
 						err := sbClient.TriggerVoteKick(player)
 
 						Expect(err).ShouldNot(HaveOccurred())
-						Expect(sbServer.Rooms[0].GetGame().VoteKick).ToNot(BeNil())
-						Expect(sbServer.Rooms[0].GetGame().VoteKick.Player).To(Equal(player))
-						Expect(sbServer.Rooms[0].GetGame().VoteKick.Issuer).To(Equal(username))
+						Expect(sbServer.Rooms[0].GetGame().Votes).To(HaveLen(1))
+						Expect(sbServer.Rooms[0].GetGame().Votes[0].Kind).To(Equal(game.KickVote))
+						Expect(sbServer.Rooms[0].GetGame().Votes[0].Target).To(Equal(player))
+						Expect(sbServer.Rooms[0].GetGame().Votes[0].Issuer).To(Equal(username))
 					})
 				})
 
@@ -427,7 +882,7 @@ var _ = Describe("Story Builder Admin Handlers test", func() {
 						err := sbClient.TriggerVoteKick(player)
 
 						Expect(err).Should(HaveOccurred())
-						Expect(sbServer.Rooms[0].GetGame().VoteKick).To(BeNil())
+						Expect(sbServer.Rooms[0].GetGame().Votes).To(BeEmpty())
 					})
 				})
 			})
@@ -502,12 +957,12 @@ var _ = Describe("Story Builder Admin Handlers test", func() {
 							Expect(err).ShouldNot(HaveOccurred())
 
 							// Vote should be counted
-							Expect(sbServer.Rooms[0].GetGame().VoteKick.Count).To(Equal(1))
+							Expect(sbServer.Rooms[0].GetGame().Votes[0].Count).To(Equal(1))
 						})
 					})
 					Context("And there is no ongoing vote", func() {
 						It("should not submit vote and return error", func() {
-							sbServer.Rooms[0].GetGame().VoteKick = nil
+							sbServer.Rooms[0].GetGame().Votes = make([]*game.Vote, 0)
 
 							err := sbClient.SubmitVote()
 
@@ -554,7 +1009,7 @@ var _ = Describe("Story Builder Admin Handlers test", func() {
 			Context("When room does not exist", func() {
 				It("should return error", func() {
 					// Increase treshold to test double voting
-					sbServer.Rooms[0].GetGame().VoteKick = game.NewVoteKick(username, player, 2, 60)
+					sbServer.Rooms[0].GetGame().Votes = []*game.Vote{game.NewVote(1, game.KickVote, username, player, 2, 60)}
 
 					sbClient.SubmitVote()
 					err := sbClient.SubmitVote()
@@ -566,7 +1021,8 @@ var _ = Describe("Story Builder Admin Handlers test", func() {
 
 			Context("When room does not exist", func() {
 				It("should return error", func() {
-					sbServer.Rooms[0].GetGame().Players = make([]string, 0)
+					// Remove the user from the game, keeping enough players for the vote to stay open
+					sbServer.Rooms[0].GetGame().Players = []string{player, "other-player", "third-player"}
 					err := sbClient.SubmitVote()
 
 					Expect(err).Should(HaveOccurred())
@@ -597,6 +1053,83 @@ var _ = Describe("Story Builder Admin Handlers test", func() {
 			})
 		})
 
+		Describe("Specifically trigger vote requests of other kinds", func() {
+			Context("When a skip vote is triggered", func() {
+				It("should trigger a vote for the current turn and not return error", func() {
+					err := sbClient.TriggerVote("skip", "")
+
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(sbServer.Rooms[0].GetGame().Votes).To(HaveLen(1))
+					Expect(sbServer.Rooms[0].GetGame().Votes[0].Kind).To(Equal(game.SkipVote))
+					Expect(sbServer.Rooms[0].GetGame().Votes[0].Target).To(Equal(username))
+				})
+			})
+
+			Context("When votes of several kinds are triggered", func() {
+				It("should run all of them at once", func() {
+					Expect(sbClient.TriggerVoteKick(player)).To(Succeed())
+					Expect(sbClient.TriggerVote("end", "")).To(Succeed())
+
+					Expect(sbServer.Rooms[0].GetGame().Votes).To(HaveLen(2))
+				})
+			})
+
+			Context("When an unknown kind is requested", func() {
+				It("should return error", func() {
+					err := sbClient.TriggerVote("no-such-kind", "")
+
+					Expect(err).Should(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("could not trigger vote: Illegal Vote-Kind header value."))
+				})
+			})
+
+			Context("When the game is paused", func() {
+				It("should return error", func() {
+					sbServer.Rooms[0].GetGame().Paused = true
+
+					err := sbClient.TriggerVote("end", "")
+
+					Expect(err).Should(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("could not trigger vote: the game is paused"))
+				})
+			})
+		})
+
+		Describe("Specifically cast vote requests with a choice", func() {
+			BeforeEach(func() {
+				sbServer.Rooms[0].GetGame().TriggerVoteKick(username, player, 1, 60)
+				sbServer.Rooms[0].GetGame().TriggerVote(player, game.EndVote, "", 1, 60)
+			})
+			Context("When a vote against is cast for a specific vote", func() {
+				It("should count it and not return error", func() {
+					voteID := sbServer.Rooms[0].GetGame().Votes[1].ID
+					err := sbClient.CastVote(voteID, false)
+
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(sbServer.Rooms[0].GetGame().Votes[1].Against).To(Equal(1))
+					Expect(sbServer.Rooms[0].GetGame().Votes[0].Against).To(Equal(0))
+				})
+			})
+
+			Context("When no vote is specified while several are ongoing", func() {
+				It("should return error", func() {
+					err := sbClient.CastVote(0, true)
+
+					Expect(err).Should(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("cannot vote: There are several ongoing votes. Specify which one to vote for."))
+				})
+			})
+
+			Context("When a vote that doesn't exist is specified", func() {
+				It("should return error", func() {
+					err := sbClient.CastVote(42, true)
+
+					Expect(err).Should(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("cannot vote: There is no ongoing vote with id 42."))
+				})
+			})
+		})
+
 		Context("When request is a wrong method type", func() {
 			It("should return HTTP 405 status code", func() {
 				resp, err := http.Get(ts.URL + "/vote/" + room.Name + "/")
@@ -35,3 +35,21 @@ func (sbServer *SBServer) GetGame(roomName string) (*game.Game, error) {
 
 	return nil, errors.New("room \"" + roomName + "\" doesn't exist")
 }
+
+// GetVoteSettings returns the acceptance ratio and time limit the server uses for votes of the provided kind.
+// Falls back to the game defaults for vote kinds the server has no settings for.
+func (sbServer *SBServer) GetVoteSettings(kind game.VoteKind) game.VoteSettings {
+	if settings, ok := sbServer.VoteSettings[kind]; ok {
+		return settings
+	}
+	return game.DefaultVoteSettings[kind]
+}
+
+// GetGameSettings returns the settings the server uses for new games, unless the player starting the game provides them.
+// Falls back to the game defaults if the server has no game settings.
+func (sbServer *SBServer) GetGameSettings() game.Settings {
+	if sbServer.GameSettings != nil {
+		return *sbServer.GameSettings
+	}
+	return game.DefaultSettings
+}
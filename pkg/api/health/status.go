@@ -0,0 +1,46 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package health
+
+import "time"
+
+// Database states reported in the status of the server.
+const (
+	DatabaseUp   = "up"
+	DatabaseDown = "down"
+)
+
+// Status is the health report of a story builder server, returned by its /status endpoint.
+type Status struct {
+	Version string `json:"version"`
+	// Started is the time the server started listening.
+	Started time.Time `json:"started"`
+	// Uptime is the number of whole seconds since the server started listening.
+	Uptime      int64  `json:"uptimeSeconds"`
+	Rooms       int    `json:"rooms"`
+	ActiveGames int    `json:"activeGames"`
+	OnlineUsers int    `json:"onlineUsers"`
+	Database    string `json:"database"`
+}
+
+// Healthy returns true if the server can serve requests that need the database.
+func (status Status) Healthy() bool {
+	return status.Database == DatabaseUp
+}
+
+// UptimeDuration returns the uptime of the server as a duration.
+func (status Status) UptimeDuration() time.Duration {
+	return time.Duration(status.Uptime) * time.Second
+}
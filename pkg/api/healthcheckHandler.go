@@ -35,11 +35,17 @@ func (server *SBServer) HealthcheckHandler(w http.ResponseWriter, r *http.Reques
 				w.Write([]byte("Error during decoding of authorization header."))
 				return
 			}
-			if err := server.Database.LoginUser(user, pass); err != nil {
+			if err := server.loginUser(r, user, pass); err != nil {
+				if writeLockedOut(w, err) {
+					return
+				}
 				w.WriteHeader(401)
 				w.Write([]byte("Authentication for user \"" + user + "\" failed."))
 				return
 			}
+			if !server.allowUser(w, r, user) {
+				return
+			}
 
 			// Validate room
 			urlSuffix := strings.TrimPrefix(r.URL.Path, "/healthcheck/")
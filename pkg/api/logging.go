@@ -0,0 +1,120 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"os"
+	"regexp"
+	"time"
+
+	"github.com/pavelhadzhiev/story-builder/pkg/api/audit"
+	"github.com/pavelhadzhiev/story-builder/pkg/api/logging"
+	"github.com/pavelhadzhiev/story-builder/pkg/util"
+)
+
+// defaultLogger is used by servers without a logger of their own.
+var defaultLogger = logging.NewLogger(os.Stderr, logging.Info, logging.Logfmt)
+
+// requestIDHeader carries the ID of a request. Clients can provide one, otherwise the server generates it. It's always sent back in the response.
+const requestIDHeader = "X-Request-ID"
+
+var requestIDPattern = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+type requestIDKey struct{}
+
+// SetLogger replaces the logger the server writes its request logs and lifecycle messages to.
+func (sbServer *SBServer) SetLogger(logger *logging.Logger) {
+	sbServer.logger = logger
+}
+
+// SetAuditLog makes the server record security-relevant actions in the provided audit log.
+func (sbServer *SBServer) SetAuditLog(auditLog *audit.Log) {
+	sbServer.auditLog = auditLog
+}
+
+// log writes a structured message to the logger of the server. The fields are alternating keys and values.
+func (sbServer *SBServer) log(level logging.Level, message string, keyvals ...interface{}) {
+	logger := sbServer.logger
+	if logger == nil {
+		logger = defaultLogger
+	}
+	logger.Log(level, message, keyvals...)
+}
+
+// audit records the entry in the audit log of the server, adding the IP address and the ID of the provided request, if there is one.
+// Failures are logged, as they shouldn't fail the action that is audited.
+func (sbServer *SBServer) audit(r *http.Request, entry audit.Entry) {
+	if sbServer.auditLog == nil {
+		return
+	}
+	if r != nil {
+		entry.IP = clientIP(r)
+		entry.RequestID = requestID(r)
+	}
+	if _, err := sbServer.auditLog.Record(entry); err != nil {
+		sbServer.log(logging.Error, "cannot write to the audit log", "action", entry.Action, "error", err)
+	}
+}
+
+// requestID returns the ID the logged wrapper gave to the request.
+func requestID(r *http.Request) string {
+	id, _ := r.Context().Value(requestIDKey{}).(string)
+	return id
+}
+
+func newRequestID() string {
+	bytes := make([]byte, 8)
+	if _, err := rand.Read(bytes); err != nil {
+		return "unknown"
+	}
+	return hex.EncodeToString(bytes)
+}
+
+// logged wraps the provided handler, giving every request to the provided route an ID and writing a request log once it's handled.
+// Requests that fail with a 5xx status are logged with the error level.
+func (sbServer *SBServer) logged(route string, handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		id := r.Header.Get(requestIDHeader)
+		if !requestIDPattern.MatchString(id) {
+			id = newRequestID()
+		}
+		w.Header().Set(requestIDHeader, id)
+		r = r.WithContext(context.WithValue(r.Context(), requestIDKey{}, id))
+
+		recorder := &statusRecorder{ResponseWriter: w, status: 200}
+		handler(recorder, r)
+
+		level := logging.Info
+		if recorder.status >= 500 {
+			level = logging.Error
+		}
+		user, _ := util.ExtractUsernameFromAuthorizationHeader(r.Header.Get("Authorization"))
+		sbServer.log(level, "request",
+			"requestId", id,
+			"method", r.Method,
+			"route", route,
+			"path", r.URL.Path,
+			"status", recorder.status,
+			"durationMs", float64(time.Since(start).Microseconds())/1000,
+			"ip", clientIP(r),
+			"user", user,
+		)
+	}
+}
@@ -0,0 +1,194 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Level is the severity of a log message. Messages below the level of the logger are dropped.
+type Level int
+
+const (
+	// Debug is the level of messages that help tracing problems.
+	Debug Level = iota - 1
+	// Info is the level of request logs and messages about the lifecycle of the server. It's the default level.
+	Info
+	// Warn is the level of messages about suspicious client behaviour, such as rate limited requests and failed logins.
+	Warn
+	// Error is the level of messages about failures of the server, including requests that failed with a 5xx status.
+	Error
+)
+
+// Levels lists the names of the log levels, from the most to the least verbose.
+var Levels = []string{"debug", "info", "warn", "error"}
+
+func (level Level) String() string {
+	if level < Debug || level > Error {
+		return fmt.Sprintf("level(%d)", int(level))
+	}
+	return Levels[level-Debug]
+}
+
+// ParseLevel returns the log level with the provided name.
+// Returns error if there is no such log level.
+func ParseLevel(level string) (Level, error) {
+	for index, name := range Levels {
+		if strings.EqualFold(level, name) {
+			return Level(index) + Debug, nil
+		}
+	}
+	return Info, fmt.Errorf("unknown log level \"%s\", must be one of: %s", level, strings.Join(Levels, ", "))
+}
+
+// Format is the format in which log messages are written.
+type Format string
+
+const (
+	// Logfmt writes every message as a line of key=value pairs.
+	Logfmt Format = "logfmt"
+	// JSON writes every message as a JSON object on its own line.
+	JSON Format = "json"
+)
+
+// ParseFormat returns the log format with the provided name.
+// Returns error if there is no such log format.
+func ParseFormat(format string) (Format, error) {
+	switch Format(strings.ToLower(format)) {
+	case Logfmt:
+		return Logfmt, nil
+	case JSON:
+		return JSON, nil
+	default:
+		return Logfmt, fmt.Errorf("unknown log format \"%s\", must be one of: logfmt, json", format)
+	}
+}
+
+// Logger writes structured, leveled log messages. It's safe for concurrent use.
+type Logger struct {
+	out    io.Writer
+	level  Level
+	format Format
+
+	mutex sync.Mutex
+	now   func() time.Time
+}
+
+// NewLogger returns a logger that writes the messages of the provided level and above to the provided writer in the provided format.
+func NewLogger(out io.Writer, level Level, format Format) *Logger {
+	return &Logger{out: out, level: level, format: format, now: time.Now}
+}
+
+// Enabled returns true if messages of the provided level are written.
+func (logger *Logger) Enabled(level Level) bool {
+	return level >= logger.level
+}
+
+// Log writes a message with the provided level and fields, unless the level is below the level of the logger.
+// The fields are alternating keys and values. Every message gets the time, the level and the message as its first fields.
+func (logger *Logger) Log(level Level, message string, keyvals ...interface{}) {
+	if !logger.Enabled(level) {
+		return
+	}
+	fields := append([]interface{}{"time", logger.now().UTC().Format(time.RFC3339Nano), "level", level.String(), "msg", message}, keyvals...)
+	if len(fields)%2 != 0 {
+		fields = append(fields, "(missing)")
+	}
+
+	var line []byte
+	if logger.format == JSON {
+		line = formatJSON(fields)
+	} else {
+		line = formatLogfmt(fields)
+	}
+
+	logger.mutex.Lock()
+	defer logger.mutex.Unlock()
+	logger.out.Write(line)
+}
+
+func formatJSON(fields []interface{}) []byte {
+	buffer := &bytes.Buffer{}
+	buffer.WriteByte('{')
+	for index := 0; index < len(fields); index += 2 {
+		if index > 0 {
+			buffer.WriteByte(',')
+		}
+		key, _ := json.Marshal(fmt.Sprint(fields[index]))
+		buffer.Write(key)
+		buffer.WriteByte(':')
+		value, err := json.Marshal(jsonValue(fields[index+1]))
+		if err != nil {
+			value, _ = json.Marshal(fmt.Sprint(fields[index+1]))
+		}
+		buffer.Write(value)
+	}
+	buffer.WriteString("}\n")
+	return buffer.Bytes()
+}
+
+// jsonValue turns values that don't marshal to a useful JSON value, such as errors and durations, into strings.
+func jsonValue(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case error:
+		return typed.Error()
+	case time.Duration:
+		return typed.String()
+	case fmt.Stringer:
+		return typed.String()
+	default:
+		return value
+	}
+}
+
+func formatLogfmt(fields []interface{}) []byte {
+	buffer := &bytes.Buffer{}
+	for index := 0; index < len(fields); index += 2 {
+		if index > 0 {
+			buffer.WriteByte(' ')
+		}
+		buffer.WriteString(logfmtKey(fmt.Sprint(fields[index])))
+		buffer.WriteByte('=')
+		buffer.WriteString(logfmtValue(fmt.Sprint(fields[index+1])))
+	}
+	buffer.WriteByte('\n')
+	return buffer.Bytes()
+}
+
+func logfmtKey(key string) string {
+	return strings.Map(func(r rune) rune {
+		if r <= ' ' || r == '=' || r == '"' {
+			return '_'
+		}
+		return r
+	}, key)
+}
+
+func logfmtValue(value string) string {
+	if value == "" {
+		return `""`
+	}
+	if strings.ContainsAny(value, " =\"\\") || strings.IndexFunc(value, func(r rune) bool { return r < ' ' }) >= 0 {
+		return strconv.Quote(value)
+	}
+	return value
+}
@@ -0,0 +1,75 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseLevel(t *testing.T) {
+	for _, name := range Levels {
+		level, err := ParseLevel(strings.ToUpper(name))
+		if err != nil || level.String() != name {
+			t.Errorf("log level \"%s\" should be parsed case-insensitively", name)
+		}
+	}
+	if _, err := ParseLevel("loud"); err == nil {
+		t.Error("parsing an unknown log level should return error")
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	if format, err := ParseFormat("JSON"); err != nil || format != JSON {
+		t.Error("log formats should be parsed case-insensitively")
+	}
+	if _, err := ParseFormat("xml"); err == nil {
+		t.Error("parsing an unknown log format should return error")
+	}
+}
+
+func newTestLogger(format Format) (*Logger, *bytes.Buffer) {
+	out := &bytes.Buffer{}
+	logger := NewLogger(out, Info, format)
+	logger.now = func() time.Time { return time.Date(2019, 6, 1, 15, 0, 0, 0, time.UTC) }
+	return logger, out
+}
+
+func TestLogfmt(t *testing.T) {
+	logger, out := newTestLogger(Logfmt)
+	logger.Log(Info, "request", "route", "/rooms/", "status", 200, "user", "", "error", errors.New(`room "x" not found`))
+
+	expected := `time=2019-06-01T15:00:00Z level=info msg=request route=/rooms/ status=200 user="" error="room \"x\" not found"` + "\n"
+	if out.String() != expected {
+		t.Errorf("expected %q, got %q", expected, out.String())
+	}
+}
+
+func TestJSON(t *testing.T) {
+	logger, out := newTestLogger(JSON)
+	logger.Log(Warn, "slow request", "durationMs", 1500, "timeout", 2*time.Second, "odd")
+
+	fields := make(map[string]interface{})
+	if err := json.Unmarshal(out.Bytes(), &fields); err != nil {
+		t.Fatalf("every message should be a JSON object: %v", err)
+	}
+	if fields["level"] != "warn" || fields["msg"] != "slow request" || fields["time"] != "2019-06-01T15:00:00Z" {
+		t.Errorf("every message should start with the time, the level and the message, got %v", fields)
+	}
+	if fields["durationMs"] != float64(1500) || fields["timeout"] != "2s" || fields["odd"] != "(missing)" {
+		t.Errorf("unexpected fields: %v", fields)
+	}
+}
+
+func TestLevelFiltering(t *testing.T) {
+	logger, out := newTestLogger(Logfmt)
+	logger.Log(Debug, "hidden")
+	if out.Len() != 0 {
+		t.Error("messages below the level of the logger should not be written")
+	}
+	if logger.Enabled(Debug) || !logger.Enabled(Error) {
+		t.Error("only the level of the logger and the levels above it should be enabled")
+	}
+}
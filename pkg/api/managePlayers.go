@@ -52,6 +52,7 @@ func (sbServer *SBServer) LeaveRoom(roomName, player string) error {
 			for playerIndex, playerName := range sbServer.Rooms[roomIndex].Online {
 				if playerName == player {
 					sbServer.Rooms[roomIndex].Online = util.DeleteFromSlice(sbServer.Rooms[roomIndex].Online, playerIndex)
+					sbServer.Rooms[roomIndex].LeaveLobby(player)
 					return nil
 				}
 			}
@@ -0,0 +1,75 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"strconv"
+
+	"github.com/pavelhadzhiev/story-builder/pkg/api/prompts"
+)
+
+// ResolvePrompt finds the prompt that should seed a new game in the provided room. The selector is either "random" or the ID of a prompt.
+// Returns error if there is no such prompt, it belongs to another room or there are no prompts to pick from.
+func (sbServer *SBServer) ResolvePrompt(roomName, selector string) (*prompts.Prompt, error) {
+	if selector == "random" {
+		available, err := sbServer.Prompts.GetPrompts(roomName)
+		if err != nil {
+			return nil, err
+		}
+		if len(available) == 0 {
+			return nil, fmt.Errorf("there are no prompts available in room \"%s\"", roomName)
+		}
+		return &available[rand.Intn(len(available))], nil
+	}
+
+	id, err := strconv.Atoi(selector)
+	if err != nil || id <= 0 {
+		return nil, errors.New("prompt must be \"random\" or a prompt ID")
+	}
+	prompt, err := sbServer.Prompts.GetPrompt(id)
+	if err != nil {
+		return nil, err
+	}
+	if prompt == nil || (!prompt.IsServerWide() && prompt.Room != roomName) {
+		return nil, fmt.Errorf("prompt #%d doesn't exist in room \"%s\"", id, roomName)
+	}
+	return prompt, nil
+}
+
+// CanManagePrompt returns true if the user is allowed to add, edit and delete prompts in the provided room.
+// Room prompts can be managed by the room's admins. Server-wide prompts (empty room) can be managed only by server admins, who can manage
+// all prompts.
+func (sbServer *SBServer) CanManagePrompt(roomName, user string) bool {
+	if sbServer.IsServerAdmin(user) {
+		return true
+	}
+	if roomName == "" {
+		return false
+	}
+	for _, room := range sbServer.Rooms {
+		if room.Name != roomName {
+			continue
+		}
+		for _, admin := range room.Admins {
+			if admin == user {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -16,8 +16,14 @@ package api
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 
+	"github.com/pavelhadzhiev/story-builder/pkg/api/audit"
+	"github.com/pavelhadzhiev/story-builder/pkg/api/game"
+	"github.com/pavelhadzhiev/story-builder/pkg/api/logging"
 	"github.com/pavelhadzhiev/story-builder/pkg/api/rooms"
+	"github.com/pavelhadzhiev/story-builder/pkg/api/webhooks"
 )
 
 // GetAllRooms retrieves all rooms from the server and returns them.
@@ -26,15 +32,81 @@ func (sbServer *SBServer) GetAllRooms() []rooms.Room {
 }
 
 // CreateNewRoom creates a new room in the server, using the provided model.
-// Returns error if a room with this name already exists.
+// Returns error if a room with this name already exists. Room names that differ only in case are considered the same.
 func (sbServer *SBServer) CreateNewRoom(room *rooms.Room) error {
-	if _, err := sbServer.GetRoom(room.Name); err == nil {
-		return errors.New("a room with this name already exists")
+	for _, existing := range sbServer.Rooms {
+		if strings.EqualFold(existing.Name, room.Name) {
+			return errors.New("a room with this name already exists")
+		}
 	}
+	room.SetVoteListener(sbServer.auditVotes(room.Name))
+	room.SetEventListener(sbServer.gameEvents(room.Name))
 	sbServer.Rooms = append(sbServer.Rooms, *room)
 	return nil
 }
 
+// auditVotes returns a vote listener that records the outcome of the votes in the provided room in the audit log.
+// Passed votes to revert an entry are recorded as redactions.
+func (sbServer *SBServer) auditVotes(roomName string) func(vote *game.Vote, passed bool) {
+	return func(vote *game.Vote, passed bool) {
+		entry := audit.Entry{
+			Action:  audit.VoteFailed,
+			Actor:   vote.Issuer,
+			Target:  vote.Target,
+			Room:    roomName,
+			Details: fmt.Sprintf("vote #%d to %s, %d in favour, %d against", vote.ID, vote.Description(), vote.Count, vote.Against),
+		}
+		if passed {
+			entry.Action = audit.VotePassed
+			if vote.Kind == game.RevertVote {
+				entry.Action = audit.EntryRedacted
+			}
+		}
+		sbServer.audit(nil, entry)
+	}
+}
+
+// gameEvents returns the event listener of the games in the provided room, which delivers their events to the webhooks of the room and
+// reminds the players about their turns.
+func (sbServer *SBServer) gameEvents(roomName string) func(event game.Event) {
+	dispatch, remind := sbServer.dispatchEvents(roomName), sbServer.remindTurns(roomName)
+	return func(event game.Event) {
+		dispatch(event)
+		remind(event)
+	}
+}
+
+// dispatchEvents returns an event listener that delivers the events of the games in the provided room to the webhooks of the room that
+// subscribe to them.
+func (sbServer *SBServer) dispatchEvents(roomName string) func(event game.Event) {
+	return func(event game.Event) {
+		if sbServer.dispatcher == nil {
+			return
+		}
+		room, err := sbServer.GetRoom(roomName)
+		if err != nil {
+			return
+		}
+		subscribers := room.Subscribers(string(event.Kind))
+		if len(subscribers) == 0 {
+			return
+		}
+		payload := webhooks.NewPayload(roomName, event)
+		for _, webhook := range subscribers {
+			if _, err := sbServer.dispatcher.Dispatch(roomName, webhook, payload); err != nil {
+				sbServer.log(logging.Error, "cannot dispatch webhook event", "room", roomName, "event", payload.Event, "webhook", webhook.ID, "error", err)
+			}
+		}
+	}
+}
+
+// forgetDeliveries drops the webhook delivery log of a deleted room, so a new room with the same name starts with an empty log.
+func (sbServer *SBServer) forgetDeliveries(roomName string) {
+	if sbServer.dispatcher != nil {
+		sbServer.dispatcher.Forget(roomName)
+	}
+}
+
 // GetRoom retrieves the room with the provided name from the server.
 // Returns error if a room with this name doesn't exist.
 func (sbServer *SBServer) GetRoom(roomName string) (*rooms.Room, error) {
@@ -70,6 +142,7 @@ func (sbServer *SBServer) DeleteRoom(roomName, issuer string) error {
 	} else {
 		sbServer.Rooms = sbServer.Rooms[:index]
 	}
+	sbServer.forgetDeliveries(roomName)
 
 	return nil
 }
@@ -0,0 +1,151 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"errors"
+
+	"github.com/pavelhadzhiev/story-builder/pkg/api/logging"
+	"github.com/pavelhadzhiev/story-builder/pkg/api/users"
+	"github.com/pavelhadzhiev/story-builder/pkg/util"
+)
+
+// BootstrapServerAdmins gives the server admin role to the provided users. Only registered users get the role - unknown usernames are
+// logged and skipped, so nobody can claim the role by registering one of them.
+// Returns error if the database can't be read or updated.
+func (sbServer *SBServer) BootstrapServerAdmins(usernames []string) error {
+	for _, username := range usernames {
+		userExists, err := sbServer.Database.UserExists(username)
+		if err != nil {
+			return err
+		}
+		if !userExists {
+			sbServer.log(logging.Warn, "skipping server admin that is not registered", "user", username)
+			continue
+		}
+		if err := sbServer.Database.SetRole(username, users.RoleServerAdmin); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// IsServerAdmin returns true if the provided user has the server admin role and its account is not disabled.
+// Returns false if the user can't be looked up.
+func (sbServer *SBServer) IsServerAdmin(username string) bool {
+	user, err := sbServer.Database.GetUser(username)
+	return err == nil && user != nil && user.IsServerAdmin() && !user.Disabled
+}
+
+// IsBannedFromServer returns true if the provided user is banned from all rooms of the server. Returns false if the user can't be looked up.
+func (sbServer *SBServer) IsBannedFromServer(username string) bool {
+	user, err := sbServer.Database.GetUser(username)
+	return err == nil && user != nil && user.Banned
+}
+
+// GetUsers returns all users of the server, marking the ones that are logged in as online.
+func (sbServer *SBServer) GetUsers() ([]users.User, error) {
+	allUsers, err := sbServer.Database.GetUsers()
+	if err != nil {
+		return nil, err
+	}
+	for index, user := range allUsers {
+		allUsers[index].Online = sbServer.isLoggedIn(user.Username)
+	}
+	return allUsers, nil
+}
+
+// DisableUser disables the account of the provided user, logging it out and removing it from all rooms. Disabled server admins lose their role.
+// Returns error if the user doesn't exist or the database can't be updated.
+func (sbServer *SBServer) DisableUser(username string) error {
+	if err := sbServer.Database.SetDisabled(username, true); err != nil {
+		return err
+	}
+	if err := sbServer.Database.SetRole(username, users.RoleUser); err != nil {
+		return err
+	}
+	sbServer.logOut(username)
+	sbServer.removeFromAllRooms(username)
+	return nil
+}
+
+// BanUser bans the provided user from all rooms of the server, removing it from the rooms it's in.
+// Returns error if the user doesn't exist or the database can't be updated.
+func (sbServer *SBServer) BanUser(username string) error {
+	if err := sbServer.Database.SetBanned(username, true); err != nil {
+		return err
+	}
+	sbServer.removeFromAllRooms(username)
+	return nil
+}
+
+// DeleteUser deletes the account of the provided user. The user is logged out and removed from all rooms, and its entries and chat messages are anonymized.
+// Returns error if the user doesn't exist or the database can't be updated.
+func (sbServer *SBServer) DeleteUser(username string) error {
+	if err := sbServer.Database.DeleteUser(username); err != nil {
+		return err
+	}
+	sbServer.logOut(username)
+	sbServer.removeFromAllRooms(username)
+	for index := range sbServer.Rooms {
+		sbServer.Rooms[index].ForgetUser(username)
+	}
+	return nil
+}
+
+// DeleteAnyRoom deletes the room with the provided name, regardless of who created it.
+// Returns error if a room with this name doesn't exist.
+func (sbServer *SBServer) DeleteAnyRoom(roomName string) error {
+	for index, room := range sbServer.Rooms {
+		if room.Name == roomName {
+			sbServer.Rooms = append(sbServer.Rooms[:index], sbServer.Rooms[index+1:]...)
+			sbServer.forgetDeliveries(roomName)
+			return nil
+		}
+	}
+	return errors.New("room with name \"" + roomName + "\" doesn't exist")
+}
+
+func (sbServer *SBServer) isLoggedIn(username string) bool {
+	for _, user := range sbServer.Online {
+		if user == username {
+			return true
+		}
+	}
+	return false
+}
+
+func (sbServer *SBServer) logOut(username string) {
+	for index, user := range sbServer.Online {
+		if user == username {
+			sbServer.Online = util.DeleteFromSlice(sbServer.Online, index)
+			return
+		}
+	}
+}
+
+// removeFromAllRooms takes the user out of every room it's in, kicking it from the running games.
+func (sbServer *SBServer) removeFromAllRooms(username string) {
+	for index := range sbServer.Rooms {
+		room := &sbServer.Rooms[index]
+		if !room.IsOnline(username) {
+			continue
+		}
+		sbServer.LeaveRoom(room.Name, username)
+		if game := room.GetGame(); game != nil && !game.Finished {
+			game.Kick(username)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/pavelhadzhiev/story-builder/pkg/metrics"
+)
+
+// MetricsHandler is an http handler for the metrics endpoint. It writes the metrics of the server in the Prometheus text exposition format.
+func (server *SBServer) MetricsHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		w.WriteHeader(200)
+		server.stateMetrics().Write(w)
+		metrics.DefaultRegistry.Write(w)
+	default:
+		w.WriteHeader(405)
+	}
+}
+
+// stateMetrics returns gauges of the in-memory state of the server, computed when they are written.
+func (server *SBServer) stateMetrics() *metrics.Registry {
+	registry := metrics.NewRegistry()
+	registry.Register(metrics.NewGaugeFunc("storybuilder_online_users", "Number of logged in users.", func() float64 {
+		return float64(len(server.Online))
+	}))
+	registry.Register(metrics.NewGaugeFunc("storybuilder_rooms", "Number of rooms.", func() float64 {
+		return float64(len(server.Rooms))
+	}))
+	registry.Register(metrics.NewGaugeFunc("storybuilder_active_games", "Number of games that are not finished, including the ones in a lobby.", func() float64 {
+		return float64(server.activeGames())
+	}))
+	return registry
+}
+
+// activeGames returns the number of games that are not finished, including the ones in a lobby.
+func (server *SBServer) activeGames() int {
+	active := 0
+	for index := range server.Rooms {
+		if game := server.Rooms[index].GetGame(); game != nil && !game.Finished {
+			active++
+		}
+	}
+	return active
+}
+
+// instrumented wraps the provided handler, counting the requests to the provided route by method and status code and observing their duration.
+func (server *SBServer) instrumented(route string, handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		recorder := &statusRecorder{ResponseWriter: w, status: 200}
+		handler(recorder, r)
+		metrics.HTTPRequests.Inc(route, methodLabel(r.Method), strconv.Itoa(recorder.status))
+		metrics.ObserveSince(metrics.HTTPRequestDuration, start, route)
+	}
+}
+
+// methodLabel returns the method of a request as a metric label. Methods that the API doesn't use are counted as "other", so clients
+// can't create a new series with every made-up method.
+func methodLabel(method string) string {
+	switch method {
+	case http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete, http.MethodHead, http.MethodOptions:
+		return method
+	default:
+		return "other"
+	}
+}
+
+// statusRecorder remembers the status code written by a handler. Handlers that don't write one respond with 200.
+type statusRecorder struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+}
+
+func (recorder *statusRecorder) WriteHeader(status int) {
+	if !recorder.wroteHeader {
+		recorder.status = status
+		recorder.wroteHeader = true
+	}
+	recorder.ResponseWriter.WriteHeader(status)
+}
@@ -0,0 +1,104 @@
+package api
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pavelhadzhiev/story-builder/pkg/api/rooms"
+	"github.com/pavelhadzhiev/story-builder/pkg/db/dbfakes"
+	"github.com/pavelhadzhiev/story-builder/pkg/metrics"
+)
+
+var _ = Describe("Story Builder Metrics Handler test", func() {
+	var sbServer *SBServer
+	var ts *httptest.Server
+
+	scrape := func() string {
+		resp, err := http.Get(ts.URL + "/metrics")
+		Expect(err).ShouldNot(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(200))
+		Expect(resp.Header.Get("Content-Type")).To(ContainSubstring("text/plain; version=0.0.4"))
+		body, err := ioutil.ReadAll(resp.Body)
+		Expect(err).ShouldNot(HaveOccurred())
+		return string(body)
+	}
+
+	BeforeEach(func() {
+		room := rooms.NewRoom("room", "creator")
+		room.Online = append(room.Online, "creator")
+		room.StartGame("creator", 0, 100, 0)
+
+		sbServer = &SBServer{
+			Database: &dbfakes.FakeUserDatabase{},
+			Rooms:    []rooms.Room{*room, *rooms.NewRoom("empty", "creator")},
+			Online:   []string{"creator", "other"},
+		}
+
+		mux := http.NewServeMux()
+		mux.HandleFunc("/metrics", sbServer.instrumented("/metrics", sbServer.MetricsHandler))
+		mux.HandleFunc("/healthcheck/", sbServer.instrumented("/healthcheck/", sbServer.HealthcheckHandler))
+		ts = httptest.NewServer(mux)
+	})
+
+	AfterEach(func() {
+		ts.Close()
+	})
+
+	Describe("Handle metrics request", func() {
+		Context("When request is valid", func() {
+			It("should expose the state of the server", func() {
+				body := scrape()
+
+				Expect(body).To(ContainSubstring("# TYPE storybuilder_online_users gauge\nstorybuilder_online_users 2\n"))
+				Expect(body).To(ContainSubstring("\nstorybuilder_rooms 2\n"))
+				Expect(body).To(ContainSubstring("\nstorybuilder_active_games 1\n"))
+				Expect(body).To(ContainSubstring("# TYPE storybuilder_entries_submitted_total counter"))
+				Expect(body).To(ContainSubstring("# TYPE storybuilder_db_query_duration_seconds histogram"))
+			})
+		})
+
+		Context("When requests were handled", func() {
+			It("should count them by route and status and observe their duration", func() {
+				before := metrics.HTTPRequests.Value("/healthcheck/", "GET", "405")
+				durations := metrics.HTTPRequestDuration.Count("/healthcheck/")
+
+				resp, err := http.Get(ts.URL + "/healthcheck/")
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(resp.StatusCode).To(Equal(405))
+
+				Expect(metrics.HTTPRequests.Value("/healthcheck/", "GET", "405")).To(Equal(before + 1))
+				Expect(metrics.HTTPRequestDuration.Count("/healthcheck/")).To(Equal(durations + 1))
+				Expect(scrape()).To(ContainSubstring(`storybuilder_http_requests_total{route="/healthcheck/",method="GET",status="405"}`))
+			})
+		})
+
+		Context("When requests use methods the API doesn't know", func() {
+			It("should count them as other methods", func() {
+				before := metrics.HTTPRequests.Value("/healthcheck/", "other", "405")
+
+				for _, method := range []string{"PROPFIND", "MADE-UP"} {
+					request, _ := http.NewRequest(method, ts.URL+"/healthcheck/", nil)
+					resp, err := http.DefaultClient.Do(request)
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(resp.StatusCode).To(Equal(405))
+				}
+
+				Expect(metrics.HTTPRequests.Value("/healthcheck/", "other", "405")).To(Equal(before + 2))
+				Expect(metrics.HTTPRequests.Value("/healthcheck/", "PROPFIND", "405")).To(BeZero())
+			})
+		})
+
+		Context("When request is a wrong method type", func() {
+			It("should return HTTP 405 status code", func() {
+				resp, err := http.Post(ts.URL+"/metrics", "text/plain", nil)
+
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(resp.StatusCode).To(Equal(http.StatusMethodNotAllowed))
+			})
+		})
+	})
+})
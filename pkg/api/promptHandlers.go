@@ -0,0 +1,172 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/pavelhadzhiev/story-builder/pkg/api/prompts"
+)
+
+// PromptHandler is an http handler for the story builder's prompt library API
+func (server *SBServer) PromptHandler(w http.ResponseWriter, r *http.Request) {
+	// Anyone can read prompts, but managing them requires a verified identity, as admin rights depend on it.
+	var issuer string
+	if r.Method != http.MethodGet {
+		var ok bool
+		if issuer, ok = server.authenticate(w, r); !ok {
+			return
+		}
+	}
+
+	if r.URL.Path == "/prompts/" {
+		switch r.Method {
+		case http.MethodGet:
+			roomPrompts, err := server.Prompts.GetPrompts(r.URL.Query().Get("room"))
+			if err != nil {
+				w.WriteHeader(500)
+				w.Write([]byte("Error while retrieving prompts."))
+				return
+			}
+			responseBody, err := json.Marshal(roomPrompts)
+			if err != nil {
+				w.WriteHeader(500)
+				w.Write([]byte("Error during serialization of retrieved prompts."))
+				return
+			}
+			w.Write(responseBody)
+			return
+		case http.MethodPost:
+			var prompt = &prompts.Prompt{}
+			defer r.Body.Close()
+			if err := json.NewDecoder(r.Body).Decode(prompt); err != nil || strings.TrimSpace(prompt.Text) == "" {
+				w.WriteHeader(400)
+				w.Write([]byte("Prompt must have a text."))
+				return
+			}
+			if !prompt.IsServerWide() {
+				if _, err := server.GetRoom(prompt.Room); err != nil {
+					w.WriteHeader(404)
+					w.Write([]byte("Room \"" + prompt.Room + "\" doesn't exist."))
+					return
+				}
+			}
+			if !server.CanManagePrompt(prompt.Room, issuer) {
+				w.WriteHeader(403)
+				w.Write([]byte("You are not authorized to add this prompt."))
+				return
+			}
+
+			prompt.Author = issuer
+			id, err := server.Prompts.AddPrompt(prompt)
+			if err != nil {
+				w.WriteHeader(500)
+				w.Write([]byte("Error while saving prompt."))
+				return
+			}
+			prompt.ID = id
+			responseBody, err := json.Marshal(prompt)
+			if err != nil {
+				w.WriteHeader(500)
+				w.Write([]byte("Error during serialization of saved prompt."))
+				return
+			}
+			w.WriteHeader(201)
+			w.Write(responseBody)
+			return
+		default:
+			w.WriteHeader(405)
+			return
+		}
+	}
+
+	urlSuffix := strings.TrimPrefix(r.URL.Path, "/prompts/")
+	urlSuffixSplit := strings.Split(urlSuffix, "/")
+	if len(urlSuffixSplit) > 2 || (len(urlSuffixSplit) == 2 && urlSuffixSplit[1] != "") {
+		w.WriteHeader(400)
+		w.Write([]byte("Prompt ID is illegal."))
+		return
+	}
+	id, err := strconv.Atoi(urlSuffixSplit[0])
+	if err != nil {
+		w.WriteHeader(400)
+		w.Write([]byte("Prompt ID is illegal."))
+		return
+	}
+
+	prompt, err := server.Prompts.GetPrompt(id)
+	if err != nil {
+		w.WriteHeader(500)
+		w.Write([]byte("Error while retrieving prompt."))
+		return
+	}
+	if prompt == nil {
+		w.WriteHeader(404)
+		w.Write([]byte(fmt.Sprintf("Prompt #%d doesn't exist.", id)))
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		responseBody, err := json.Marshal(prompt)
+		if err != nil {
+			w.WriteHeader(500)
+			w.Write([]byte("Error during serialization of retrieved prompt."))
+			return
+		}
+		w.Write(responseBody)
+	case http.MethodPut:
+		var update = &prompts.Prompt{}
+		defer r.Body.Close()
+		if err := json.NewDecoder(r.Body).Decode(update); err != nil || strings.TrimSpace(update.Text) == "" {
+			w.WriteHeader(400)
+			w.Write([]byte("Prompt must have a text."))
+			return
+		}
+		if !server.CanManagePrompt(prompt.Room, issuer) {
+			w.WriteHeader(403)
+			w.Write([]byte("You are not authorized to edit this prompt."))
+			return
+		}
+
+		prompt.Text = update.Text
+		if err := server.Prompts.UpdatePrompt(prompt); err != nil {
+			w.WriteHeader(500)
+			w.Write([]byte("Error while saving prompt."))
+			return
+		}
+		w.Write([]byte(fmt.Sprintf("Prompt #%d successfully updated.", id)))
+	case http.MethodDelete:
+		if !server.CanManagePrompt(prompt.Room, issuer) {
+			w.WriteHeader(403)
+			w.Write([]byte("You are not authorized to delete this prompt."))
+			return
+		}
+
+		if err := server.Prompts.DeletePrompt(id); err != nil {
+			w.WriteHeader(500)
+			w.Write([]byte("Error while deleting prompt."))
+			return
+		}
+		w.WriteHeader(204)
+	default:
+		w.WriteHeader(405)
+		return
+	}
+}
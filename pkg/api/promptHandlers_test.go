@@ -0,0 +1,285 @@
+package api
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pavelhadzhiev/story-builder/pkg/api/game"
+	"github.com/pavelhadzhiev/story-builder/pkg/api/prompts"
+	"github.com/pavelhadzhiev/story-builder/pkg/api/rooms"
+	"github.com/pavelhadzhiev/story-builder/pkg/api/users"
+	"github.com/pavelhadzhiev/story-builder/pkg/client"
+	"github.com/pavelhadzhiev/story-builder/pkg/config"
+	"github.com/pavelhadzhiev/story-builder/pkg/db/dbfakes"
+)
+
+var _ = Describe("Story Builder Prompt Handlers test", func() {
+	var sbClient *client.SBClient
+	var clientConfig *config.SBConfiguration
+	var sbServer *SBServer
+	var room *rooms.Room
+	var promptDatabase *dbfakes.FakePromptDatabase
+	var ts *httptest.Server
+
+	username := "username"
+	password := "password"
+	authHeader := "Basic " + base64.StdEncoding.EncodeToString([]byte(username+":"+password))
+
+	roomName := "Test Room"
+	player := "test-player"
+
+	roomPrompt := prompts.Prompt{ID: 1, Room: roomName, Text: "Once upon a time...", Author: username}
+	serverWidePrompt := prompts.Prompt{ID: 2, Text: "It was a dark and stormy night.", Author: username}
+	otherRoomPrompt := prompts.Prompt{ID: 3, Room: "Other Room", Text: "In a galaxy far, far away...", Author: player}
+
+	BeforeEach(func() {
+		room = rooms.NewRoom(roomName, username)
+		room.Online = append(room.Online, username, player)
+
+		promptDatabase = &dbfakes.FakePromptDatabase{}
+		promptDatabase.GetPromptStub = func(id int) (*prompts.Prompt, error) {
+			for _, prompt := range []prompts.Prompt{roomPrompt, serverWidePrompt, otherRoomPrompt} {
+				if prompt.ID == id {
+					return &prompt, nil
+				}
+			}
+			return nil, nil
+		}
+		promptDatabase.GetPromptsReturns([]prompts.Prompt{roomPrompt, serverWidePrompt}, nil)
+		promptDatabase.AddPromptReturns(4, nil)
+
+		sbServer = &SBServer{
+			Database: &dbfakes.FakeUserDatabase{},
+			Prompts:  promptDatabase,
+			Rooms:    make([]rooms.Room, 0),
+			Online:   make([]string, 0),
+		}
+		sbServer.Rooms = append(sbServer.Rooms, *room)
+	})
+
+	Describe("Handle prompt library requests", func() {
+		BeforeEach(func() {
+			ts = httptest.NewServer(http.HandlerFunc(sbServer.PromptHandler))
+
+			clientConfig = &config.SBConfiguration{URL: ts.URL, Authorization: authHeader, Room: roomName}
+			sbClient = client.NewTestSBClient(clientConfig, ts.Client())
+		})
+
+		Describe("Specifically list prompts request", func() {
+			It("should return the room and server-wide prompts", func() {
+				result, err := sbClient.GetPrompts(roomName)
+
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(result).To(Equal([]prompts.Prompt{roomPrompt, serverWidePrompt}))
+				Expect(promptDatabase.GetPromptsArgsForCall(0)).To(Equal(roomName))
+			})
+		})
+
+		Describe("Specifically get prompt request", func() {
+			Context("When prompt exists", func() {
+				It("should return the prompt", func() {
+					result, err := sbClient.GetPrompt(roomPrompt.ID)
+
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(*result).To(Equal(roomPrompt))
+				})
+			})
+
+			Context("When prompt does not exist", func() {
+				It("should return error", func() {
+					_, err := sbClient.GetPrompt(42)
+
+					Expect(err).Should(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("prompt #42 doesn't exist"))
+				})
+			})
+		})
+
+		Describe("Specifically add prompt request", func() {
+			Context("When request is valid", func() {
+				It("should save the prompt on behalf of the user", func() {
+					result, err := sbClient.AddPrompt(roomName, "A new beginning.")
+
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(result.ID).To(Equal(4))
+					Expect(promptDatabase.AddPromptCallCount()).To(Equal(1))
+					saved := promptDatabase.AddPromptArgsForCall(0)
+					Expect(saved.Room).To(Equal(roomName))
+					Expect(saved.Author).To(Equal(username))
+				})
+			})
+
+			Context("When the prompt is server-wide and the user is a room admin", func() {
+				It("should return error", func() {
+					_, err := sbClient.AddPrompt("", "A new beginning.")
+
+					Expect(err).Should(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("cannot add prompt: requires admin access"))
+					Expect(promptDatabase.AddPromptCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("When the prompt is server-wide and the user is a server admin", func() {
+				It("should save the prompt", func() {
+					sbServer.Database.(*dbfakes.FakeUserDatabase).GetUserReturns(&users.User{Username: username, Role: users.RoleServerAdmin}, nil)
+
+					_, err := sbClient.AddPrompt("", "A new beginning.")
+
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(promptDatabase.AddPromptArgsForCall(0).Room).To(Equal(""))
+				})
+			})
+
+			Context("When the user claims to be a server admin with a wrong password", func() {
+				It("should return error", func() {
+					userDatabase := sbServer.Database.(*dbfakes.FakeUserDatabase)
+					userDatabase.GetUserReturns(&users.User{Username: username, Role: users.RoleServerAdmin}, nil)
+					userDatabase.LoginUserReturns(errors.New("wrong password"))
+
+					_, err := sbClient.AddPrompt("", "A new beginning.")
+
+					Expect(err).Should(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("could not authenticate user"))
+					Expect(promptDatabase.AddPromptCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("When user doesn't have permissions", func() {
+				It("should return error", func() {
+					sbServer.Rooms[0].Admins = make([]string, 0)
+
+					_, err := sbClient.AddPrompt(roomName, "A new beginning.")
+
+					Expect(err).Should(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("cannot add prompt: requires admin access"))
+					Expect(promptDatabase.AddPromptCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("When room does not exist", func() {
+				It("should return error", func() {
+					_, err := sbClient.AddPrompt("non-existent-room", "A new beginning.")
+
+					Expect(err).Should(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("room \"non-existent-room\" doesn't exist"))
+				})
+			})
+
+			Context("When the prompt text is empty", func() {
+				It("should return error", func() {
+					_, err := sbClient.AddPrompt(roomName, " ")
+
+					Expect(err).Should(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("prompt must have a text"))
+				})
+			})
+		})
+
+		Describe("Specifically edit prompt request", func() {
+			Context("When request is valid", func() {
+				It("should update the prompt text", func() {
+					err := sbClient.EditPrompt(roomPrompt.ID, "Twice upon a time...")
+
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(promptDatabase.UpdatePromptArgsForCall(0).Text).To(Equal("Twice upon a time..."))
+				})
+			})
+
+			Context("When the prompt belongs to a room the user doesn't administer", func() {
+				It("should return error", func() {
+					err := sbClient.EditPrompt(otherRoomPrompt.ID, "Twice upon a time...")
+
+					Expect(err).Should(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("cannot edit prompt: requires admin access"))
+				})
+			})
+		})
+
+		Describe("Specifically delete prompt request", func() {
+			Context("When request is valid", func() {
+				It("should delete the prompt", func() {
+					err := sbClient.DeletePrompt(roomPrompt.ID)
+
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(promptDatabase.DeletePromptArgsForCall(0)).To(Equal(roomPrompt.ID))
+				})
+			})
+
+			Context("When the password is wrong", func() {
+				It("should return error and not delete the prompt", func() {
+					sbServer.Database.(*dbfakes.FakeUserDatabase).LoginUserReturns(errors.New("wrong password"))
+
+					err := sbClient.DeletePrompt(serverWidePrompt.ID)
+
+					Expect(err).Should(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("could not authenticate user"))
+					Expect(promptDatabase.DeletePromptCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("When prompt does not exist", func() {
+				It("should return error", func() {
+					err := sbClient.DeletePrompt(42)
+
+					Expect(err).Should(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("prompt #42 doesn't exist"))
+				})
+			})
+		})
+	})
+
+	Describe("Handle start game requests with a prompt", func() {
+		BeforeEach(func() {
+			ts = httptest.NewServer(http.HandlerFunc(sbServer.ManageGamesHandler))
+
+			clientConfig = &config.SBConfiguration{URL: ts.URL, Authorization: authHeader, Room: roomName}
+			sbClient = client.NewTestSBClient(clientConfig, ts.Client())
+		})
+
+		Context("When a prompt ID is provided", func() {
+			It("should seed the story with the prompt", func() {
+				err := sbClient.StartGame(60, 100, 0, "2", nil)
+
+				Expect(err).ShouldNot(HaveOccurred())
+				story := sbServer.Rooms[0].GetGame().Story
+				Expect(story).To(Equal([]game.Entry{{Text: serverWidePrompt.Text, Player: game.SystemPlayer}}))
+			})
+		})
+
+		Context("When a random prompt is requested", func() {
+			It("should seed the story with one of the available prompts", func() {
+				err := sbClient.StartGame(60, 100, 0, "random", nil)
+
+				Expect(err).ShouldNot(HaveOccurred())
+				story := sbServer.Rooms[0].GetGame().Story
+				Expect(story).To(HaveLen(1))
+				Expect(story[0].Text).To(BeElementOf(roomPrompt.Text, serverWidePrompt.Text))
+			})
+		})
+
+		Context("When the prompt belongs to another room", func() {
+			It("should return error and not start the game", func() {
+				err := sbClient.StartGame(60, 100, 0, "3", nil)
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("prompt #3 doesn't exist in room \"" + roomName + "\""))
+				Expect(sbServer.Rooms[0].GetGame()).To(BeNil())
+			})
+		})
+
+		Context("When there are no prompts to pick from", func() {
+			It("should return error", func() {
+				promptDatabase.GetPromptsReturns([]prompts.Prompt{}, nil)
+
+				err := sbClient.StartGame(60, 100, 0, "random", nil)
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("there are no prompts available"))
+			})
+		})
+	})
+})
@@ -0,0 +1,39 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prompts
+
+import "fmt"
+
+// Prompt represents a story opening that can be used to seed the story of a new game.
+// Prompts without a room are server-wide and can be used in any room.
+type Prompt struct {
+	ID     int    `json:"id"`
+	Room   string `json:"room,omitempty"`
+	Text   string `json:"text"`
+	Author string `json:"author,omitempty"`
+}
+
+// IsServerWide returns true if the prompt is not bound to a specific room.
+func (prompt Prompt) IsServerWide() bool {
+	return prompt.Room == ""
+}
+
+func (prompt Prompt) String() string {
+	scope := "server-wide"
+	if !prompt.IsServerWide() {
+		scope = fmt.Sprintf("room \"%s\"", prompt.Room)
+	}
+	return fmt.Sprintf("#%d (%s, by \"%s\"): %s", prompt.ID, scope, prompt.Author, prompt.Text)
+}
@@ -0,0 +1,107 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/pavelhadzhiev/story-builder/pkg/api/audit"
+	"github.com/pavelhadzhiev/story-builder/pkg/api/logging"
+	"github.com/pavelhadzhiev/story-builder/pkg/api/ratelimit"
+)
+
+// ConfigureRateLimits replaces the rate limiting and brute-force protection settings of the server. The state of the previous limits is dropped.
+func (sbServer *SBServer) ConfigureRateLimits(settings ratelimit.Settings) {
+	sbServer.limiter = ratelimit.NewLimiter(settings)
+	sbServer.lockout = ratelimit.NewLockout(settings)
+}
+
+// rateLimited wraps the provided handler, rejecting requests from IP addresses that are over their rate limit with 429.
+// Users are limited only once their password is verified - see allowUser - so nobody can use up the limit of another user. This happens on
+// login, healthcheck and every route that calls authenticate. Gameplay routes, e.g. /gameplay/, /vote/ and /chat/, identify players by the name
+// in the authorization header without checking the password, so only the limit of the IP address applies to them.
+func (sbServer *SBServer) rateLimited(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !sbServer.allow(w, r, "ip:"+clientIP(r)) {
+			return
+		}
+		handler(w, r)
+	}
+}
+
+// allowUser writes a 429 response and returns false if the provided user, whose password was verified, is over its rate limit.
+func (sbServer *SBServer) allowUser(w http.ResponseWriter, r *http.Request, username string) bool {
+	return sbServer.allow(w, r, "user:"+username)
+}
+
+func (sbServer *SBServer) allow(w http.ResponseWriter, r *http.Request, key string) bool {
+	if allowed, retryAfter := sbServer.limiter.Allow(key); !allowed {
+		sbServer.log(logging.Warn, "rate limited", "requestId", requestID(r), "path", r.URL.Path, "key", key)
+		writeTooManyRequests(w, retryAfter)
+		return false
+	}
+	return true
+}
+
+// loginUser checks the provided credentials against the database, locking out the user and the IP address of the request after too many failed attempts.
+// Returns a ratelimit.LockedOutError without checking the credentials while they are locked out. A successful login resets the failures of the user
+// only - the IP address keeps its failures, so logging into another account doesn't reset a brute-force attempt.
+func (sbServer *SBServer) loginUser(r *http.Request, username, password string) error {
+	userKey, ipKey := "user:"+username, "ip:"+clientIP(r)
+	if err := sbServer.lockout.Check(userKey, ipKey); err != nil {
+		return err
+	}
+	if err := sbServer.Database.LoginUser(username, password); err != nil {
+		sbServer.log(logging.Warn, "failed login", "requestId", requestID(r), "user", username, "ip", clientIP(r))
+		sbServer.audit(r, audit.Entry{Action: audit.LoginFailed, Actor: username})
+		sbServer.lockout.Fail(userKey, ipKey)
+		return err
+	}
+	sbServer.lockout.Succeed(userKey)
+	return nil
+}
+
+// writeLockedOut writes a 429 response if the provided error is a ratelimit.LockedOutError. Returns true if it did.
+func writeLockedOut(w http.ResponseWriter, err error) bool {
+	if lockedOut, ok := err.(*ratelimit.LockedOutError); ok {
+		writeTooManyRequests(w, lockedOut.RetryAfter)
+		return true
+	}
+	return false
+}
+
+func writeTooManyRequests(w http.ResponseWriter, retryAfter time.Duration) {
+	seconds := int(math.Ceil(retryAfter.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+	w.Header().Set("Retry-After", strconv.Itoa(seconds))
+	w.WriteHeader(429)
+	w.Write([]byte(fmt.Sprintf("Too many requests. Try again in %d seconds.", seconds)))
+}
+
+// clientIP returns the IP address the request was sent from.
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
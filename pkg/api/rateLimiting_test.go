@@ -0,0 +1,160 @@
+package api
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pavelhadzhiev/story-builder/pkg/api/ratelimit"
+	"github.com/pavelhadzhiev/story-builder/pkg/api/rooms"
+	"github.com/pavelhadzhiev/story-builder/pkg/db/dbfakes"
+)
+
+var _ = Describe("Story Builder Rate Limiting test", func() {
+	var sbServer *SBServer
+	var database *dbfakes.FakeUserDatabase
+	var ts *httptest.Server
+
+	username := "username"
+	password := "password"
+	authHeader := func(pass string) string {
+		return "Basic " + base64.StdEncoding.EncodeToString([]byte(username+":"+pass))
+	}
+
+	post := func(path, authorization string) *http.Response {
+		request, _ := http.NewRequest(http.MethodPost, ts.URL+path, nil)
+		request.Header.Add("Authorization", authorization)
+		response, err := ts.Client().Do(request)
+		Expect(err).ShouldNot(HaveOccurred())
+		return response
+	}
+
+	BeforeEach(func() {
+		database = &dbfakes.FakeUserDatabase{}
+		database.LoginUserStub = func(user, pass string) error {
+			if pass == password {
+				return nil
+			}
+			return errors.New("password incorrect")
+		}
+
+		sbServer = &SBServer{
+			Database: database,
+			Rooms:    make([]rooms.Room, 0),
+			Online:   make([]string, 0),
+		}
+	})
+
+	AfterEach(func() {
+		ts.Close()
+	})
+
+	Describe("Rate limiting", func() {
+		BeforeEach(func() {
+			sbServer.ConfigureRateLimits(ratelimit.Settings{RequestsPerSecond: 0.1, Burst: 2})
+			ts = httptest.NewServer(sbServer.rateLimited(sbServer.HealthcheckHandler))
+		})
+
+		Context("When requests from other IP addresses claim to be a user", func() {
+			It("should not use up the limit of the user", func() {
+				for i, ip := range []string{"192.0.2.1", "192.0.2.2", "192.0.2.3", "192.0.2.4"} {
+					request := httptest.NewRequest(http.MethodPost, "/healthcheck/", nil)
+					request.RemoteAddr = ip + ":1234"
+					request.Header.Add("Authorization", authHeader("wrong"))
+					recorder := httptest.NewRecorder()
+
+					sbServer.rateLimited(sbServer.HealthcheckHandler)(recorder, request)
+
+					Expect(recorder.Code).NotTo(Equal(http.StatusTooManyRequests), "request %d", i)
+				}
+			})
+		})
+
+		Context("When a user makes too many authenticated requests", func() {
+			It("should reject them with 429 once the password is verified", func() {
+				authenticate := func(ip string) int {
+					request := httptest.NewRequest(http.MethodGet, "/account/", nil)
+					request.RemoteAddr = ip + ":1234"
+					request.Header.Add("Authorization", authHeader(password))
+					recorder := httptest.NewRecorder()
+					sbServer.authenticate(recorder, request)
+					return recorder.Code
+				}
+
+				Expect(authenticate("192.0.2.1")).To(Equal(http.StatusOK))
+				Expect(authenticate("192.0.2.2")).To(Equal(http.StatusOK))
+				Expect(authenticate("192.0.2.3")).To(Equal(http.StatusTooManyRequests))
+			})
+		})
+
+		Context("When a user logs in too many times", func() {
+			It("should reject the logins with 429 once the password is verified", func() {
+				login := func(ip string) int {
+					request := httptest.NewRequest(http.MethodPost, "/login/", nil)
+					request.RemoteAddr = ip + ":1234"
+					request.Header.Add("Authorization", authHeader(password))
+					recorder := httptest.NewRecorder()
+					sbServer.LoginHandler(recorder, request)
+					sbServer.Online = make([]string, 0)
+					return recorder.Code
+				}
+
+				Expect(login("192.0.2.1")).To(Equal(http.StatusOK))
+				Expect(login("192.0.2.2")).To(Equal(http.StatusOK))
+				Expect(login("192.0.2.3")).To(Equal(http.StatusTooManyRequests))
+			})
+		})
+
+		Context("When an IP address makes too many requests", func() {
+			It("should reject them with 429 and Retry-After", func() {
+				Expect(post("/healthcheck/", "").StatusCode).To(Equal(http.StatusOK))
+				Expect(post("/healthcheck/", "").StatusCode).To(Equal(http.StatusOK))
+
+				response := post("/healthcheck/", "")
+
+				Expect(response.StatusCode).To(Equal(http.StatusTooManyRequests))
+				Expect(response.Header.Get("Retry-After")).To(Equal("10"))
+			})
+		})
+	})
+
+	Describe("Login lockout", func() {
+		BeforeEach(func() {
+			sbServer.ConfigureRateLimits(ratelimit.Settings{LockoutThreshold: 3, LockoutDuration: time.Minute, MaxLockoutDuration: time.Hour})
+			ts = httptest.NewServer(http.HandlerFunc(sbServer.LoginHandler))
+		})
+
+		Context("When there are too many failed logins", func() {
+			It("should lock the user out, even with the right password", func() {
+				for i := 0; i < 3; i++ {
+					Expect(post("/login/", authHeader("wrong")).StatusCode).To(Equal(http.StatusUnauthorized))
+				}
+
+				response := post("/login/", authHeader(password))
+
+				Expect(response.StatusCode).To(Equal(http.StatusTooManyRequests))
+				Expect(response.Header.Get("Retry-After")).To(Equal("60"))
+				Expect(database.LoginUserCallCount()).To(Equal(3))
+				Expect(sbServer.Online).To(BeEmpty())
+			})
+		})
+
+		Context("When a login succeeds before the threshold", func() {
+			It("should reset the failed logins of the user, but not of the IP address", func() {
+				post("/login/", authHeader("wrong"))
+				post("/login/", authHeader("wrong"))
+				Expect(post("/login/", authHeader(password)).StatusCode).To(Equal(http.StatusOK))
+				sbServer.Online = make([]string, 0)
+
+				Expect(post("/login/", authHeader("wrong")).StatusCode).To(Equal(http.StatusUnauthorized))
+				Expect(post("/login/", authHeader(password)).StatusCode).To(Equal(http.StatusTooManyRequests))
+
+				Expect(sbServer.lockout.Check("user:" + username)).To(Succeed())
+			})
+		})
+	})
+})
@@ -0,0 +1,100 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// maxIdleBuckets is the number of buckets a limiter keeps before it forgets the ones that are full again.
+const maxIdleBuckets = 10000
+
+// Limiter limits the rate of requests per key, e.g. per IP address or per user, using a token bucket for every key.
+// A nil limiter allows everything.
+type Limiter struct {
+	rate  float64
+	burst float64
+
+	mutex   sync.Mutex
+	buckets map[string]*bucket
+	now     func() time.Time
+}
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// NewLimiter creates a limiter that allows the rate and burst of requests per key from the provided settings.
+// Returns nil if the settings disable rate limiting.
+func NewLimiter(settings Settings) *Limiter {
+	if settings.RequestsPerSecond <= 0 {
+		return nil
+	}
+	burst := settings.Burst
+	if burst < 1 {
+		burst = 1
+	}
+	return &Limiter{
+		rate:    settings.RequestsPerSecond,
+		burst:   float64(burst),
+		buckets: make(map[string]*bucket),
+		now:     time.Now,
+	}
+}
+
+// Allow takes a request for the provided key. If the key is over its limit, it returns false and the time after which the request would be allowed.
+func (limiter *Limiter) Allow(key string) (bool, time.Duration) {
+	if limiter == nil {
+		return true, 0
+	}
+	limiter.mutex.Lock()
+	defer limiter.mutex.Unlock()
+
+	now := limiter.now()
+	if len(limiter.buckets) > maxIdleBuckets {
+		limiter.forgetFullBuckets(now)
+	}
+	keyBucket, ok := limiter.buckets[key]
+	if !ok {
+		keyBucket = &bucket{tokens: limiter.burst, updated: now}
+		limiter.buckets[key] = keyBucket
+	}
+	keyBucket.refill(now, limiter.rate, limiter.burst)
+
+	if keyBucket.tokens < 1 {
+		wait := (1 - keyBucket.tokens) / limiter.rate
+		return false, time.Duration(math.Ceil(wait * float64(time.Second)))
+	}
+	keyBucket.tokens--
+	return true, 0
+}
+
+// forgetFullBuckets drops the buckets that have refilled completely, as they behave the same as new ones.
+func (limiter *Limiter) forgetFullBuckets(now time.Time) {
+	for key, keyBucket := range limiter.buckets {
+		keyBucket.refill(now, limiter.rate, limiter.burst)
+		if keyBucket.tokens >= limiter.burst {
+			delete(limiter.buckets, key)
+		}
+	}
+}
+
+func (keyBucket *bucket) refill(now time.Time, rate, burst float64) {
+	keyBucket.tokens = math.Min(burst, keyBucket.tokens+now.Sub(keyBucket.updated).Seconds()*rate)
+	keyBucket.updated = now
+}
@@ -0,0 +1,157 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// failureMemory is how long failed logins are remembered for a key that is not locked out.
+const failureMemory = time.Hour
+
+// Lockout protects logins from brute-force attacks. After too many failed logins in a row for a key, e.g. a username or an IP address,
+// the key is locked out for a time that doubles with every further failure. A nil lockout never locks anything out.
+type Lockout struct {
+	threshold   int
+	duration    time.Duration
+	maxDuration time.Duration
+
+	mutex    sync.Mutex
+	failures map[string]*failures
+	now      func() time.Time
+}
+
+type failures struct {
+	count       int
+	lockedUntil time.Time
+	last        time.Time
+}
+
+// LockedOutError is returned when a login is not attempted, because the user or the IP address is locked out.
+type LockedOutError struct {
+	RetryAfter time.Duration
+}
+
+func (err *LockedOutError) Error() string {
+	return fmt.Sprintf("too many failed logins, try again in %v", err.RetryAfter)
+}
+
+// NewLockout creates a lockout with the threshold and durations from the provided settings.
+// Returns nil if the settings disable lockouts.
+func NewLockout(settings Settings) *Lockout {
+	if settings.LockoutThreshold <= 0 {
+		return nil
+	}
+	return &Lockout{
+		threshold:   settings.LockoutThreshold,
+		duration:    settings.LockoutDuration,
+		maxDuration: settings.MaxLockoutDuration,
+		failures:    make(map[string]*failures),
+		now:         time.Now,
+	}
+}
+
+// Check returns a LockedOutError if any of the provided keys is locked out.
+func (lockout *Lockout) Check(keys ...string) error {
+	if lockout == nil {
+		return nil
+	}
+	lockout.mutex.Lock()
+	defer lockout.mutex.Unlock()
+
+	now := lockout.now()
+	var retryAfter time.Duration
+	for _, key := range keys {
+		if keyFailures, ok := lockout.failures[key]; ok && keyFailures.lockedUntil.After(now) {
+			if wait := keyFailures.lockedUntil.Sub(now); wait > retryAfter {
+				retryAfter = wait
+			}
+		}
+	}
+	if retryAfter > 0 {
+		return &LockedOutError{RetryAfter: retryAfter}
+	}
+	return nil
+}
+
+// Fail records a failed login for the provided keys, locking them out once they reach the threshold.
+func (lockout *Lockout) Fail(keys ...string) {
+	if lockout == nil {
+		return
+	}
+	lockout.mutex.Lock()
+	defer lockout.mutex.Unlock()
+
+	now := lockout.now()
+	lockout.forgetExpired(now)
+	for _, key := range keys {
+		keyFailures, ok := lockout.failures[key]
+		if !ok {
+			keyFailures = &failures{}
+			lockout.failures[key] = keyFailures
+		}
+		keyFailures.count++
+		keyFailures.last = now
+		if keyFailures.count >= lockout.threshold {
+			keyFailures.lockedUntil = now.Add(lockout.lockoutDuration(keyFailures.count - lockout.threshold))
+		}
+	}
+}
+
+// Succeed resets the failed logins of the provided keys.
+func (lockout *Lockout) Succeed(keys ...string) {
+	if lockout == nil {
+		return
+	}
+	lockout.mutex.Lock()
+	defer lockout.mutex.Unlock()
+
+	for _, key := range keys {
+		delete(lockout.failures, key)
+	}
+}
+
+// maxDoublings caps the number of times a lockout doubles. Without a max lockout duration, the doubling stops there anyway.
+const maxDoublings = 62
+
+// longestLockout is the longest duration a lockout can have without overflowing.
+const longestLockout = time.Duration(math.MaxInt64)
+
+// lockoutDuration returns the duration of a lockout after the provided number of failures past the threshold.
+func (lockout *Lockout) lockoutDuration(extraFailures int) time.Duration {
+	if extraFailures > maxDoublings {
+		extraFailures = maxDoublings
+	}
+	duration := lockout.duration
+	for i := 0; i < extraFailures && duration <= longestLockout/2; i++ {
+		duration *= 2
+	}
+	if lockout.maxDuration > 0 && duration > lockout.maxDuration {
+		return lockout.maxDuration
+	}
+	return duration
+}
+
+// forgetExpired drops the failures of keys that are not locked out and haven't failed for a while.
+func (lockout *Lockout) forgetExpired(now time.Time) {
+	for key, keyFailures := range lockout.failures {
+		if keyFailures.lockedUntil.Before(now) && now.Sub(keyFailures.last) > failureMemory {
+			delete(lockout.failures, key)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (clock *fakeClock) Now() time.Time {
+	return clock.now
+}
+
+func (clock *fakeClock) Advance(duration time.Duration) {
+	clock.now = clock.now.Add(duration)
+}
+
+var testSettings = Settings{
+	RequestsPerSecond:  1,
+	Burst:              2,
+	LockoutThreshold:   3,
+	LockoutDuration:    10 * time.Second,
+	MaxLockoutDuration: 30 * time.Second,
+}
+
+func TestLimiterAllowsBurstThenRate(t *testing.T) {
+	clock := &fakeClock{now: time.Now()}
+	limiter := NewLimiter(testSettings)
+	limiter.now = clock.Now
+
+	for i := 0; i < 2; i++ {
+		if allowed, _ := limiter.Allow("ip:1.2.3.4"); !allowed {
+			t.Fatal("requests within the burst should be allowed")
+		}
+	}
+	allowed, retryAfter := limiter.Allow("ip:1.2.3.4")
+	if allowed || retryAfter != time.Second {
+		t.Errorf("requests over the burst should be rejected until a token refills, got %v and %v", allowed, retryAfter)
+	}
+	if allowed, _ := limiter.Allow("ip:5.6.7.8"); !allowed {
+		t.Error("keys should be limited separately")
+	}
+
+	clock.Advance(time.Second)
+	if allowed, _ := limiter.Allow("ip:1.2.3.4"); !allowed {
+		t.Error("a request should be allowed once a token refills")
+	}
+}
+
+func TestDisabledLimits(t *testing.T) {
+	limiter := NewLimiter(Settings{})
+	lockout := NewLockout(Settings{})
+
+	for i := 0; i < 100; i++ {
+		if allowed, _ := limiter.Allow("ip:1.2.3.4"); !allowed {
+			t.Fatal("a disabled limiter should allow everything")
+		}
+		lockout.Fail("user:alice")
+	}
+	if err := lockout.Check("user:alice"); err != nil {
+		t.Error("a disabled lockout should never lock out")
+	}
+}
+
+func TestLockoutDoublesAfterThreshold(t *testing.T) {
+	clock := &fakeClock{now: time.Now()}
+	lockout := NewLockout(testSettings)
+	lockout.now = clock.Now
+
+	lockout.Fail("user:alice")
+	lockout.Fail("user:alice")
+	if err := lockout.Check("user:alice"); err != nil {
+		t.Fatal("keys should not be locked out before the threshold")
+	}
+
+	lockout.Fail("user:alice")
+	err := lockout.Check("user:bob", "user:alice")
+	if lockedOut, ok := err.(*LockedOutError); !ok || lockedOut.RetryAfter != 10*time.Second {
+		t.Fatalf("the first lockout should last the lockout duration, got %v", err)
+	}
+
+	clock.Advance(10 * time.Second)
+	if err := lockout.Check("user:alice"); err != nil {
+		t.Fatal("the lockout should expire")
+	}
+	lockout.Fail("user:alice")
+	if lockedOut, ok := lockout.Check("user:alice").(*LockedOutError); !ok || lockedOut.RetryAfter != 20*time.Second {
+		t.Error("every failure after the threshold should double the lockout")
+	}
+
+	clock.Advance(20 * time.Second)
+	lockout.Fail("user:alice")
+	if lockedOut, ok := lockout.Check("user:alice").(*LockedOutError); !ok || lockedOut.RetryAfter != 30*time.Second {
+		t.Error("the lockout should be capped at the max lockout duration")
+	}
+}
+
+func TestLockoutResetsOnSuccess(t *testing.T) {
+	lockout := NewLockout(testSettings)
+
+	lockout.Fail("user:alice")
+	lockout.Fail("user:alice")
+	lockout.Succeed("user:alice")
+	lockout.Fail("user:alice")
+	if err := lockout.Check("user:alice"); err != nil {
+		t.Error("a successful login should reset the failures")
+	}
+}
+
+func TestLockoutWithoutMaxDurationDoesNotOverflow(t *testing.T) {
+	settings := testSettings
+	settings.MaxLockoutDuration = 0
+	lockout := NewLockout(settings)
+
+	previous := time.Duration(0)
+	for extraFailures := 0; extraFailures < 200; extraFailures++ {
+		duration := lockout.lockoutDuration(extraFailures)
+		if duration < previous {
+			t.Fatalf("the lockout after %d extra failures should not be shorter than the previous one, got %v after %v", extraFailures, duration, previous)
+		}
+		previous = duration
+	}
+	if previous <= 0 || previous > longestLockout {
+		t.Errorf("the lockout should stay positive and at most %v, got %v", longestLockout, previous)
+	}
+
+	for i := 0; i < 100; i++ {
+		lockout.Fail("user:alice")
+	}
+	if lockedOut, ok := lockout.Check("user:alice").(*LockedOutError); !ok || lockedOut.RetryAfter <= 0 {
+		t.Errorf("the key should stay locked out after many failures, got %v", lockout.Check("user:alice"))
+	}
+}
@@ -0,0 +1,41 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit
+
+import "time"
+
+// Settings configure the rate limiting and the brute-force protection of the server.
+type Settings struct {
+	// RequestsPerSecond is the number of requests a single IP address or user can make per second on average. Zero disables rate limiting.
+	RequestsPerSecond float64 `json:"requestsPerSecond"`
+	// Burst is the number of requests a single IP address or user can make at once, before the average rate applies.
+	Burst int `json:"burst"`
+
+	// LockoutThreshold is the number of failed logins in a row, after which a user or an IP address is locked out. Zero disables lockouts.
+	LockoutThreshold int `json:"lockoutThreshold"`
+	// LockoutDuration is the duration of the first lockout. It doubles with every failed login after it.
+	LockoutDuration time.Duration `json:"lockoutDuration"`
+	// MaxLockoutDuration caps the duration of a lockout.
+	MaxLockoutDuration time.Duration `json:"maxLockoutDuration"`
+}
+
+// DefaultSettings are the rate limiting settings used, unless the server is configured otherwise.
+var DefaultSettings = Settings{
+	RequestsPerSecond:  10,
+	Burst:              30,
+	LockoutThreshold:   5,
+	LockoutDuration:    30 * time.Second,
+	MaxLockoutDuration: 15 * time.Minute,
+}
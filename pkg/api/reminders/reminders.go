@@ -0,0 +1,167 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reminders
+
+import (
+	"bytes"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"sync"
+	"time"
+
+	"github.com/pavelhadzhiev/story-builder/pkg/api/users"
+	"github.com/pavelhadzhiev/story-builder/pkg/metrics"
+)
+
+// timeout is the time the SMTP relay has to accept a reminder.
+const timeout = 10 * time.Second
+
+// ProfileSource looks up the profiles of users, which hold their email addresses and whether they want turn reminders.
+type ProfileSource interface {
+	GetProfile(username string) (*users.Profile, error)
+}
+
+// Reminders emails players when it's their turn, if they opted in on their profile. Reminders to the same player in the same room are
+// throttled, so fast games don't flood their inbox. It's safe for concurrent use.
+type Reminders struct {
+	settings Settings
+	profiles ProfileSource
+
+	mutex    sync.Mutex
+	lastSent map[string]time.Time
+}
+
+// NewReminders returns reminders that are sent through the SMTP relay in the provided settings to the addresses in the provided profiles.
+func NewReminders(settings Settings, profiles ProfileSource) *Reminders {
+	return &Reminders{
+		settings: settings,
+		profiles: profiles,
+		lastSent: make(map[string]time.Time),
+	}
+}
+
+// RemindTurn emails the player with the provided username that it's their turn in the provided room. It blocks until the relay accepts
+// the reminder, so it's meant to be called in its own goroutine.
+// Returns true if a reminder was sent. Players that didn't opt in and players that were reminded about the room less than the configured
+// interval ago are skipped. Returns error if the profile can't be looked up or the relay doesn't accept the reminder.
+func (reminders *Reminders) RemindTurn(username, room string) (bool, error) {
+	profile, err := reminders.profiles.GetProfile(username)
+	if err != nil {
+		return false, err
+	}
+	if profile == nil || !profile.TurnReminders || profile.Email == "" {
+		return false, nil
+	}
+
+	key := username + "\n" + room
+	reminders.mutex.Lock()
+	if last, ok := reminders.lastSent[key]; ok && time.Since(last) < reminders.settings.Interval {
+		reminders.mutex.Unlock()
+		metrics.TurnReminders.Inc("throttled")
+		return false, nil
+	}
+	reminders.lastSent[key] = time.Now()
+	reminders.mutex.Unlock()
+
+	if err := reminders.send(profile.Email, turnMessage(reminders.settings.From, profile, room)); err != nil {
+		reminders.mutex.Lock()
+		delete(reminders.lastSent, key) // let the next turn try again
+		reminders.mutex.Unlock()
+		metrics.TurnReminders.Inc("failed")
+		return false, err
+	}
+	metrics.TurnReminders.Inc("sent")
+	return true, nil
+}
+
+// turnMessage returns the email that reminds the player with the provided profile about their turn in the provided room.
+func turnMessage(from string, profile *users.Profile, room string) []byte {
+	name := profile.Username
+	if profile.DisplayName != "" {
+		name = profile.DisplayName
+	}
+
+	message := &bytes.Buffer{}
+	fmt.Fprintf(message, "From: %s\r\n", from)
+	fmt.Fprintf(message, "To: %s\r\n", profile.Email)
+	fmt.Fprintf(message, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", "Your turn in "+room))
+	fmt.Fprintf(message, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	fmt.Fprintf(message, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(message, "Content-Type: text/plain; charset=utf-8\r\n")
+	fmt.Fprintf(message, "\r\n")
+	fmt.Fprintf(message, "Hi %s,\r\n\r\n", name)
+	fmt.Fprintf(message, "It's your turn in room \"%s\". Execute `story-builder get-game` to read the story so far and `story-builder add-entry` to continue it.\r\n\r\n", room)
+	fmt.Fprintf(message, "You get this email because you turned on turn reminders. To unsubscribe, execute `story-builder profile --turn-reminders=false`.\r\n")
+	return message.Bytes()
+}
+
+// send delivers the message to the provided recipient through the SMTP relay. Like smtp.SendMail, it upgrades the connection with
+// STARTTLS and authenticates when the relay supports it, but it gives up if the relay doesn't respond in time.
+func (reminders *Reminders) send(to string, message []byte) error {
+	from, err := mail.ParseAddress(reminders.settings.From)
+	if err != nil {
+		return fmt.Errorf("invalid sender address: %v", err)
+	}
+	recipient, err := mail.ParseAddress(to)
+	if err != nil {
+		return fmt.Errorf("invalid recipient address: %v", err)
+	}
+
+	conn, err := net.DialTimeout("tcp", reminders.settings.Address(), timeout)
+	if err != nil {
+		return err
+	}
+	conn.SetDeadline(time.Now().Add(timeout))
+	client, err := smtp.NewClient(conn, reminders.settings.Host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: reminders.settings.Host}); err != nil {
+			return err
+		}
+	}
+	if reminders.settings.Username != "" {
+		if ok, _ := client.Extension("AUTH"); ok {
+			if err := client.Auth(smtp.PlainAuth("", reminders.settings.Username, reminders.settings.Password, reminders.settings.Host)); err != nil {
+				return err
+			}
+		}
+	}
+	if err := client.Mail(from.Address); err != nil {
+		return err
+	}
+	if err := client.Rcpt(recipient.Address); err != nil {
+		return err
+	}
+	data, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := data.Write(message); err != nil {
+		return err
+	}
+	if err := data.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
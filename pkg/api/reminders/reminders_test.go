@@ -0,0 +1,126 @@
+package reminders
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pavelhadzhiev/story-builder/pkg/api/reminders/smtptest"
+	"github.com/pavelhadzhiev/story-builder/pkg/api/users"
+)
+
+const room = "tavern"
+
+type profiles map[string]*users.Profile
+
+func (profiles profiles) GetProfile(username string) (*users.Profile, error) {
+	if username == "broken" {
+		return nil, errors.New("database lookup failed")
+	}
+	return profiles[username], nil
+}
+
+var testProfiles = profiles{
+	"alice": {Username: "alice", DisplayName: "Alice", Email: "alice@example.com", TurnReminders: true},
+	"bob":   {Username: "bob", Email: "bob@example.com"},
+	"carol": {Username: "carol", Email: "carol@example.com", TurnReminders: true},
+}
+
+func newTestReminders(t *testing.T, interval time.Duration) (*Reminders, *smtptest.Server) {
+	relay, err := smtptest.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	settings := Settings{Host: relay.Host, Port: relay.Port, From: "Story Builder <noreply@example.com>", Interval: interval}
+	return NewReminders(settings, testProfiles), relay
+}
+
+func TestRemindTurn(t *testing.T) {
+	reminders, relay := newTestReminders(t, time.Minute)
+	defer relay.Close()
+
+	sent, err := reminders.RemindTurn("alice", room)
+	if err != nil || !sent {
+		t.Fatalf("a reminder should be sent to a player who opted in: %v", err)
+	}
+
+	messages := relay.Messages()
+	if len(messages) != 1 {
+		t.Fatalf("the relay should get a single message, got %d", len(messages))
+	}
+	message := messages[0]
+	if message.From != "noreply@example.com" || len(message.To) != 1 || message.To[0] != "alice@example.com" {
+		t.Errorf("the reminder should be sent from the configured address to the player, got %s to %v", message.From, message.To)
+	}
+	for _, expected := range []string{"Subject: Your turn in tavern", "Hi Alice,", "It's your turn in room \"tavern\"", "--turn-reminders=false"} {
+		if !strings.Contains(message.Data, expected) {
+			t.Errorf("the reminder should contain %q, got:\n%s", expected, message.Data)
+		}
+	}
+}
+
+func TestRemindTurnSkipsPlayersWithoutReminders(t *testing.T) {
+	reminders, relay := newTestReminders(t, time.Minute)
+	defer relay.Close()
+
+	for _, username := range []string{"bob", "unknown"} {
+		if sent, err := reminders.RemindTurn(username, room); err != nil || sent {
+			t.Errorf("no reminder should be sent to %s, got %v, %v", username, sent, err)
+		}
+	}
+	if _, err := reminders.RemindTurn("broken", room); err == nil {
+		t.Error("a failed profile lookup should be returned")
+	}
+	if len(relay.Messages()) != 0 {
+		t.Error("the relay should get no messages")
+	}
+}
+
+func TestRemindTurnThrottling(t *testing.T) {
+	reminders, relay := newTestReminders(t, 200*time.Millisecond)
+	defer relay.Close()
+
+	reminders.RemindTurn("alice", room)
+	if sent, _ := reminders.RemindTurn("alice", room); sent {
+		t.Error("a second reminder within the interval should be throttled")
+	}
+	if sent, _ := reminders.RemindTurn("alice", "other room"); !sent {
+		t.Error("reminders about other rooms should not be throttled")
+	}
+	if sent, _ := reminders.RemindTurn("carol", room); !sent {
+		t.Error("reminders to other players should not be throttled")
+	}
+
+	time.Sleep(250 * time.Millisecond)
+	if sent, _ := reminders.RemindTurn("alice", room); !sent {
+		t.Error("a reminder should be sent again once the interval passes")
+	}
+	if len(relay.Messages()) != 4 {
+		t.Errorf("the relay should get 4 messages, got %d", len(relay.Messages()))
+	}
+}
+
+func TestRemindTurnRelayFailure(t *testing.T) {
+	reminders, relay := newTestReminders(t, time.Minute)
+	defer relay.Close()
+
+	relay.RejectRecipients(true)
+	if sent, err := reminders.RemindTurn("alice", room); err == nil || sent {
+		t.Error("a rejected reminder should return error")
+	}
+
+	relay.RejectRecipients(false)
+	if sent, err := reminders.RemindTurn("alice", room); err != nil || !sent {
+		t.Errorf("a failed reminder should not throttle the next one: %v", err)
+	}
+}
+
+func TestRemindTurnUnreachableRelay(t *testing.T) {
+	reminders, relay := newTestReminders(t, time.Minute)
+	relay.Close()
+
+	if _, err := reminders.RemindTurn("alice", room); err == nil {
+		t.Error("an unreachable relay should return error")
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reminders
+
+import (
+	"fmt"
+	"time"
+)
+
+// Settings configure the SMTP relay that turn reminders are sent through.
+type Settings struct {
+	// Host is the host of the SMTP relay. An empty host disables turn reminders.
+	Host string `json:"host,omitempty"`
+	Port int    `json:"port"`
+	// Username and Password authenticate the server to the relay. They are sent only over TLS or to a relay on localhost.
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+	// From is the sender address of the reminders, e.g. "Story Builder <noreply@example.com>".
+	From string `json:"from,omitempty"`
+	// Interval is the minimum time between two reminders to the same player in the same room.
+	Interval time.Duration `json:"interval"`
+}
+
+// DefaultSettings are the turn reminder settings used, unless the server is configured otherwise.
+var DefaultSettings = Settings{
+	Port:     587,
+	Interval: 15 * time.Minute,
+}
+
+// Enabled returns true if the server should send turn reminders.
+func (settings Settings) Enabled() bool {
+	return settings.Host != ""
+}
+
+// Address returns the address of the SMTP relay in the "host:port" format.
+func (settings Settings) Address() string {
+	return fmt.Sprintf("%s:%d", settings.Host, settings.Port)
+}
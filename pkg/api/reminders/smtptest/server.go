@@ -0,0 +1,163 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package smtptest provides a fake SMTP relay for testing code that sends emails.
+package smtptest
+
+import (
+	"bufio"
+	"net"
+	"strings"
+	"sync"
+)
+
+// Message is an email accepted by the fake relay.
+type Message struct {
+	From string
+	To   []string
+	// Data is the message as sent after the DATA command, with its headers and CRLF line endings.
+	Data string
+}
+
+// Server is a fake SMTP relay listening on a local port. It accepts every message, unless told to reject recipients, and keeps it in
+// memory. It doesn't support STARTTLS or authentication.
+type Server struct {
+	// Host and Port are the address the relay listens at.
+	Host string
+	Port int
+
+	listener net.Listener
+	mutex    sync.Mutex
+	messages []Message
+	reject   bool
+	done     sync.WaitGroup
+}
+
+// NewServer starts a fake SMTP relay on a random local port. Close it when done.
+func NewServer() (*Server, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	address := listener.Addr().(*net.TCPAddr)
+	server := &Server{Host: address.IP.String(), Port: address.Port, listener: listener}
+
+	server.done.Add(1)
+	go server.serve()
+	return server, nil
+}
+
+// Messages returns the messages the relay accepted, oldest first.
+func (server *Server) Messages() []Message {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	return append([]Message(nil), server.messages...)
+}
+
+// RejectRecipients makes the relay reject every recipient with a permanent error, or accept them again.
+func (server *Server) RejectRecipients(reject bool) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	server.reject = reject
+}
+
+// Close stops the relay and waits for the open connections to finish.
+func (server *Server) Close() {
+	server.listener.Close()
+	server.done.Wait()
+}
+
+func (server *Server) serve() {
+	defer server.done.Done()
+	for {
+		conn, err := server.listener.Accept()
+		if err != nil {
+			return
+		}
+		server.done.Add(1)
+		go func() {
+			defer server.done.Done()
+			defer conn.Close()
+			server.handle(conn)
+		}()
+	}
+}
+
+// handle speaks enough SMTP with a single client for net/smtp to send messages.
+func (server *Server) handle(conn net.Conn) {
+	reader := bufio.NewReader(conn)
+	reply := func(line string) {
+		conn.Write([]byte(line + "\r\n"))
+	}
+
+	reply("220 localhost fake SMTP relay")
+	message := Message{}
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		command := strings.ToUpper(line)
+		switch {
+		case strings.HasPrefix(command, "EHLO"), strings.HasPrefix(command, "HELO"):
+			reply("250 localhost")
+		case strings.HasPrefix(command, "MAIL FROM:"):
+			message = Message{From: trimAddress(line[len("MAIL FROM:"):])}
+			reply("250 OK")
+		case strings.HasPrefix(command, "RCPT TO:"):
+			server.mutex.Lock()
+			reject := server.reject
+			server.mutex.Unlock()
+			if reject {
+				reply("550 mailbox unavailable")
+				continue
+			}
+			message.To = append(message.To, trimAddress(line[len("RCPT TO:"):]))
+			reply("250 OK")
+		case command == "DATA":
+			reply("354 end data with <CR><LF>.<CR><LF>")
+			data := &strings.Builder{}
+			for {
+				dataLine, err := reader.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if dataLine == ".\r\n" {
+					break
+				}
+				data.WriteString(strings.TrimPrefix(dataLine, "."))
+			}
+			message.Data = data.String()
+			server.mutex.Lock()
+			server.messages = append(server.messages, message)
+			server.mutex.Unlock()
+			reply("250 OK")
+		case command == "RSET":
+			message = Message{}
+			reply("250 OK")
+		case command == "NOOP":
+			reply("250 OK")
+		case command == "QUIT":
+			reply("221 bye")
+			return
+		default:
+			reply("502 command not implemented")
+		}
+	}
+}
+
+func trimAddress(address string) string {
+	return strings.Trim(strings.TrimSpace(address), "<>")
+}
@@ -2,14 +2,17 @@ package api
 
 import (
 	"encoding/base64"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/pavelhadzhiev/story-builder/pkg/api/rooms"
 	"github.com/pavelhadzhiev/story-builder/pkg/client"
 	"github.com/pavelhadzhiev/story-builder/pkg/config"
+	"github.com/pavelhadzhiev/story-builder/pkg/db/dbfakes"
 )
 
 var _ = Describe("Story Builder Admin Handlers test", func() {
@@ -34,8 +37,9 @@ var _ = Describe("Story Builder Admin Handlers test", func() {
 
 		// Create the server and add the configured room to it
 		sbServer = &SBServer{
-			Rooms:  make([]rooms.Room, 0),
-			Online: make([]string, 0),
+			Database: &dbfakes.FakeUserDatabase{},
+			Rooms:    make([]rooms.Room, 0),
+			Online:   make([]string, 0),
 		}
 		sbServer.Rooms = append(sbServer.Rooms, *room)
 	})
@@ -70,6 +74,28 @@ var _ = Describe("Story Builder Admin Handlers test", func() {
 				})
 			})
 
+			Context("When the request claims another creator", func() {
+				It("should create the room on behalf of the authenticated user", func() {
+					err := sbClient.CreateNewRoom(rooms.NewRoom("other-room", "someone-else"))
+
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(sbServer.Rooms[1].Creator).To(Equal(username))
+					Expect(sbServer.Rooms[1].Admins).To(Equal([]string{username}))
+				})
+			})
+
+			Context("When the password is wrong", func() {
+				It("should not create a new room and return error", func() {
+					sbServer.Database.(*dbfakes.FakeUserDatabase).LoginUserReturns(errors.New("wrong password"))
+
+					err := sbClient.CreateNewRoom(rooms.NewRoom("other-room", username))
+
+					Expect(err).Should(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("could not authenticate user"))
+					Expect(len(sbServer.Rooms)).To(Equal(1))
+				})
+			})
+
 			Context("When there already exists a room with this name", func() {
 				It("should not create a new room and return error", func() {
 					err := sbClient.CreateNewRoom(room)
@@ -82,6 +108,48 @@ var _ = Describe("Story Builder Admin Handlers test", func() {
 			})
 		})
 
+		Describe("Specifically create room request with an illegal name", func() {
+			Context("When a room with the same name in a different case exists", func() {
+				It("should not create a new room and return error", func() {
+					err := sbClient.CreateNewRoom(rooms.NewRoom("test room", username))
+
+					Expect(err).Should(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("already exists"))
+					Expect(len(sbServer.Rooms)).To(Equal(1))
+				})
+			})
+
+			Context("When the name contains illegal symbols", func() {
+				It("should be rejected by the client", func() {
+					err := sbClient.CreateNewRoom(rooms.NewRoom("bad/name", username))
+
+					Expect(err).Should(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("room name can only contain"))
+					Expect(len(sbServer.Rooms)).To(Equal(1))
+				})
+
+				It("should be rejected by the server", func() {
+					request, _ := http.NewRequest(http.MethodPost, ts.URL+"/rooms/", strings.NewReader(`{"name":"bad/name"}`))
+					request.Header.Add("Authorization", authHeader)
+
+					response, err := ts.Client().Do(request)
+
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(len(sbServer.Rooms)).To(Equal(1))
+				})
+			})
+
+			Context("When the name is reserved", func() {
+				It("should not create a new room and return error", func() {
+					err := sbClient.CreateNewRoom(rooms.NewRoom("System", username))
+
+					Expect(err).Should(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("room name \"System\" is reserved"))
+				})
+			})
+		})
+
 		Describe("Specifically get a single room request", func() {
 			Context("When request is valid", func() {
 				It("should return the room and not return error", func() {
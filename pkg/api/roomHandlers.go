@@ -16,9 +16,11 @@ package api
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"strings"
 
+	"github.com/pavelhadzhiev/story-builder/pkg/api/audit"
 	"github.com/pavelhadzhiev/story-builder/pkg/api/rooms"
 	"github.com/pavelhadzhiev/story-builder/pkg/util"
 )
@@ -38,20 +40,40 @@ func (server *SBServer) RoomHandler(w http.ResponseWriter, r *http.Request) {
 			w.Write(responseBody)
 			return
 		case http.MethodPost:
-			var room = &rooms.Room{}
+			var requested = &rooms.Room{}
 			defer r.Body.Close()
-			if err := json.NewDecoder(r.Body).Decode(room); err != nil {
+			if err := json.NewDecoder(r.Body).Decode(requested); err != nil {
 				w.WriteHeader(500)
 				w.Write([]byte("Error during serialization of retrieved rooms."))
 				return
 			}
 
+			if err := util.ValidateRoomName(requested.Name); err != nil {
+				w.WriteHeader(400)
+				w.Write([]byte(fmt.Sprintf("Room name is not valid: %v.", err)))
+				return
+			}
+
+			creator, ok := server.authenticate(w, r)
+			if !ok {
+				return
+			}
+			if server.IsBannedFromServer(creator) {
+				w.WriteHeader(403)
+				w.Write([]byte("You are banned from the server."))
+				return
+			}
+
+			// The creator is the authenticated user, whatever the request claims, so the audit log records who actually created the room.
+			room := rooms.NewRoom(requested.Name, creator)
+
 			if err := server.CreateNewRoom(room); err != nil {
 				w.WriteHeader(409)
 				w.Write([]byte("Cannot create more room. A room with this name already exists"))
 				return
 			}
 
+			server.audit(r, audit.Entry{Action: audit.RoomCreated, Actor: room.Creator, Room: room.Name})
 			w.WriteHeader(201)
 			return
 		default:
@@ -86,10 +108,8 @@ func (server *SBServer) RoomHandler(w http.ResponseWriter, r *http.Request) {
 		w.Write([]byte("Error during serialization of retrieved room."))
 		return
 	case http.MethodDelete:
-		issuer, err := util.ExtractUsernameFromAuthorizationHeader(r.Header.Get("Authorization"))
-		if err != nil {
-			w.WriteHeader(500)
-			w.Write([]byte("Error during decoding of authorization header."))
+		issuer, ok := server.authenticate(w, r)
+		if !ok {
 			return
 		}
 		if _, err := server.GetRoom(roomName); err != nil {
@@ -102,6 +122,7 @@ func (server *SBServer) RoomHandler(w http.ResponseWriter, r *http.Request) {
 			w.Write([]byte("You are not authorized to delete this room."))
 			return
 		}
+		server.audit(r, audit.Entry{Action: audit.RoomDeleted, Actor: issuer, Room: roomName})
 		w.WriteHeader(204)
 		return
 	default:
@@ -134,6 +155,11 @@ func (server *SBServer) JoinRoomHandler(w http.ResponseWriter, r *http.Request)
 			w.Write([]byte("Room \"" + roomName + "\" not found."))
 			return
 		}
+		if server.IsBannedFromServer(player) {
+			w.WriteHeader(403)
+			w.Write([]byte("You are banned from the server."))
+			return
+		}
 		if err := server.JoinRoom(roomName, player); err != nil {
 			w.WriteHeader(403)
 			w.Write([]byte("The user doesn't have permissions to join that room."))
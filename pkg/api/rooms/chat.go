@@ -0,0 +1,91 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rooms
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pavelhadzhiev/story-builder/pkg/api/game"
+)
+
+// MaxMessageLength is the maximum number of symbols allowed in a chat message.
+const MaxMessageLength = 280
+
+// maxChatLog is the number of chat messages a room keeps - older messages are dropped.
+const maxChatLog = 200
+
+// Message is a chat message, posted in a room by one of its players.
+type Message struct {
+	ID     int       `json:"id"`
+	Author string    `json:"author"`
+	Text   string    `json:"text"`
+	Time   time.Time `json:"time"`
+}
+
+func (message Message) String() string {
+	return fmt.Sprintf("[%s] %s: %s", message.Time.Local().Format("15:04:05"), message.Author, message.Text)
+}
+
+// PostMessage adds a message with the provided text to the room's chat on the author's behalf and returns it.
+// Returns error if the author is not in the room or is muted, or if the message is empty or too long.
+func (room *Room) PostMessage(author, text string) (*Message, error) {
+	if !room.IsOnline(author) {
+		return nil, errors.New("user is not in the room")
+	}
+	if room.IsMuted(author) {
+		return nil, errors.New("user is muted")
+	}
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, errors.New("message is empty")
+	}
+	if len([]rune(text)) > MaxMessageLength {
+		return nil, fmt.Errorf("message is longer than %d symbols", MaxMessageLength)
+	}
+
+	message := room.appendMessage(author, text)
+	return &message, nil
+}
+
+// Announce posts a message from game.SystemPlayer in the room's chat, e.g. to warn the players that the server is shutting down.
+func (room *Room) Announce(text string) Message {
+	return room.appendMessage(game.SystemPlayer, text)
+}
+
+// appendMessage adds a message to the chat, dropping the oldest messages once the chat log is full.
+func (room *Room) appendMessage(author, text string) Message {
+	room.lastMessageID++
+	message := Message{ID: room.lastMessageID, Author: author, Text: text, Time: time.Now()}
+	room.chat = append(room.chat, message)
+	if len(room.chat) > maxChatLog {
+		room.chat = room.chat[len(room.chat)-maxChatLog:]
+	}
+	return message
+}
+
+// GetMessages returns the chat messages posted after the message with the provided ID, oldest first.
+// Pass 0 to get all messages the room still keeps.
+func (room *Room) GetMessages(since int) []Message {
+	messages := make([]Message, 0)
+	for _, message := range room.chat {
+		if message.ID > since {
+			messages = append(messages, message)
+		}
+	}
+	return messages
+}
@@ -0,0 +1,81 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rooms
+
+import (
+	"errors"
+	"time"
+
+	"github.com/pavelhadzhiev/story-builder/pkg/api/game"
+)
+
+// MutePlayer mutes the provided player in the room for the provided duration - pass 0 to mute until the player is unmuted.
+// Muted players stay in the room and can watch, but they are skipped in the turn rotation and can't post in the chat or trigger votes.
+// Muting a muted player replaces the duration of the mute.
+// Returns error if the player to mute is an admin or if the issuer doesn't have admin access or is not in the room.
+func (room *Room) MutePlayer(playerToMute, issuer string, duration time.Duration) error {
+	if err := room.checkUserPermissions(issuer); err != nil {
+		return err
+	}
+	if room.IsAdmin(playerToMute) {
+		return errors.New("admins can't be muted")
+	}
+
+	var until time.Time
+	if duration > 0 {
+		until = time.Now().Add(duration)
+	}
+	if room.muted == nil {
+		room.muted = make(map[string]time.Time)
+	}
+	room.muted[playerToMute] = until
+	if room.game != nil {
+		room.game.Mute(playerToMute, until)
+	}
+	return nil
+}
+
+// UnmutePlayer lifts the mute of the provided player.
+// Returns error if the player is not muted or if the issuer doesn't have admin access or is not in the room.
+func (room *Room) UnmutePlayer(playerToUnmute, issuer string) error {
+	if err := room.checkUserPermissions(issuer); err != nil {
+		return err
+	}
+	if !room.IsMuted(playerToUnmute) {
+		return errors.New("player is not muted")
+	}
+
+	delete(room.muted, playerToUnmute)
+	if room.game != nil {
+		room.game.Unmute(playerToUnmute)
+	}
+	return nil
+}
+
+// IsMuted returns true if the provided player is on the room's moderation list and can't post in the chat.
+// Mutes with a zero expiry time last until they are lifted.
+func (room *Room) IsMuted(player string) bool {
+	until, muted := room.muted[player]
+	return muted && (until.IsZero() || time.Now().Before(until))
+}
+
+// applyMutes mutes the players on the room's moderation list in the provided game.
+func (room *Room) applyMutes(newGame *game.Game) {
+	for player, until := range room.muted {
+		if room.IsMuted(player) {
+			newGame.Mute(player, until)
+		}
+	}
+}
@@ -17,10 +17,15 @@ package rooms
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/pavelhadzhiev/story-builder/pkg/api/game"
+	"github.com/pavelhadzhiev/story-builder/pkg/api/webhooks"
 )
 
+// maxHistory is the number of finished games a room keeps, so they can be browsed and forked.
+const maxHistory = 100
+
 // Room represents a story builder room, in which a select group of players can play the game
 type Room struct {
 	Name    string   `json:"name"`
@@ -31,6 +36,18 @@ type Room struct {
 
 	game         *game.Game
 	previousGame *game.Game
+	history      []*game.Game
+	lastGameID   int
+
+	chat          []Message
+	lastMessageID int
+	muted         map[string]time.Time
+
+	subscriptions []webhooks.Webhook
+	lastWebhookID int
+
+	voteListener  func(vote *game.Vote, passed bool)
+	eventListener func(event game.Event)
 }
 
 // NewRoom creates a room with the provided name and creator, initializing all required structures and arrays and using the default timeout (180 seconds)
@@ -46,6 +63,11 @@ func NewRoom(name, creator string) *Room {
 
 		game:         nil,
 		previousGame: nil,
+
+		chat:  make([]Message, 0),
+		muted: make(map[string]time.Time),
+
+		subscriptions: make([]webhooks.Webhook, 0),
 	}
 }
 
@@ -60,15 +82,198 @@ func (room *Room) StartGame(initiator string, timeLimit, maxLength, entriesCount
 		return err
 	}
 
+	if err := room.archiveFinishedGame(); err != nil {
+		return err
+	}
+	room.setGame(game.StartGame(initiator, room.Online, timeLimit, maxLength, entriesCount))
+	return nil
+}
+
+// StartTeamGame starts a new team game, splitting the online players into teams according to the provided specification (see game.ParseTeams).
+// Returns error if a game is already started and still ongoing, the teams are illegal or if user doesn't have admin access or is not in the room.
+func (room *Room) StartTeamGame(initiator, teamSpec string, timeLimit, maxLength, entriesCount int) error {
+	if err := room.checkUserPermissions(initiator); err != nil {
+		return err
+	}
+
+	teams, err := game.ParseTeams(teamSpec, room.Online)
+	if err != nil {
+		return err
+	}
+
+	if err := room.archiveFinishedGame(); err != nil {
+		return err
+	}
+	room.setGame(game.StartTeamGame(initiator, teams, timeLimit, maxLength, entriesCount))
+	return nil
+}
+
+// OpenLobby opens a ready-check lobby for a new game. The game starts with the ready players only, once all online players are ready,
+// once the provided quorum of online players is ready or once the countdown (in seconds) expires. Pass 0 for quorum and countdown if you don't want these features.
+// Returns error if a game is already started and still ongoing or if user doesn't have admin access or is not in the room.
+func (room *Room) OpenLobby(initiator string, timeLimit, maxLength, entriesCount int, quorum float64, countdown int) error {
+	if err := room.checkUserPermissions(initiator); err != nil {
+		return err
+	}
+
+	if err := room.archiveFinishedGame(); err != nil {
+		return err
+	}
+	room.setGame(game.OpenLobby(initiator, timeLimit, maxLength, entriesCount, quorum, countdown))
+	return nil
+}
+
+// SetReady marks the provided player as ready in the open lobby. If enough players are ready, the game starts.
+// Returns error if there isn't an open lobby or the player is not in the room.
+func (room *Room) SetReady(player string) error {
+	if !room.IsOnline(player) {
+		return errors.New("user is not in the room")
+	}
+
+	if room.game == nil || room.game.Lobby == nil {
+		return errors.New("there isn't an open lobby")
+	}
+
+	return room.game.SetReady(player, room.Online)
+}
+
+// LeaveLobby takes back the ready state of the provided player, once it's no longer in the room, and starts the game if enough of the
+// remaining players are ready. The lobby is closed if none of the ready players is left.
+func (room *Room) LeaveLobby(player string) {
+	if room.game == nil || room.game.Lobby == nil {
+		return
+	}
+	room.game.LeaveLobby(player, room.Online)
+	if room.game.Lobby != nil && len(room.game.Lobby.Ready) == 0 {
+		room.game.Finished = true // stops the lobby countdown
+		room.game = nil
+	}
+}
+
+// CloseLobby cancels the open lobby without starting the game.
+// Returns error if there isn't an open lobby or if user doesn't have admin access or is not in the room.
+func (room *Room) CloseLobby(issuer string) error {
+	if err := room.checkUserPermissions(issuer); err != nil {
+		return err
+	}
+
+	if room.game == nil || room.game.Lobby == nil {
+		return errors.New("there isn't an open lobby")
+	}
+
+	room.game.Finished = true // stops the lobby countdown
+	room.game = nil
+	return nil
+}
+
+// ForkGame starts a new game whose story continues the first entries of a finished game, up to and including entry number "at" (counting from 1).
+// The game to fork is looked up by ID - pass 0 to fork the last finished game. The other settings are the same as for StartGame.
+// Returns error if a game is still ongoing, there is no such game, it can't be forked at this entry or if user doesn't have admin access or is not in the room.
+func (room *Room) ForkGame(initiator string, gameID, at, timeLimit, maxLength, entriesCount int) error {
+	if err := room.checkUserPermissions(initiator); err != nil {
+		return err
+	}
+
+	if err := room.archiveFinishedGame(); err != nil {
+		return err
+	}
+
+	parent := room.previousGame
+	if gameID != 0 {
+		var err error
+		if parent, err = room.GetGameByID(gameID); err != nil {
+			return err
+		}
+	}
+	if parent == nil {
+		return errors.New("there isn't a finished game to fork")
+	}
+
+	forked, err := game.ForkGame(parent, at, initiator, room.Online, timeLimit, maxLength, entriesCount)
+	if err != nil {
+		return err
+	}
+	room.setGame(forked)
+	return nil
+}
+
+// GetGameByID returns the current or an archived game with the provided ID.
+// Returns error if there is no such game.
+func (room *Room) GetGameByID(id int) (*game.Game, error) {
+	if room.game != nil && room.game.ID == id {
+		return room.game, nil
+	}
+	for _, archived := range room.history {
+		if archived.ID == id {
+			return archived, nil
+		}
+	}
+	return nil, fmt.Errorf("there is no game with id %d", id)
+}
+
+// StoryTree returns summaries of the archived games and the current game of the room, from which the tree of forked stories can be built.
+func (room *Room) StoryTree() []game.Summary {
+	summaries := make([]game.Summary, 0, len(room.history)+1)
+	for _, archived := range room.history {
+		summaries = append(summaries, archived.Summarize())
+	}
+	if room.game != nil {
+		summaries = append(summaries, room.game.Summarize())
+	}
+	return summaries
+}
+
+// setGame gives the provided game the next game ID in the room, applies the room's mutes and listeners to it and makes it the current game.
+// Games that don't wait in a lobby announce their start right away.
+func (room *Room) setGame(newGame *game.Game) {
+	room.lastGameID++
+	newGame.ID = room.lastGameID
+	room.applyMutes(newGame)
+	newGame.SetVoteListener(room.voteListener)
+	newGame.SetEventListener(room.eventListener)
+	room.game = newGame
+	if newGame.Lobby == nil {
+		newGame.AnnounceStart()
+	}
+}
+
+// SetVoteListener makes the games of the room call the provided function whenever a vote passes or fails, including the current game.
+func (room *Room) SetVoteListener(listener func(vote *game.Vote, passed bool)) {
+	room.voteListener = listener
+	if room.game != nil {
+		room.game.SetVoteListener(listener)
+	}
+}
+
+// SetEventListener makes the games of the room call the provided function on every transition, including the current game.
+// See game.SetEventListener.
+func (room *Room) SetEventListener(listener func(event game.Event)) {
+	room.eventListener = listener
+	if room.game != nil {
+		room.game.SetEventListener(listener)
+	}
+}
+
+// archive moves the current game to the previous game and to the room's history, dropping the oldest games once the history is full.
+func (room *Room) archive() {
+	room.previousGame = room.game
+	room.history = append(room.history, room.game)
+	if len(room.history) > maxHistory {
+		room.history = room.history[len(room.history)-maxHistory:]
+	}
+	room.game = nil
+}
+
+// archiveFinishedGame moves a finished game to the previous game, making room for a new one.
+// Returns error if there is an unfinished game.
+func (room *Room) archiveFinishedGame() error {
 	if room.game != nil {
 		if room.game.Finished {
-			room.previousGame = room.game
-			room.game = nil
+			room.archive()
 		} else {
 			return errors.New("there is an unfinished game")
 		}
 	}
-	room.game = game.StartGame(initiator, room.Online, timeLimit, maxLength, entriesCount)
 	return nil
 }
 
@@ -84,12 +289,21 @@ func (room *Room) AddEntry(entry, issuer string) error {
 	}
 
 	if room.game.Finished {
-		room.previousGame = room.game
-		room.game = nil
+		room.archive()
 	}
 	return nil
 }
 
+// VoteForTeam casts the provided player's vote for the winning story of the running team game.
+// Returns error if there isn't a started game or the vote is illegal.
+func (room *Room) VoteForTeam(voter, team string) error {
+	if room.game == nil {
+		return errors.New("there isn't a started game")
+	}
+
+	return room.game.VoteForTeam(voter, team)
+}
+
 // GetGame returns the current or last finished game.
 func (room *Room) GetGame() *game.Game {
 	if room.game != nil {
@@ -116,6 +330,34 @@ func (room *Room) EndGame(issuer string, entries int) error {
 	return nil
 }
 
+// PauseGame freezes the currently played game until it is resumed.
+// Returns error if there isn't a started game, it's already paused or if user doesn't have admin access or is not in the room.
+func (room *Room) PauseGame(issuer string) error {
+	if err := room.checkUserPermissions(issuer); err != nil {
+		return err
+	}
+
+	if room.game == nil {
+		return errors.New("there isn't a started game")
+	}
+
+	return room.game.Pause()
+}
+
+// ResumeGame unfreezes the currently played game.
+// Returns error if there isn't a started game, it isn't paused or if user doesn't have admin access or is not in the room.
+func (room *Room) ResumeGame(issuer string) error {
+	if err := room.checkUserPermissions(issuer); err != nil {
+		return err
+	}
+
+	if room.game == nil {
+		return errors.New("there isn't a started game")
+	}
+
+	return room.game.Resume()
+}
+
 // PromoteAdmin makes the provided user an admin in the room.
 // Returns error if the issuer of the promotion is not an admin.
 func (room *Room) PromoteAdmin(userToPromote, issuer string) error {
@@ -138,10 +380,41 @@ func (room *Room) BanPlayer(playerToBan, issuer string) error {
 			room.Online = append(room.Online[:index], room.Online[index+1:]...)
 		}
 	}
+	room.LeaveLobby(playerToBan)
 	room.Banned = append(room.Banned, playerToBan)
 	return nil
 }
 
+// ForgetUser removes every trace of the provided user from the room, once its account is deleted.
+// The user's entries in all games of the room and its chat messages are attributed to game.DeletedPlayer and it's no longer an admin.
+// If the user created the room, the room is handed to its longest-serving admin, or left without a creator if it has no other admins,
+// so a new account with the same name can't claim it.
+func (room *Room) ForgetUser(user string) {
+	for _, archived := range room.history { // includes the previous game
+		archived.AnonymizePlayer(user)
+	}
+	if room.game != nil {
+		room.game.AnonymizePlayer(user)
+	}
+	for index := range room.chat {
+		if room.chat[index].Author == user {
+			room.chat[index].Author = game.DeletedPlayer
+		}
+	}
+	for index, admin := range room.Admins {
+		if admin == user {
+			room.Admins = append(room.Admins[:index], room.Admins[index+1:]...)
+			break
+		}
+	}
+	if room.Creator == user {
+		room.Creator = ""
+		if len(room.Admins) > 0 {
+			room.Creator = room.Admins[0]
+		}
+	}
+}
+
 // IsBanned returns true of the provided player has been banned from the room and false otherwise.
 func (room *Room) IsBanned(player string) bool {
 	for _, banned := range room.Banned {
@@ -162,6 +435,16 @@ func (room *Room) IsOnline(player string) bool {
 	return false
 }
 
+// IsAdmin returns true if the provided user is an admin of the room.
+func (room *Room) IsAdmin(user string) bool {
+	for _, admin := range room.Admins {
+		if admin == user {
+			return true
+		}
+	}
+	return false
+}
+
 // checkUserPermissions returns error if the user is not an admin or joined in the room.
 func (room *Room) checkUserPermissions(user string) error {
 	isAdmin, isOnline := false, false
@@ -0,0 +1,118 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rooms
+
+import (
+	"time"
+
+	"github.com/pavelhadzhiev/story-builder/pkg/api/game"
+	"github.com/pavelhadzhiev/story-builder/pkg/api/webhooks"
+)
+
+// Snapshot holds a room with its games, chat and moderation list, so it can be restored after a server restart.
+type Snapshot struct {
+	Name    string   `json:"name"`
+	Creator string   `json:"creator,omitempty"`
+	Admins  []string `json:"admins,omitempty"`
+	Banned  []string `json:"banned,omitempty"`
+	Online  []string `json:"online,omitempty"`
+
+	Game           *game.Snapshot   `json:"game,omitempty"`
+	PreviousGameID int              `json:"previousGameId,omitempty"`
+	History        []*game.Snapshot `json:"history,omitempty"`
+	LastGameID     int              `json:"lastGameId,omitempty"`
+
+	Chat          []Message            `json:"chat,omitempty"`
+	LastMessageID int                  `json:"lastMessageId,omitempty"`
+	Muted         map[string]time.Time `json:"muted,omitempty"`
+
+	Webhooks      []webhooks.Webhook `json:"webhooks,omitempty"`
+	LastWebhookID int                `json:"lastWebhookId,omitempty"`
+}
+
+// Snapshot captures the room. Call it after Stop, so the game timers don't change the room in the meantime.
+func (room *Room) Snapshot() *Snapshot {
+	snapshot := &Snapshot{
+		Name:    room.Name,
+		Creator: room.Creator,
+		Admins:  room.Admins,
+		Banned:  room.Banned,
+		Online:  room.Online,
+
+		History:    make([]*game.Snapshot, 0, len(room.history)),
+		LastGameID: room.lastGameID,
+
+		Chat:          room.chat,
+		LastMessageID: room.lastMessageID,
+		Muted:         room.muted,
+
+		Webhooks:      room.subscriptions,
+		LastWebhookID: room.lastWebhookID,
+	}
+	if room.game != nil {
+		snapshot.Game = room.game.Snapshot()
+	}
+	if room.previousGame != nil {
+		snapshot.PreviousGameID = room.previousGame.ID
+	}
+	for _, archived := range room.history {
+		snapshot.History = append(snapshot.History, archived.Snapshot())
+	}
+	return snapshot
+}
+
+// RestoreRoom turns the snapshot back into a room. The current game is restored as described in game.Restore.
+func RestoreRoom(snapshot *Snapshot) *Room {
+	room := NewRoom(snapshot.Name, snapshot.Creator)
+	room.Admins = snapshot.Admins
+	if snapshot.Banned != nil {
+		room.Banned = snapshot.Banned
+	}
+	if snapshot.Online != nil {
+		room.Online = snapshot.Online
+	}
+
+	for _, archived := range snapshot.History {
+		restored := game.Restore(archived)
+		room.history = append(room.history, restored)
+		if restored.ID == snapshot.PreviousGameID {
+			room.previousGame = restored
+		}
+	}
+	if snapshot.Game != nil {
+		room.game = game.Restore(snapshot.Game)
+	}
+	room.lastGameID = snapshot.LastGameID
+
+	if snapshot.Chat != nil {
+		room.chat = snapshot.Chat
+	}
+	room.lastMessageID = snapshot.LastMessageID
+	if snapshot.Muted != nil {
+		room.muted = snapshot.Muted
+	}
+	if snapshot.Webhooks != nil {
+		room.subscriptions = snapshot.Webhooks
+	}
+	room.lastWebhookID = snapshot.LastWebhookID
+	return room
+}
+
+// Stop stops the timers of the room's current game for good. It's used when the server shuts down.
+func (room *Room) Stop() {
+	if room.game != nil {
+		room.game.Stop()
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rooms
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pavelhadzhiev/story-builder/pkg/api/webhooks"
+)
+
+// maxWebhooks is the number of webhooks a room can have.
+const maxWebhooks = 10
+
+// AddWebhook subscribes the provided webhook to the events of the room, giving it the next webhook ID in the room, and returns it.
+// Returns error if the webhook is not valid, the room has too many webhooks or the issuer doesn't have admin access or is not in the room.
+func (room *Room) AddWebhook(webhook webhooks.Webhook, issuer string) (*webhooks.Webhook, error) {
+	if err := room.checkUserPermissions(issuer); err != nil {
+		return nil, err
+	}
+	if err := webhook.Validate(); err != nil {
+		return nil, err
+	}
+	if len(room.subscriptions) >= maxWebhooks {
+		return nil, fmt.Errorf("the room can't have more than %d webhooks", maxWebhooks)
+	}
+
+	room.lastWebhookID++
+	webhook.ID = room.lastWebhookID
+	webhook.Creator = issuer
+	webhook.Created = time.Now()
+	room.subscriptions = append(room.subscriptions, webhook)
+	return &webhook, nil
+}
+
+// RemoveWebhook unsubscribes the webhook with the provided ID from the events of the room.
+// Returns error if there is no such webhook or the issuer doesn't have admin access or is not in the room.
+func (room *Room) RemoveWebhook(id int, issuer string) error {
+	if err := room.checkUserPermissions(issuer); err != nil {
+		return err
+	}
+	for index, webhook := range room.subscriptions {
+		if webhook.ID == id {
+			room.subscriptions = append(room.subscriptions[:index], room.subscriptions[index+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("there is no webhook with id %d", id)
+}
+
+// GetWebhooks returns the webhooks of the room, without their secrets.
+// Returns error if the issuer doesn't have admin access or is not in the room.
+func (room *Room) GetWebhooks(issuer string) ([]webhooks.Webhook, error) {
+	if err := room.checkUserPermissions(issuer); err != nil {
+		return nil, err
+	}
+	redacted := make([]webhooks.Webhook, 0, len(room.subscriptions))
+	for _, webhook := range room.subscriptions {
+		redacted = append(redacted, webhook.Redacted())
+	}
+	return redacted, nil
+}
+
+// HasWebhook returns true if the room has a webhook with the provided ID.
+func (room *Room) HasWebhook(id int) bool {
+	for _, webhook := range room.subscriptions {
+		if webhook.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// Subscribers returns the webhooks of the room that subscribe to the event with the provided name, with their secrets.
+func (room *Room) Subscribers(event string) []webhooks.Webhook {
+	subscribers := make([]webhooks.Webhook, 0)
+	for _, webhook := range room.subscriptions {
+		if webhook.Subscribes(event) {
+			subscribers = append(subscribers, webhook)
+		}
+	}
+	return subscribers
+}
@@ -15,69 +15,160 @@
 package api
 
 import (
+	"context"
+	"crypto/tls"
 	"fmt"
 	"net/http"
+	"time"
 
+	"github.com/pavelhadzhiev/story-builder/pkg/api/audit"
+	"github.com/pavelhadzhiev/story-builder/pkg/api/game"
+	"github.com/pavelhadzhiev/story-builder/pkg/api/logging"
+	"github.com/pavelhadzhiev/story-builder/pkg/api/ratelimit"
+	"github.com/pavelhadzhiev/story-builder/pkg/api/reminders"
 	"github.com/pavelhadzhiev/story-builder/pkg/api/rooms"
+	"github.com/pavelhadzhiev/story-builder/pkg/api/webhooks"
 
 	"github.com/pavelhadzhiev/story-builder/pkg/db"
 )
 
+// shutdownAnnouncement is posted in the chat of every room when the server shuts down.
+const shutdownAnnouncement = "The server is shutting down."
+
 // SBServer implements the story builder server API. It contains a database and some configurations. Use the Start and Shutdown methods to manage.
 type SBServer struct {
-	Database db.UserDatabase
-	Rooms    []rooms.Room
-	Online   []string
-
-	srv *http.Server
+	Database     db.UserDatabase
+	Prompts      db.PromptDatabase
+	Rooms        []rooms.Room
+	Online       []string
+	VoteSettings map[game.VoteKind]game.VoteSettings
+	GameSettings *game.Settings
+
+	srv         *http.Server
+	limiter     *ratelimit.Limiter
+	lockout     *ratelimit.Lockout
+	tlsCertFile string
+	tlsKeyFile  string
+	logger      *logging.Logger
+	auditLog    *audit.Log
+	dispatcher  *webhooks.Dispatcher
+	reminders   *reminders.Reminders
+	started     time.Time
 }
 
 // NewSBServer returns a story builder server configured for localhost:<port> that will use the provided database
 func NewSBServer(sbdb *db.SBDatabase, port int) (sbServer *SBServer) {
 	sbServer = &SBServer{
-		Database: sbdb,
-		Rooms:    make([]rooms.Room, 0),
-		Online:   make([]string, 0),
+		Database:     sbdb,
+		Prompts:      sbdb,
+		Rooms:        make([]rooms.Room, 0),
+		Online:       make([]string, 0),
+		VoteSettings: game.DefaultVoteSettings,
+
+		srv:        &http.Server{Addr: fmt.Sprintf(":%d", port)},
+		limiter:    ratelimit.NewLimiter(ratelimit.DefaultSettings),
+		lockout:    ratelimit.NewLockout(ratelimit.DefaultSettings),
+		dispatcher: webhooks.NewDispatcher(webhooks.DefaultSettings),
+	}
 
-		srv: &http.Server{Addr: fmt.Sprintf(":%d", port)},
+	handle := func(pattern string, handler http.HandlerFunc) {
+		http.HandleFunc(pattern, sbServer.instrumented(pattern, sbServer.logged(pattern, sbServer.rateLimited(handler))))
+	}
+	// Probes aren't rate limited, as they often come through a load balancer or a proxy that shares its address with other clients.
+	probe := func(pattern string, handler http.HandlerFunc) {
+		http.HandleFunc(pattern, sbServer.instrumented(pattern, sbServer.logged(pattern, handler)))
 	}
 
 	http.HandleFunc("/", defaultHandler)
-	http.HandleFunc("/healthcheck/", sbServer.HealthcheckHandler)
+	handle("/healthcheck/", sbServer.HealthcheckHandler)
+	probe("/livez", sbServer.LivezHandler)
+	probe("/readyz", sbServer.ReadyzHandler)
+	handle("/status", sbServer.StatusHandler)
+	handle("/metrics", sbServer.MetricsHandler)
+
+	handle("/register/", sbServer.RegistrationHandler)
+	handle("/login/", sbServer.LoginHandler)
+	handle("/logout/", sbServer.LogoutHandler)
+	handle("/account/", sbServer.AccountHandler)
+	handle("/profiles/", sbServer.ProfileHandler)
+
+	handle("/rooms/", sbServer.RoomHandler)
+	handle("/join-room/", sbServer.JoinRoomHandler)
+	handle("/leave-room/", sbServer.LeaveRoomHandler)
+
+	handle("/vote/", sbServer.VoteHandler)
+	handle("/team-vote/", sbServer.TeamVoteHandler)
+	handle("/gameplay/", sbServer.GameplayHandler)
+	handle("/story-tree/", sbServer.StoryTreeHandler)
+	handle("/manage-games/", sbServer.ManageGamesHandler)
+	handle("/pause-game/", sbServer.PauseGameHandler)
+	handle("/lobby/", sbServer.LobbyHandler)
+	handle("/prompts/", sbServer.PromptHandler)
+	handle("/chat/", sbServer.ChatHandler)
+	handle("/webhooks/", sbServer.WebhookHandler)
+
+	handle("/admin/", sbServer.PromoteAdminHandler)
+	handle("/admin/ban/", sbServer.BanHandler)
+	handle("/admin/kick/", sbServer.KickHandler)
+	handle("/admin/mute/", sbServer.MuteHandler)
+
+	handle("/server-admin/", sbServer.ServerAdminHandler)
 
-	http.HandleFunc("/register/", sbServer.RegistrationHandler)
-	http.HandleFunc("/login/", sbServer.LoginHandler)
-	http.HandleFunc("/logout/", sbServer.LogoutHandler)
-
-	http.HandleFunc("/rooms/", sbServer.RoomHandler)
-	http.HandleFunc("/join-room/", sbServer.JoinRoomHandler)
-	http.HandleFunc("/leave-room/", sbServer.LeaveRoomHandler)
-
-	http.HandleFunc("/vote/", sbServer.VoteHandler)
-	http.HandleFunc("/gameplay/", sbServer.GameplayHandler)
-	http.HandleFunc("/manage-games/", sbServer.ManageGamesHandler)
+	return
+}
 
-	http.HandleFunc("/admin/", sbServer.PromoteAdminHandler)
-	http.HandleFunc("/admin/ban/", sbServer.BanHandler)
-	http.HandleFunc("/admin/kick/", sbServer.KickHandler)
+// SetAddress replaces the address the server listens at, e.g. ":8080" or "127.0.0.1:8080". Call it before Start.
+func (sbServer *SBServer) SetAddress(addr string) {
+	sbServer.srv.Addr = addr
+}
 
-	return
+// EnableTLS makes the server serve HTTPS, using the certificate and the private key in the provided PEM files. Call it before Start.
+// Returns error if the files can't be read or don't hold a matching certificate and key.
+func (sbServer *SBServer) EnableTLS(certFile, keyFile string) error {
+	if _, err := tls.LoadX509KeyPair(certFile, keyFile); err != nil {
+		return fmt.Errorf("cannot load TLS certificate: %v", err)
+	}
+	sbServer.tlsCertFile = certFile
+	sbServer.tlsKeyFile = keyFile
+	return nil
 }
 
-// Start starts an HTTP server, using the available configuration
+// Start starts an HTTP server, using the available configuration. If TLS is enabled, it serves HTTPS.
 func (sbServer *SBServer) Start() {
+	sbServer.log(logging.Info, "listening", "addr", sbServer.srv.Addr, "tls", sbServer.tlsCertFile != "")
+	sbServer.started = time.Now()
 	go func() {
-		if err := sbServer.srv.ListenAndServe(); err != nil {
+		var err error
+		if sbServer.tlsCertFile != "" {
+			err = sbServer.srv.ListenAndServeTLS(sbServer.tlsCertFile, sbServer.tlsKeyFile)
+		} else {
+			err = sbServer.srv.ListenAndServe()
+		}
+		if err != nil && err != http.ErrServerClosed {
+			sbServer.log(logging.Error, "server failed", "error", err)
 			panic(err)
 		}
 	}()
 }
 
-// Shutdown stops the HTTP server gracefully. It's supposed to be used in a defer statement in the process that starts the server, in case of errors.
-func (sbServer *SBServer) Shutdown() {
-	if err := sbServer.srv.Shutdown(nil); err != nil {
-		panic(err) // failed or timed out while shutting down the server
+// Shutdown stops the server gracefully. It announces the shutdown in all rooms, stops accepting new requests and waits for the ongoing ones
+// until the provided context is done. Then it stops the game timers, so the state of the server no longer changes and can be snapshotted.
+// Returns error if the context is done before all ongoing requests have finished.
+func (sbServer *SBServer) Shutdown(ctx context.Context) error {
+	for index := range sbServer.Rooms {
+		sbServer.Rooms[index].Announce(shutdownAnnouncement)
+	}
+
+	var err error
+	if sbServer.srv != nil {
+		sbServer.log(logging.Info, "shutting down")
+		err = sbServer.srv.Shutdown(ctx)
+	}
+
+	for index := range sbServer.Rooms {
+		sbServer.Rooms[index].Stop()
 	}
+	return err
 }
 
 func defaultHandler(w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,218 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pavelhadzhiev/story-builder/pkg/api/audit"
+	"github.com/pavelhadzhiev/story-builder/pkg/api/users"
+)
+
+// ServerAdminHandler is an http handler for the story builder's server admin API.
+// It serves /server-admin/users, /server-admin/users/<user>/<disable|ban|admin|password>, /server-admin/rooms/<room> and /server-admin/audit.
+func (server *SBServer) ServerAdminHandler(w http.ResponseWriter, r *http.Request) {
+	issuer, ok := server.authenticate(w, r)
+	if !ok {
+		return
+	}
+	user, err := server.Database.GetUser(issuer)
+	if err != nil {
+		w.WriteHeader(500)
+		w.Write([]byte("Database lookup failed."))
+		return
+	}
+	if user == nil || user.Disabled || !user.IsServerAdmin() {
+		w.WriteHeader(403)
+		w.Write([]byte("Requires server admin access."))
+		return
+	}
+
+	urlSuffix := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/server-admin/"), "/")
+	urlSuffixSplit := strings.Split(urlSuffix, "/")
+	switch {
+	case len(urlSuffixSplit) == 1 && urlSuffixSplit[0] == "users":
+		server.handleListUsers(w, r)
+	case len(urlSuffixSplit) == 3 && urlSuffixSplit[0] == "users":
+		server.handleManageUser(w, r, issuer, urlSuffixSplit[1], urlSuffixSplit[2])
+	case len(urlSuffixSplit) == 1 && urlSuffixSplit[0] == "audit":
+		server.handleQueryAuditLog(w, r)
+	case len(urlSuffixSplit) == 2 && urlSuffixSplit[0] == "rooms":
+		server.handleDeleteAnyRoom(w, r, issuer, urlSuffixSplit[1])
+	default:
+		w.WriteHeader(404)
+		w.Write([]byte("Request URL is illegal."))
+	}
+}
+
+func (server *SBServer) handleListUsers(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(405)
+		return
+	}
+
+	allUsers, err := server.GetUsers()
+	if err != nil {
+		w.WriteHeader(500)
+		w.Write([]byte("Error while retrieving users."))
+		return
+	}
+	responseBody, err := json.Marshal(allUsers)
+	if err != nil {
+		w.WriteHeader(500)
+		w.Write([]byte("Error during serialization of retrieved users."))
+		return
+	}
+	w.Write(responseBody)
+}
+
+func (server *SBServer) handleManageUser(w http.ResponseWriter, r *http.Request, issuer, username, action string) {
+	user, err := server.Database.GetUser(username)
+	if err != nil {
+		w.WriteHeader(500)
+		w.Write([]byte("Database lookup failed."))
+		return
+	}
+	if user == nil {
+		w.WriteHeader(404)
+		w.Write([]byte("User \"" + username + "\" doesn't exist."))
+		return
+	}
+	if username == issuer && action != "password" {
+		w.WriteHeader(409)
+		w.Write([]byte("Server admins can't take this action against themselves."))
+		return
+	}
+
+	var message, auditAction string
+	switch {
+	case action == "disable" && r.Method == http.MethodPost:
+		err = server.DisableUser(username)
+		auditAction = audit.UserDisabled
+		message = "User \"" + username + "\" has been disabled."
+	case action == "disable" && r.Method == http.MethodDelete:
+		err = server.Database.SetDisabled(username, false)
+		auditAction = audit.UserEnabled
+		message = "User \"" + username + "\" has been enabled."
+	case action == "ban" && r.Method == http.MethodPost:
+		err = server.BanUser(username)
+		auditAction = audit.ServerBan
+		message = "User \"" + username + "\" has been banned from the server."
+	case action == "ban" && r.Method == http.MethodDelete:
+		err = server.Database.SetBanned(username, false)
+		auditAction = audit.ServerUnban
+		message = "User \"" + username + "\" has been unbanned from the server."
+	case action == "admin" && r.Method == http.MethodPost:
+		err = server.Database.SetRole(username, users.RoleServerAdmin)
+		auditAction = audit.ServerAdminAdded
+		message = "User \"" + username + "\" has been promoted to server admin."
+	case action == "admin" && r.Method == http.MethodDelete:
+		err = server.Database.SetRole(username, users.RoleUser)
+		auditAction = audit.ServerAdminRemoved
+		message = "User \"" + username + "\" is no longer a server admin."
+	case action == "password" && r.Method == http.MethodPut:
+		newPassword := r.Header.Get("New-Password")
+		if newPassword == "" {
+			w.WriteHeader(400)
+			w.Write([]byte("Missing New-Password header."))
+			return
+		}
+		err = server.Database.UpdatePassword(username, newPassword)
+		auditAction = audit.PasswordReset
+		message = "The password of user \"" + username + "\" has been reset."
+	case action == "disable" || action == "ban" || action == "admin" || action == "password":
+		w.WriteHeader(405)
+		return
+	default:
+		w.WriteHeader(404)
+		w.Write([]byte("Request URL is illegal."))
+		return
+	}
+
+	if err != nil {
+		w.WriteHeader(500)
+		w.Write([]byte("Database write failed."))
+		return
+	}
+	server.audit(r, audit.Entry{Action: auditAction, Actor: issuer, Target: username})
+	w.Write([]byte(message))
+}
+
+func (server *SBServer) handleDeleteAnyRoom(w http.ResponseWriter, r *http.Request, issuer, roomName string) {
+	if r.Method != http.MethodDelete {
+		w.WriteHeader(405)
+		return
+	}
+
+	if err := server.DeleteAnyRoom(roomName); err != nil {
+		w.WriteHeader(404)
+		w.Write([]byte("Room \"" + roomName + "\" not found."))
+		return
+	}
+	server.audit(r, audit.Entry{Action: audit.RoomDeleted, Actor: issuer, Room: roomName, Details: "deleted by a server admin"})
+	w.WriteHeader(204)
+}
+
+// handleQueryAuditLog returns the entries of the audit log that match the actor, action, room, since (RFC 3339) and limit query parameters.
+func (server *SBServer) handleQueryAuditLog(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(405)
+		return
+	}
+	if server.auditLog == nil {
+		w.WriteHeader(404)
+		w.Write([]byte("The audit log is not enabled on this server."))
+		return
+	}
+
+	query := r.URL.Query()
+	filter := audit.Filter{Actor: query.Get("actor"), Action: query.Get("action"), Room: query.Get("room")}
+	if since := query.Get("since"); since != "" {
+		sinceTime, err := time.Parse(time.RFC3339, since)
+		if err != nil {
+			w.WriteHeader(400)
+			w.Write([]byte("Illegal since parameter value, use RFC 3339 format."))
+			return
+		}
+		filter.Since = sinceTime
+	}
+	if limit := query.Get("limit"); limit != "" {
+		limitNumber, err := strconv.Atoi(limit)
+		if err != nil || limitNumber < 0 {
+			w.WriteHeader(400)
+			w.Write([]byte("Illegal limit parameter value."))
+			return
+		}
+		filter.Limit = limitNumber
+	}
+
+	entries, err := server.auditLog.Query(filter)
+	if err != nil {
+		w.WriteHeader(500)
+		w.Write([]byte("Error while reading the audit log."))
+		return
+	}
+	responseBody, err := json.Marshal(entries)
+	if err != nil {
+		w.WriteHeader(500)
+		w.Write([]byte("Error during serialization of audit entries."))
+		return
+	}
+	w.Write(responseBody)
+}
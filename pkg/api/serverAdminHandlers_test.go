@@ -0,0 +1,368 @@
+package api
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pavelhadzhiev/story-builder/pkg/api/audit"
+	"github.com/pavelhadzhiev/story-builder/pkg/api/rooms"
+	"github.com/pavelhadzhiev/story-builder/pkg/api/users"
+	"github.com/pavelhadzhiev/story-builder/pkg/client"
+	"github.com/pavelhadzhiev/story-builder/pkg/config"
+	"github.com/pavelhadzhiev/story-builder/pkg/db/dbfakes"
+)
+
+var _ = Describe("Story Builder Server Admin Handlers test", func() {
+	var sbClient *client.SBClient
+	var sbServer *SBServer
+	var database *dbfakes.FakeUserDatabase
+	var ts *httptest.Server
+	var registered map[string]*users.User
+
+	admin := "server-admin"
+	player := "test-player"
+	password := "password"
+	authHeader := func(username string) string {
+		return "Basic " + base64.StdEncoding.EncodeToString([]byte(username+":"+password))
+	}
+
+	roomName := "Test Room"
+
+	BeforeEach(func() {
+		// Fake a user database that keeps the users in memory
+		registered = map[string]*users.User{
+			admin:  {Username: admin, Role: users.RoleServerAdmin},
+			player: {Username: player, Role: users.RoleUser},
+		}
+		database = &dbfakes.FakeUserDatabase{}
+		database.GetUserStub = func(username string) (*users.User, error) {
+			if user, ok := registered[username]; ok {
+				copied := *user
+				return &copied, nil
+			}
+			return nil, nil
+		}
+		database.GetUsersStub = func() ([]users.User, error) {
+			return []users.User{*registered[player], *registered[admin]}, nil
+		}
+		database.SetDisabledStub = func(username string, disabled bool) error {
+			registered[username].Disabled = disabled
+			return nil
+		}
+		database.SetBannedStub = func(username string, banned bool) error {
+			registered[username].Banned = banned
+			return nil
+		}
+		database.SetRoleStub = func(username, role string) error {
+			registered[username].Role = role
+			return nil
+		}
+
+		// Create a room with the player in it
+		room := rooms.NewRoom(roomName, player)
+		room.Online = append(room.Online, player)
+
+		sbServer = &SBServer{
+			Database: database,
+			Rooms:    []rooms.Room{*room},
+			Online:   []string{admin, player},
+		}
+
+		ts = httptest.NewServer(http.HandlerFunc(sbServer.ServerAdminHandler))
+		sbClient = client.NewTestSBClient(&config.SBConfiguration{URL: ts.URL, Authorization: authHeader(admin)}, ts.Client())
+	})
+
+	AfterEach(func() {
+		ts.Close()
+	})
+
+	Describe("Handle list users request", func() {
+		It("should return all users and mark the logged in ones as online", func() {
+			sbServer.Online = []string{admin}
+
+			allUsers, err := sbClient.GetUsers()
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(allUsers).To(HaveLen(2))
+			Expect(allUsers[0].Username).To(Equal(player))
+			Expect(allUsers[0].Online).To(BeFalse())
+			Expect(allUsers[1].Username).To(Equal(admin))
+			Expect(allUsers[1].Online).To(BeTrue())
+			Expect(allUsers[1].IsServerAdmin()).To(BeTrue())
+		})
+	})
+
+	Describe("Handle disable user request", func() {
+		It("should disable the account, log the user out and remove it from the rooms", func() {
+			err := sbClient.DisableUser(player)
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(registered[player].Disabled).To(BeTrue())
+			Expect(sbServer.Online).To(Equal([]string{admin}))
+			Expect(sbServer.Rooms[0].IsOnline(player)).To(BeFalse())
+		})
+
+		It("should enable the account again", func() {
+			registered[player].Disabled = true
+
+			err := sbClient.EnableUser(player)
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(registered[player].Disabled).To(BeFalse())
+		})
+	})
+
+	Describe("Handle ban user request", func() {
+		It("should ban the user and remove it from the rooms", func() {
+			err := sbClient.BanUserFromServer(player)
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(registered[player].Banned).To(BeTrue())
+			Expect(sbServer.Rooms[0].IsOnline(player)).To(BeFalse())
+			Expect(sbServer.IsBannedFromServer(player)).To(BeTrue())
+		})
+
+		It("should prevent the banned user from joining rooms", func() {
+			registered[player].Banned = true
+			joinServer := httptest.NewServer(http.HandlerFunc(sbServer.JoinRoomHandler))
+			defer joinServer.Close()
+			playerClient := client.NewTestSBClient(&config.SBConfiguration{URL: joinServer.URL, Authorization: authHeader(player)}, joinServer.Client())
+
+			err := playerClient.JoinRoom(roomName)
+
+			Expect(err).Should(HaveOccurred())
+		})
+	})
+
+	Describe("Handle promote server admin request", func() {
+		It("should give the user the server admin role", func() {
+			err := sbClient.PromoteServerAdmin(player)
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(sbServer.IsServerAdmin(player)).To(BeTrue())
+		})
+	})
+
+	Describe("Handle reset password request", func() {
+		It("should update the password of the user", func() {
+			err := sbClient.ResetPassword(player, "new-password")
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(database.UpdatePasswordCallCount()).To(Equal(1))
+			username, newPassword := database.UpdatePasswordArgsForCall(0)
+			Expect(username).To(Equal(player))
+			Expect(newPassword).To(Equal("new-password"))
+		})
+	})
+
+	Describe("Handle delete room request", func() {
+		It("should delete a room the admin didn't create", func() {
+			err := sbClient.DeleteAnyRoom(roomName)
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(sbServer.Rooms).To(BeEmpty())
+		})
+
+		It("should return error when the room doesn't exist", func() {
+			err := sbClient.DeleteAnyRoom("missing")
+
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("server admin action failed"))
+		})
+	})
+
+	Describe("Handle audit log request", func() {
+		BeforeEach(func() {
+			sbServer.SetAuditLog(audit.NewMemoryLog())
+		})
+
+		It("should record the actions of server admins", func() {
+			Expect(sbClient.BanUserFromServer(player)).To(Succeed())
+			Expect(sbClient.DeleteAnyRoom(roomName)).To(Succeed())
+
+			entries, err := sbClient.GetAuditLog(audit.Filter{})
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(entries).To(HaveLen(2))
+			Expect(entries[0].Action).To(Equal(audit.ServerBan))
+			Expect(entries[0].Actor).To(Equal(admin))
+			Expect(entries[0].Target).To(Equal(player))
+			Expect(entries[1].Action).To(Equal(audit.RoomDeleted))
+			Expect(entries[1].Room).To(Equal(roomName))
+		})
+
+		It("should filter the entries", func() {
+			Expect(sbClient.PromoteServerAdmin(player)).To(Succeed())
+			Expect(sbClient.DisableUser(player)).To(Succeed())
+			Expect(sbClient.EnableUser(player)).To(Succeed())
+
+			entries, err := sbClient.GetAuditLog(audit.Filter{Actor: admin, Action: audit.UserDisabled})
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(entries).To(HaveLen(1))
+			Expect(entries[0].Target).To(Equal(player))
+
+			entries, err = sbClient.GetAuditLog(audit.Filter{Limit: 2})
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(entries).To(HaveLen(2))
+			Expect(entries[1].Action).To(Equal(audit.UserEnabled))
+		})
+
+		It("should reject users without the server admin role", func() {
+			playerClient := client.NewTestSBClient(&config.SBConfiguration{URL: ts.URL, Authorization: authHeader(player)}, ts.Client())
+
+			_, err := playerClient.GetAuditLog(audit.Filter{})
+
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("requires server admin access"))
+		})
+
+		It("should return error when the audit log is not enabled", func() {
+			sbServer.SetAuditLog(nil)
+
+			_, err := sbClient.GetAuditLog(audit.Filter{})
+
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("not enabled"))
+		})
+
+		It("should reject invalid query parameters", func() {
+			request, _ := http.NewRequest(http.MethodGet, ts.URL+"/server-admin/audit?since=yesterday", nil)
+			request.Header.Set("Authorization", authHeader(admin))
+
+			response, err := ts.Client().Do(request)
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(response.StatusCode).To(Equal(400))
+		})
+	})
+
+	Describe("Handle requests that are not allowed", func() {
+		It("should reject users without the server admin role", func() {
+			playerClient := client.NewTestSBClient(&config.SBConfiguration{URL: ts.URL, Authorization: authHeader(player)}, ts.Client())
+
+			err := playerClient.DisableUser(admin)
+
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("requires server admin access"))
+			Expect(registered[admin].Disabled).To(BeFalse())
+		})
+
+		It("should reject users with wrong credentials", func() {
+			database.LoginUserReturns(errors.New("wrong password"))
+
+			_, err := sbClient.GetUsers()
+
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("could not authenticate user"))
+		})
+
+		It("should not let server admins act against themselves", func() {
+			err := sbClient.BanUserFromServer(admin)
+
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("server admin action failed"))
+			Expect(registered[admin].Banned).To(BeFalse())
+		})
+
+		It("should return error when the user doesn't exist", func() {
+			err := sbClient.DisableUser("missing")
+
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("doesn't exist"))
+		})
+	})
+
+	Describe("Handle disabled server admins", func() {
+		It("should reject their requests", func() {
+			registered[admin].Disabled = true
+
+			_, err := sbClient.GetUsers()
+
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("requires server admin access"))
+		})
+
+		It("should revoke their role when they are disabled", func() {
+			other := "other-admin"
+			registered[other] = &users.User{Username: other, Role: users.RoleServerAdmin}
+
+			Expect(sbClient.DisableUser(other)).To(Succeed())
+
+			Expect(registered[other].Disabled).To(BeTrue())
+			Expect(registered[other].Role).To(Equal(users.RoleUser))
+			Expect(sbServer.IsServerAdmin(other)).To(BeFalse())
+		})
+	})
+
+	Describe("Bootstrap server admins", func() {
+		BeforeEach(func() {
+			database.UserExistsStub = func(username string) (bool, error) {
+				_, ok := registered[username]
+				return ok, nil
+			}
+		})
+
+		It("should give the role to registered users only", func() {
+			Expect(sbServer.BootstrapServerAdmins([]string{player, "newcomer"})).To(Succeed())
+
+			Expect(registered[player].Role).To(Equal(users.RoleServerAdmin))
+			Expect(database.SetRoleCallCount()).To(Equal(1))
+		})
+
+		It("should not give the role to users that register later", func() {
+			Expect(sbServer.BootstrapServerAdmins([]string{"newcomer"})).To(Succeed())
+			registerServer := httptest.NewServer(http.HandlerFunc(sbServer.RegistrationHandler))
+			defer registerServer.Close()
+			newcomerClient := client.NewTestSBClient(&config.SBConfiguration{URL: registerServer.URL, Authorization: authHeader("newcomer")}, registerServer.Client())
+
+			Expect(newcomerClient.Register()).To(Succeed())
+
+			Expect(database.SetRoleCallCount()).To(Equal(0))
+		})
+	})
+
+	Describe("Handle login of a disabled user", func() {
+		It("should reject the login", func() {
+			registered[player].Disabled = true
+			sbServer.Online = []string{}
+			loginServer := httptest.NewServer(http.HandlerFunc(sbServer.LoginHandler))
+			defer loginServer.Close()
+			playerClient := client.NewTestSBClient(&config.SBConfiguration{URL: loginServer.URL, Authorization: authHeader(player)}, loginServer.Client())
+
+			err := playerClient.Login()
+
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("disabled"))
+		})
+	})
+
+	Describe("Handle logins", func() {
+		It("should record successful and failed logins in the audit log", func() {
+			auditLog := audit.NewMemoryLog()
+			sbServer.SetAuditLog(auditLog)
+			sbServer.Online = []string{}
+			loginServer := httptest.NewServer(http.HandlerFunc(sbServer.LoginHandler))
+			defer loginServer.Close()
+			playerClient := client.NewTestSBClient(&config.SBConfiguration{URL: loginServer.URL, Authorization: authHeader(player)}, loginServer.Client())
+
+			Expect(playerClient.Login()).To(Succeed())
+			sbServer.Online = []string{}
+			database.LoginUserReturns(errors.New("wrong password"))
+			Expect(playerClient.Login()).ShouldNot(Succeed())
+
+			entries, err := auditLog.Query(audit.Filter{Actor: player})
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(entries).To(HaveLen(2))
+			Expect(entries[0].Action).To(Equal(audit.Login))
+			Expect(entries[1].Action).To(Equal(audit.LoginFailed))
+			Expect(entries[1].IP).ToNot(BeEmpty())
+		})
+	})
+})
@@ -1,9 +1,21 @@
 package api
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/pavelhadzhiev/story-builder/pkg/api/game"
+	"github.com/pavelhadzhiev/story-builder/pkg/api/logging"
+	"github.com/pavelhadzhiev/story-builder/pkg/api/rooms"
 	"github.com/pavelhadzhiev/story-builder/pkg/db"
+	"github.com/pavelhadzhiev/story-builder/pkg/util"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -36,3 +48,132 @@ func TestServerCreation(t *testing.T) {
 		t.Errorf("got '%v' want '%v'", sbServer.Database, database)
 	}
 }
+
+func TestEnableTLS(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "story-builder-tls")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tempDir)
+	certFile, keyFile := filepath.Join(tempDir, "server.crt"), filepath.Join(tempDir, "server.key")
+
+	sbServer := &SBServer{}
+	if err := sbServer.EnableTLS(certFile, keyFile); err == nil {
+		t.Error("enabling TLS with missing certificate files should return error")
+	}
+
+	if err := util.GenerateSelfSignedCertificate(certFile, keyFile, []string{"localhost"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := sbServer.EnableTLS(certFile, keyFile); err != nil {
+		t.Errorf("enabling TLS with a generated certificate should not return error, got %v", err)
+	}
+	if sbServer.tlsCertFile != certFile || sbServer.tlsKeyFile != keyFile {
+		t.Error("the certificate files should be kept for Start")
+	}
+}
+
+func TestShutdownAndSnapshot(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "story-builder-snapshot")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tempDir)
+	snapshotFile := filepath.Join(tempDir, "snapshot.json")
+
+	room := rooms.NewRoom("room", "creator")
+	room.Online = append(room.Online, "creator", "player")
+	if err := room.StartGame("creator", 60, 100, 0); err != nil {
+		t.Fatal(err)
+	}
+	room.AddEntry("once upon a time", "creator")
+	sbServer := &SBServer{Rooms: []rooms.Room{*room}, Online: []string{"creator", "player"}}
+
+	if err := sbServer.Shutdown(context.Background()); err != nil {
+		t.Fatalf("shutdown without a running HTTP server should not fail: %v", err)
+	}
+	messages := sbServer.Rooms[0].GetMessages(0)
+	if len(messages) != 1 || messages[0].Author != game.SystemPlayer || messages[0].Text != shutdownAnnouncement {
+		t.Error("shutdown should be announced in the rooms")
+	}
+
+	if err := sbServer.SaveSnapshot(snapshotFile); err != nil {
+		t.Fatal(err)
+	}
+	restored := &SBServer{}
+	if _, err := restored.LoadSnapshot(snapshotFile); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(restored.Rooms) != 1 || len(restored.Online) != 2 {
+		t.Fatal("rooms and online users should be restored")
+	}
+	restoredRoom := &restored.Rooms[0]
+	restoredGame := restoredRoom.GetGame()
+	if restoredGame == nil || !restoredGame.Paused || restoredGame.Turn != "player" || len(restoredGame.Story) != 1 {
+		t.Error("the running game should be restored paused")
+	}
+	if len(restoredRoom.GetMessages(0)) != 1 {
+		t.Error("the chat should be restored")
+	}
+	if err := restoredRoom.ResumeGame("creator"); err != nil {
+		t.Errorf("admins should be able to resume the restored game: %v", err)
+	}
+	if err := restoredRoom.StartGame("creator", 60, 100, 0); err == nil {
+		t.Error("the restored game should still be running")
+	}
+}
+
+func TestLoadMissingSnapshot(t *testing.T) {
+	sbServer := &SBServer{}
+	if _, err := sbServer.LoadSnapshot(filepath.Join(os.TempDir(), "missing-story-builder-snapshot.json")); !os.IsNotExist(err) {
+		t.Errorf("loading a missing snapshot should return a not exist error, got %v", err)
+	}
+}
+
+func TestGetGameSettings(t *testing.T) {
+	sbServer := &SBServer{}
+	if sbServer.GetGameSettings() != game.DefaultSettings {
+		t.Error("servers without game settings should use the defaults")
+	}
+	sbServer.GameSettings = &game.Settings{TimeLimit: 90, MaxLength: 0, EntriesCount: 10}
+	if sbServer.GetGameSettings() != *sbServer.GameSettings {
+		t.Error("servers should use their own game settings")
+	}
+}
+
+func TestRequestLogging(t *testing.T) {
+	out := &bytes.Buffer{}
+	sbServer := &SBServer{}
+	sbServer.SetLogger(logging.NewLogger(out, logging.Info, logging.JSON))
+	var handledID string
+	handler := sbServer.logged("/rooms/", func(w http.ResponseWriter, r *http.Request) {
+		handledID = requestID(r)
+		w.WriteHeader(503)
+	})
+
+	request := httptest.NewRequest(http.MethodGet, "/rooms/tavern", nil)
+	request.Header.Set(requestIDHeader, "client-id-1")
+	recorder := httptest.NewRecorder()
+	handler(recorder, request)
+
+	if handledID != "client-id-1" || recorder.Header().Get(requestIDHeader) != "client-id-1" {
+		t.Errorf("valid request IDs provided by the client should be kept, got %q", handledID)
+	}
+	fields := make(map[string]interface{})
+	if err := json.Unmarshal(out.Bytes(), &fields); err != nil {
+		t.Fatalf("the request log should be a JSON object: %v", err)
+	}
+	if fields["level"] != "error" || fields["requestId"] != "client-id-1" || fields["route"] != "/rooms/" || fields["path"] != "/rooms/tavern" || fields["status"] != float64(503) {
+		t.Errorf("unexpected request log: %v", fields)
+	}
+
+	request = httptest.NewRequest(http.MethodGet, "/rooms/tavern", nil)
+	request.Header.Set(requestIDHeader, "not a valid id")
+	recorder = httptest.NewRecorder()
+	handler(recorder, request)
+
+	if handledID == "not a valid id" || handledID == "" || recorder.Header().Get(requestIDHeader) != handledID {
+		t.Errorf("invalid request IDs should be replaced with a generated one, got %q", handledID)
+	}
+}
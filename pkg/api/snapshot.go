@@ -0,0 +1,96 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pavelhadzhiev/story-builder/pkg/api/rooms"
+)
+
+// Snapshot holds the in-memory state of the server - the rooms with their games and chats and the online users.
+// It's written to disk when the server shuts down and restored on the next start.
+type Snapshot struct {
+	Time   time.Time         `json:"time"`
+	Online []string          `json:"online,omitempty"`
+	Rooms  []*rooms.Snapshot `json:"rooms,omitempty"`
+}
+
+// Snapshot captures the in-memory state of the server. Call it after Shutdown, so the game timers don't change the state in the meantime.
+func (sbServer *SBServer) Snapshot() *Snapshot {
+	snapshot := &Snapshot{
+		Time:   time.Now(),
+		Online: sbServer.Online,
+		Rooms:  make([]*rooms.Snapshot, 0, len(sbServer.Rooms)),
+	}
+	for index := range sbServer.Rooms {
+		snapshot.Rooms = append(snapshot.Rooms, sbServer.Rooms[index].Snapshot())
+	}
+	return snapshot
+}
+
+// Restore replaces the rooms and the online users of the server with the ones in the snapshot. Call it before Start.
+func (sbServer *SBServer) Restore(snapshot *Snapshot) {
+	sbServer.Rooms = make([]rooms.Room, 0, len(snapshot.Rooms))
+	for _, roomSnapshot := range snapshot.Rooms {
+		room := rooms.RestoreRoom(roomSnapshot)
+		room.SetVoteListener(sbServer.auditVotes(room.Name))
+		room.SetEventListener(sbServer.gameEvents(room.Name))
+		sbServer.Rooms = append(sbServer.Rooms, *room)
+	}
+	sbServer.Online = make([]string, 0, len(snapshot.Online))
+	sbServer.Online = append(sbServer.Online, snapshot.Online...)
+}
+
+// SaveSnapshot writes a snapshot of the server to the provided file as JSON, readable by the owner only.
+// The file is replaced at once, so a failed write doesn't leave a broken snapshot behind.
+func (sbServer *SBServer) SaveSnapshot(path string) error {
+	data, err := json.MarshalIndent(sbServer.Snapshot(), "", "  ")
+	if err != nil {
+		return err
+	}
+	tempFile, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tempFile.Name())
+	if _, err := tempFile.Write(data); err != nil {
+		tempFile.Close()
+		return err
+	}
+	if err := tempFile.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tempFile.Name(), path)
+}
+
+// LoadSnapshot restores the server from the snapshot in the provided file and returns the time the snapshot was taken.
+// Returns error if the file can't be read or doesn't hold a snapshot. Use os.IsNotExist to tell if there is no snapshot.
+func (sbServer *SBServer) LoadSnapshot(path string) (time.Time, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return time.Time{}, err
+	}
+	snapshot := &Snapshot{}
+	if err := json.Unmarshal(data, snapshot); err != nil {
+		return time.Time{}, err
+	}
+	sbServer.Restore(snapshot)
+	return snapshot.Time, nil
+}
@@ -0,0 +1,106 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/pavelhadzhiev/story-builder/pkg/api/health"
+	"github.com/pavelhadzhiev/story-builder/pkg/api/logging"
+)
+
+// Version is the version of the server, reported by the status endpoint. Release builds set it with
+// -ldflags "-X github.com/pavelhadzhiev/story-builder/pkg/api.Version=<version>".
+var Version = "dev"
+
+// LivezHandler is an http handler for the liveness endpoint. It responds with 200 as long as the server is able to handle requests at all.
+func (server *SBServer) LivezHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		w.WriteHeader(200)
+		w.Write([]byte("OK"))
+	default:
+		w.WriteHeader(405)
+	}
+}
+
+// ReadyzHandler is an http handler for the readiness endpoint. It responds with 200 if the database is reachable and with 503 otherwise,
+// so the server doesn't get traffic it can't serve.
+func (server *SBServer) ReadyzHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		if err := server.pingDatabase(); err != nil {
+			server.log(logging.Warn, "not ready", "requestId", requestID(r), "error", err)
+			w.WriteHeader(503)
+			w.Write([]byte("The database is not reachable."))
+			return
+		}
+		w.WriteHeader(200)
+		w.Write([]byte("OK"))
+	default:
+		w.WriteHeader(405)
+	}
+}
+
+// StatusHandler is an http handler for the status endpoint. It returns the health report of the server as JSON.
+// The status code is 200 even if the database is down - the report says so.
+func (server *SBServer) StatusHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		response, err := json.Marshal(server.Status())
+		if err != nil {
+			w.WriteHeader(500)
+			w.Write([]byte("Error while serializing the status of the server."))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(200)
+		w.Write(response)
+	default:
+		w.WriteHeader(405)
+	}
+}
+
+// Status returns the health report of the server, checking whether the database is reachable. The report is public, so it doesn't say
+// why the database is down - the reason is logged instead.
+func (server *SBServer) Status() health.Status {
+	status := health.Status{
+		Version:     Version,
+		Started:     server.started,
+		Rooms:       len(server.Rooms),
+		ActiveGames: server.activeGames(),
+		OnlineUsers: len(server.Online),
+		Database:    health.DatabaseUp,
+	}
+	if !server.started.IsZero() {
+		status.Uptime = int64(time.Since(server.started) / time.Second)
+	}
+	if err := server.pingDatabase(); err != nil {
+		server.log(logging.Warn, "database is down", "error", err)
+		status.Database = health.DatabaseDown
+	}
+	return status
+}
+
+// pingDatabase checks that the user database of the server is reachable.
+func (server *SBServer) pingDatabase() error {
+	if server.Database == nil {
+		return errors.New("the server has no database")
+	}
+	return server.Database.Ping()
+}
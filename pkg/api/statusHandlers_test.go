@@ -0,0 +1,129 @@
+package api
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pavelhadzhiev/story-builder/pkg/api/health"
+	"github.com/pavelhadzhiev/story-builder/pkg/api/rooms"
+	"github.com/pavelhadzhiev/story-builder/pkg/client"
+	"github.com/pavelhadzhiev/story-builder/pkg/config"
+	"github.com/pavelhadzhiev/story-builder/pkg/db/dbfakes"
+)
+
+var _ = Describe("Story Builder Status Handlers test", func() {
+	var sbServer *SBServer
+	var database *dbfakes.FakeUserDatabase
+
+	get := func(handler http.HandlerFunc, method string) (int, string) {
+		recorder := httptest.NewRecorder()
+		handler(recorder, httptest.NewRequest(method, "/", nil))
+		body, _ := ioutil.ReadAll(recorder.Body)
+		return recorder.Code, string(body)
+	}
+
+	BeforeEach(func() {
+		database = &dbfakes.FakeUserDatabase{}
+		sbServer = &SBServer{
+			Database: database,
+			Rooms:    []rooms.Room{*rooms.NewRoom("Test Room", "creator")},
+			Online:   []string{"creator"},
+			started:  time.Now().Add(-time.Minute),
+		}
+	})
+
+	Describe("Handle liveness request", func() {
+		It("should respond with 200 even if the database is down", func() {
+			database.PingReturns(errors.New("connection refused"))
+
+			status, _ := get(sbServer.LivezHandler, http.MethodGet)
+
+			Expect(status).To(Equal(200))
+			Expect(database.PingCallCount()).To(Equal(0))
+		})
+
+		It("should reject other methods", func() {
+			status, _ := get(sbServer.LivezHandler, http.MethodPost)
+
+			Expect(status).To(Equal(405))
+		})
+	})
+
+	Describe("Handle readiness request", func() {
+		It("should respond with 200 when the database is reachable", func() {
+			status, _ := get(sbServer.ReadyzHandler, http.MethodGet)
+
+			Expect(status).To(Equal(200))
+			Expect(database.PingCallCount()).To(Equal(1))
+		})
+
+		It("should respond with 503 when the database is not reachable", func() {
+			database.PingReturns(errors.New("connection refused"))
+
+			status, body := get(sbServer.ReadyzHandler, http.MethodGet)
+
+			Expect(status).To(Equal(503))
+			Expect(body).To(ContainSubstring("database is not reachable"))
+		})
+
+		It("should respond with 503 when the server has no database", func() {
+			sbServer.Database = nil
+
+			status, _ := get(sbServer.ReadyzHandler, http.MethodGet)
+
+			Expect(status).To(Equal(503))
+		})
+	})
+
+	Describe("Handle status request", func() {
+		var ts *httptest.Server
+		var sbClient *client.SBClient
+
+		BeforeEach(func() {
+			ts = httptest.NewServer(http.HandlerFunc(sbServer.StatusHandler))
+			sbClient = client.NewTestSBClient(&config.SBConfiguration{URL: ts.URL}, ts.Client())
+		})
+
+		AfterEach(func() {
+			ts.Close()
+		})
+
+		It("should report the state of the server", func() {
+			status, err := sbClient.GetStatus()
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(status.Version).To(Equal(Version))
+			Expect(status.UptimeDuration()).To(BeNumerically(">=", time.Minute))
+			Expect(status.Rooms).To(Equal(1))
+			Expect(status.OnlineUsers).To(Equal(1))
+			Expect(status.ActiveGames).To(Equal(0))
+			Expect(status.Database).To(Equal(health.DatabaseUp))
+			Expect(status.Healthy()).To(BeTrue())
+		})
+
+		It("should report a database that is down", func() {
+			database.PingReturns(errors.New("connection refused"))
+
+			status, err := sbClient.GetStatus()
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(status.Database).To(Equal(health.DatabaseDown))
+		})
+
+		It("should not reveal why the database is down", func() {
+			database.PingReturns(errors.New("dial tcp 10.0.0.5:3306: connection refused"))
+
+			code, body := get(sbServer.StatusHandler, http.MethodGet)
+
+			Expect(code).To(Equal(200))
+			Expect(body).To(ContainSubstring(`"database":"down"`))
+			Expect(body).NotTo(ContainSubstring("connection refused"))
+			Expect(body).NotTo(ContainSubstring("10.0.0.5"))
+		})
+	})
+})
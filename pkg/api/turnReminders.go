@@ -0,0 +1,45 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"github.com/pavelhadzhiev/story-builder/pkg/api/game"
+	"github.com/pavelhadzhiev/story-builder/pkg/api/logging"
+	"github.com/pavelhadzhiev/story-builder/pkg/api/reminders"
+)
+
+// EnableTurnReminders makes the server email players when it's their turn, through the SMTP relay in the provided settings.
+// Only players that turned on turn reminders on their profile get them.
+func (sbServer *SBServer) EnableTurnReminders(settings reminders.Settings) {
+	sbServer.reminders = reminders.NewReminders(settings, sbServer.Database)
+}
+
+// remindTurns returns an event listener that reminds the players of the games in the provided room about their turns.
+// Reminders are sent in the background, so a slow SMTP relay doesn't hold up the game.
+func (sbServer *SBServer) remindTurns(roomName string) func(event game.Event) {
+	return func(event game.Event) {
+		if sbServer.reminders == nil || event.Kind != game.TurnStarted {
+			return
+		}
+		go func() {
+			sent, err := sbServer.reminders.RemindTurn(event.Player, roomName)
+			if err != nil {
+				sbServer.log(logging.Warn, "cannot send turn reminder", "room", roomName, "user", event.Player, "error", err)
+			} else if sent {
+				sbServer.log(logging.Debug, "sent turn reminder", "room", roomName, "user", event.Player)
+			}
+		}()
+	}
+}
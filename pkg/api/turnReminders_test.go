@@ -0,0 +1,69 @@
+package api
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pavelhadzhiev/story-builder/pkg/api/reminders"
+	"github.com/pavelhadzhiev/story-builder/pkg/api/reminders/smtptest"
+	"github.com/pavelhadzhiev/story-builder/pkg/api/rooms"
+	"github.com/pavelhadzhiev/story-builder/pkg/api/users"
+	"github.com/pavelhadzhiev/story-builder/pkg/db/dbfakes"
+)
+
+var _ = Describe("Story Builder Turn Reminders test", func() {
+	var sbServer *SBServer
+	var room *rooms.Room
+	var relay *smtptest.Server
+
+	username := "username"
+	player := "test-player"
+	roomName := "Test Room"
+
+	BeforeEach(func() {
+		var err error
+		relay, err = smtptest.NewServer()
+		Expect(err).ShouldNot(HaveOccurred())
+
+		database := &dbfakes.FakeUserDatabase{}
+		database.GetProfileStub = func(user string) (*users.Profile, error) {
+			return &users.Profile{Username: user, Email: user + "@example.com", TurnReminders: user == player}, nil
+		}
+
+		sbServer = &SBServer{
+			Database: database,
+			Rooms:    make([]rooms.Room, 0),
+			Online:   make([]string, 0),
+		}
+		sbServer.EnableTurnReminders(reminders.Settings{Host: relay.Host, Port: relay.Port, From: "noreply@example.com", Interval: time.Minute})
+		Expect(sbServer.CreateNewRoom(rooms.NewRoom(roomName, username))).To(Succeed())
+		room, _ = sbServer.GetRoom(roomName)
+		room.Online = append(room.Online, username, player)
+	})
+
+	AfterEach(func() {
+		relay.Close()
+	})
+
+	It("should remind the players who opted in when it's their turn", func() {
+		Expect(room.StartGame(username, 60, 100, 0)).To(Succeed())
+		Consistently(relay.Messages, 100*time.Millisecond).Should(BeEmpty())
+
+		Expect(room.AddEntry("Once upon a time", username)).To(Succeed())
+		Eventually(relay.Messages).Should(HaveLen(1))
+		message := relay.Messages()[0]
+		Expect(message.To).To(Equal([]string{player + "@example.com"}))
+		Expect(message.Data).To(ContainSubstring("Subject: Your turn in Test Room"))
+	})
+
+	It("should throttle reminders about the same room", func() {
+		Expect(room.StartGame(username, 60, 100, 0)).To(Succeed())
+
+		Expect(room.AddEntry("Once upon a time", username)).To(Succeed())
+		Eventually(relay.Messages).Should(HaveLen(1))
+		Expect(room.AddEntry("there was a dragon", player)).To(Succeed())
+		Expect(room.AddEntry("who liked stories", username)).To(Succeed())
+		Consistently(relay.Messages, 100*time.Millisecond).Should(HaveLen(1))
+	})
+})
@@ -0,0 +1,90 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package users
+
+import (
+	"fmt"
+	"net/mail"
+)
+
+// MaxDisplayNameLength is the maximum number of symbols in a display name.
+const MaxDisplayNameLength = 64
+
+// MaxBioLength is the maximum number of symbols in a bio.
+const MaxBioLength = 500
+
+// MaxEmailLength is the maximum length of an email address, as limited by SMTP.
+const MaxEmailLength = 254
+
+// Profile represents the information a user shares about themselves. The email address and the notification settings are private -
+// only the user can see them.
+type Profile struct {
+	Username    string `json:"username"`
+	DisplayName string `json:"displayName,omitempty"`
+	Bio         string `json:"bio,omitempty"`
+
+	Email string `json:"email,omitempty"`
+	// TurnReminders is true if the user wants an email when it's their turn. Setting it to false unsubscribes them.
+	TurnReminders bool `json:"turnReminders,omitempty"`
+}
+
+// Validate returns error if the display name or the bio of the profile are too long, the email address is not valid,
+// or turn reminders are turned on without an email address.
+func (profile Profile) Validate() error {
+	if len([]rune(profile.DisplayName)) > MaxDisplayNameLength {
+		return fmt.Errorf("display name is longer than %d symbols", MaxDisplayNameLength)
+	}
+	if len([]rune(profile.Bio)) > MaxBioLength {
+		return fmt.Errorf("bio is longer than %d symbols", MaxBioLength)
+	}
+	if profile.Email != "" {
+		if len(profile.Email) > MaxEmailLength {
+			return fmt.Errorf("email address is longer than %d symbols", MaxEmailLength)
+		}
+		if address, err := mail.ParseAddress(profile.Email); err != nil || address.Address != profile.Email {
+			return fmt.Errorf("\"%s\" is not a valid email address", profile.Email)
+		}
+	}
+	if profile.TurnReminders && profile.Email == "" {
+		return fmt.Errorf("turn reminders require an email address")
+	}
+	return nil
+}
+
+// Public returns a copy of the profile without its private information, as other users see it.
+func (profile Profile) Public() Profile {
+	profile.Email = ""
+	profile.TurnReminders = false
+	return profile
+}
+
+func (profile Profile) String() string {
+	profileString := fmt.Sprintf("Username: %s\n", profile.Username)
+	if profile.DisplayName != "" {
+		profileString += fmt.Sprintf("Display name: %s\n", profile.DisplayName)
+	}
+	if profile.Bio != "" {
+		profileString += fmt.Sprintf("Bio: %s\n", profile.Bio)
+	}
+	if profile.Email != "" {
+		profileString += fmt.Sprintf("Email: %s\n", profile.Email)
+		reminders := "off"
+		if profile.TurnReminders {
+			reminders = "on"
+		}
+		profileString += fmt.Sprintf("Turn reminders: %s\n", reminders)
+	}
+	return profileString
+}
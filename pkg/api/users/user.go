@@ -0,0 +1,61 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package users
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RoleUser is the role of regular users.
+const RoleUser = "user"
+
+// RoleServerAdmin is the role of server administrators, who can manage all users and rooms of the server.
+const RoleServerAdmin = "server-admin"
+
+// User represents a story builder account, as seen by server administrators.
+// Disabled accounts can't log in. Banned users can log in, but can't create or join any room on the server.
+type User struct {
+	Username string `json:"username"`
+	Role     string `json:"role,omitempty"`
+	Disabled bool   `json:"disabled,omitempty"`
+	Banned   bool   `json:"banned,omitempty"`
+	Online   bool   `json:"online,omitempty"`
+}
+
+// IsServerAdmin returns true if the user has the server administrator role.
+func (user User) IsServerAdmin() bool {
+	return user.Role == RoleServerAdmin
+}
+
+func (user User) String() string {
+	flags := make([]string, 0)
+	if user.IsServerAdmin() {
+		flags = append(flags, "server admin")
+	}
+	if user.Online {
+		flags = append(flags, "online")
+	}
+	if user.Disabled {
+		flags = append(flags, "disabled")
+	}
+	if user.Banned {
+		flags = append(flags, "banned")
+	}
+	if len(flags) == 0 {
+		return user.Username
+	}
+	return fmt.Sprintf("%s (%s)", user.Username, strings.Join(flags, ", "))
+}
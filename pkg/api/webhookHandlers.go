@@ -0,0 +1,168 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/pavelhadzhiev/story-builder/pkg/api/audit"
+	"github.com/pavelhadzhiev/story-builder/pkg/api/webhooks"
+)
+
+// ConfigureWebhooks replaces the settings of webhook deliveries, e.g. to allow receivers on the local network. Call it before Start.
+func (sbServer *SBServer) ConfigureWebhooks(settings webhooks.Settings) {
+	sbServer.dispatcher = webhooks.NewDispatcher(settings)
+}
+
+// WebhookHandler is an http handler for the story builder's webhooks API. Only the admins of a room, while in the room, can manage its
+// webhooks and read their delivery log. The routes are "/webhooks/<room>", "/webhooks/<room>/<id>" and "/webhooks/<room>/<id>/deliveries".
+func (server *SBServer) WebhookHandler(w http.ResponseWriter, r *http.Request) {
+	urlSuffix := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/webhooks/"), "/")
+	urlSuffixSplit := strings.Split(urlSuffix, "/")
+	if urlSuffixSplit[0] == "" || len(urlSuffixSplit) > 3 || (len(urlSuffixSplit) == 3 && urlSuffixSplit[2] != "deliveries") {
+		w.WriteHeader(400)
+		w.Write([]byte("Request URL is illegal."))
+		return
+	}
+	roomName := urlSuffixSplit[0]
+
+	room, err := server.GetRoom(roomName)
+	if err != nil {
+		w.WriteHeader(404)
+		w.Write([]byte("Room \"" + roomName + "\" doesn't exist."))
+		return
+	}
+
+	issuer, ok := server.authenticate(w, r)
+	if !ok {
+		return
+	}
+	if !room.IsAdmin(issuer) || !room.IsOnline(issuer) {
+		w.WriteHeader(403)
+		w.Write([]byte("You don't have admin access for room \"" + roomName + "\"."))
+		return
+	}
+
+	if len(urlSuffixSplit) == 1 {
+		switch r.Method {
+		case http.MethodGet:
+			roomWebhooks, err := room.GetWebhooks(issuer)
+			if err != nil {
+				w.WriteHeader(403)
+				w.Write([]byte(fmt.Sprintf("Webhooks cannot be listed: %v.", err)))
+				return
+			}
+			responseBody, err := json.Marshal(roomWebhooks)
+			if err != nil {
+				w.WriteHeader(500)
+				w.Write([]byte("Error during serialization of webhooks."))
+				return
+			}
+			w.Write(responseBody)
+		case http.MethodPost:
+			var webhook = webhooks.Webhook{}
+			defer r.Body.Close()
+			if err := json.NewDecoder(r.Body).Decode(&webhook); err != nil {
+				w.WriteHeader(400)
+				w.Write([]byte("Webhook must have a URL."))
+				return
+			}
+			if err := webhook.Validate(); err != nil {
+				w.WriteHeader(400)
+				w.Write([]byte(fmt.Sprintf("Webhook cannot be added: %v.", err)))
+				return
+			}
+			if err := server.dispatcher.CheckURL(webhook.URL); err != nil {
+				w.WriteHeader(400)
+				w.Write([]byte(fmt.Sprintf("Webhook cannot be added: %v.", err)))
+				return
+			}
+			if webhook.Secret == "" {
+				if webhook.Secret, err = webhooks.NewSecret(); err != nil {
+					w.WriteHeader(500)
+					w.Write([]byte("Error during generation of webhook secret."))
+					return
+				}
+			}
+			added, err := room.AddWebhook(webhook, issuer)
+			if err != nil {
+				w.WriteHeader(400)
+				w.Write([]byte(fmt.Sprintf("Webhook cannot be added: %v.", err)))
+				return
+			}
+			server.audit(r, audit.Entry{Action: audit.WebhookAdded, Actor: issuer, Room: roomName, Details: added.Redacted().String()})
+
+			responseBody, err := json.Marshal(added)
+			if err != nil {
+				w.WriteHeader(500)
+				w.Write([]byte("Error during serialization of added webhook."))
+				return
+			}
+			w.WriteHeader(201)
+			w.Write(responseBody)
+		default:
+			w.WriteHeader(405)
+		}
+		return
+	}
+
+	id, err := strconv.Atoi(urlSuffixSplit[1])
+	if err != nil || id <= 0 {
+		w.WriteHeader(400)
+		w.Write([]byte("Webhook ID is illegal."))
+		return
+	}
+	if !room.HasWebhook(id) {
+		w.WriteHeader(404)
+		w.Write([]byte(fmt.Sprintf("Webhook #%d doesn't exist in room \"%s\".", id, roomName)))
+		return
+	}
+
+	if len(urlSuffixSplit) == 3 {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(405)
+			return
+		}
+		deliveries := make([]webhooks.Delivery, 0)
+		if server.dispatcher != nil {
+			deliveries = server.dispatcher.Deliveries(roomName, id)
+		}
+		responseBody, err := json.Marshal(deliveries)
+		if err != nil {
+			w.WriteHeader(500)
+			w.Write([]byte("Error during serialization of webhook deliveries."))
+			return
+		}
+		w.Write(responseBody)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodDelete:
+		if err := room.RemoveWebhook(id, issuer); err != nil {
+			w.WriteHeader(400)
+			w.Write([]byte(fmt.Sprintf("Webhook cannot be removed: %v.", err)))
+			return
+		}
+		server.audit(r, audit.Entry{Action: audit.WebhookRemoved, Actor: issuer, Room: roomName, Details: fmt.Sprintf("webhook #%d", id)})
+		w.WriteHeader(204)
+	default:
+		w.WriteHeader(405)
+	}
+}
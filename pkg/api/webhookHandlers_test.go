@@ -0,0 +1,215 @@
+package api
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pavelhadzhiev/story-builder/pkg/api/rooms"
+	"github.com/pavelhadzhiev/story-builder/pkg/api/webhooks"
+	"github.com/pavelhadzhiev/story-builder/pkg/client"
+	"github.com/pavelhadzhiev/story-builder/pkg/config"
+	"github.com/pavelhadzhiev/story-builder/pkg/db/dbfakes"
+)
+
+var _ = Describe("Story Builder Webhook Handlers test", func() {
+	var sbClient *client.SBClient
+	var clientConfig *config.SBConfiguration
+	var sbServer *SBServer
+	var room *rooms.Room
+	var ts *httptest.Server
+
+	var receiver *httptest.Server
+	var receivedMutex sync.Mutex
+	var received map[string]webhooks.Payload
+	var signatures []bool
+
+	username := "username"
+	password := "password"
+	authHeader := "Basic " + base64.StdEncoding.EncodeToString([]byte(username+":"+password))
+
+	roomName := "Test Room"
+	player := "test-player"
+	secret := "test-secret"
+
+	BeforeEach(func() {
+		received = make(map[string]webhooks.Payload)
+		signatures = make([]bool, 0)
+		receiver = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := ioutil.ReadAll(r.Body)
+			var payload webhooks.Payload
+			json.Unmarshal(body, &payload)
+
+			receivedMutex.Lock()
+			defer receivedMutex.Unlock()
+			received[payload.Event] = payload
+			signatures = append(signatures, webhooks.Verify(secret, body, r.Header.Get(webhooks.SignatureHeader)))
+		}))
+
+		dispatcher := webhooks.NewDispatcher(webhooks.Settings{AllowedNetworks: []string{"127.0.0.1"}})
+		dispatcher.Backoff = 10 * time.Millisecond
+		sbServer = &SBServer{
+			Database:   &dbfakes.FakeUserDatabase{},
+			Rooms:      make([]rooms.Room, 0),
+			Online:     make([]string, 0),
+			dispatcher: dispatcher,
+		}
+		Expect(sbServer.CreateNewRoom(rooms.NewRoom(roomName, username))).To(Succeed())
+		room, _ = sbServer.GetRoom(roomName)
+		room.Online = append(room.Online, username, player)
+
+		ts = httptest.NewServer(http.HandlerFunc(sbServer.WebhookHandler))
+		clientConfig = &config.SBConfiguration{URL: ts.URL, Authorization: authHeader, Room: roomName}
+		sbClient = client.NewTestSBClient(clientConfig, ts.Client())
+	})
+
+	AfterEach(func() {
+		sbServer.dispatcher.Wait()
+		receiver.Close()
+		ts.Close()
+	})
+
+	Describe("Specifically add webhook request", func() {
+		Context("When request is valid", func() {
+			It("should add the webhook and return it with its secret", func() {
+				webhook, err := sbClient.AddWebhook(receiver.URL, []string{"game.started"}, secret)
+
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(webhook.ID).To(Equal(1))
+				Expect(webhook.Secret).To(Equal(secret))
+				Expect(webhook.Creator).To(Equal(username))
+				Expect(room.HasWebhook(1)).To(BeTrue())
+			})
+
+			It("should generate a secret when none is provided", func() {
+				webhook, err := sbClient.AddWebhook(receiver.URL, nil, "")
+
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(webhook.Secret).To(HaveLen(64))
+			})
+		})
+
+		Context("When the webhook is invalid", func() {
+			It("should return error", func() {
+				_, err := sbClient.AddWebhook(receiver.URL, []string{"game.paused"}, "")
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("unknown event \"game.paused\""))
+			})
+		})
+
+		Context("When the URL resolves to a blocked address", func() {
+			It("should return error", func() {
+				sbServer.ConfigureWebhooks(webhooks.DefaultSettings)
+
+				_, err := sbClient.AddWebhook(receiver.URL, nil, "")
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("address 127.0.0.1 is not allowed"))
+			})
+		})
+
+		Context("When the user is not an admin of the room", func() {
+			It("should return error", func() {
+				playerConfig := &config.SBConfiguration{URL: ts.URL, Authorization: "Basic " + base64.StdEncoding.EncodeToString([]byte(player+":"+password)), Room: roomName}
+				playerClient := client.NewTestSBClient(playerConfig, ts.Client())
+
+				_, err := playerClient.AddWebhook(receiver.URL, nil, "")
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("permissions to manage webhooks"))
+			})
+		})
+
+		Context("When the password of the room admin is wrong", func() {
+			It("should return error and not add the webhook", func() {
+				sbServer.Database.(*dbfakes.FakeUserDatabase).LoginUserReturns(errors.New("wrong password"))
+
+				_, err := sbClient.AddWebhook(receiver.URL, nil, "")
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("could not authenticate user"))
+				Expect(room.HasWebhook(1)).To(BeFalse())
+			})
+		})
+
+		Context("When the room doesn't exist", func() {
+			It("should return error", func() {
+				otherConfig := &config.SBConfiguration{URL: ts.URL, Authorization: authHeader, Room: "Other Room"}
+				otherClient := client.NewTestSBClient(otherConfig, ts.Client())
+
+				_, err := otherClient.AddWebhook(receiver.URL, nil, "")
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("doesn't exist"))
+			})
+		})
+	})
+
+	Describe("Specifically list webhooks request", func() {
+		It("should return the webhooks without their secrets", func() {
+			sbClient.AddWebhook(receiver.URL, nil, secret)
+
+			result, err := sbClient.GetWebhooks()
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(result).To(HaveLen(1))
+			Expect(result[0].URL).To(Equal(receiver.URL))
+			Expect(result[0].Secret).To(BeEmpty())
+		})
+	})
+
+	Describe("Specifically remove webhook request", func() {
+		Context("When the webhook exists", func() {
+			It("should remove it", func() {
+				sbClient.AddWebhook(receiver.URL, nil, secret)
+
+				Expect(sbClient.RemoveWebhook(1)).To(Succeed())
+				Expect(room.HasWebhook(1)).To(BeFalse())
+			})
+		})
+
+		Context("When the webhook doesn't exist", func() {
+			It("should return error", func() {
+				err := sbClient.RemoveWebhook(42)
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("Webhook #42 doesn't exist"))
+			})
+		})
+	})
+
+	Describe("Delivery of game events", func() {
+		It("should deliver the subscribed events of the room's games, signed with the secret", func() {
+			sbClient.AddWebhook(receiver.URL, []string{"game.started", "turn.started"}, secret)
+			sbClient.AddWebhook(receiver.URL, []string{"game.finished"}, secret)
+
+			Expect(room.StartGame(username, 60, 100, 0)).To(Succeed())
+			sbServer.dispatcher.Wait()
+
+			receivedMutex.Lock()
+			defer receivedMutex.Unlock()
+			Expect(received).To(HaveLen(2))
+			Expect(received["game.started"].Room).To(Equal(roomName))
+			Expect(received["game.started"].Players).To(Equal([]string{username, player}))
+			Expect(received["turn.started"].Player).To(Equal(username))
+			Expect(signatures).To(Equal([]bool{true, true}))
+
+			deliveries, err := sbClient.GetWebhookDeliveries(1)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(deliveries).To(HaveLen(2))
+			Expect(deliveries[0].Status).To(Equal(webhooks.Delivered))
+
+			deliveries, err = sbClient.GetWebhookDeliveries(2)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(deliveries).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,229 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhooks
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultMaxAttempts is the number of times a delivery is attempted before it fails for good.
+	DefaultMaxAttempts = 5
+	// DefaultBackoff is the wait before the first retry of a delivery. Every further retry waits twice as long as the previous one.
+	DefaultBackoff = 2 * time.Second
+
+	// requestTimeout is the time a receiver has to respond to a delivery.
+	requestTimeout = 10 * time.Second
+	// maxDeliveries is the number of deliveries the log keeps for every room - older deliveries are dropped.
+	maxDeliveries = 100
+)
+
+// DeliveryStatus is the state of a delivery.
+type DeliveryStatus string
+
+const (
+	// Pending deliveries are being attempted or wait for a retry.
+	Pending DeliveryStatus = "pending"
+	// Delivered deliveries were accepted by the receiver with a 2xx response.
+	Delivered DeliveryStatus = "delivered"
+	// Failed deliveries were rejected by the receiver or ran out of attempts.
+	Failed DeliveryStatus = "failed"
+)
+
+// Delivery is an entry of the delivery log - an event sent to a webhook, with the outcome of its last attempt.
+type Delivery struct {
+	ID         int            `json:"id"`
+	Webhook    int            `json:"webhook"`
+	Event      string         `json:"event"`
+	Time       time.Time      `json:"time"`
+	Status     DeliveryStatus `json:"status"`
+	Attempts   int            `json:"attempts"`
+	StatusCode int            `json:"statusCode,omitempty"`
+	Error      string         `json:"error,omitempty"`
+}
+
+func (delivery Delivery) String() string {
+	deliveryString := fmt.Sprintf("#%d [%s] %s to webhook #%d: %s after %d attempt(s)",
+		delivery.ID, delivery.Time.Local().Format("2006-01-02 15:04:05"), delivery.Event, delivery.Webhook, delivery.Status, delivery.Attempts)
+	if delivery.StatusCode != 0 {
+		deliveryString += fmt.Sprintf(", last response %d", delivery.StatusCode)
+	}
+	if delivery.Error != "" {
+		deliveryString += " (" + delivery.Error + ")"
+	}
+	return deliveryString
+}
+
+// Dispatcher delivers events to webhooks in the background, retrying failed deliveries with exponential backoff, and keeps a log of
+// the deliveries of every room in memory. It's safe for concurrent use.
+type Dispatcher struct {
+	// MaxAttempts is the number of times a delivery is attempted before it fails for good.
+	MaxAttempts int
+	// Backoff is the wait before the first retry of a delivery, doubled for every further retry.
+	Backoff time.Duration
+
+	client     *http.Client
+	guard      *guard
+	mutex      sync.Mutex
+	deliveries map[string][]*Delivery
+	lastID     int
+	inFlight   sync.WaitGroup
+}
+
+// NewDispatcher returns a dispatcher with the default attempts and backoff. It doesn't follow redirects, which count as failures,
+// and refuses to connect to blocked addresses, unless the provided settings allow them.
+func NewDispatcher(settings Settings) *Dispatcher {
+	guard := newGuard(settings)
+	dialer := &net.Dialer{Timeout: requestTimeout, Control: guard.control}
+	return &Dispatcher{
+		MaxAttempts: DefaultMaxAttempts,
+		Backoff:     DefaultBackoff,
+
+		client: &http.Client{
+			Timeout:   requestTimeout,
+			Transport: &http.Transport{DialContext: dialer.DialContext},
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		guard:      guard,
+		deliveries: make(map[string][]*Delivery),
+	}
+}
+
+// CheckURL returns error if the host of the URL can't be resolved or resolves to a blocked address, e.g. a loopback, private or
+// link-local one. Deliveries are checked again when they connect, in case the host resolves to another address by then.
+func (dispatcher *Dispatcher) CheckURL(rawURL string) error {
+	return dispatcher.guard.checkURL(rawURL)
+}
+
+// Dispatch records a pending delivery of the payload to the webhook in the log of the provided room and delivers it in the background.
+// Returns the delivery, or error if the payload can't be serialized.
+func (dispatcher *Dispatcher) Dispatch(room string, webhook Webhook, payload Payload) (Delivery, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return Delivery{}, err
+	}
+
+	dispatcher.mutex.Lock()
+	dispatcher.lastID++
+	delivery := &Delivery{ID: dispatcher.lastID, Webhook: webhook.ID, Event: payload.Event, Time: time.Now(), Status: Pending}
+	roomDeliveries := append(dispatcher.deliveries[room], delivery)
+	if len(roomDeliveries) > maxDeliveries {
+		roomDeliveries = roomDeliveries[len(roomDeliveries)-maxDeliveries:]
+	}
+	dispatcher.deliveries[room] = roomDeliveries
+	dispatched := *delivery
+	dispatcher.mutex.Unlock()
+
+	dispatcher.inFlight.Add(1)
+	go func() {
+		defer dispatcher.inFlight.Done()
+		dispatcher.deliver(delivery, webhook, body)
+	}()
+	return dispatched, nil
+}
+
+// deliver attempts the delivery until it succeeds, the receiver rejects it or the attempts run out.
+func (dispatcher *Dispatcher) deliver(delivery *Delivery, webhook Webhook, body []byte) {
+	backoff := dispatcher.Backoff
+	for attempt := 1; ; attempt++ {
+		statusCode, err := dispatcher.send(delivery, webhook, body)
+
+		dispatcher.mutex.Lock()
+		delivery.Attempts = attempt
+		delivery.StatusCode = statusCode
+		delivery.Error = ""
+		if err != nil {
+			delivery.Error = err.Error()
+		}
+		retry := isRetryable(statusCode, err) && attempt < dispatcher.MaxAttempts
+		switch {
+		case err == nil && statusCode >= 200 && statusCode < 300:
+			delivery.Status = Delivered
+		case !retry:
+			delivery.Status = Failed
+		}
+		dispatcher.mutex.Unlock()
+
+		if !retry {
+			return
+		}
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+// send posts the body to the webhook once and returns the status code of the response.
+func (dispatcher *Dispatcher) send(delivery *Delivery, webhook Webhook, body []byte) (int, error) {
+	req, err := http.NewRequest(http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, delivery.Event)
+	req.Header.Set(DeliveryHeader, strconv.Itoa(delivery.ID))
+	req.Header.Set(SignatureHeader, Sign(webhook.Secret, body))
+
+	resp, err := dispatcher.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	resp.Body.Close()
+	return resp.StatusCode, nil
+}
+
+// isRetryable returns true if an attempt failed in a way that may not repeat - the receiver couldn't be reached, timed out, failed
+// or asked to slow down. Successful attempts are not retried.
+func isRetryable(statusCode int, err error) bool {
+	if err != nil {
+		return true
+	}
+	return statusCode == http.StatusRequestTimeout || statusCode == http.StatusTooManyRequests || statusCode >= 500
+}
+
+// Deliveries returns the deliveries to the webhook with the provided ID from the log of the provided room, oldest first.
+// Pass 0 to get the deliveries to all webhooks of the room.
+func (dispatcher *Dispatcher) Deliveries(room string, webhookID int) []Delivery {
+	dispatcher.mutex.Lock()
+	defer dispatcher.mutex.Unlock()
+
+	deliveries := make([]Delivery, 0)
+	for _, delivery := range dispatcher.deliveries[room] {
+		if webhookID == 0 || delivery.Webhook == webhookID {
+			deliveries = append(deliveries, *delivery)
+		}
+	}
+	return deliveries
+}
+
+// Forget drops the delivery log of the provided room. Deliveries in progress are not stopped.
+func (dispatcher *Dispatcher) Forget(room string) {
+	dispatcher.mutex.Lock()
+	defer dispatcher.mutex.Unlock()
+	delete(dispatcher.deliveries, room)
+}
+
+// Wait blocks until all deliveries are done, including their retries.
+func (dispatcher *Dispatcher) Wait() {
+	dispatcher.inFlight.Wait()
+}
@@ -0,0 +1,74 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhooks
+
+import (
+	"time"
+
+	"github.com/pavelhadzhiev/story-builder/pkg/api/game"
+)
+
+// Payload is the JSON body of the request that delivers an event.
+type Payload struct {
+	Event  string    `json:"event"`
+	Room   string    `json:"room"`
+	Time   time.Time `json:"time"`
+	GameID int       `json:"gameId"`
+	// Players are the players of the game.
+	Players []string `json:"players,omitempty"`
+	// Player is the player whose turn has started, for "turn.started" events.
+	Player string `json:"player,omitempty"`
+	// Team is the team of the player whose turn has started, for "turn.started" events of team games.
+	Team string `json:"team,omitempty"`
+	// Story is the finished story, for "game.finished" events. Team games have a story for every team instead.
+	Story []game.Entry `json:"story,omitempty"`
+	// Teams are the teams with their stories, for "game.finished" events of team games.
+	Teams []TeamStory `json:"teams,omitempty"`
+	// Winner is the team whose story won, for "game.finished" events of team games.
+	Winner string `json:"winner,omitempty"`
+}
+
+// TeamStory is the story of a team in a finished team game.
+type TeamStory struct {
+	Name    string       `json:"name"`
+	Players []string     `json:"players,omitempty"`
+	Story   []game.Entry `json:"story,omitempty"`
+}
+
+// NewPayload returns the payload of an event of a game in the provided room. It copies what it needs from the game, so the game can
+// change while the payload is being delivered.
+func NewPayload(room string, event game.Event) Payload {
+	payload := Payload{
+		Event:   string(event.Kind),
+		Room:    room,
+		Time:    time.Now(),
+		GameID:  event.Game.ID,
+		Players: append([]string(nil), event.Game.Players...),
+		Player:  event.Player,
+		Team:    event.Team,
+	}
+	if event.Kind == game.GameFinished {
+		payload.Story = append([]game.Entry(nil), event.Game.Story...)
+		for _, team := range event.Game.Teams {
+			payload.Teams = append(payload.Teams, TeamStory{
+				Name:    team.Name,
+				Players: append([]string(nil), team.Players...),
+				Story:   append([]game.Entry(nil), team.Story...),
+			})
+		}
+		payload.Winner = event.Game.Winner
+	}
+	return payload
+}
@@ -0,0 +1,156 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"syscall"
+)
+
+// Settings configure the receivers that webhooks may deliver to.
+type Settings struct {
+	// AllowedNetworks are IP addresses and CIDR ranges, e.g. "127.0.0.1" or "10.0.0.0/8", that webhooks may deliver to even though
+	// they are blocked by default. Use them for local receivers and tests.
+	AllowedNetworks []string `json:"allowedNetworks,omitempty"`
+}
+
+// DefaultSettings are the webhook settings used, unless the server is configured otherwise.
+var DefaultSettings = Settings{}
+
+// blockedNetworks are the networks webhooks can't deliver to, unless they are allowed in the settings - loopback, private,
+// link-local (including the cloud metadata address 169.254.169.254), shared and unspecified addresses.
+var blockedNetworks = parseNetworks(
+	"0.0.0.0/8",
+	"10.0.0.0/8",
+	"100.64.0.0/10",
+	"127.0.0.0/8",
+	"169.254.0.0/16",
+	"172.16.0.0/12",
+	"192.168.0.0/16",
+	"::/128",
+	"::1/128",
+	"fc00::/7",
+	"fe80::/10",
+)
+
+func parseNetworks(networks ...string) []*net.IPNet {
+	parsed := make([]*net.IPNet, 0, len(networks))
+	for _, network := range networks {
+		ipNet, err := parseNetwork(network)
+		if err != nil {
+			panic(err)
+		}
+		parsed = append(parsed, ipNet)
+	}
+	return parsed
+}
+
+// parseNetwork parses a CIDR range or a single IP address, which is treated as a range of its own.
+func parseNetwork(network string) (*net.IPNet, error) {
+	if !strings.Contains(network, "/") {
+		ip := net.ParseIP(network)
+		if ip == nil {
+			return nil, fmt.Errorf("\"%s\" is not a valid IP address or CIDR range", network)
+		}
+		if ip4 := ip.To4(); ip4 != nil {
+			return &net.IPNet{IP: ip4, Mask: net.CIDRMask(32, 32)}, nil
+		}
+		return &net.IPNet{IP: ip, Mask: net.CIDRMask(128, 128)}, nil
+	}
+	_, ipNet, err := net.ParseCIDR(network)
+	if err != nil {
+		return nil, fmt.Errorf("\"%s\" is not a valid IP address or CIDR range", network)
+	}
+	return ipNet, nil
+}
+
+// Validate returns error if any of the allowed networks is not an IP address or a CIDR range.
+func (settings Settings) Validate() error {
+	for _, network := range settings.AllowedNetworks {
+		if _, err := parseNetwork(network); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// guard decides which IP addresses webhooks may deliver to.
+type guard struct {
+	allowed []*net.IPNet
+}
+
+func newGuard(settings Settings) *guard {
+	allowed := make([]*net.IPNet, 0, len(settings.AllowedNetworks))
+	for _, network := range settings.AllowedNetworks {
+		if ipNet, err := parseNetwork(network); err == nil {
+			allowed = append(allowed, ipNet)
+		}
+	}
+	return &guard{allowed: allowed}
+}
+
+// checkIP returns error if the IP address is blocked and not allowed.
+func (guard *guard) checkIP(ip net.IP) error {
+	for _, ipNet := range guard.allowed {
+		if ipNet.Contains(ip) {
+			return nil
+		}
+	}
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+	}
+	for _, ipNet := range blockedNetworks {
+		if ipNet.Contains(ip) {
+			return fmt.Errorf("address %s is not allowed", ip)
+		}
+	}
+	return nil
+}
+
+// checkURL resolves the host of the URL and returns error if any of its addresses is blocked or the host can't be resolved.
+func (guard *guard) checkURL(rawURL string) error {
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+	ips, err := net.DefaultResolver.LookupIPAddr(context.Background(), parsedURL.Hostname())
+	if err != nil {
+		return fmt.Errorf("cannot resolve host \"%s\"", parsedURL.Hostname())
+	}
+	for _, ip := range ips {
+		if err := guard.checkIP(ip.IP); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// control checks the address a connection is about to be made to, after the host is resolved. Checking the address at dial time
+// rather than when the webhook is added stops hosts that resolve to an allowed address first and to a blocked one later.
+func (guard *guard) control(network, address string, conn syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return fmt.Errorf("address %s is not an IP address", host)
+	}
+	return guard.checkIP(ip)
+}
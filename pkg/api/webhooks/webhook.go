@@ -0,0 +1,124 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pavelhadzhiev/story-builder/pkg/api/game"
+)
+
+// Events lists the names of all events that webhooks can subscribe to.
+var Events = []string{string(game.GameStarted), string(game.TurnStarted), string(game.GameFinished)}
+
+// Headers of the requests that deliver events.
+const (
+	// EventHeader holds the name of the delivered event.
+	EventHeader = "X-Story-Builder-Event"
+	// DeliveryHeader holds the ID of the delivery. Retries of a delivery have the same ID.
+	DeliveryHeader = "X-Story-Builder-Delivery"
+	// SignatureHeader holds "sha256=" followed by the hex-encoded HMAC-SHA256 of the request body, keyed with the secret of the webhook.
+	SignatureHeader = "X-Story-Builder-Signature"
+)
+
+// Webhook is a subscription of a room to its events. Every event the webhook subscribes to is posted to its URL as a signed JSON payload.
+type Webhook struct {
+	ID  int    `json:"id"`
+	URL string `json:"url"`
+	// Events are the names of the events the webhook subscribes to. A webhook without events subscribes to all events.
+	Events []string `json:"events,omitempty"`
+	// Secret is the key of the payload signatures. It's returned only when the webhook is added.
+	Secret  string    `json:"secret,omitempty"`
+	Creator string    `json:"creator,omitempty"`
+	Created time.Time `json:"created,omitempty"`
+}
+
+func (webhook Webhook) String() string {
+	events := "all events"
+	if len(webhook.Events) > 0 {
+		events = strings.Join(webhook.Events, ", ")
+	}
+	return fmt.Sprintf("#%d %s (%s)", webhook.ID, webhook.URL, events)
+}
+
+// Validate returns error if the webhook doesn't have an absolute http or https URL or subscribes to an unknown event.
+func (webhook Webhook) Validate() error {
+	parsedURL, err := url.Parse(webhook.URL)
+	if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") || parsedURL.Host == "" {
+		return fmt.Errorf("URL \"%s\" is not a valid http or https URL", webhook.URL)
+	}
+	for _, event := range webhook.Events {
+		if !isEvent(event) {
+			return fmt.Errorf("unknown event \"%s\", must be one of: %s", event, strings.Join(Events, ", "))
+		}
+	}
+	return nil
+}
+
+// Subscribes returns true if the webhook subscribes to the event with the provided name.
+func (webhook Webhook) Subscribes(event string) bool {
+	if len(webhook.Events) == 0 {
+		return true
+	}
+	for _, subscribed := range webhook.Events {
+		if subscribed == event {
+			return true
+		}
+	}
+	return false
+}
+
+// Redacted returns a copy of the webhook without its secret.
+func (webhook Webhook) Redacted() Webhook {
+	webhook.Secret = ""
+	return webhook
+}
+
+func isEvent(name string) bool {
+	for _, event := range Events {
+		if event == name {
+			return true
+		}
+	}
+	return false
+}
+
+// NewSecret returns a random secret for signing payloads.
+func NewSecret() (string, error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(bytes), nil
+}
+
+// Sign returns the signature of the body, as sent in the SignatureHeader.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify returns true if the signature matches the body. Receivers can use it to check that a delivery comes from the server.
+func Verify(secret string, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, body)), []byte(signature))
+}
@@ -0,0 +1,308 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pavelhadzhiev/story-builder/pkg/api/game"
+)
+
+const secret = "secret"
+const room = "tavern"
+
+// receiver is a webhook endpoint that records the deliveries it gets and responds with the next of its status codes.
+type receiver struct {
+	mutex       sync.Mutex
+	statusCodes []int
+	requests    []*http.Request
+	bodies      [][]byte
+}
+
+func (receiver *receiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	receiver.mutex.Lock()
+	defer receiver.mutex.Unlock()
+
+	body, _ := ioutil.ReadAll(r.Body)
+	receiver.requests = append(receiver.requests, r)
+	receiver.bodies = append(receiver.bodies, body)
+
+	statusCode := 200
+	if len(receiver.statusCodes) > 0 {
+		statusCode, receiver.statusCodes = receiver.statusCodes[0], receiver.statusCodes[1:]
+	}
+	w.WriteHeader(statusCode)
+}
+
+func newTestDispatcher() *Dispatcher {
+	dispatcher := NewDispatcher(Settings{AllowedNetworks: []string{"127.0.0.1"}})
+	dispatcher.MaxAttempts = 3
+	dispatcher.Backoff = 10 * time.Millisecond
+	return dispatcher
+}
+
+func newTestPayload(kind game.EventKind) Payload {
+	g := game.StartGame("alice", []string{"alice", "bob"}, 60, 100, 0)
+	return NewPayload(room, game.Event{Kind: kind, Game: g, Player: "alice"})
+}
+
+func TestSignedDelivery(t *testing.T) {
+	receiver := &receiver{}
+	ts := httptest.NewServer(receiver)
+	defer ts.Close()
+
+	dispatcher := newTestDispatcher()
+	delivery, err := dispatcher.Dispatch(room, Webhook{ID: 1, URL: ts.URL, Secret: secret}, newTestPayload(game.TurnStarted))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if delivery.Status != Pending {
+		t.Errorf("a dispatched delivery should be pending, got %s", delivery.Status)
+	}
+	dispatcher.Wait()
+
+	if len(receiver.requests) != 1 {
+		t.Fatalf("the receiver should get a single request, got %d", len(receiver.requests))
+	}
+	request, body := receiver.requests[0], receiver.bodies[0]
+	if !Verify(secret, body, request.Header.Get(SignatureHeader)) {
+		t.Error("the signature of the delivery should match its body")
+	}
+	if Verify("other secret", body, request.Header.Get(SignatureHeader)) {
+		t.Error("the signature of the delivery should not match with another secret")
+	}
+	if request.Header.Get(EventHeader) != "turn.started" || request.Header.Get(DeliveryHeader) != "1" {
+		t.Errorf("the delivery should have its event and ID in the headers, got %v", request.Header)
+	}
+
+	var payload Payload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		t.Fatal(err)
+	}
+	if payload.Event != "turn.started" || payload.Room != room || payload.Player != "alice" || len(payload.Players) != 2 {
+		t.Errorf("the payload should describe the event, got %+v", payload)
+	}
+
+	deliveries := dispatcher.Deliveries(room, 1)
+	if len(deliveries) != 1 || deliveries[0].Status != Delivered || deliveries[0].Attempts != 1 || deliveries[0].StatusCode != 200 {
+		t.Errorf("the delivery should be logged as delivered, got %v", deliveries)
+	}
+}
+
+func TestDeliveryRetriedUntilSuccess(t *testing.T) {
+	receiver := &receiver{statusCodes: []int{503, 429}}
+	ts := httptest.NewServer(receiver)
+	defer ts.Close()
+
+	dispatcher := newTestDispatcher()
+	dispatcher.Dispatch(room, Webhook{ID: 1, URL: ts.URL, Secret: secret}, newTestPayload(game.GameStarted))
+	dispatcher.Wait()
+
+	if len(receiver.requests) != 3 {
+		t.Fatalf("the delivery should be attempted until it succeeds, got %d attempts", len(receiver.requests))
+	}
+	for _, request := range receiver.requests {
+		if request.Header.Get(DeliveryHeader) != "1" {
+			t.Error("retries should keep the ID of the delivery")
+		}
+	}
+	deliveries := dispatcher.Deliveries(room, 1)
+	if len(deliveries) != 1 || deliveries[0].Status != Delivered || deliveries[0].Attempts != 3 {
+		t.Errorf("the delivery should be logged as delivered after 3 attempts, got %v", deliveries)
+	}
+}
+
+func TestDeliveryFailsAfterMaxAttempts(t *testing.T) {
+	receiver := &receiver{statusCodes: []int{500, 500, 500, 500}}
+	ts := httptest.NewServer(receiver)
+	defer ts.Close()
+
+	dispatcher := newTestDispatcher()
+	dispatcher.Dispatch(room, Webhook{ID: 1, URL: ts.URL, Secret: secret}, newTestPayload(game.GameStarted))
+	dispatcher.Wait()
+
+	if len(receiver.requests) != dispatcher.MaxAttempts {
+		t.Errorf("the delivery should be attempted %d times, got %d", dispatcher.MaxAttempts, len(receiver.requests))
+	}
+	deliveries := dispatcher.Deliveries(room, 0)
+	if len(deliveries) != 1 || deliveries[0].Status != Failed || deliveries[0].StatusCode != 500 {
+		t.Errorf("the delivery should be logged as failed, got %v", deliveries)
+	}
+}
+
+func TestRejectedDeliveryNotRetried(t *testing.T) {
+	receiver := &receiver{statusCodes: []int{404}}
+	ts := httptest.NewServer(receiver)
+	defer ts.Close()
+
+	dispatcher := newTestDispatcher()
+	dispatcher.Dispatch(room, Webhook{ID: 1, URL: ts.URL, Secret: secret}, newTestPayload(game.GameStarted))
+	dispatcher.Wait()
+
+	if len(receiver.requests) != 1 {
+		t.Errorf("rejected deliveries should not be retried, got %d attempts", len(receiver.requests))
+	}
+	if deliveries := dispatcher.Deliveries(room, 1); deliveries[0].Status != Failed {
+		t.Errorf("the delivery should be logged as failed, got %v", deliveries)
+	}
+}
+
+func TestUnreachableReceiver(t *testing.T) {
+	ts := httptest.NewServer(&receiver{})
+	ts.Close()
+
+	dispatcher := newTestDispatcher()
+	dispatcher.Dispatch(room, Webhook{ID: 1, URL: ts.URL, Secret: secret}, newTestPayload(game.GameStarted))
+	dispatcher.Wait()
+
+	deliveries := dispatcher.Deliveries(room, 1)
+	if deliveries[0].Status != Failed || deliveries[0].Attempts != dispatcher.MaxAttempts || deliveries[0].Error == "" {
+		t.Errorf("the delivery should fail with the error after all attempts, got %v", deliveries)
+	}
+}
+
+func TestDeliveryLog(t *testing.T) {
+	ts := httptest.NewServer(&receiver{})
+	defer ts.Close()
+
+	dispatcher := newTestDispatcher()
+	for i := 0; i < maxDeliveries+5; i++ {
+		dispatcher.Dispatch(room, Webhook{ID: 1 + i%2, URL: ts.URL}, newTestPayload(game.TurnStarted))
+	}
+	dispatcher.Dispatch("other room", Webhook{ID: 3, URL: ts.URL}, newTestPayload(game.TurnStarted))
+	dispatcher.Wait()
+
+	all := dispatcher.Deliveries(room, 0)
+	if len(all) != maxDeliveries || all[0].ID != 6 {
+		t.Errorf("the log should keep the last %d deliveries of the room, got %d starting from #%d", maxDeliveries, len(all), all[0].ID)
+	}
+	if len(dispatcher.Deliveries(room, 1)) != maxDeliveries/2 {
+		t.Error("the log should be filtered by webhook")
+	}
+
+	dispatcher.Forget(room)
+	if len(dispatcher.Deliveries(room, 0)) != 0 || len(dispatcher.Deliveries("other room", 0)) != 1 {
+		t.Error("forgetting a room should drop its log only")
+	}
+}
+
+func TestValidateWebhook(t *testing.T) {
+	valid := []Webhook{
+		{URL: "http://localhost:8080/hook"},
+		{URL: "https://example.com/hook", Events: []string{"game.started", "game.finished"}},
+	}
+	for _, webhook := range valid {
+		if err := webhook.Validate(); err != nil {
+			t.Errorf("webhook %v should be valid: %v", webhook, err)
+		}
+	}
+
+	invalid := []Webhook{
+		{URL: ""},
+		{URL: "example.com/hook"},
+		{URL: "ftp://example.com/hook"},
+		{URL: "https://example.com/hook", Events: []string{"game.paused"}},
+	}
+	for _, webhook := range invalid {
+		if err := webhook.Validate(); err == nil {
+			t.Errorf("webhook %v should be invalid", webhook)
+		}
+	}
+}
+
+func TestSubscribes(t *testing.T) {
+	all := Webhook{}
+	if !all.Subscribes("game.started") || !all.Subscribes("turn.started") {
+		t.Error("a webhook without events should subscribe to all events")
+	}
+
+	finished := Webhook{Events: []string{"game.finished"}}
+	if finished.Subscribes("turn.started") || !finished.Subscribes("game.finished") {
+		t.Error("a webhook with events should subscribe to them only")
+	}
+}
+
+func TestFinishedPayload(t *testing.T) {
+	g := game.StartGame("alice", []string{"alice", "bob"}, 60, 100, 0)
+	g.AddEntry("Once upon a time", "alice")
+
+	started := NewPayload(room, game.Event{Kind: game.GameStarted, Game: g})
+	if started.Story != nil {
+		t.Error("only payloads of finished games should have their story")
+	}
+
+	finished := NewPayload(room, game.Event{Kind: game.GameFinished, Game: g})
+	g.AddEntry("there was a dragon", "bob")
+	if len(finished.Story) != 1 || finished.Story[0].Text != "Once upon a time" {
+		t.Errorf("the payload should have a copy of the story, got %v", finished.Story)
+	}
+}
+
+func TestBlockedReceiver(t *testing.T) {
+	receiver := &receiver{}
+	ts := httptest.NewServer(receiver)
+	defer ts.Close()
+
+	dispatcher := NewDispatcher(DefaultSettings)
+	dispatcher.MaxAttempts = 1
+	dispatcher.Dispatch(room, Webhook{ID: 1, URL: ts.URL, Secret: secret}, newTestPayload(game.GameStarted))
+	dispatcher.Wait()
+
+	if len(receiver.requests) != 0 {
+		t.Errorf("deliveries to loopback addresses should be blocked, got %d requests", len(receiver.requests))
+	}
+	deliveries := dispatcher.Deliveries(room, 1)
+	if deliveries[0].Status != Failed || !strings.Contains(deliveries[0].Error, "address 127.0.0.1 is not allowed") {
+		t.Errorf("the delivery should be logged as failed with the blocked address, got %v", deliveries)
+	}
+}
+
+func TestCheckURL(t *testing.T) {
+	tests := []struct {
+		url     string
+		allowed []string
+		blocked bool
+	}{
+		{url: "http://127.0.0.1:8080/hook", blocked: true},
+		{url: "http://localhost/hook", blocked: true},
+		{url: "http://[::1]/hook", blocked: true},
+		{url: "http://[::ffff:127.0.0.1]/hook", blocked: true},
+		{url: "http://10.1.2.3/hook", blocked: true},
+		{url: "http://172.16.0.1/hook", blocked: true},
+		{url: "http://192.168.1.1/hook", blocked: true},
+		{url: "http://169.254.169.254/latest/meta-data", blocked: true},
+		{url: "http://[fe80::1]/hook", blocked: true},
+		{url: "http://[fd00:ec2::254]/hook", blocked: true},
+		{url: "http://0.0.0.0/hook", blocked: true},
+		{url: "https://203.0.113.10/hook"},
+		{url: "https://[2001:db8::1]/hook"},
+		{url: "http://127.0.0.1:8080/hook", allowed: []string{"127.0.0.1"}},
+		{url: "http://10.1.2.3/hook", allowed: []string{"10.0.0.0/8"}},
+		{url: "http://192.168.1.1/hook", allowed: []string{"10.0.0.0/8"}, blocked: true},
+	}
+	for _, test := range tests {
+		dispatcher := NewDispatcher(Settings{AllowedNetworks: test.allowed})
+		err := dispatcher.CheckURL(test.url)
+		if test.blocked && err == nil {
+			t.Errorf("%s should be blocked with allowed networks %v", test.url, test.allowed)
+		}
+		if !test.blocked && err != nil {
+			t.Errorf("%s should not be blocked with allowed networks %v, got %v", test.url, test.allowed, err)
+		}
+	}
+}
+
+func TestSettingsValidate(t *testing.T) {
+	if err := (Settings{AllowedNetworks: []string{"127.0.0.1", "10.0.0.0/8", "::1"}}).Validate(); err != nil {
+		t.Errorf("valid networks should be accepted, got %v", err)
+	}
+	err := (Settings{AllowedNetworks: []string{"localhost"}}).Validate()
+	if err == nil || err.Error() != "\"localhost\" is not a valid IP address or CIDR range" {
+		t.Errorf("hostnames should be rejected, got %v", err)
+	}
+}
@@ -0,0 +1,190 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bot
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/pavelhadzhiev/story-builder/pkg/api/game"
+	"github.com/pavelhadzhiev/story-builder/pkg/client"
+)
+
+// maxComposeAttempts is the number of entries a bot composes before giving up on a turn, if none satisfies the game's rules.
+const maxComposeAttempts = 20
+
+// Bot is a player that plays through the regular story builder client. It readies up in lobbies and writes an entry, using its strategy, whenever it's its turn.
+type Bot struct {
+	Name         string
+	PollInterval time.Duration
+	Output       io.Writer
+
+	client   *client.SBClient
+	strategy Strategy
+	learned  string
+}
+
+// NewBot creates a bot that plays as the user with the provided name, using the provided client. The client should already be logged in and joined in a room.
+func NewBot(sbClient *client.SBClient, name string, strategy Strategy) *Bot {
+	return &Bot{
+		Name:         name,
+		PollInterval: time.Second,
+		Output:       ioutil.Discard,
+
+		client:   sbClient,
+		strategy: strategy,
+	}
+}
+
+// Run learns the finished stories of the room and plays until the stop channel is closed. Errors during a move are written to the bot's
+// output and don't stop it.
+func (bot *Bot) Run(stop <-chan struct{}) {
+	if err := bot.LearnHistory(); err != nil {
+		fmt.Fprintf(bot.Output, "%s: cannot learn the previous stories of the room: %v\n", bot.Name, err)
+	}
+	for {
+		if err := bot.Play(); err != nil {
+			fmt.Fprintf(bot.Output, "%s: %v\n", bot.Name, err)
+		}
+		select {
+		case <-stop:
+			return
+		case <-time.After(bot.PollInterval):
+		}
+	}
+}
+
+// Play checks the game in the room once and makes a move if there is one to make.
+// The bot learns from finished stories, readies up in open lobbies and writes an entry if it's its turn.
+func (bot *Bot) Play() error {
+	sbGame, err := bot.client.GetGame()
+	if err != nil {
+		return nil // no game has been started yet
+	}
+
+	story, turn := sbGame.Story, sbGame.Turn
+	if team := sbGame.TeamOf(bot.Name); team != nil { // in team games the bot plays in its team's story
+		story, turn = team.Story, team.Turn
+	}
+
+	switch {
+	case sbGame.Finished:
+		bot.learnGame(sbGame)
+	case sbGame.Lobby != nil:
+		for _, player := range sbGame.Lobby.Ready {
+			if player == bot.Name {
+				return nil
+			}
+		}
+		return bot.client.Ready()
+	case turn == bot.Name && !sbGame.Paused:
+		entry, err := bot.compose(sbGame, story)
+		if err != nil {
+			return err
+		}
+		if err := bot.client.AddEntry(entry); err != nil {
+			return err
+		}
+		fmt.Fprintf(bot.Output, "%s: %s\n", bot.Name, entry)
+	}
+	return nil
+}
+
+// LearnHistory lets the strategy learn the stories of all finished games of the room, so the bot doesn't start from scratch in rooms
+// that have been played in before.
+func (bot *Bot) LearnHistory() error {
+	summaries, err := bot.client.GetStoryTree()
+	if err != nil {
+		return err
+	}
+	for _, summary := range summaries {
+		if !summary.Finished {
+			continue
+		}
+		sbGame, err := bot.client.GetGameByID(summary.ID)
+		if err != nil {
+			return err
+		}
+		bot.learnGame(sbGame)
+	}
+	return nil
+}
+
+// learnGame lets the strategy learn the story of the provided game, or the stories of its teams.
+func (bot *Bot) learnGame(sbGame *game.Game) {
+	bot.learn(sbGame.Story)
+	for _, team := range sbGame.Teams {
+		bot.learn(team.Story)
+	}
+}
+
+// learn lets the strategy learn the provided story, unless it's the story it learned last.
+func (bot *Bot) learn(story []game.Entry) {
+	if len(story) == 0 {
+		return
+	}
+	key := fmt.Sprintf("%d:%s", len(story), story[len(story)-1].Text)
+	if key == bot.learned {
+		return
+	}
+	bot.strategy.Learn(story)
+	bot.learned = key
+}
+
+// compose asks the strategy for entries to continue the provided story until one satisfies the max length and constraints of the game.
+func (bot *Bot) compose(sbGame *game.Game, story []game.Entry) (string, error) {
+	requiredWord := sbGame.Constraints.NextRequiredWord(story)
+	for attempt := 0; attempt < maxComposeAttempts; attempt++ {
+		candidates := []string{bot.strategy.Compose(story)}
+		if requiredWord != "" {
+			candidates = append(candidates, strings.TrimRight(candidates[0], ".!?")+" - "+requiredWord+".")
+		}
+		for _, candidate := range candidates {
+			candidate = truncate(candidate, sbGame.MaxLength)
+			if isValid(candidate, sbGame, story) {
+				return candidate, nil
+			}
+		}
+	}
+	return "", errors.New("couldn't compose an entry that satisfies the rules of the game")
+}
+
+func isValid(entry string, sbGame *game.Game, story []game.Entry) bool {
+	if entry == "" {
+		return false
+	}
+	for _, validator := range sbGame.Constraints.Validators() {
+		if err := validator.Validate(entry, story); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// truncate cuts the entry at the last whole word that fits in the max length. A max length of 0 means no limit.
+func truncate(entry string, maxLength int) string {
+	if maxLength <= 0 || len(entry) <= maxLength {
+		return entry
+	}
+	entry = entry[:maxLength]
+	if index := strings.LastIndex(entry, " "); index > 0 {
+		entry = entry[:index]
+	}
+	return entry
+}
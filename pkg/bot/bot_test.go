@@ -0,0 +1,190 @@
+package bot
+
+import (
+	"encoding/json"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/pavelhadzhiev/story-builder/pkg/api/game"
+	"github.com/pavelhadzhiev/story-builder/pkg/client"
+	"github.com/pavelhadzhiev/story-builder/pkg/config"
+)
+
+const botName = "test-bot"
+
+// fakeServer serves the provided game and records the entries and ready requests it receives.
+func fakeServer(sbGame *game.Game, entries *[]string, readies *int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/gameplay/"):
+			body, _ := json.Marshal(sbGame)
+			w.Write(body)
+		case r.Method == http.MethodPost && strings.HasPrefix(r.URL.Path, "/gameplay/"):
+			*entries = append(*entries, r.Header.Get("Entry-Text"))
+		case r.Method == http.MethodPut && strings.HasPrefix(r.URL.Path, "/lobby/"):
+			*readies++
+		default:
+			w.WriteHeader(404)
+		}
+	}))
+}
+
+func newTestBot(server *httptest.Server, strategy Strategy) *Bot {
+	sbClient := client.NewTestSBClient(&config.SBConfiguration{URL: server.URL, Room: "room"}, server.Client())
+	return NewBot(sbClient, botName, strategy)
+}
+
+func TestBotPlaysOnItsTurn(t *testing.T) {
+	var entries []string
+	var readies int
+	sbGame := &game.Game{Turn: botName, Players: []string{"player", botName}, MaxLength: 30}
+	server := fakeServer(sbGame, &entries, &readies)
+	defer server.Close()
+
+	if err := newTestBot(server, NewCannedStrategy(rand.New(rand.NewSource(1)))).Play(); err != nil {
+		t.Errorf("play should pass with no error, got %v", err)
+	}
+	if len(entries) != 1 || entries[0] == "" || len(entries[0]) > 30 {
+		t.Errorf("bot should have submitted a single entry within the max length, got %v", entries)
+	}
+}
+
+func TestBotWaitsForItsTurn(t *testing.T) {
+	var entries []string
+	var readies int
+	sbGame := &game.Game{Turn: "player", Players: []string{"player", botName}}
+	server := fakeServer(sbGame, &entries, &readies)
+	defer server.Close()
+
+	newTestBot(server, NewCannedStrategy(rand.New(rand.NewSource(1)))).Play()
+	if len(entries) != 0 {
+		t.Error("bot should not play on another player's turn")
+	}
+}
+
+func TestBotReadiesUpInLobby(t *testing.T) {
+	var entries []string
+	var readies int
+	sbGame := &game.Game{Lobby: &game.Lobby{Initiator: "player", Ready: []string{"player"}}}
+	server := fakeServer(sbGame, &entries, &readies)
+	defer server.Close()
+
+	newTestBot(server, NewCannedStrategy(rand.New(rand.NewSource(1)))).Play()
+	if readies != 1 {
+		t.Error("bot should have readied up in the lobby")
+	}
+}
+
+func TestBotFollowsConstraints(t *testing.T) {
+	var entries []string
+	var readies int
+	constraints, _ := game.NewConstraints([]string{"dragon"}, "", false)
+	sbGame := &game.Game{Turn: botName, Players: []string{botName}, Constraints: constraints}
+	server := fakeServer(sbGame, &entries, &readies)
+	defer server.Close()
+
+	newTestBot(server, NewMarkovStrategy(rand.New(rand.NewSource(1)))).Play()
+	if len(entries) != 1 || !strings.Contains(entries[0], "dragon") {
+		t.Errorf("bot should have submitted an entry with the required word, got %v", entries)
+	}
+}
+
+func TestBotPlaysInItsTeam(t *testing.T) {
+	var entries []string
+	var readies int
+	sbGame := &game.Game{Teams: []*game.Team{
+		{Name: "red", Players: []string{"player"}, Turn: "player"},
+		{Name: "blue", Players: []string{botName}, Turn: botName},
+	}}
+	server := fakeServer(sbGame, &entries, &readies)
+	defer server.Close()
+
+	newTestBot(server, NewCannedStrategy(rand.New(rand.NewSource(1)))).Play()
+	if len(entries) != 1 {
+		t.Error("bot should play on its turn in its team")
+	}
+}
+
+func TestCannedStrategyAvoidsUsedPhrases(t *testing.T) {
+	strategy := NewCannedStrategy(rand.New(rand.NewSource(1)))
+	strategy.phrases = []string{"first", "second"}
+
+	if entry := strategy.Compose([]game.Entry{{Text: "first"}}); entry != "second" {
+		t.Errorf("canned strategy should pick an unused phrase, got \"%s\"", entry)
+	}
+}
+
+func TestMarkovStrategyLearnsFromStories(t *testing.T) {
+	strategy := NewMarkovStrategy(rand.New(rand.NewSource(1)))
+	strategy.chain = make(map[string][]string)
+	strategy.starts = make([][2]string, 0)
+
+	strategy.Learn([]game.Entry{{Text: "The brave knight rode into the dark forest."}})
+	if entry := strategy.Compose(nil); entry != "The brave knight rode into the dark forest." {
+		t.Errorf("markov strategy should only know the learned story, got \"%s\"", entry)
+	}
+}
+
+func TestNewStrategy(t *testing.T) {
+	if _, err := NewStrategy("Markov"); err != nil {
+		t.Error("markov strategy should be available")
+	}
+	if _, err := NewStrategy("unknown"); err == nil || !strings.Contains(err.Error(), "canned, markov") {
+		t.Error("unknown strategy should return error, listing the available ones")
+	}
+}
+
+// learningStrategy records the stories it learns.
+type learningStrategy struct {
+	learned [][]game.Entry
+}
+
+func (strategy *learningStrategy) Learn(story []game.Entry) {
+	strategy.learned = append(strategy.learned, story)
+}
+
+func (strategy *learningStrategy) Compose(story []game.Entry) string {
+	return "Then it ended."
+}
+
+func TestBotLearnsHistory(t *testing.T) {
+	history := map[string]*game.Game{
+		"1": {ID: 1, Finished: true, Story: []game.Entry{{Text: "Once upon a time."}}},
+		"2": {ID: 2, Story: []game.Entry{{Text: "The story goes on."}}},
+		"3": {ID: 3, Finished: true, Teams: []*game.Team{
+			{Name: "red", Story: []game.Entry{{Text: "The red knight rode out."}}},
+			{Name: "blue", Story: []game.Entry{{Text: "The blue knight stayed home."}}},
+		}},
+	}
+	var fetched []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/story-tree/"):
+			summaries := []game.Summary{history["1"].Summarize(), history["2"].Summarize(), history["3"].Summarize()}
+			body, _ := json.Marshal(summaries)
+			w.Write(body)
+		case strings.HasPrefix(r.URL.Path, "/gameplay/"):
+			id := r.URL.Query().Get("id")
+			fetched = append(fetched, id)
+			body, _ := json.Marshal(history[id])
+			w.Write(body)
+		default:
+			w.WriteHeader(404)
+		}
+	}))
+	defer server.Close()
+
+	strategy := &learningStrategy{}
+	if err := newTestBot(server, strategy).LearnHistory(); err != nil {
+		t.Fatalf("learning the history should pass with no error, got %v", err)
+	}
+	if len(fetched) != 2 || fetched[0] != "1" || fetched[1] != "3" {
+		t.Errorf("bot should fetch the finished games only, got %v", fetched)
+	}
+	if len(strategy.learned) != 3 || strategy.learned[0][0].Text != "Once upon a time." || strategy.learned[2][0].Text != "The blue knight stayed home." {
+		t.Errorf("bot should learn the stories of the finished games and their teams, got %v", strategy.learned)
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bot
+
+import (
+	"math/rand"
+
+	"github.com/pavelhadzhiev/story-builder/pkg/api/game"
+)
+
+// cannedPhrases are story entries that fit in most stories. They are used by the canned strategy and as the initial corpus of the markov strategy.
+var cannedPhrases = []string{
+	"Suddenly, the lights went out.",
+	"Nobody expected what happened next.",
+	"A strange noise came from the basement.",
+	"The old man smiled and said nothing.",
+	"It started to rain, harder than ever before.",
+	"Somewhere in the distance, a dog was barking.",
+	"Then the phone rang.",
+	"Everyone turned to look at the door.",
+	"The map was wrong, of course.",
+	"She opened the letter with shaking hands.",
+	"The cat knew more than it was letting on.",
+	"By morning, everything had changed.",
+	"He laughed, but the joke was on him.",
+	"The door creaked open by itself.",
+	"A small boat appeared on the horizon.",
+	"Nobody remembered who had locked the gate.",
+}
+
+// CannedStrategy writes entries from a list of phrases, avoiding phrases that are already in the story.
+type CannedStrategy struct {
+	phrases []string
+	random  *rand.Rand
+}
+
+// NewCannedStrategy creates a canned strategy using the built-in phrases.
+func NewCannedStrategy(random *rand.Rand) *CannedStrategy {
+	return &CannedStrategy{phrases: cannedPhrases, random: random}
+}
+
+// Learn does nothing, as canned phrases don't depend on previous stories.
+func (strategy *CannedStrategy) Learn(story []game.Entry) {}
+
+// Compose returns a random phrase that is not in the story yet. If all of them are used, any phrase is returned.
+func (strategy *CannedStrategy) Compose(story []game.Entry) string {
+	unused := make([]string, 0, len(strategy.phrases))
+	for _, phrase := range strategy.phrases {
+		if !containsText(story, phrase) {
+			unused = append(unused, phrase)
+		}
+	}
+	if len(unused) == 0 {
+		unused = strategy.phrases
+	}
+	return unused[strategy.random.Intn(len(unused))]
+}
+
+func containsText(story []game.Entry, text string) bool {
+	for _, entry := range story {
+		if entry.Text == text {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,83 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bot
+
+import (
+	"math/rand"
+	"strings"
+
+	"github.com/pavelhadzhiev/story-builder/pkg/api/game"
+)
+
+// maxMarkovWords is the number of words after which a markov entry is cut, if it doesn't reach the end of a sentence.
+const maxMarkovWords = 20
+
+// MarkovStrategy writes entries using a word-level Markov chain, where the next word depends on the previous two.
+// It starts off with the canned phrases and learns from every story it sees.
+type MarkovStrategy struct {
+	chain  map[string][]string
+	starts [][2]string
+	random *rand.Rand
+}
+
+// NewMarkovStrategy creates a markov strategy, trained on the built-in canned phrases.
+func NewMarkovStrategy(random *rand.Rand) *MarkovStrategy {
+	strategy := &MarkovStrategy{
+		chain:  make(map[string][]string),
+		starts: make([][2]string, 0),
+		random: random,
+	}
+	for _, phrase := range cannedPhrases {
+		strategy.learnText(phrase)
+	}
+	return strategy
+}
+
+// Learn adds the entries of the provided story to the chain. Entries the strategy has already learned are learned again, making them more likely.
+func (strategy *MarkovStrategy) Learn(story []game.Entry) {
+	for _, entry := range story {
+		strategy.learnText(entry.Text)
+	}
+}
+
+func (strategy *MarkovStrategy) learnText(text string) {
+	words := strings.Fields(text)
+	if len(words) < 2 {
+		return
+	}
+	strategy.starts = append(strategy.starts, [2]string{words[0], words[1]})
+	for index := 2; index < len(words); index++ {
+		key := words[index-2] + " " + words[index-1]
+		strategy.chain[key] = append(strategy.chain[key], words[index])
+	}
+}
+
+// Compose generates a sentence, starting from a random beginning of a learned entry.
+func (strategy *MarkovStrategy) Compose(story []game.Entry) string {
+	start := strategy.starts[strategy.random.Intn(len(strategy.starts))]
+	words := []string{start[0], start[1]}
+	for len(words) < maxMarkovWords && !endsSentence(words[len(words)-1]) {
+		next := strategy.chain[words[len(words)-2]+" "+words[len(words)-1]]
+		if len(next) == 0 {
+			break
+		}
+		words = append(words, next[strategy.random.Intn(len(next))])
+	}
+	return strings.Join(words, " ")
+}
+
+func endsSentence(word string) bool {
+	return strings.HasSuffix(word, ".") || strings.HasSuffix(word, "!") || strings.HasSuffix(word, "?")
+}
@@ -0,0 +1,59 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bot
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pavelhadzhiev/story-builder/pkg/api/game"
+)
+
+// Strategy decides what a bot writes when it's its turn. Strategies run fully offline.
+type Strategy interface {
+	// Learn lets the strategy study a story, e.g. a previous story of the room.
+	Learn(story []game.Entry)
+	// Compose returns the next entry for the provided story.
+	Compose(story []game.Entry) string
+}
+
+// strategies holds the constructors of all built-in strategies by name.
+var strategies = map[string]func(random *rand.Rand) Strategy{
+	"markov": func(random *rand.Rand) Strategy { return NewMarkovStrategy(random) },
+	"canned": func(random *rand.Rand) Strategy { return NewCannedStrategy(random) },
+}
+
+// StrategyNames returns the names of all built-in strategies in alphabetical order.
+func StrategyNames() []string {
+	names := make([]string, 0, len(strategies))
+	for name := range strategies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewStrategy creates the built-in strategy with the provided name.
+// Returns error if there is no such strategy.
+func NewStrategy(name string) (Strategy, error) {
+	constructor, ok := strategies[strings.ToLower(name)]
+	if !ok {
+		return nil, fmt.Errorf("unknown strategy \"%s\", must be one of: %s", name, strings.Join(StrategyNames(), ", "))
+	}
+	return constructor(rand.New(rand.NewSource(time.Now().UnixNano()))), nil
+}
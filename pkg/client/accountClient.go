@@ -0,0 +1,129 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/pavelhadzhiev/story-builder/pkg/api/users"
+)
+
+// ChangePassword replaces the password of the logged in user with the provided new password.
+// Returns error if the old password is wrong or the user can't be authenticated.
+func (client *SBClient) ChangePassword(oldPassword, newPassword string) error {
+	if newPassword == "" {
+		return errors.New("the new password can't be empty")
+	}
+	headers := make(map[string]string)
+	headers["Old-Password"] = oldPassword
+	headers["New-Password"] = newPassword
+
+	response, err := client.call(http.MethodPut, "/account/password", nil, headers)
+	if err != nil {
+		return fmt.Errorf("error during http request: %v", err)
+	}
+	switch response.StatusCode {
+	case 200:
+		return nil
+	case 400:
+		return errors.New("both the old and the new password are required")
+	case 401:
+		return errors.New("could not authenticate user")
+	case 403:
+		return errors.New("the old password is wrong")
+	default:
+		return errors.New("something went really wrong :(")
+	}
+}
+
+// DeleteAccount deletes the account of the logged in user. The user is removed from all rooms and its entries are anonymized.
+// Returns error if the user can't be authenticated.
+func (client *SBClient) DeleteAccount() error {
+	response, err := client.call(http.MethodDelete, "/account/", nil, nil)
+	if err != nil {
+		return fmt.Errorf("error during http request: %v", err)
+	}
+	switch response.StatusCode {
+	case 200:
+		return nil
+	case 401:
+		return errors.New("could not authenticate user")
+	default:
+		return errors.New("something went really wrong :(")
+	}
+}
+
+// GetProfile retrieves the profile of the user with the provided username. Its email address and turn reminders are returned only to the user.
+// Returns error if the user doesn't exist.
+func (client *SBClient) GetProfile(username string) (*users.Profile, error) {
+	response, err := client.call(http.MethodGet, "/profiles/"+username, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error during http request: %v", err)
+	}
+	switch response.StatusCode {
+	case 200:
+		defer response.Body.Close()
+		var profile = &users.Profile{}
+		if err := json.NewDecoder(response.Body).Decode(profile); err != nil {
+			return nil, fmt.Errorf("failed to deserialize response from server: %v", err)
+		}
+		return profile, nil
+	case 404:
+		return nil, errors.New("user \"" + username + "\" doesn't exist")
+	default:
+		return nil, errors.New("something went really wrong :(")
+	}
+}
+
+// UpdateProfile replaces the profile of the logged in user - its display name, bio, email address and turn reminders - with the provided one.
+// Returns error if the profile is not valid or the user can't update this profile.
+func (client *SBClient) UpdateProfile(profile *users.Profile) (*users.Profile, error) {
+	requestBody, err := json.Marshal(profile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize profile: %v", err)
+	}
+
+	response, err := client.call(http.MethodPut, "/profiles/"+profile.Username, bytes.NewBuffer(requestBody), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error during http request: %v", err)
+	}
+	switch response.StatusCode {
+	case 200:
+		defer response.Body.Close()
+		var updated = &users.Profile{}
+		if err := json.NewDecoder(response.Body).Decode(updated); err != nil {
+			return nil, fmt.Errorf("failed to deserialize response from server: %v", err)
+		}
+		return updated, nil
+	case 400:
+		defer response.Body.Close()
+		errorMessage, err := ioutil.ReadAll(response.Body)
+		if err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("cannot update profile: %s", string(errorMessage))
+	case 401:
+		return nil, errors.New("could not authenticate user")
+	case 403:
+		return nil, errors.New("users can only update their own profile")
+	default:
+		return nil, errors.New("something went really wrong :(")
+	}
+}
@@ -0,0 +1,114 @@
+package client
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pavelhadzhiev/story-builder/pkg/api/users"
+	"github.com/pavelhadzhiev/story-builder/pkg/config"
+)
+
+var _ = Describe("Story Builder Account Client test", func() {
+	var client *SBClient
+	var responseStatusCode int
+	var responseBody []byte
+	var sbServer *httptest.Server
+	testHandler := TestingHandler(&responseBody, &responseStatusCode)
+
+	username := "user"
+	password := "password"
+	authHeader := base64.StdEncoding.EncodeToString([]byte(username + ":" + password))
+
+	BeforeEach(func() {
+		sbServer = httptest.NewServer(testHandler)
+		clientConfig := &config.SBConfiguration{URL: sbServer.URL, Authorization: authHeader}
+		client = NewSBClient(clientConfig)
+	})
+
+	Describe("Change password", func() {
+		Context("When request is valid", func() {
+			It("should not return error", func() {
+				responseStatusCode = http.StatusOK
+
+				err := client.ChangePassword(password, "new-password")
+
+				Expect(err).ShouldNot(HaveOccurred())
+			})
+		})
+
+		Context("When the old password is wrong", func() {
+			It("should return error", func() {
+				responseStatusCode = http.StatusForbidden
+
+				err := client.ChangePassword("wrong-password", "new-password")
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("the old password is wrong"))
+			})
+		})
+
+		Context("When the new password is empty", func() {
+			It("should return error", func() {
+				err := client.ChangePassword(password, "")
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("the new password can't be empty"))
+			})
+		})
+	})
+
+	Describe("Delete account", func() {
+		Context("When request is valid", func() {
+			It("should not return error", func() {
+				responseStatusCode = http.StatusOK
+
+				err := client.DeleteAccount()
+
+				Expect(err).ShouldNot(HaveOccurred())
+			})
+		})
+
+		Context("When invalid status code is returned by SB server", func() {
+			It("should return error", func() {
+				responseStatusCode = http.StatusCreated
+
+				err := client.DeleteAccount()
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("something went really wrong :("))
+			})
+		})
+	})
+
+	Describe("Get profile", func() {
+		Context("When request is valid", func() {
+			It("should return the profile", func() {
+				responseStatusCode = http.StatusOK
+				responseBody, _ = json.Marshal(users.Profile{Username: username, DisplayName: "The Bard"})
+
+				profile, err := client.GetProfile(username)
+
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(profile.DisplayName).To(Equal("The Bard"))
+			})
+		})
+	})
+
+	Describe("Update profile", func() {
+		Context("When the profile is not valid", func() {
+			It("should return error", func() {
+				responseStatusCode = http.StatusBadRequest
+				responseBody = []byte("Profile cannot be updated: bio is longer than 500 symbols.")
+
+				_, err := client.UpdateProfile(&users.Profile{Username: username})
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("cannot update profile: Profile cannot be updated: bio is longer than 500 symbols."))
+			})
+		})
+	})
+})
@@ -27,11 +27,13 @@ func (client *SBClient) BanPlayer(player string) error {
 	roomName := client.config.Room
 	response, err := client.call(http.MethodDelete, "/admin/ban/"+roomName+"/"+player, nil, nil)
 	if err != nil {
-		return fmt.Errorf("error during http request: %e", err)
+		return fmt.Errorf("error during http request: %v", err)
 	}
 	switch response.StatusCode {
 	case 200:
 		return nil
+	case 401:
+		return errors.New("could not authenticate user")
 	case 403:
 		return errors.New("user does not have permissions to ban in room \"" + roomName + "\"")
 	case 404:
@@ -54,11 +56,13 @@ func (client *SBClient) KickPlayer(player string) error {
 	roomName := client.config.Room
 	response, err := client.call(http.MethodDelete, "/admin/kick/"+roomName+"/"+player, nil, nil)
 	if err != nil {
-		return fmt.Errorf("error during http request: %e", err)
+		return fmt.Errorf("error during http request: %v", err)
 	}
 	switch response.StatusCode {
 	case 200:
 		return nil
+	case 401:
+		return errors.New("could not authenticate user")
 	case 403:
 		return errors.New("user does not have permissions to kick in room \"" + roomName + "\"")
 	case 404:
@@ -79,11 +83,13 @@ func (client *SBClient) PromoteAdmin(user string) error {
 	roomName := client.config.Room
 	response, err := client.call(http.MethodPost, "/admin/"+roomName+"/"+user, nil, nil)
 	if err != nil {
-		return fmt.Errorf("error during http request: %e", err)
+		return fmt.Errorf("error during http request: %v", err)
 	}
 	switch response.StatusCode {
 	case 200:
 		return nil
+	case 401:
+		return errors.New("could not authenticate user")
 	case 403:
 		return errors.New("user does not have permissions to promote in room \"" + roomName + "\"")
 	case 404:
@@ -97,3 +103,61 @@ func (client *SBClient) PromoteAdmin(user string) error {
 		return errors.New("something went really wrong :(")
 	}
 }
+
+// MutePlayer mutes the provided player in the joined room for the provided duration in seconds - pass 0 to mute until the player is unmuted.
+// Muted players stay in the room, but they are skipped in the turn rotation and can't post in the chat or trigger votes.
+// Returns error if either the room or the player doesn't exist, the player is an admin or if the issuer doesn't have admin access for the room.
+func (client *SBClient) MutePlayer(player string, duration int) error {
+	if duration < 0 {
+		return errors.New("could not mute player: negative duration")
+	}
+	roomName := client.config.Room
+	headers := make(map[string]string)
+	headers["Mute-Duration"] = fmt.Sprint(duration)
+	response, err := client.call(http.MethodPost, "/admin/mute/"+roomName+"/"+player, nil, headers)
+	if err != nil {
+		return fmt.Errorf("error during http request: %v", err)
+	}
+	switch response.StatusCode {
+	case 200:
+		return nil
+	case 403:
+		return errors.New("user does not have permissions to mute in room \"" + roomName + "\"")
+	case 400, 404:
+		defer response.Body.Close()
+		errorMessage, err := ioutil.ReadAll(response.Body)
+		if err != nil {
+			return err
+		}
+		return fmt.Errorf("could not mute player: %s", string(errorMessage))
+	default:
+		return errors.New("something went really wrong :(")
+	}
+}
+
+// UnmutePlayer lifts the mute of the provided player in the joined room.
+// Returns error if the room doesn't exist, the player is not muted or if the issuer doesn't have admin access for the room.
+func (client *SBClient) UnmutePlayer(player string) error {
+	roomName := client.config.Room
+	response, err := client.call(http.MethodDelete, "/admin/mute/"+roomName+"/"+player, nil, nil)
+	if err != nil {
+		return fmt.Errorf("error during http request: %v", err)
+	}
+	switch response.StatusCode {
+	case 200:
+		return nil
+	case 403:
+		return errors.New("user does not have permissions to unmute in room \"" + roomName + "\"")
+	case 404:
+		defer response.Body.Close()
+		errorMessage, err := ioutil.ReadAll(response.Body)
+		if err != nil {
+			return err
+		}
+		return fmt.Errorf("could not unmute player: %s", string(errorMessage))
+	case 409:
+		return errors.New("player is not muted")
+	default:
+		return errors.New("something went really wrong :(")
+	}
+}
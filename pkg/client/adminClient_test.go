@@ -238,4 +238,71 @@ var _ = Describe("Story Builder Game Client test", func() {
 			})
 		})
 	})
+
+	Describe("Mute player", func() {
+		Context("When request is valid", func() {
+			It("should not return error", func() {
+				responseStatusCode = http.StatusOK
+
+				err := client.MutePlayer(player, 600)
+
+				Expect(err).ShouldNot(HaveOccurred())
+			})
+		})
+
+		Context("When the duration is negative", func() {
+			It("should return error", func() {
+				err := client.MutePlayer(player, -1)
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("negative duration"))
+			})
+		})
+
+		Context("When the player can't be muted", func() {
+			It("should return the server's reason", func() {
+				responseStatusCode = http.StatusBadRequest
+				responseBody = []byte("Player cannot be muted: admins can't be muted.")
+
+				err := client.MutePlayer(player, 0)
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("could not mute player: Player cannot be muted: admins can't be muted."))
+			})
+		})
+
+		Context("When user is not an admin", func() {
+			It("should return error", func() {
+				responseStatusCode = http.StatusForbidden
+
+				err := client.MutePlayer(player, 0)
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("user does not have permissions to mute in room \"" + room.Name + "\""))
+			})
+		})
+	})
+
+	Describe("Unmute player", func() {
+		Context("When request is valid", func() {
+			It("should not return error", func() {
+				responseStatusCode = http.StatusOK
+
+				err := client.UnmutePlayer(player)
+
+				Expect(err).ShouldNot(HaveOccurred())
+			})
+		})
+
+		Context("When the player is not muted", func() {
+			It("should return error", func() {
+				responseStatusCode = http.StatusConflict
+
+				err := client.UnmutePlayer(player)
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(Equal("player is not muted"))
+			})
+		})
+	})
 })
@@ -17,6 +17,7 @@ package client
 import (
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 )
 
@@ -24,14 +25,19 @@ import (
 func (client *SBClient) Register() error {
 	response, err := client.call(http.MethodPost, "/register/", nil, nil)
 	if err != nil {
-		return fmt.Errorf("error during http request: %e", err)
+		return fmt.Errorf("error during http request: %v", err)
 	}
 
 	switch response.StatusCode {
 	case 200:
 		return nil
 	case 400:
-		return errors.New("credentials have illegal characters")
+		defer response.Body.Close()
+		errorMessage, err := ioutil.ReadAll(response.Body)
+		if err != nil {
+			return err
+		}
+		return fmt.Errorf("credentials have illegal characters: %s", string(errorMessage))
 	case 409:
 		return errors.New("username already exists")
 	default:
@@ -39,11 +45,14 @@ func (client *SBClient) Register() error {
 	}
 }
 
+// ErrAlreadyLoggedIn is returned by Login if the user is already logged in on the server.
+var ErrAlreadyLoggedIn = errors.New("user is already logged in")
+
 // Login makes a request to the story builder server to check whether the user in the configuration is registered in the server DB.
 func (client *SBClient) Login() error {
 	response, err := client.call(http.MethodPost, "/login/", nil, nil)
 	if err != nil {
-		return fmt.Errorf("error during http request: %e", err)
+		return fmt.Errorf("error during http request: %v", err)
 	}
 
 	switch response.StatusCode {
@@ -53,8 +62,10 @@ func (client *SBClient) Login() error {
 		return errors.New("credentials have illegal characters")
 	case 401:
 		return errors.New("user doesn't exist or password is wrong")
+	case 403:
+		return errors.New("the account has been disabled by a server admin")
 	case 409:
-		return errors.New("user is already logged in")
+		return ErrAlreadyLoggedIn
 	default:
 		return errors.New("something went really wrong :(")
 	}
@@ -64,7 +75,7 @@ func (client *SBClient) Login() error {
 func (client *SBClient) Logout() error {
 	response, err := client.call(http.MethodPost, "/logout/", nil, nil)
 	if err != nil {
-		return fmt.Errorf("error during http request: %e", err)
+		return fmt.Errorf("error during http request: %v", err)
 	}
 
 	switch response.StatusCode {
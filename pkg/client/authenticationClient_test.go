@@ -123,14 +123,24 @@ var _ = Describe("Story Builder Game Client test", func() {
 			})
 		})
 
+		Context("When the account is disabled", func() {
+			It("should return error", func() {
+				responseStatusCode = http.StatusForbidden
+
+				err := client.Login()
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("the account has been disabled by a server admin"))
+			})
+		})
+
 		Context("When user is already logged in", func() {
 			It("should return error", func() {
 				responseStatusCode = http.StatusConflict
 
 				err := client.Login()
 
-				Expect(err).Should(HaveOccurred())
-				Expect(err.Error()).To(ContainSubstring("user is already logged in"))
+				Expect(err).To(Equal(ErrAlreadyLoggedIn))
 			})
 		})
 
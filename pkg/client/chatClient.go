@@ -0,0 +1,88 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/pavelhadzhiev/story-builder/pkg/api/rooms"
+)
+
+// SendMessage posts a message with the provided text in the chat of the joined room.
+// Returns error if room doesn't exist, the user is not in the room or is muted, or the message is empty or too long.
+func (client *SBClient) SendMessage(text string) (*rooms.Message, error) {
+	roomName := client.config.Room
+	requestBody, err := json.Marshal(&rooms.Message{Text: text})
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize message: %v", err)
+	}
+
+	response, err := client.call(http.MethodPost, "/chat/"+roomName, bytes.NewBuffer(requestBody), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error during http request: %v", err)
+	}
+	switch response.StatusCode {
+	case 201:
+		defer response.Body.Close()
+		var message = &rooms.Message{}
+		if err := json.NewDecoder(response.Body).Decode(message); err != nil {
+			return nil, fmt.Errorf("failed to deserialize response from server: %v", err)
+		}
+		return message, nil
+	case 400, 403:
+		defer response.Body.Close()
+		errorMessage, err := ioutil.ReadAll(response.Body)
+		if err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("cannot send message: %s", string(errorMessage))
+	case 404:
+		return nil, errors.New("room \"" + roomName + "\" doesn't exist")
+	default:
+		return nil, errors.New("something went really wrong :(")
+	}
+}
+
+// GetMessages retrieves the chat messages of the joined room that were posted after the message with the provided ID, oldest first.
+// Pass 0 to get all messages the room keeps. Returns error if room doesn't exist or the user is not in the room.
+func (client *SBClient) GetMessages(since int) ([]rooms.Message, error) {
+	roomName := client.config.Room
+	response, err := client.call(http.MethodGet, fmt.Sprintf("/chat/%s?since=%d", roomName, since), nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error during http request: %v", err)
+	}
+	switch response.StatusCode {
+	case 200:
+		defer response.Body.Close()
+		var messages = make([]rooms.Message, 0)
+		if err := json.NewDecoder(response.Body).Decode(&messages); err != nil {
+			return nil, fmt.Errorf("failed to deserialize response from server: %v", err)
+		}
+		return messages, nil
+	case 400:
+		return nil, errors.New("illegal since cursor")
+	case 403:
+		return nil, errors.New("cannot read chat: requires user to be joined in the room")
+	case 404:
+		return nil, errors.New("room \"" + roomName + "\" doesn't exist")
+	default:
+		return nil, errors.New("something went really wrong :(")
+	}
+}
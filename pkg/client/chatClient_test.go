@@ -0,0 +1,107 @@
+package client
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pavelhadzhiev/story-builder/pkg/api/rooms"
+	"github.com/pavelhadzhiev/story-builder/pkg/config"
+)
+
+var _ = Describe("Story Builder Chat Client test", func() {
+	var client *SBClient
+	var responseStatusCode int
+	var responseBody []byte
+	var sbServer *httptest.Server
+	testHandler := TestingHandler(&responseBody, &responseStatusCode)
+
+	username := "user"
+	password := "password"
+	authHeader := base64.StdEncoding.EncodeToString([]byte(username + ":" + password))
+
+	roomName := "roomName"
+	message := rooms.Message{ID: 1, Author: username, Text: "Let's make it a mystery!", Time: time.Date(2019, 6, 1, 12, 0, 0, 0, time.UTC)}
+
+	BeforeEach(func() {
+		sbServer = httptest.NewServer(testHandler)
+		clientConfig := &config.SBConfiguration{URL: sbServer.URL, Authorization: authHeader, Room: roomName}
+		client = NewSBClient(clientConfig)
+	})
+
+	Describe("Send a message", func() {
+		Context("When request is valid", func() {
+			It("should return the posted message", func() {
+				responseStatusCode = http.StatusCreated
+				responseBody, _ = json.Marshal(message)
+
+				result, err := client.SendMessage(message.Text)
+
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(*result).To(Equal(message))
+			})
+		})
+
+		Context("When the user is muted", func() {
+			It("should return the server's reason", func() {
+				responseStatusCode = http.StatusForbidden
+				responseBody = []byte("You are muted in this room.")
+
+				_, err := client.SendMessage(message.Text)
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("cannot send message: You are muted in this room."))
+			})
+		})
+
+		Context("When room doesn't exist", func() {
+			It("should return error", func() {
+				responseStatusCode = http.StatusNotFound
+
+				_, err := client.SendMessage(message.Text)
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("room \"" + roomName + "\" doesn't exist"))
+			})
+		})
+	})
+
+	Describe("Get messages", func() {
+		Context("When request is valid", func() {
+			It("should return the messages", func() {
+				responseStatusCode = http.StatusOK
+				responseBody, _ = json.Marshal([]rooms.Message{message})
+
+				result, err := client.GetMessages(0)
+
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(result).To(Equal([]rooms.Message{message}))
+			})
+		})
+
+		Context("When the user is not in the room", func() {
+			It("should return error", func() {
+				responseStatusCode = http.StatusForbidden
+
+				_, err := client.GetMessages(0)
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("requires user to be joined in the room"))
+			})
+		})
+
+		Context("When invalid status code is returned by SB server", func() {
+			It("should return error", func() {
+				responseStatusCode = http.StatusCreated
+
+				_, err := client.GetMessages(0)
+
+				Expect(err).Should(HaveOccurred())
+			})
+		})
+	})
+})
@@ -15,7 +15,9 @@
 package client
 
 import (
+	"bytes"
 	"io"
+	"io/ioutil"
 	"net/http"
 
 	"github.com/pavelhadzhiev/story-builder/pkg/config"
@@ -26,12 +28,18 @@ type SBClient struct {
 	config     *config.SBConfiguration
 	httpClient *http.Client
 	headers    *http.Header
+
+	err error // returned by every request, if the client can't be set up
+
+	lastStatus int32 // status code of the last response, see ErrorKind
 }
 
 // NewSBClient creates a new StoryBuilderClient with a given SBConfiguration.
 // It attaches an application/json content-type header and authorization, if set in the configuration.
+// If the TLS settings in the configuration are not valid, every request of the client returns error.
 func NewSBClient(config *config.SBConfiguration) *SBClient {
-	client := &SBClient{config: config, httpClient: &http.Client{}}
+	httpClient, err := newHTTPClient(config)
+	client := &SBClient{config: config, httpClient: httpClient, err: err}
 	client.headers = &http.Header{}
 	client.headers.Add("Content-Type", "application/json")
 	if len(client.config.Authorization) > 0 {
@@ -41,25 +49,59 @@ func NewSBClient(config *config.SBConfiguration) *SBClient {
 	return client
 }
 
+// call sends a request to the story builder server. If the server responds with 429 Too Many Requests, the request is retried
+// once the time in the Retry-After header passes, unless it's longer than maxRetryAfter or the request was already retried maxRetries times.
 func (client *SBClient) call(method string, path string, body io.Reader, headers map[string]string) (*http.Response, error) {
+	if client.err != nil {
+		return nil, client.err
+	}
+
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = ioutil.ReadAll(body); err != nil {
+			return nil, err
+		}
+	}
+
+	for retries := 0; ; retries++ {
+		client.setLastStatus(statusUnreachable)
+		resp, err := client.send(method, path, payload, headers)
+		if err != nil {
+			return nil, err
+		}
+		client.setLastStatus(resp.StatusCode)
+		if resp.StatusCode != http.StatusTooManyRequests {
+			return resp, nil
+		}
+
+		resp.Body.Close()
+		retryAfter := parseRetryAfter(resp.Header.Get("Retry-After"))
+		if retries >= maxRetries || retryAfter > maxRetryAfter {
+			return nil, &TooManyRequestsError{RetryAfter: retryAfter}
+		}
+		sleep(retryAfter)
+	}
+}
+
+func (client *SBClient) send(method string, path string, payload []byte, headers map[string]string) (*http.Response, error) {
 	fullURL := client.config.URL + path
 
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
 	req, err := http.NewRequest(method, fullURL, body)
 	if err != nil {
 		return nil, err
 	}
 
-	req.Header = *client.headers
+	req.Header = client.headers.Clone()
 	for key, value := range headers {
 		req.Header.Add(key, value)
 	}
 
-	resp, err := client.httpClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-
-	return resp, nil
+	return client.httpClient.Do(req)
 }
 
 func NewTestSBClient(config *config.SBConfiguration, httpClient *http.Client) *SBClient {
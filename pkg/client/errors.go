@@ -0,0 +1,88 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"errors"
+	"sync/atomic"
+)
+
+// ErrorKind tells apart the reasons for which the requests of the client fail.
+type ErrorKind int
+
+const (
+	// UnknownError is the kind of errors that didn't come from a request of the client.
+	UnknownError ErrorKind = iota
+	// UserError means the request was invalid, e.g. it referred to a missing room or the server rejected its input.
+	UserError
+	// AuthError means the user couldn't be authenticated or isn't allowed to do what the request asked for.
+	AuthError
+	// ServerError means the server couldn't be reached, failed or kept rejecting the request because of too many requests.
+	ServerError
+)
+
+func (kind ErrorKind) String() string {
+	switch kind {
+	case UserError:
+		return "user"
+	case AuthError:
+		return "auth"
+	case ServerError:
+		return "server"
+	default:
+		return "unknown"
+	}
+}
+
+// statusUnreachable is the last status of the client while a request is being sent and after it fails to get a response.
+const statusUnreachable = -1
+
+func (client *SBClient) setLastStatus(status int) {
+	atomic.StoreInt32(&client.lastStatus, int32(status))
+}
+
+// ResetLastStatus forgets the status code of the last response, so ErrorKind considers errors returned before the next request user errors.
+func (client *SBClient) ResetLastStatus() {
+	client.setLastStatus(0)
+}
+
+// ErrorKind returns the kind of the provided error, returned by the last request of the client, judging by the status code of its response.
+// Errors returned before the client sent any request are considered user errors, as they come from validating the input of the request.
+func (client *SBClient) ErrorKind(err error) ErrorKind {
+	if err == nil {
+		return UnknownError
+	}
+	var tooManyRequests *TooManyRequestsError
+	if errors.As(err, &tooManyRequests) {
+		return ServerError
+	}
+	if client.err != nil {
+		return UserError
+	}
+
+	status := int(atomic.LoadInt32(&client.lastStatus))
+	switch {
+	case status == 0:
+		return UserError
+	case status == statusUnreachable || status >= 500:
+		return ServerError
+	case status == 401 || status == 403:
+		return AuthError
+	case status >= 400:
+		return UserError
+	default: // the server responded successfully, but not as expected
+		return ServerError
+	}
+}
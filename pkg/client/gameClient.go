@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"strings"
 
 	"github.com/pavelhadzhiev/story-builder/pkg/api/game"
 )
@@ -30,14 +31,14 @@ func (client *SBClient) GetGame() (*game.Game, error) {
 	roomName := client.config.Room
 	response, err := client.call(http.MethodGet, "/gameplay/"+roomName, nil, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error during http request: %e", err)
+		return nil, fmt.Errorf("error during http request: %v", err)
 	}
 	switch response.StatusCode {
 	case 200:
 		defer response.Body.Close()
 		game := &game.Game{}
 		if err := json.NewDecoder(response.Body).Decode(game); err != nil {
-			return nil, fmt.Errorf("failed to deserialize response from server: %e", err)
+			return nil, fmt.Errorf("failed to deserialize response from server: %v", err)
 		}
 		return game, nil
 	case 404:
@@ -47,6 +48,54 @@ func (client *SBClient) GetGame() (*game.Game, error) {
 	}
 }
 
+// GetGameByID retrieves a game from the history of the joined room by its ID.
+// Returns error if room doesn't exist or the room has no game with that ID.
+func (client *SBClient) GetGameByID(id int) (*game.Game, error) {
+	roomName := client.config.Room
+	response, err := client.call(http.MethodGet, fmt.Sprintf("/gameplay/%s?id=%d", roomName, id), nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error during http request: %v", err)
+	}
+	switch response.StatusCode {
+	case 200:
+		defer response.Body.Close()
+		game := &game.Game{}
+		if err := json.NewDecoder(response.Body).Decode(game); err != nil {
+			return nil, fmt.Errorf("failed to deserialize response from server: %v", err)
+		}
+		return game, nil
+	case 400:
+		return nil, errors.New("illegal game id")
+	case 404:
+		return nil, fmt.Errorf("room \"%s\" doesn't exist or has no game #%d", roomName, id)
+	default:
+		return nil, errors.New("something went really wrong :(")
+	}
+}
+
+// GetStoryTree retrieves the summaries of the games played in the joined room, which show which stories were forked from which.
+// Returns error if room doesn't exist.
+func (client *SBClient) GetStoryTree() ([]game.Summary, error) {
+	roomName := client.config.Room
+	response, err := client.call(http.MethodGet, "/story-tree/"+roomName, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error during http request: %v", err)
+	}
+	switch response.StatusCode {
+	case 200:
+		defer response.Body.Close()
+		var summaries []game.Summary
+		if err := json.NewDecoder(response.Body).Decode(&summaries); err != nil {
+			return nil, fmt.Errorf("failed to deserialize response from server: %v", err)
+		}
+		return summaries, nil
+	case 404:
+		return nil, errors.New("room \"" + roomName + "\" doesn't exist")
+	default:
+		return nil, errors.New("something went really wrong :(")
+	}
+}
+
 // AddEntry adds the provided entry in the game of the room with the provided name on behalf of the user.
 // Returns error if room doesn't exist, game is not started or it's not the users turn.
 func (client *SBClient) AddEntry(entry string) error {
@@ -55,7 +104,7 @@ func (client *SBClient) AddEntry(entry string) error {
 	headers["Entry-Text"] = entry
 	response, err := client.call(http.MethodPost, "/gameplay/"+roomName, nil, headers)
 	if err != nil {
-		return fmt.Errorf("error during http request: %e", err)
+		return fmt.Errorf("error during http request: %v", err)
 	}
 	switch response.StatusCode {
 	case 200:
@@ -77,8 +126,48 @@ func (client *SBClient) AddEntry(entry string) error {
 }
 
 // StartGame triggers a game in the room with the provided name.
-// Returns error if room doesn't exist, a game is already running or the user doesn't have the required permissions.
-func (client *SBClient) StartGame(timeLimit, maxLength, entriesCount int) error {
+// The prompt is either "random", the ID of a prompt from the server's prompt library or empty if the story should start blank.
+// The constraints are the optional challenges every entry must satisfy - pass nil if you don't want any.
+// Returns error if room doesn't exist, a game is already running, the prompt or the constraints are illegal or the user doesn't have the required permissions.
+func (client *SBClient) StartGame(timeLimit, maxLength, entriesCount int, prompt string, constraints *game.Constraints) error {
+	return client.StartTeamGame("", timeLimit, maxLength, entriesCount, prompt, constraints)
+}
+
+// StartTeamGame triggers a team game in the joined room, in which every team builds its own story and a final vote picks the winning one.
+// The teams are either the number of teams to split the room into or a list of teams in the format "name=player1,player2;name2=player3". Pass an empty string for a regular game.
+// The other settings are the same as for StartGame, with the entries count applying to each team separately.
+// Returns error if room doesn't exist, a game is already running, the teams, prompt or constraints are illegal or the user doesn't have the required permissions.
+func (client *SBClient) StartTeamGame(teams string, timeLimit, maxLength, entriesCount int, prompt string, constraints *game.Constraints) error {
+	headers := make(map[string]string)
+	if prompt != "" {
+		headers["Prompt"] = prompt
+	}
+	if teams != "" {
+		headers["Teams"] = teams
+	}
+	return client.startGame(headers, timeLimit, maxLength, entriesCount, constraints)
+}
+
+// ForkGame starts a new game in the joined room, whose story continues a finished game from its entry number "at" (counting from 1).
+// The game ID selects which of the room's finished games to fork - pass 0 for the last one.
+// The other settings are the same as for StartGame.
+// Returns error if room doesn't exist, a game is already running, the game can't be forked at that entry or the user doesn't have the required permissions.
+func (client *SBClient) ForkGame(gameID, at, timeLimit, maxLength, entriesCount int, constraints *game.Constraints) error {
+	if gameID < 0 {
+		return errors.New("cannot start game: negative game id")
+	}
+	if at <= 0 {
+		return errors.New("cannot start game: the entry to fork at must be positive")
+	}
+	headers := make(map[string]string)
+	headers["Fork-At"] = fmt.Sprint(at)
+	if gameID != 0 {
+		headers["Game-ID"] = fmt.Sprint(gameID)
+	}
+	return client.startGame(headers, timeLimit, maxLength, entriesCount, constraints)
+}
+
+func (client *SBClient) startGame(headers map[string]string, timeLimit, maxLength, entriesCount int, constraints *game.Constraints) error {
 	if timeLimit < 0 {
 		return errors.New("cannot start game: negative time limit value")
 	}
@@ -93,17 +182,24 @@ func (client *SBClient) StartGame(timeLimit, maxLength, entriesCount int) error
 	}
 	roomName := client.config.Room
 
-	headers := make(map[string]string)
 	headers["Time-Limit"] = fmt.Sprint(timeLimit)
 	headers["Max-Length"] = fmt.Sprint(maxLength)
 	headers["Entries-Count"] = fmt.Sprint(entriesCount)
+	addConstraintHeaders(headers, constraints)
 	response, err := client.call(http.MethodPost, "/manage-games/"+roomName, nil, headers)
 	if err != nil {
-		return fmt.Errorf("error during http request: %e", err)
+		return fmt.Errorf("error during http request: %v", err)
 	}
 	switch response.StatusCode {
 	case 200:
 		return nil
+	case 400:
+		defer response.Body.Close()
+		errorMessage, err := ioutil.ReadAll(response.Body)
+		if err != nil {
+			return err
+		}
+		return fmt.Errorf("cannot start game: %s", string(errorMessage))
 	case 403:
 		return errors.New("cannot start game: requires admin access")
 	case 404:
@@ -115,6 +211,37 @@ func (client *SBClient) StartGame(timeLimit, maxLength, entriesCount int) error
 	}
 }
 
+// VoteForTeam casts the user's vote for the winning story in the final vote of the team game in the joined room.
+// Returns error if room doesn't exist, there is no final vote going on or the vote is illegal.
+func (client *SBClient) VoteForTeam(team string) error {
+	roomName := client.config.Room
+	headers := make(map[string]string)
+	headers["Team"] = team
+	response, err := client.call(http.MethodPost, "/team-vote/"+roomName, nil, headers)
+	if err != nil {
+		return fmt.Errorf("error during http request: %v", err)
+	}
+	switch response.StatusCode {
+	case 200:
+		return nil
+	case 400:
+		return errors.New("missing team to vote for")
+	case 403:
+		defer response.Body.Close()
+		errorMessage, err := ioutil.ReadAll(response.Body)
+		if err != nil {
+			return err
+		}
+		return fmt.Errorf("illegal vote: %s", string(errorMessage))
+	case 404:
+		return errors.New("room \"" + roomName + "\" doesn't exist")
+	case 409:
+		return errors.New("there is no final vote going on in \"" + roomName + "\"")
+	default:
+		return errors.New("something went really wrong :(")
+	}
+}
+
 // EndGame ends a running game. Once called it will set the game's remaining entries to the provided number and the game will effectively end after the count is reached.
 // Returns error if room doesn't exist, no game is running or the user doesn't have the required permissions.
 func (client *SBClient) EndGame(entriesCount int) error {
@@ -129,7 +256,7 @@ func (client *SBClient) EndGame(entriesCount int) error {
 	headers["Entries-Count"] = fmt.Sprint(entriesCount)
 	response, err := client.call(http.MethodDelete, "/manage-games/"+roomName, nil, headers)
 	if err != nil {
-		return fmt.Errorf("error during http request: %e", err)
+		return fmt.Errorf("error during http request: %v", err)
 	}
 	switch response.StatusCode {
 	case 202:
@@ -145,17 +272,192 @@ func (client *SBClient) EndGame(entriesCount int) error {
 	}
 }
 
-// TriggerVoteKick triggers a democratic vote to kick the player with the provided username from the game in the room with the provided room name.
-// Returns error if room doesn't exist, game is not started, the player is not in the game, or another vote is currently ongoing.
-func (client *SBClient) TriggerVoteKick(playerToKick string) error {
-	response, err := client.call(http.MethodPost, "/vote/"+client.config.Room+"/"+playerToKick, nil, nil)
+// OpenLobby opens a ready-check lobby for a new game in the joined room. The game settings are the same as for StartGame.
+// The quorum is the part of the players in the room that must be ready for the game to start (0 means everyone) and the countdown is the time in seconds after which the game starts anyway (0 means no countdown).
+// Returns error if room doesn't exist, a game is already running, the prompt or the constraints are illegal or the user doesn't have the required permissions.
+func (client *SBClient) OpenLobby(timeLimit, maxLength, entriesCount int, quorum float64, countdown int, prompt string, constraints *game.Constraints) error {
+	if timeLimit < 0 {
+		return errors.New("cannot open lobby: negative time limit value")
+	}
+	if maxLength < 0 {
+		return errors.New("cannot open lobby: negative max length value")
+	}
+	if entriesCount < 0 {
+		return errors.New("cannot open lobby: negative entries value")
+	}
+	if quorum < 0 || quorum > 1 {
+		return errors.New("cannot open lobby: quorum must be between 0 and 1")
+	}
+	if countdown < 0 {
+		return errors.New("cannot open lobby: negative countdown value")
+	}
+	if client.config.Room == "" {
+		return errors.New("cannot open lobby: requires user to be joined in the room")
+	}
+	roomName := client.config.Room
+
+	headers := make(map[string]string)
+	headers["Time-Limit"] = fmt.Sprint(timeLimit)
+	headers["Max-Length"] = fmt.Sprint(maxLength)
+	headers["Entries-Count"] = fmt.Sprint(entriesCount)
+	headers["Quorum"] = fmt.Sprint(quorum)
+	headers["Countdown"] = fmt.Sprint(countdown)
+	if prompt != "" {
+		headers["Prompt"] = prompt
+	}
+	addConstraintHeaders(headers, constraints)
+	response, err := client.call(http.MethodPost, "/lobby/"+roomName, nil, headers)
 	if err != nil {
-		return fmt.Errorf("error during http request: %e", err)
+		return fmt.Errorf("error during http request: %v", err)
 	}
 	switch response.StatusCode {
-	case 202:
+	case 200:
+		return nil
+	case 400:
+		defer response.Body.Close()
+		errorMessage, err := ioutil.ReadAll(response.Body)
+		if err != nil {
+			return err
+		}
+		return fmt.Errorf("cannot open lobby: %s", string(errorMessage))
+	case 403:
+		return errors.New("cannot open lobby: requires admin access")
+	case 404:
+		return errors.New("room \"" + roomName + "\" doesn't exist")
+	case 409:
+		return errors.New("a game is already running in \"" + roomName + "\"")
+	default:
+		return errors.New("something went really wrong :(")
+	}
+}
+
+// Ready marks the user as ready in the open lobby of the joined room.
+// Returns error if room doesn't exist, there isn't an open lobby or the user is already ready.
+func (client *SBClient) Ready() error {
+	roomName := client.config.Room
+	response, err := client.call(http.MethodPut, "/lobby/"+roomName, nil, nil)
+	if err != nil {
+		return fmt.Errorf("error during http request: %v", err)
+	}
+	switch response.StatusCode {
+	case 200:
+		return nil
+	case 403:
+		return errors.New("cannot get ready: user is not in room \"" + roomName + "\"")
+	case 404:
+		return errors.New("room \"" + roomName + "\" doesn't exist")
+	case 409:
+		defer response.Body.Close()
+		errorMessage, err := ioutil.ReadAll(response.Body)
+		if err != nil {
+			return err
+		}
+		return fmt.Errorf("cannot get ready: %s", string(errorMessage))
+	default:
+		return errors.New("something went really wrong :(")
+	}
+}
+
+// CloseLobby cancels the open lobby in the joined room without starting the game.
+// Returns error if room doesn't exist, there isn't an open lobby or the user doesn't have the required permissions.
+func (client *SBClient) CloseLobby() error {
+	roomName := client.config.Room
+	response, err := client.call(http.MethodDelete, "/lobby/"+roomName, nil, nil)
+	if err != nil {
+		return fmt.Errorf("error during http request: %v", err)
+	}
+	switch response.StatusCode {
+	case 200:
 		return nil
+	case 403:
+		return errors.New("cannot close lobby: requires admin access")
 	case 404:
+		return errors.New("room \"" + roomName + "\" doesn't exist")
+	case 409:
+		return errors.New("there isn't an open lobby in \"" + roomName + "\"")
+	default:
+		return errors.New("something went really wrong :(")
+	}
+}
+
+// PauseGame freezes the running game in the joined room, including its turn timer and any ongoing vote.
+// Returns error if room doesn't exist, no game is running, the game is already paused or the user doesn't have the required permissions.
+func (client *SBClient) PauseGame() error {
+	if client.config.Room == "" {
+		return errors.New("cannot pause game: requires user to be joined in the room")
+	}
+	roomName := client.config.Room
+	response, err := client.call(http.MethodPost, "/pause-game/"+roomName, nil, nil)
+	if err != nil {
+		return fmt.Errorf("error during http request: %v", err)
+	}
+	switch response.StatusCode {
+	case 200:
+		return nil
+	case 403:
+		return errors.New("cannot pause game: requires admin access")
+	case 404:
+		return errors.New("room \"" + roomName + "\" doesn't exist")
+	case 409:
+		defer response.Body.Close()
+		errorMessage, err := ioutil.ReadAll(response.Body)
+		if err != nil {
+			return err
+		}
+		return fmt.Errorf("cannot pause game: %s", string(errorMessage))
+	default:
+		return errors.New("something went really wrong :(")
+	}
+}
+
+// ResumeGame unfreezes a paused game in the joined room.
+// Returns error if room doesn't exist, no game is running, the game is not paused or the user doesn't have the required permissions.
+func (client *SBClient) ResumeGame() error {
+	if client.config.Room == "" {
+		return errors.New("cannot resume game: requires user to be joined in the room")
+	}
+	roomName := client.config.Room
+	response, err := client.call(http.MethodDelete, "/pause-game/"+roomName, nil, nil)
+	if err != nil {
+		return fmt.Errorf("error during http request: %v", err)
+	}
+	switch response.StatusCode {
+	case 200:
+		return nil
+	case 403:
+		return errors.New("cannot resume game: requires admin access")
+	case 404:
+		return errors.New("room \"" + roomName + "\" doesn't exist")
+	case 409:
+		defer response.Body.Close()
+		errorMessage, err := ioutil.ReadAll(response.Body)
+		if err != nil {
+			return err
+		}
+		return fmt.Errorf("cannot resume game: %s", string(errorMessage))
+	default:
+		return errors.New("something went really wrong :(")
+	}
+}
+
+// TriggerVote triggers a democratic vote of the provided kind (kick, skip, end or revert) in the game in the joined room.
+// The target is the player to kick for kick votes and should be empty for the other kinds.
+// Returns error if room doesn't exist, game is not started, the target is not in the game, or a vote for the same thing is currently ongoing.
+func (client *SBClient) TriggerVote(kind, target string) error {
+	path := "/vote/" + client.config.Room
+	if target != "" {
+		path += "/" + target
+	}
+	headers := make(map[string]string)
+	headers["Vote-Kind"] = kind
+	response, err := client.call(http.MethodPost, path, nil, headers)
+	if err != nil {
+		return fmt.Errorf("error during http request: %v", err)
+	}
+	switch response.StatusCode {
+	case 202:
+		return nil
+	case 400, 404:
 		defer response.Body.Close()
 		errorMessage, err := ioutil.ReadAll(response.Body)
 		if err != nil {
@@ -163,25 +465,42 @@ func (client *SBClient) TriggerVoteKick(playerToKick string) error {
 		}
 		return fmt.Errorf("could not trigger vote: %s", string(errorMessage))
 	case 409:
-		return errors.New("there is already an ongoing vote")
+		return errors.New("there is already an ongoing vote for this")
+	case 423:
+		return errors.New("could not trigger vote: the game is paused")
 	default:
 		return errors.New("something went really wrong :(")
 	}
 }
 
-// SubmitVote tells the server that the user agrees with the ongoing vote.
-// Returns error if room doesn't exist, game is not started or no vote is currently running.
-func (client *SBClient) SubmitVote() error {
-	response, err := client.call(http.MethodPut, "/vote/"+client.config.Room, nil, nil)
+// TriggerVoteKick triggers a democratic vote to kick the player with the provided username from the game in the room with the provided room name.
+// Returns error if room doesn't exist, game is not started, the player is not in the game, or another vote to kick the player is currently ongoing.
+func (client *SBClient) TriggerVoteKick(playerToKick string) error {
+	return client.TriggerVote(string(game.KickVote), playerToKick)
+}
+
+// CastVote submits the user's vote in favour or against the ongoing vote with the provided ID.
+// If the ID is 0, the vote is cast for the only ongoing vote.
+// Returns error if room doesn't exist, game is not started or no such vote is currently running.
+func (client *SBClient) CastVote(voteID int, inFavour bool) error {
+	headers := make(map[string]string)
+	if voteID != 0 {
+		headers["Vote-ID"] = fmt.Sprint(voteID)
+	}
+	headers["Vote-Choice"] = "yes"
+	if !inFavour {
+		headers["Vote-Choice"] = "no"
+	}
+	response, err := client.call(http.MethodPut, "/vote/"+client.config.Room, nil, headers)
 	if err != nil {
-		return fmt.Errorf("error during http request: %e", err)
+		return fmt.Errorf("error during http request: %v", err)
 	}
 	switch response.StatusCode {
 	case 200:
 		return nil
 	case 403:
 		return errors.New("cannot vote: user is not part of the game")
-	case 404:
+	case 400, 404:
 		defer response.Body.Close()
 		errorMessage, err := ioutil.ReadAll(response.Body)
 		if err != nil {
@@ -190,7 +509,30 @@ func (client *SBClient) SubmitVote() error {
 		return fmt.Errorf("cannot vote: %s", string(errorMessage))
 	case 409:
 		return errors.New("cannot vote: user has already voted once")
+	case 423:
+		return errors.New("cannot vote: the game is paused")
 	default:
 		return errors.New("something went really wrong :(")
 	}
 }
+
+// SubmitVote tells the server that the user agrees with the only ongoing vote.
+// Returns error if room doesn't exist, game is not started or no vote is currently running.
+func (client *SBClient) SubmitVote() error {
+	return client.CastVote(0, true)
+}
+
+func addConstraintHeaders(headers map[string]string, constraints *game.Constraints) {
+	if constraints == nil {
+		return
+	}
+	if len(constraints.RequiredWords) > 0 {
+		headers["Required-Words"] = strings.Join(constraints.RequiredWords, ",")
+	}
+	if constraints.BannedLetters != "" {
+		headers["Banned-Letters"] = constraints.BannedLetters
+	}
+	if constraints.Rhyme {
+		headers["Rhyme"] = "true"
+	}
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/pavelhadzhiev/story-builder/pkg/config"
 
+	"github.com/pavelhadzhiev/story-builder/pkg/api/game"
 	"github.com/pavelhadzhiev/story-builder/pkg/api/rooms"
 
 	. "github.com/onsi/ginkgo"
@@ -196,7 +197,7 @@ var _ = Describe("Story Builder Game Client test", func() {
 				It("should not return error", func() {
 					responseStatusCode = http.StatusOK
 
-					err := client.StartGame(timeLimit, maxLength, entriesCount)
+					err := client.StartGame(timeLimit, maxLength, entriesCount, "", nil)
 
 					Expect(err).ShouldNot(HaveOccurred())
 				})
@@ -207,7 +208,7 @@ var _ = Describe("Story Builder Game Client test", func() {
 
 					nonExistentRoom := "non-existent-room"
 					client.config.Room = nonExistentRoom
-					err := client.StartGame(timeLimit, maxLength, entriesCount)
+					err := client.StartGame(timeLimit, maxLength, entriesCount, "", nil)
 
 					Expect(err).Should(HaveOccurred())
 					Expect(err.Error()).To(ContainSubstring(fmt.Sprintf("room \"%s\" doesn't exist", nonExistentRoom)))
@@ -219,7 +220,7 @@ var _ = Describe("Story Builder Game Client test", func() {
 			It("should return error", func() {
 				responseStatusCode = http.StatusConflict
 
-				err := client.StartGame(timeLimit, maxLength, entriesCount)
+				err := client.StartGame(timeLimit, maxLength, entriesCount, "", nil)
 
 				Expect(err).Should(HaveOccurred())
 				Expect(err.Error()).To(ContainSubstring("a game is already running in \"" + room.Name + "\""))
@@ -230,7 +231,7 @@ var _ = Describe("Story Builder Game Client test", func() {
 			It("should return error", func() {
 				responseStatusCode = http.StatusForbidden
 
-				err := client.StartGame(timeLimit, maxLength, entriesCount)
+				err := client.StartGame(timeLimit, maxLength, entriesCount, "", nil)
 
 				Expect(err).Should(HaveOccurred())
 				Expect(err.Error()).To(ContainSubstring("cannot start game: requires admin access"))
@@ -241,7 +242,7 @@ var _ = Describe("Story Builder Game Client test", func() {
 			It("should return error", func() {
 				responseStatusCode = http.StatusCreated
 
-				err := client.StartGame(timeLimit, maxLength, entriesCount)
+				err := client.StartGame(timeLimit, maxLength, entriesCount, "", nil)
 
 				Expect(err).Should(HaveOccurred())
 			})
@@ -253,7 +254,7 @@ var _ = Describe("Story Builder Game Client test", func() {
 
 				responseStatusCode = http.StatusOK
 
-				err := client.StartGame(timeLimit, maxLength, entriesCount)
+				err := client.StartGame(timeLimit, maxLength, entriesCount, "", nil)
 
 				Expect(err).Should(HaveOccurred())
 			})
@@ -263,7 +264,7 @@ var _ = Describe("Story Builder Game Client test", func() {
 			Context("Without being in a room", func() {
 				It("should return error", func() {
 					client.wipeRoom()
-					err := client.StartGame(timeLimit, maxLength, entriesCount)
+					err := client.StartGame(timeLimit, maxLength, entriesCount, "", nil)
 
 					Expect(err).Should(HaveOccurred())
 					Expect(err.Error()).To(ContainSubstring("cannot start game: requires user to be joined in the room"))
@@ -272,7 +273,7 @@ var _ = Describe("Story Builder Game Client test", func() {
 
 			Context("With illegal time left setting", func() {
 				It("should return error", func() {
-					err := client.StartGame(-1, maxLength, entriesCount)
+					err := client.StartGame(-1, maxLength, entriesCount, "", nil)
 
 					Expect(err).Should(HaveOccurred())
 					Expect(err.Error()).To(ContainSubstring("cannot start game: negative time limit value"))
@@ -281,7 +282,7 @@ var _ = Describe("Story Builder Game Client test", func() {
 
 			Context("With illegal max length setting", func() {
 				It("should return error", func() {
-					err := client.StartGame(timeLimit, -1, entriesCount)
+					err := client.StartGame(timeLimit, -1, entriesCount, "", nil)
 
 					Expect(err).Should(HaveOccurred())
 					Expect(err.Error()).To(ContainSubstring("cannot start game: negative max length value"))
@@ -290,7 +291,7 @@ var _ = Describe("Story Builder Game Client test", func() {
 
 			Context("With illegal entries count setting", func() {
 				It("should return error", func() {
-					err := client.StartGame(timeLimit, maxLength, -1)
+					err := client.StartGame(timeLimit, maxLength, -1, "", nil)
 
 					Expect(err).Should(HaveOccurred())
 					Expect(err.Error()).To(ContainSubstring("cannot start game: negative entries value"))
@@ -391,6 +392,239 @@ var _ = Describe("Story Builder Game Client test", func() {
 		})
 	})
 
+	Describe("Start a team game", func() {
+		Context("When request is valid", func() {
+			It("should not return error", func() {
+				responseStatusCode = http.StatusOK
+
+				err := client.StartTeamGame("2", timeLimit, maxLength, entriesCount, "", nil)
+
+				Expect(err).ShouldNot(HaveOccurred())
+			})
+		})
+
+		Context("When the teams are illegal", func() {
+			It("should return the server's reason", func() {
+				responseStatusCode = http.StatusBadRequest
+				responseBody = []byte("Illegal teams: there must be at least two teams.")
+
+				err := client.StartTeamGame("1", timeLimit, maxLength, entriesCount, "", nil)
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("cannot start game: Illegal teams: there must be at least two teams."))
+			})
+		})
+	})
+
+	Describe("Fork a finished game", func() {
+		Context("When request is valid", func() {
+			It("should not return error", func() {
+				responseStatusCode = http.StatusOK
+
+				err := client.ForkGame(0, 2, timeLimit, maxLength, entriesCount, nil)
+
+				Expect(err).ShouldNot(HaveOccurred())
+			})
+		})
+
+		Context("When the fork point is not positive", func() {
+			It("should return error without calling the server", func() {
+				err := client.ForkGame(0, 0, timeLimit, maxLength, entriesCount, nil)
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("the entry to fork at must be positive"))
+			})
+		})
+
+		Context("When the game can't be forked", func() {
+			It("should return the server's reason", func() {
+				responseStatusCode = http.StatusBadRequest
+				responseBody = []byte("Game cannot be forked: the fork point must be between 1 and 2.")
+
+				err := client.ForkGame(1, 5, timeLimit, maxLength, entriesCount, nil)
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("the fork point must be between 1 and 2"))
+			})
+		})
+	})
+
+	Describe("Get the story tree", func() {
+		Context("When request is valid", func() {
+			It("should return the game summaries", func() {
+				responseStatusCode = http.StatusOK
+				responseBody = []byte(`[{"id":1,"entries":3,"finished":true},{"id":2,"forkedFrom":{"gameId":1,"at":2},"entries":2}]`)
+
+				summaries, err := client.GetStoryTree()
+
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(summaries).To(HaveLen(2))
+				Expect(summaries[1].ForkedFrom).To(Equal(&game.Fork{GameID: 1, At: 2}))
+			})
+		})
+
+		Context("When room doesn't exist", func() {
+			It("should return error", func() {
+				responseStatusCode = http.StatusNotFound
+
+				_, err := client.GetStoryTree()
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("doesn't exist"))
+			})
+		})
+	})
+
+	Describe("Vote for a team", func() {
+		Context("When request is valid", func() {
+			It("should not return error", func() {
+				responseStatusCode = http.StatusOK
+
+				err := client.VoteForTeam("red")
+
+				Expect(err).ShouldNot(HaveOccurred())
+			})
+		})
+
+		Context("When there is no final vote", func() {
+			It("should return error", func() {
+				responseStatusCode = http.StatusConflict
+
+				err := client.VoteForTeam("red")
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("there is no final vote going on"))
+			})
+		})
+
+		Context("When the vote is illegal", func() {
+			It("should return the server's reason", func() {
+				responseStatusCode = http.StatusForbidden
+				responseBody = []byte("There was an error while casting your vote: team \"green\" doesn't exist")
+
+				err := client.VoteForTeam("green")
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("team \"green\" doesn't exist"))
+			})
+		})
+	})
+
+	Describe("Pause a running game", func() {
+		Context("When request is valid", func() {
+			It("should not return error", func() {
+				responseStatusCode = http.StatusOK
+
+				err := client.PauseGame()
+
+				Expect(err).ShouldNot(HaveOccurred())
+			})
+		})
+
+		Context("When room does not exist", func() {
+			It("should return error", func() {
+				responseStatusCode = http.StatusNotFound
+
+				err := client.PauseGame()
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("room \"" + room.Name + "\" doesn't exist"))
+			})
+		})
+
+		Context("When the game cannot be paused", func() {
+			It("should return error", func() {
+				errorMessage := "The game is already paused."
+				responseBody = []byte(errorMessage)
+				responseStatusCode = http.StatusConflict
+
+				err := client.PauseGame()
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring(fmt.Sprintf("cannot pause game: %s", errorMessage)))
+			})
+		})
+
+		Context("When issuer does not have admin access", func() {
+			It("should return error", func() {
+				responseStatusCode = http.StatusForbidden
+
+				err := client.PauseGame()
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("cannot pause game: requires admin access"))
+			})
+		})
+
+		Context("When there is an HTTP error", func() {
+			It("should return error", func() {
+				setupFaultyServer()
+
+				responseStatusCode = http.StatusOK
+
+				err := client.PauseGame()
+
+				Expect(err).Should(HaveOccurred())
+			})
+		})
+
+		Context("Without being in a room", func() {
+			It("should return error", func() {
+				client.wipeRoom()
+				err := client.PauseGame()
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("cannot pause game: requires user to be joined in the room"))
+			})
+		})
+	})
+
+	Describe("Resume a paused game", func() {
+		Context("When request is valid", func() {
+			It("should not return error", func() {
+				responseStatusCode = http.StatusOK
+
+				err := client.ResumeGame()
+
+				Expect(err).ShouldNot(HaveOccurred())
+			})
+		})
+
+		Context("When the game cannot be resumed", func() {
+			It("should return error", func() {
+				errorMessage := "The game is not paused."
+				responseBody = []byte(errorMessage)
+				responseStatusCode = http.StatusConflict
+
+				err := client.ResumeGame()
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring(fmt.Sprintf("cannot resume game: %s", errorMessage)))
+			})
+		})
+
+		Context("When issuer does not have admin access", func() {
+			It("should return error", func() {
+				responseStatusCode = http.StatusForbidden
+
+				err := client.ResumeGame()
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("cannot resume game: requires admin access"))
+			})
+		})
+
+		Context("When invalid status code is returned by SB server", func() {
+			It("should return error", func() {
+				responseStatusCode = http.StatusCreated
+
+				err := client.ResumeGame()
+
+				Expect(err).Should(HaveOccurred())
+			})
+		})
+	})
+
 	Describe("Trigger a vote to kick a player", func() {
 		playerToKick := "some-other-player"
 		room := rooms.NewRoom("roomName", username)
@@ -536,4 +770,156 @@ var _ = Describe("Story Builder Game Client test", func() {
 			})
 		})
 	})
+
+	Describe("Trigger a vote of a specific kind", func() {
+		Context("When request is valid", func() {
+			It("should not return error", func() {
+				responseStatusCode = http.StatusAccepted
+
+				err := client.TriggerVote("skip", "")
+
+				Expect(err).ShouldNot(HaveOccurred())
+			})
+		})
+
+		Context("When the vote kind is illegal", func() {
+			It("should return error", func() {
+				errorMessage := "Illegal Vote-Kind header value."
+				responseBody = []byte(errorMessage)
+				responseStatusCode = http.StatusBadRequest
+
+				err := client.TriggerVote("no-such-kind", "")
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring(fmt.Sprintf("could not trigger vote: %s", errorMessage)))
+			})
+		})
+
+		Context("When the game is paused", func() {
+			It("should return error", func() {
+				responseStatusCode = http.StatusLocked
+
+				err := client.TriggerVote("end", "")
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("could not trigger vote: the game is paused"))
+			})
+		})
+	})
+
+	Describe("Cast a vote for a specific campaign", func() {
+		Context("When request is valid", func() {
+			It("should not return error", func() {
+				responseStatusCode = http.StatusOK
+
+				err := client.CastVote(2, false)
+
+				Expect(err).ShouldNot(HaveOccurred())
+			})
+		})
+
+		Context("When the vote to cast for is ambiguous", func() {
+			It("should return error", func() {
+				errorMessage := "There are several ongoing votes. Specify which one to vote for."
+				responseBody = []byte(errorMessage)
+				responseStatusCode = http.StatusBadRequest
+
+				err := client.CastVote(0, true)
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring(fmt.Sprintf("cannot vote: %s", errorMessage)))
+			})
+		})
+
+		Context("When the game is paused", func() {
+			It("should return error", func() {
+				responseStatusCode = http.StatusLocked
+
+				err := client.CastVote(1, true)
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("cannot vote: the game is paused"))
+			})
+		})
+	})
+
+	Describe("Open a lobby", func() {
+		Context("When request is valid", func() {
+			It("should not return error", func() {
+				responseStatusCode = http.StatusOK
+
+				err := client.OpenLobby(timeLimit, maxLength, entriesCount, 0.5, 30, "", nil)
+
+				Expect(err).ShouldNot(HaveOccurred())
+			})
+		})
+
+		Context("When a game is already running", func() {
+			It("should return error", func() {
+				responseStatusCode = http.StatusConflict
+
+				err := client.OpenLobby(timeLimit, maxLength, entriesCount, 0, 0, "", nil)
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("a game is already running in \"" + room.Name + "\""))
+			})
+		})
+
+		Context("With illegal quorum setting", func() {
+			It("should return error", func() {
+				err := client.OpenLobby(timeLimit, maxLength, entriesCount, 1.5, 0, "", nil)
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("cannot open lobby: quorum must be between 0 and 1"))
+			})
+		})
+	})
+
+	Describe("Get ready in a lobby", func() {
+		Context("When request is valid", func() {
+			It("should not return error", func() {
+				responseStatusCode = http.StatusOK
+
+				err := client.Ready()
+
+				Expect(err).ShouldNot(HaveOccurred())
+			})
+		})
+
+		Context("When the user cannot get ready", func() {
+			It("should return error", func() {
+				errorMessage := "There isn't an open lobby."
+				responseBody = []byte(errorMessage)
+				responseStatusCode = http.StatusConflict
+
+				err := client.Ready()
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring(fmt.Sprintf("cannot get ready: %s", errorMessage)))
+			})
+		})
+	})
+
+	Describe("Close a lobby", func() {
+		Context("When request is valid", func() {
+			It("should not return error", func() {
+				responseStatusCode = http.StatusOK
+
+				err := client.CloseLobby()
+
+				Expect(err).ShouldNot(HaveOccurred())
+			})
+		})
+
+		Context("When issuer does not have admin access", func() {
+			It("should return error", func() {
+				responseStatusCode = http.StatusForbidden
+
+				err := client.CloseLobby()
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("cannot close lobby: requires admin access"))
+			})
+		})
+	})
 })
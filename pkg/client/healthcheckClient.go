@@ -15,11 +15,13 @@
 package client
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
 
+	"github.com/pavelhadzhiev/story-builder/pkg/api/health"
 	"github.com/pavelhadzhiev/story-builder/pkg/config"
 )
 
@@ -34,7 +36,7 @@ func (client *SBClient) HealthCheck(configurator config.SBConfigurator) error {
 	response, err := client.call(http.MethodPost, "/healthcheck/"+client.config.Room, nil, nil)
 	if err != nil {
 		defer client.wipeConnection()
-		return errors.New("server is not valid or unhealthy: Configuration was wiped clean. Use the connect command to connect to a server")
+		return fmt.Errorf("server is not valid or unhealthy (%v): Configuration was wiped clean. Use the connect command to connect to a server", err)
 	}
 	switch response.StatusCode {
 	case 200:
@@ -53,6 +55,27 @@ func (client *SBClient) HealthCheck(configurator config.SBConfigurator) error {
 	}
 }
 
+// GetStatus retrieves the health report of the server, including whether its database is reachable.
+func (client *SBClient) GetStatus() (*health.Status, error) {
+	response, err := client.call(http.MethodGet, "/status", nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error during http request: %v", err)
+	}
+	switch response.StatusCode {
+	case 200:
+		defer response.Body.Close()
+		var status health.Status
+		if err := json.NewDecoder(response.Body).Decode(&status); err != nil {
+			return nil, fmt.Errorf("failed to deserialize response from server: %v", err)
+		}
+		return &status, nil
+	case 404:
+		return nil, errors.New("the server doesn't report its status, it may be running an older version")
+	default:
+		return nil, errors.New("something went really wrong :(")
+	}
+}
+
 func validateURL(URL string) error {
 	if URL == "" {
 		return errors.New("missing URL")
@@ -72,6 +95,8 @@ func validateURL(URL string) error {
 
 func (client *SBClient) wipeConnection() {
 	client.config.URL = ""
+	client.config.CAFile = ""
+	client.config.InsecureSkipVerify = false
 	client.wipeAuthorization()
 }
 
@@ -2,9 +2,12 @@ package client
 
 import (
 	"encoding/base64"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"time"
 
+	"github.com/pavelhadzhiev/story-builder/pkg/api/health"
 	"github.com/pavelhadzhiev/story-builder/pkg/config"
 	"github.com/pavelhadzhiev/story-builder/pkg/config/configfakes"
 
@@ -154,4 +157,32 @@ var _ = Describe("Story Builder Room Client test", func() {
 			})
 		})
 	})
+
+	Describe("Get status", func() {
+		Context("When request is valid", func() {
+			It("should return the status", func() {
+				responseStatusCode = http.StatusOK
+				responseBody, _ = json.Marshal(health.Status{Version: "1.0.0", Uptime: 90, Rooms: 2, Database: health.DatabaseDown})
+
+				status, err := client.GetStatus()
+
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(status.Version).To(Equal("1.0.0"))
+				Expect(status.UptimeDuration()).To(Equal(90 * time.Second))
+				Expect(status.Rooms).To(Equal(2))
+				Expect(status.Healthy()).To(BeFalse())
+			})
+		})
+
+		Context("When the server doesn't have a status endpoint", func() {
+			It("should return error", func() {
+				responseStatusCode = http.StatusNotFound
+
+				_, err := client.GetStatus()
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("older version"))
+			})
+		})
+	})
 })
@@ -0,0 +1,159 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+
+	"github.com/pavelhadzhiev/story-builder/pkg/api/prompts"
+)
+
+// GetPrompts retrieves the server-wide prompts and the prompts of the provided room. Pass an empty room to get the server-wide prompts only.
+func (client *SBClient) GetPrompts(roomName string) ([]prompts.Prompt, error) {
+	path := "/prompts/"
+	if roomName != "" {
+		path += "?room=" + url.QueryEscape(roomName)
+	}
+	response, err := client.call(http.MethodGet, path, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error during http request: %v", err)
+	}
+	switch response.StatusCode {
+	case 200:
+		defer response.Body.Close()
+		var result = make([]prompts.Prompt, 0)
+		if err := json.NewDecoder(response.Body).Decode(&result); err != nil {
+			return nil, fmt.Errorf("failed to deserialize response from server: %v", err)
+		}
+		return result, nil
+	default:
+		return nil, errors.New("something went really wrong :(")
+	}
+}
+
+// GetPrompt retrieves the prompt with the provided ID.
+// Returns error if there is no such prompt.
+func (client *SBClient) GetPrompt(id int) (*prompts.Prompt, error) {
+	response, err := client.call(http.MethodGet, fmt.Sprintf("/prompts/%d", id), nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error during http request: %v", err)
+	}
+	switch response.StatusCode {
+	case 200:
+		defer response.Body.Close()
+		var prompt = &prompts.Prompt{}
+		if err := json.NewDecoder(response.Body).Decode(prompt); err != nil {
+			return nil, fmt.Errorf("failed to deserialize response from server: %v", err)
+		}
+		return prompt, nil
+	case 404:
+		return nil, fmt.Errorf("prompt #%d doesn't exist", id)
+	default:
+		return nil, errors.New("something went really wrong :(")
+	}
+}
+
+// AddPrompt adds a prompt with the provided text to the prompt library of the provided room. Pass an empty room to add a server-wide prompt.
+// Returns the saved prompt or error if the room doesn't exist or the user doesn't have the required permissions.
+func (client *SBClient) AddPrompt(roomName, text string) (*prompts.Prompt, error) {
+	requestBody, err := json.Marshal(&prompts.Prompt{Room: roomName, Text: text})
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize prompt: %v", err)
+	}
+
+	response, err := client.call(http.MethodPost, "/prompts/", bytes.NewBuffer(requestBody), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error during http request: %v", err)
+	}
+	switch response.StatusCode {
+	case 201:
+		defer response.Body.Close()
+		var prompt = &prompts.Prompt{}
+		if err := json.NewDecoder(response.Body).Decode(prompt); err != nil {
+			return nil, fmt.Errorf("failed to deserialize response from server: %v", err)
+		}
+		return prompt, nil
+	case 400:
+		return nil, errors.New("prompt must have a text")
+	case 401:
+		return nil, errors.New("could not authenticate user")
+	case 403:
+		return nil, errors.New("cannot add prompt: requires admin access")
+	case 404:
+		return nil, errors.New("room \"" + roomName + "\" doesn't exist")
+	default:
+		return nil, errors.New("something went really wrong :(")
+	}
+}
+
+// EditPrompt replaces the text of the prompt with the provided ID.
+// Returns error if there is no such prompt or the user doesn't have the required permissions.
+func (client *SBClient) EditPrompt(id int, text string) error {
+	requestBody, err := json.Marshal(&prompts.Prompt{Text: text})
+	if err != nil {
+		return fmt.Errorf("failed to serialize prompt: %v", err)
+	}
+
+	response, err := client.call(http.MethodPut, fmt.Sprintf("/prompts/%d", id), bytes.NewBuffer(requestBody), nil)
+	if err != nil {
+		return fmt.Errorf("error during http request: %v", err)
+	}
+	switch response.StatusCode {
+	case 200:
+		return nil
+	case 400:
+		defer response.Body.Close()
+		errorMessage, err := ioutil.ReadAll(response.Body)
+		if err != nil {
+			return err
+		}
+		return fmt.Errorf("cannot edit prompt: %s", string(errorMessage))
+	case 401:
+		return errors.New("could not authenticate user")
+	case 403:
+		return errors.New("cannot edit prompt: requires admin access")
+	case 404:
+		return fmt.Errorf("prompt #%d doesn't exist", id)
+	default:
+		return errors.New("something went really wrong :(")
+	}
+}
+
+// DeletePrompt removes the prompt with the provided ID from the prompt library.
+// Returns error if there is no such prompt or the user doesn't have the required permissions.
+func (client *SBClient) DeletePrompt(id int) error {
+	response, err := client.call(http.MethodDelete, fmt.Sprintf("/prompts/%d", id), nil, nil)
+	if err != nil {
+		return fmt.Errorf("error during http request: %v", err)
+	}
+	switch response.StatusCode {
+	case 204:
+		return nil
+	case 401:
+		return errors.New("could not authenticate user")
+	case 403:
+		return errors.New("cannot delete prompt: requires admin access")
+	case 404:
+		return fmt.Errorf("prompt #%d doesn't exist", id)
+	default:
+		return errors.New("something went really wrong :(")
+	}
+}
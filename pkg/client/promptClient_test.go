@@ -0,0 +1,155 @@
+package client
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pavelhadzhiev/story-builder/pkg/api/prompts"
+	"github.com/pavelhadzhiev/story-builder/pkg/config"
+)
+
+var _ = Describe("Story Builder Prompt Client test", func() {
+	var client *SBClient
+	var responseStatusCode int
+	var responseBody []byte
+	var sbServer *httptest.Server
+	testHandler := TestingHandler(&responseBody, &responseStatusCode)
+
+	username := "user"
+	password := "password"
+	authHeader := base64.StdEncoding.EncodeToString([]byte(username + ":" + password))
+
+	roomName := "roomName"
+	prompt := prompts.Prompt{ID: 1, Room: roomName, Text: "Once upon a time...", Author: username}
+
+	BeforeEach(func() {
+		sbServer = httptest.NewServer(testHandler)
+		clientConfig := &config.SBConfiguration{URL: sbServer.URL, Authorization: authHeader, Room: roomName}
+		client = NewSBClient(clientConfig)
+	})
+
+	Describe("Get prompts", func() {
+		Context("When request is valid", func() {
+			It("should return the prompts", func() {
+				responseStatusCode = http.StatusOK
+				responseBody, _ = json.Marshal([]prompts.Prompt{prompt})
+
+				result, err := client.GetPrompts(roomName)
+
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(result).To(Equal([]prompts.Prompt{prompt}))
+			})
+		})
+
+		Context("When invalid status code is returned by SB server", func() {
+			It("should return error", func() {
+				responseStatusCode = http.StatusCreated
+
+				_, err := client.GetPrompts(roomName)
+
+				Expect(err).Should(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("Get a prompt", func() {
+		Context("When prompt exists", func() {
+			It("should return the prompt", func() {
+				responseStatusCode = http.StatusOK
+				responseBody, _ = json.Marshal(prompt)
+
+				result, err := client.GetPrompt(prompt.ID)
+
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(*result).To(Equal(prompt))
+			})
+		})
+
+		Context("When prompt does not exist", func() {
+			It("should return error", func() {
+				responseStatusCode = http.StatusNotFound
+
+				_, err := client.GetPrompt(prompt.ID)
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("prompt #1 doesn't exist"))
+			})
+		})
+	})
+
+	Describe("Add a prompt", func() {
+		Context("When request is valid", func() {
+			It("should return the saved prompt", func() {
+				responseStatusCode = http.StatusCreated
+				responseBody, _ = json.Marshal(prompt)
+
+				result, err := client.AddPrompt(roomName, prompt.Text)
+
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(*result).To(Equal(prompt))
+			})
+		})
+
+		Context("When issuer does not have admin access", func() {
+			It("should return error", func() {
+				responseStatusCode = http.StatusForbidden
+
+				_, err := client.AddPrompt(roomName, prompt.Text)
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("cannot add prompt: requires admin access"))
+			})
+		})
+	})
+
+	Describe("Edit a prompt", func() {
+		Context("When request is valid", func() {
+			It("should not return error", func() {
+				responseStatusCode = http.StatusOK
+
+				err := client.EditPrompt(prompt.ID, "Twice upon a time...")
+
+				Expect(err).ShouldNot(HaveOccurred())
+			})
+		})
+
+		Context("When the text is rejected", func() {
+			It("should return the server's reason", func() {
+				responseStatusCode = http.StatusBadRequest
+				responseBody = []byte("Prompt must have a text.")
+
+				err := client.EditPrompt(prompt.ID, "")
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("cannot edit prompt: Prompt must have a text."))
+			})
+		})
+	})
+
+	Describe("Delete a prompt", func() {
+		Context("When request is valid", func() {
+			It("should not return error", func() {
+				responseStatusCode = http.StatusNoContent
+
+				err := client.DeletePrompt(prompt.ID)
+
+				Expect(err).ShouldNot(HaveOccurred())
+			})
+		})
+
+		Context("When issuer does not have admin access", func() {
+			It("should return error", func() {
+				responseStatusCode = http.StatusForbidden
+
+				err := client.DeletePrompt(prompt.ID)
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("cannot delete prompt: requires admin access"))
+			})
+		})
+	})
+})
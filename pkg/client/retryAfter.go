@@ -0,0 +1,55 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// maxRetryAfter is the longest time the client waits to retry a request that was rejected because of too many requests.
+const maxRetryAfter = 10 * time.Second
+
+// maxRetries is the number of times the client retries a request that was rejected because of too many requests.
+const maxRetries = 3
+
+// sleep waits before retrying a request. It's a variable so tests don't have to wait.
+var sleep = time.Sleep
+
+// TooManyRequestsError is returned when the server keeps rejecting a request because of too many requests.
+type TooManyRequestsError struct {
+	RetryAfter time.Duration
+}
+
+func (err *TooManyRequestsError) Error() string {
+	return fmt.Sprintf("too many requests to the server, try again in %v", err.RetryAfter)
+}
+
+// parseRetryAfter returns the time to wait according to a Retry-After header, which holds either a number of seconds or an HTTP date.
+// Returns a second if the header is missing or illegal.
+func parseRetryAfter(header string) time.Duration {
+	if seconds, err := strconv.Atoi(header); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(header); err == nil {
+		if wait := time.Until(date); wait > 0 {
+			return wait
+		}
+		return 0
+	}
+	return time.Second
+}
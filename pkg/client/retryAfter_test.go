@@ -0,0 +1,89 @@
+package client
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pavelhadzhiev/story-builder/pkg/api/rooms"
+	"github.com/pavelhadzhiev/story-builder/pkg/config"
+)
+
+var _ = Describe("Story Builder Client Retry-After test", func() {
+	var client *SBClient
+	var sbServer *httptest.Server
+	var rejections int
+	var retryAfter string
+	var requestBodies []string
+	var waits []time.Duration
+
+	BeforeEach(func() {
+		requestBodies = make([]string, 0)
+		waits = make([]time.Duration, 0)
+		sleep = func(wait time.Duration) {
+			waits = append(waits, wait)
+		}
+
+		sbServer = httptest.NewServer(http.HandlerFunc(func(response http.ResponseWriter, req *http.Request) {
+			body, _ := ioutil.ReadAll(req.Body)
+			requestBodies = append(requestBodies, string(body))
+			if rejections > 0 {
+				rejections--
+				response.Header().Set("Retry-After", retryAfter)
+				response.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			response.WriteHeader(http.StatusCreated)
+		}))
+		client = NewSBClient(&config.SBConfiguration{URL: sbServer.URL})
+	})
+
+	AfterEach(func() {
+		sleep = time.Sleep
+		sbServer.Close()
+	})
+
+	Context("When the server asks to retry later", func() {
+		It("should wait and resend the request with the same body", func() {
+			rejections = 2
+			retryAfter = "3"
+
+			err := client.CreateNewRoom(rooms.NewRoom("roomName", "user"))
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(waits).To(Equal([]time.Duration{3 * time.Second, 3 * time.Second}))
+			Expect(requestBodies).To(HaveLen(3))
+			Expect(requestBodies[2]).To(Equal(requestBodies[0]))
+			Expect(requestBodies[0]).To(ContainSubstring("roomName"))
+		})
+	})
+
+	Context("When the server asks to retry after too long", func() {
+		It("should return error without retrying", func() {
+			rejections = 1
+			retryAfter = "120"
+
+			err := client.CreateNewRoom(rooms.NewRoom("roomName", "user"))
+
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("too many requests to the server, try again in 2m0s"))
+			Expect(waits).To(BeEmpty())
+		})
+	})
+
+	Context("When the server keeps rejecting the request", func() {
+		It("should give up after the max retries", func() {
+			rejections = 10
+			retryAfter = "1"
+
+			err := client.CreateNewRoom(rooms.NewRoom("roomName", "user"))
+
+			Expect(err).Should(HaveOccurred())
+			Expect(waits).To(HaveLen(maxRetries))
+			Expect(requestBodies).To(HaveLen(maxRetries + 1))
+		})
+	})
+})
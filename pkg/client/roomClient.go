@@ -19,23 +19,25 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 
 	"github.com/pavelhadzhiev/story-builder/pkg/api/rooms"
+	"github.com/pavelhadzhiev/story-builder/pkg/util"
 )
 
 // GetAllRooms retrieves all rooms from the server and returns them.
 func (client *SBClient) GetAllRooms() ([]rooms.Room, error) {
 	response, err := client.call(http.MethodGet, "/rooms/", nil, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error during http request: %e", err)
+		return nil, fmt.Errorf("error during http request: %v", err)
 	}
 	switch response.StatusCode {
 	case 200:
 		defer response.Body.Close()
 		var rooms = make([]rooms.Room, 0, 100)
 		if err := json.NewDecoder(response.Body).Decode(&rooms); err != nil {
-			return nil, fmt.Errorf("failed to deserialize response from server: %e", err)
+			return nil, fmt.Errorf("failed to deserialize response from server: %v", err)
 		}
 		return rooms, nil
 	default:
@@ -44,21 +46,34 @@ func (client *SBClient) GetAllRooms() ([]rooms.Room, error) {
 }
 
 // CreateNewRoom creates a new room in the server, using the provided model.
-// Returns error if a room with this name already exists.
+// Returns error if the room name is not valid or a room with this name already exists.
 func (client *SBClient) CreateNewRoom(room *rooms.Room) error {
+	if err := util.ValidateRoomName(room.Name); err != nil {
+		return err
+	}
+
 	requestBody, err := json.Marshal(room)
 	if err != nil {
-		return fmt.Errorf("failed to serialize room: %e", err)
+		return fmt.Errorf("failed to serialize room: %v", err)
 	}
 
 	buffer := bytes.NewBuffer(requestBody)
 	response, err := client.call(http.MethodPost, "/rooms/", buffer, nil)
 	if err != nil {
-		return fmt.Errorf("error during http request: %e", err)
+		return fmt.Errorf("error during http request: %v", err)
 	}
 	switch response.StatusCode {
 	case 201:
 		return nil
+	case 400:
+		defer response.Body.Close()
+		errorMessage, err := ioutil.ReadAll(response.Body)
+		if err != nil {
+			return err
+		}
+		return fmt.Errorf("cannot create room: %s", string(errorMessage))
+	case 401:
+		return errors.New("could not authenticate user")
 	case 409:
 		return errors.New("room \"" + room.Name + "\" already exists")
 	default:
@@ -71,7 +86,7 @@ func (client *SBClient) CreateNewRoom(room *rooms.Room) error {
 func (client *SBClient) GetRoom(roomName string) (*rooms.Room, error) {
 	response, err := client.call(http.MethodGet, "/rooms/"+roomName, nil, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error during http request: %e", err)
+		return nil, fmt.Errorf("error during http request: %v", err)
 	}
 	switch response.StatusCode {
 	case 200:
@@ -93,11 +108,13 @@ func (client *SBClient) GetRoom(roomName string) (*rooms.Room, error) {
 func (client *SBClient) DeleteRoom(roomName string) error {
 	response, err := client.call(http.MethodDelete, "/rooms/"+roomName, nil, nil)
 	if err != nil {
-		return fmt.Errorf("error during http request: %e", err)
+		return fmt.Errorf("error during http request: %v", err)
 	}
 	switch response.StatusCode {
 	case 204:
 		return nil
+	case 401:
+		return errors.New("could not authenticate user")
 	case 403:
 		return errors.New("user doesn't have permissions to delete this room")
 	case 404:
@@ -112,7 +129,7 @@ func (client *SBClient) DeleteRoom(roomName string) error {
 func (client *SBClient) JoinRoom(roomName string) error {
 	response, err := client.call(http.MethodPost, "/join-room/"+roomName, nil, nil)
 	if err != nil {
-		return fmt.Errorf("error during http request: %e", err)
+		return fmt.Errorf("error during http request: %v", err)
 	}
 	switch response.StatusCode {
 	case 200:
@@ -130,7 +147,7 @@ func (client *SBClient) JoinRoom(roomName string) error {
 func (client *SBClient) LeaveRoom(roomName string) error {
 	response, err := client.call(http.MethodPost, "/leave-room/"+roomName, nil, nil)
 	if err != nil {
-		return fmt.Errorf("error during http request: %e", err)
+		return fmt.Errorf("error during http request: %v", err)
 	}
 	switch response.StatusCode {
 	case 200:
@@ -122,6 +122,29 @@ var _ = Describe("Story Builder Room Client test", func() {
 			})
 		})
 
+		Context("When the room name is not valid", func() {
+			It("should return error without calling the server", func() {
+				responseStatusCode = http.StatusCreated
+
+				err := client.CreateNewRoom(&rooms.Room{Name: " padded "})
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("room name can't start or end with a space"))
+			})
+		})
+
+		Context("When the server rejects the room name", func() {
+			It("should return error", func() {
+				responseStatusCode = http.StatusBadRequest
+				responseBody = []byte("Room name is not valid.")
+
+				err := client.CreateNewRoom(room)
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("cannot create room: Room name is not valid."))
+			})
+		})
+
 		Context("When invalid status code is returned by SB server", func() {
 			It("should return error", func() {
 				responseBody, _ = json.Marshal(roomList)
@@ -0,0 +1,184 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/pavelhadzhiev/story-builder/pkg/api/audit"
+	"github.com/pavelhadzhiev/story-builder/pkg/api/users"
+)
+
+// GetUsers retrieves all users of the server, along with their role and account status.
+// Returns error if the user doesn't have server admin access.
+func (client *SBClient) GetUsers() ([]users.User, error) {
+	response, err := client.call(http.MethodGet, "/server-admin/users", nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error during http request: %v", err)
+	}
+	switch response.StatusCode {
+	case 200:
+		defer response.Body.Close()
+		var result = make([]users.User, 0)
+		if err := json.NewDecoder(response.Body).Decode(&result); err != nil {
+			return nil, fmt.Errorf("failed to deserialize response from server: %v", err)
+		}
+		return result, nil
+	default:
+		return nil, serverAdminError(response)
+	}
+}
+
+// DisableUser disables the account of the provided user, who gets logged out and can't log in until the account is enabled.
+// Returns error if the user doesn't exist or the issuer doesn't have server admin access.
+func (client *SBClient) DisableUser(username string) error {
+	return client.manageUser(http.MethodPost, username, "disable", nil)
+}
+
+// EnableUser enables the disabled account of the provided user.
+// Returns error if the user doesn't exist or the issuer doesn't have server admin access.
+func (client *SBClient) EnableUser(username string) error {
+	return client.manageUser(http.MethodDelete, username, "disable", nil)
+}
+
+// BanUserFromServer bans the provided user from all rooms of the server.
+// Returns error if the user doesn't exist or the issuer doesn't have server admin access.
+func (client *SBClient) BanUserFromServer(username string) error {
+	return client.manageUser(http.MethodPost, username, "ban", nil)
+}
+
+// UnbanUserFromServer lifts the server-wide ban of the provided user.
+// Returns error if the user doesn't exist or the issuer doesn't have server admin access.
+func (client *SBClient) UnbanUserFromServer(username string) error {
+	return client.manageUser(http.MethodDelete, username, "ban", nil)
+}
+
+// PromoteServerAdmin gives the server admin role to the provided user.
+// Returns error if the user doesn't exist or the issuer doesn't have server admin access.
+func (client *SBClient) PromoteServerAdmin(username string) error {
+	return client.manageUser(http.MethodPost, username, "admin", nil)
+}
+
+// DemoteServerAdmin takes the server admin role from the provided user.
+// Returns error if the user doesn't exist or the issuer doesn't have server admin access.
+func (client *SBClient) DemoteServerAdmin(username string) error {
+	return client.manageUser(http.MethodDelete, username, "admin", nil)
+}
+
+// ResetPassword replaces the password of the provided user with the provided one.
+// Returns error if the user doesn't exist or the issuer doesn't have server admin access.
+func (client *SBClient) ResetPassword(username, newPassword string) error {
+	if newPassword == "" {
+		return errors.New("the new password can't be empty")
+	}
+	headers := make(map[string]string)
+	headers["New-Password"] = newPassword
+	return client.manageUser(http.MethodPut, username, "password", headers)
+}
+
+// DeleteAnyRoom deletes the room with the provided name, regardless of who created it.
+// Returns error if the room doesn't exist or the issuer doesn't have server admin access.
+func (client *SBClient) DeleteAnyRoom(roomName string) error {
+	response, err := client.call(http.MethodDelete, "/server-admin/rooms/"+roomName, nil, nil)
+	if err != nil {
+		return fmt.Errorf("error during http request: %v", err)
+	}
+	switch response.StatusCode {
+	case 204:
+		return nil
+	default:
+		return serverAdminError(response)
+	}
+}
+
+func (client *SBClient) manageUser(method, username, action string, headers map[string]string) error {
+	response, err := client.call(method, "/server-admin/users/"+username+"/"+action, nil, headers)
+	if err != nil {
+		return fmt.Errorf("error during http request: %v", err)
+	}
+	switch response.StatusCode {
+	case 200:
+		return nil
+	default:
+		return serverAdminError(response)
+	}
+}
+
+// GetAuditLog retrieves the entries of the server's audit log that match the provided filter, oldest first.
+// Returns error if the issuer doesn't have server admin access or the audit log is not enabled.
+func (client *SBClient) GetAuditLog(filter audit.Filter) ([]audit.Entry, error) {
+	query := url.Values{}
+	if filter.Actor != "" {
+		query.Set("actor", filter.Actor)
+	}
+	if filter.Action != "" {
+		query.Set("action", filter.Action)
+	}
+	if filter.Room != "" {
+		query.Set("room", filter.Room)
+	}
+	if !filter.Since.IsZero() {
+		query.Set("since", filter.Since.Format(time.RFC3339))
+	}
+	if filter.Limit > 0 {
+		query.Set("limit", strconv.Itoa(filter.Limit))
+	}
+	path := "/server-admin/audit"
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+
+	response, err := client.call(http.MethodGet, path, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error during http request: %v", err)
+	}
+	switch response.StatusCode {
+	case 200:
+		defer response.Body.Close()
+		var result = make([]audit.Entry, 0)
+		if err := json.NewDecoder(response.Body).Decode(&result); err != nil {
+			return nil, fmt.Errorf("failed to deserialize response from server: %v", err)
+		}
+		return result, nil
+	default:
+		return nil, serverAdminError(response)
+	}
+}
+
+// serverAdminError builds the error for an unsuccessful response of the server admin API.
+func serverAdminError(response *http.Response) error {
+	switch response.StatusCode {
+	case 401:
+		return errors.New("could not authenticate user")
+	case 403:
+		return errors.New("requires server admin access")
+	case 400, 404, 409:
+		defer response.Body.Close()
+		errorMessage, err := ioutil.ReadAll(response.Body)
+		if err != nil {
+			return err
+		}
+		return fmt.Errorf("server admin action failed: %s", string(errorMessage))
+	default:
+		return errors.New("something went really wrong :(")
+	}
+}
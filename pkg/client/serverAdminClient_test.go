@@ -0,0 +1,158 @@
+package client
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pavelhadzhiev/story-builder/pkg/api/audit"
+	"github.com/pavelhadzhiev/story-builder/pkg/api/users"
+	"github.com/pavelhadzhiev/story-builder/pkg/config"
+)
+
+var _ = Describe("Story Builder Server Admin Client test", func() {
+	var client *SBClient
+	var responseStatusCode int
+	var responseBody []byte
+	var sbServer *httptest.Server
+	testHandler := TestingHandler(&responseBody, &responseStatusCode)
+
+	username := "user"
+	password := "password"
+	authHeader := base64.StdEncoding.EncodeToString([]byte(username + ":" + password))
+
+	player := "test-player"
+
+	BeforeEach(func() {
+		sbServer = httptest.NewServer(testHandler)
+		clientConfig := &config.SBConfiguration{URL: sbServer.URL, Authorization: authHeader}
+		client = NewSBClient(clientConfig)
+	})
+
+	Describe("Get users", func() {
+		Context("When request is valid", func() {
+			It("should return the users", func() {
+				responseStatusCode = http.StatusOK
+				responseBody, _ = json.Marshal([]users.User{{Username: player, Role: users.RoleUser, Banned: true}})
+
+				allUsers, err := client.GetUsers()
+
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(allUsers).To(HaveLen(1))
+				Expect(allUsers[0].Username).To(Equal(player))
+				Expect(allUsers[0].Banned).To(BeTrue())
+			})
+		})
+
+		Context("When issuer is not a server admin", func() {
+			It("should return error", func() {
+				responseStatusCode = http.StatusForbidden
+
+				_, err := client.GetUsers()
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("requires server admin access"))
+			})
+		})
+	})
+
+	Describe("Disable user", func() {
+		Context("When request is valid", func() {
+			It("should not return error", func() {
+				responseStatusCode = http.StatusOK
+
+				err := client.DisableUser(player)
+
+				Expect(err).ShouldNot(HaveOccurred())
+			})
+		})
+
+		Context("When user doesn't exist", func() {
+			It("should return error", func() {
+				responseStatusCode = http.StatusNotFound
+				responseBody = []byte("User doesn't exist.")
+
+				err := client.DisableUser(player)
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("server admin action failed: User doesn't exist."))
+			})
+		})
+	})
+
+	Describe("Reset password", func() {
+		Context("When the new password is empty", func() {
+			It("should return error", func() {
+				err := client.ResetPassword(player, "")
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("the new password can't be empty"))
+			})
+		})
+
+		Context("When credentials are wrong", func() {
+			It("should return error", func() {
+				responseStatusCode = http.StatusUnauthorized
+
+				err := client.ResetPassword(player, "new-password")
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("could not authenticate user"))
+			})
+		})
+	})
+
+	Describe("Delete any room", func() {
+		Context("When request is valid", func() {
+			It("should not return error", func() {
+				responseStatusCode = http.StatusNoContent
+
+				err := client.DeleteAnyRoom("roomName")
+
+				Expect(err).ShouldNot(HaveOccurred())
+			})
+		})
+
+		Context("When invalid status code is returned by SB server", func() {
+			It("should return error", func() {
+				responseStatusCode = http.StatusOK
+
+				err := client.DeleteAnyRoom("roomName")
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("something went really wrong :("))
+			})
+		})
+	})
+
+	Describe("Get audit log", func() {
+		Context("When request is valid", func() {
+			It("should return the entries", func() {
+				responseStatusCode = http.StatusOK
+				responseBody, _ = json.Marshal([]audit.Entry{{ID: 1, Action: audit.RoomBan, Actor: username, Target: player, Room: "roomName"}})
+
+				entries, err := client.GetAuditLog(audit.Filter{Actor: username, Limit: 10})
+
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(entries).To(HaveLen(1))
+				Expect(entries[0].Action).To(Equal(audit.RoomBan))
+				Expect(entries[0].Target).To(Equal(player))
+			})
+		})
+
+		Context("When the audit log is not enabled", func() {
+			It("should return error", func() {
+				responseStatusCode = http.StatusNotFound
+				responseBody = []byte("The audit log is not enabled on this server.")
+
+				_, err := client.GetAuditLog(audit.Filter{})
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("server admin action failed: The audit log is not enabled on this server."))
+			})
+		})
+	})
+})
@@ -0,0 +1,87 @@
+package client
+
+import (
+	"crypto/tls"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pavelhadzhiev/story-builder/pkg/config"
+	"github.com/pavelhadzhiev/story-builder/pkg/config/configfakes"
+	"github.com/pavelhadzhiev/story-builder/pkg/util"
+)
+
+var _ = Describe("Story Builder Client TLS test", func() {
+	var sbServer *httptest.Server
+	var tempDir string
+	var certFile string
+	configurator := &configfakes.FakeSBConfigurator{}
+
+	BeforeEach(func() {
+		var err error
+		tempDir, err = ioutil.TempDir("", "story-builder-tls")
+		Expect(err).ShouldNot(HaveOccurred())
+
+		// Serve HTTPS with a freshly generated self-signed certificate
+		certFile = filepath.Join(tempDir, "server.crt")
+		keyFile := filepath.Join(tempDir, "server.key")
+		Expect(util.GenerateSelfSignedCertificate(certFile, keyFile, []string{"127.0.0.1"})).To(Succeed())
+		certificate, err := tls.LoadX509KeyPair(certFile, keyFile)
+		Expect(err).ShouldNot(HaveOccurred())
+
+		sbServer = httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		sbServer.TLS = &tls.Config{Certificates: []tls.Certificate{certificate}}
+		sbServer.StartTLS()
+	})
+
+	AfterEach(func() {
+		sbServer.Close()
+		os.RemoveAll(tempDir)
+	})
+
+	Context("When the server certificate is trusted through the CA file", func() {
+		It("should connect", func() {
+			client := NewSBClient(&config.SBConfiguration{URL: sbServer.URL, CAFile: certFile})
+
+			Expect(client.HealthCheck(configurator)).To(Succeed())
+		})
+	})
+
+	Context("When the server certificate is not trusted", func() {
+		It("should return error and wipe the connection", func() {
+			clientConfig := &config.SBConfiguration{URL: sbServer.URL}
+			client := NewSBClient(clientConfig)
+
+			err := client.HealthCheck(configurator)
+
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("certificate"))
+			Expect(clientConfig.URL).To(BeEmpty())
+		})
+	})
+
+	Context("When certificate verification is skipped", func() {
+		It("should connect", func() {
+			client := NewSBClient(&config.SBConfiguration{URL: sbServer.URL, InsecureSkipVerify: true})
+
+			Expect(client.HealthCheck(configurator)).To(Succeed())
+		})
+	})
+
+	Context("When the CA file is not valid", func() {
+		It("should return error", func() {
+			notPEM := filepath.Join(tempDir, "not-a-certificate.txt")
+			Expect(ioutil.WriteFile(notPEM, []byte("hello"), 0644)).To(Succeed())
+			client := NewSBClient(&config.SBConfiguration{URL: sbServer.URL, CAFile: notPEM})
+
+			err := client.HealthCheck(configurator)
+
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("doesn't contain any PEM certificates"))
+		})
+	})
+})
@@ -0,0 +1,152 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/pavelhadzhiev/story-builder/pkg/api/webhooks"
+)
+
+// GetWebhooks retrieves the webhooks of the joined room, without their secrets.
+// Returns error if the room doesn't exist or the user doesn't have admin access for the room.
+func (client *SBClient) GetWebhooks() ([]webhooks.Webhook, error) {
+	roomName := client.config.Room
+	response, err := client.call(http.MethodGet, "/webhooks/"+roomName, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error during http request: %v", err)
+	}
+	switch response.StatusCode {
+	case 200:
+		defer response.Body.Close()
+		var result = make([]webhooks.Webhook, 0)
+		if err := json.NewDecoder(response.Body).Decode(&result); err != nil {
+			return nil, fmt.Errorf("failed to deserialize response from server: %v", err)
+		}
+		return result, nil
+	case 401:
+		return nil, errors.New("could not authenticate user")
+	case 403:
+		return nil, errors.New("user does not have permissions to manage webhooks in room \"" + roomName + "\"")
+	case 404:
+		return nil, errors.New("room \"" + roomName + "\" doesn't exist")
+	default:
+		return nil, errors.New("something went really wrong :(")
+	}
+}
+
+// AddWebhook subscribes the provided URL to the provided events of the joined room - pass no events to subscribe to all of them.
+// Pass an empty secret to let the server generate one. Returns the added webhook with its secret, which is not returned ever again,
+// or error if the room doesn't exist, the webhook is not valid or the user doesn't have admin access for the room.
+func (client *SBClient) AddWebhook(url string, events []string, secret string) (*webhooks.Webhook, error) {
+	roomName := client.config.Room
+	requestBody, err := json.Marshal(&webhooks.Webhook{URL: url, Events: events, Secret: secret})
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize webhook: %v", err)
+	}
+
+	response, err := client.call(http.MethodPost, "/webhooks/"+roomName, bytes.NewBuffer(requestBody), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error during http request: %v", err)
+	}
+	switch response.StatusCode {
+	case 201:
+		defer response.Body.Close()
+		var webhook = &webhooks.Webhook{}
+		if err := json.NewDecoder(response.Body).Decode(webhook); err != nil {
+			return nil, fmt.Errorf("failed to deserialize response from server: %v", err)
+		}
+		return webhook, nil
+	case 400:
+		defer response.Body.Close()
+		errorMessage, err := ioutil.ReadAll(response.Body)
+		if err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("could not add webhook: %s", string(errorMessage))
+	case 401:
+		return nil, errors.New("could not authenticate user")
+	case 403:
+		return nil, errors.New("user does not have permissions to manage webhooks in room \"" + roomName + "\"")
+	case 404:
+		return nil, errors.New("room \"" + roomName + "\" doesn't exist")
+	default:
+		return nil, errors.New("something went really wrong :(")
+	}
+}
+
+// RemoveWebhook unsubscribes the webhook with the provided ID from the events of the joined room.
+// Returns error if the room or the webhook doesn't exist or the user doesn't have admin access for the room.
+func (client *SBClient) RemoveWebhook(id int) error {
+	roomName := client.config.Room
+	response, err := client.call(http.MethodDelete, fmt.Sprintf("/webhooks/%s/%d", roomName, id), nil, nil)
+	if err != nil {
+		return fmt.Errorf("error during http request: %v", err)
+	}
+	switch response.StatusCode {
+	case 204:
+		return nil
+	case 401:
+		return errors.New("could not authenticate user")
+	case 403:
+		return errors.New("user does not have permissions to manage webhooks in room \"" + roomName + "\"")
+	case 400, 404:
+		defer response.Body.Close()
+		errorMessage, err := ioutil.ReadAll(response.Body)
+		if err != nil {
+			return err
+		}
+		return fmt.Errorf("could not remove webhook: %s", string(errorMessage))
+	default:
+		return errors.New("something went really wrong :(")
+	}
+}
+
+// GetWebhookDeliveries retrieves the delivery log of the webhook with the provided ID in the joined room, oldest first.
+// Returns error if the room or the webhook doesn't exist or the user doesn't have admin access for the room.
+func (client *SBClient) GetWebhookDeliveries(id int) ([]webhooks.Delivery, error) {
+	roomName := client.config.Room
+	response, err := client.call(http.MethodGet, fmt.Sprintf("/webhooks/%s/%d/deliveries", roomName, id), nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error during http request: %v", err)
+	}
+	switch response.StatusCode {
+	case 200:
+		defer response.Body.Close()
+		var result = make([]webhooks.Delivery, 0)
+		if err := json.NewDecoder(response.Body).Decode(&result); err != nil {
+			return nil, fmt.Errorf("failed to deserialize response from server: %v", err)
+		}
+		return result, nil
+	case 401:
+		return nil, errors.New("could not authenticate user")
+	case 403:
+		return nil, errors.New("user does not have permissions to manage webhooks in room \"" + roomName + "\"")
+	case 400, 404:
+		defer response.Body.Close()
+		errorMessage, err := ioutil.ReadAll(response.Body)
+		if err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("could not get webhook deliveries: %s", string(errorMessage))
+	default:
+		return nil, errors.New("something went really wrong :(")
+	}
+}
@@ -0,0 +1,148 @@
+package client
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pavelhadzhiev/story-builder/pkg/api/webhooks"
+	"github.com/pavelhadzhiev/story-builder/pkg/config"
+)
+
+var _ = Describe("Story Builder Webhook Client test", func() {
+	var client *SBClient
+	var responseStatusCode int
+	var responseBody []byte
+	var sbServer *httptest.Server
+	testHandler := TestingHandler(&responseBody, &responseStatusCode)
+
+	username := "user"
+	password := "password"
+	authHeader := base64.StdEncoding.EncodeToString([]byte(username + ":" + password))
+
+	roomName := "roomName"
+	webhook := webhooks.Webhook{ID: 1, URL: "https://example.com/hook", Events: []string{"game.finished"}, Creator: username}
+
+	BeforeEach(func() {
+		sbServer = httptest.NewServer(testHandler)
+		clientConfig := &config.SBConfiguration{URL: sbServer.URL, Authorization: authHeader, Room: roomName}
+		client = NewSBClient(clientConfig)
+	})
+
+	Describe("Get webhooks", func() {
+		Context("When request is valid", func() {
+			It("should return the webhooks", func() {
+				responseStatusCode = http.StatusOK
+				responseBody, _ = json.Marshal([]webhooks.Webhook{webhook})
+
+				result, err := client.GetWebhooks()
+
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(result).To(HaveLen(1))
+				Expect(result[0].URL).To(Equal(webhook.URL))
+			})
+		})
+
+		Context("When the user is not an admin", func() {
+			It("should return error", func() {
+				responseStatusCode = http.StatusForbidden
+
+				_, err := client.GetWebhooks()
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("permissions to manage webhooks"))
+			})
+		})
+	})
+
+	Describe("Add a webhook", func() {
+		Context("When request is valid", func() {
+			It("should return the added webhook with its secret", func() {
+				responseStatusCode = http.StatusCreated
+				added := webhook
+				added.Secret = "secret"
+				responseBody, _ = json.Marshal(added)
+
+				result, err := client.AddWebhook(webhook.URL, webhook.Events, "")
+
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(result.ID).To(Equal(1))
+				Expect(result.Secret).To(Equal("secret"))
+			})
+		})
+
+		Context("When the webhook is invalid", func() {
+			It("should return error with the server message", func() {
+				responseStatusCode = http.StatusBadRequest
+				responseBody = []byte("Webhook cannot be added: unknown event.")
+
+				_, err := client.AddWebhook(webhook.URL, []string{"game.paused"}, "")
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("unknown event"))
+			})
+		})
+
+		Context("When the room doesn't exist", func() {
+			It("should return error", func() {
+				responseStatusCode = http.StatusNotFound
+
+				_, err := client.AddWebhook(webhook.URL, nil, "")
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("doesn't exist"))
+			})
+		})
+	})
+
+	Describe("Remove a webhook", func() {
+		Context("When request is valid", func() {
+			It("should not return error", func() {
+				responseStatusCode = http.StatusNoContent
+
+				Expect(client.RemoveWebhook(1)).To(Succeed())
+			})
+		})
+
+		Context("When the webhook doesn't exist", func() {
+			It("should return error with the server message", func() {
+				responseStatusCode = http.StatusNotFound
+				responseBody = []byte("Webhook #1 doesn't exist.")
+
+				err := client.RemoveWebhook(1)
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("Webhook #1 doesn't exist"))
+			})
+		})
+	})
+
+	Describe("Get webhook deliveries", func() {
+		Context("When request is valid", func() {
+			It("should return the deliveries", func() {
+				responseStatusCode = http.StatusOK
+				responseBody, _ = json.Marshal([]webhooks.Delivery{{ID: 1, Webhook: 1, Event: "game.finished", Status: webhooks.Failed, Attempts: 5, StatusCode: 500}})
+
+				result, err := client.GetWebhookDeliveries(1)
+
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(result).To(HaveLen(1))
+				Expect(result[0].Status).To(Equal(webhooks.Failed))
+				Expect(result[0].Attempts).To(Equal(5))
+			})
+		})
+
+		Context("When invalid status code is returned by SB server", func() {
+			It("should return error", func() {
+				responseStatusCode = http.StatusCreated
+
+				_, err := client.GetWebhookDeliveries(1)
+
+				Expect(err).Should(HaveOccurred())
+			})
+		})
+	})
+})
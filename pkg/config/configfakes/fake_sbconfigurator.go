@@ -8,6 +8,54 @@ import (
 )
 
 type FakeSBConfigurator struct {
+	ActiveContextStub        func() (string, error)
+	activeContextMutex       sync.RWMutex
+	activeContextArgsForCall []struct {
+	}
+	activeContextReturns struct {
+		result1 string
+		result2 error
+	}
+	activeContextReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	InsecureFilesStub        func() ([]string, error)
+	insecureFilesMutex       sync.RWMutex
+	insecureFilesArgsForCall []struct {
+	}
+	insecureFilesReturns struct {
+		result1 []string
+		result2 error
+	}
+	insecureFilesReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
+	LoadStub        func() (*config.SBConfiguration, error)
+	loadMutex       sync.RWMutex
+	loadArgsForCall []struct {
+	}
+	loadReturns struct {
+		result1 *config.SBConfiguration
+		result2 error
+	}
+	loadReturnsOnCall map[int]struct {
+		result1 *config.SBConfiguration
+		result2 error
+	}
+	LoadContextsStub        func() (*config.SBContexts, error)
+	loadContextsMutex       sync.RWMutex
+	loadContextsArgsForCall []struct {
+	}
+	loadContextsReturns struct {
+		result1 *config.SBContexts
+		result2 error
+	}
+	loadContextsReturnsOnCall map[int]struct {
+		result1 *config.SBContexts
+		result2 error
+	}
 	SaveStub        func(*config.SBConfiguration) error
 	saveMutex       sync.RWMutex
 	saveArgsForCall []struct {
@@ -19,19 +67,243 @@ type FakeSBConfigurator struct {
 	saveReturnsOnCall map[int]struct {
 		result1 error
 	}
-	LoadStub        func() (*config.SBConfiguration, error)
-	loadMutex       sync.RWMutex
-	loadArgsForCall []struct{}
-	loadReturns     struct {
+	SaveContextsStub        func(*config.SBContexts) error
+	saveContextsMutex       sync.RWMutex
+	saveContextsArgsForCall []struct {
+		arg1 *config.SBContexts
+	}
+	saveContextsReturns struct {
+		result1 error
+	}
+	saveContextsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeSBConfigurator) ActiveContext() (string, error) {
+	fake.activeContextMutex.Lock()
+	ret, specificReturn := fake.activeContextReturnsOnCall[len(fake.activeContextArgsForCall)]
+	fake.activeContextArgsForCall = append(fake.activeContextArgsForCall, struct {
+	}{})
+	stub := fake.ActiveContextStub
+	fakeReturns := fake.activeContextReturns
+	fake.recordInvocation("ActiveContext", []interface{}{})
+	fake.activeContextMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeSBConfigurator) ActiveContextCallCount() int {
+	fake.activeContextMutex.RLock()
+	defer fake.activeContextMutex.RUnlock()
+	return len(fake.activeContextArgsForCall)
+}
+
+func (fake *FakeSBConfigurator) ActiveContextCalls(stub func() (string, error)) {
+	fake.activeContextMutex.Lock()
+	defer fake.activeContextMutex.Unlock()
+	fake.ActiveContextStub = stub
+}
+
+func (fake *FakeSBConfigurator) ActiveContextReturns(result1 string, result2 error) {
+	fake.activeContextMutex.Lock()
+	defer fake.activeContextMutex.Unlock()
+	fake.ActiveContextStub = nil
+	fake.activeContextReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSBConfigurator) ActiveContextReturnsOnCall(i int, result1 string, result2 error) {
+	fake.activeContextMutex.Lock()
+	defer fake.activeContextMutex.Unlock()
+	fake.ActiveContextStub = nil
+	if fake.activeContextReturnsOnCall == nil {
+		fake.activeContextReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.activeContextReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSBConfigurator) InsecureFiles() ([]string, error) {
+	fake.insecureFilesMutex.Lock()
+	ret, specificReturn := fake.insecureFilesReturnsOnCall[len(fake.insecureFilesArgsForCall)]
+	fake.insecureFilesArgsForCall = append(fake.insecureFilesArgsForCall, struct {
+	}{})
+	stub := fake.InsecureFilesStub
+	fakeReturns := fake.insecureFilesReturns
+	fake.recordInvocation("InsecureFiles", []interface{}{})
+	fake.insecureFilesMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeSBConfigurator) InsecureFilesCallCount() int {
+	fake.insecureFilesMutex.RLock()
+	defer fake.insecureFilesMutex.RUnlock()
+	return len(fake.insecureFilesArgsForCall)
+}
+
+func (fake *FakeSBConfigurator) InsecureFilesCalls(stub func() ([]string, error)) {
+	fake.insecureFilesMutex.Lock()
+	defer fake.insecureFilesMutex.Unlock()
+	fake.InsecureFilesStub = stub
+}
+
+func (fake *FakeSBConfigurator) InsecureFilesReturns(result1 []string, result2 error) {
+	fake.insecureFilesMutex.Lock()
+	defer fake.insecureFilesMutex.Unlock()
+	fake.InsecureFilesStub = nil
+	fake.insecureFilesReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSBConfigurator) InsecureFilesReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.insecureFilesMutex.Lock()
+	defer fake.insecureFilesMutex.Unlock()
+	fake.InsecureFilesStub = nil
+	if fake.insecureFilesReturnsOnCall == nil {
+		fake.insecureFilesReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.insecureFilesReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSBConfigurator) Load() (*config.SBConfiguration, error) {
+	fake.loadMutex.Lock()
+	ret, specificReturn := fake.loadReturnsOnCall[len(fake.loadArgsForCall)]
+	fake.loadArgsForCall = append(fake.loadArgsForCall, struct {
+	}{})
+	stub := fake.LoadStub
+	fakeReturns := fake.loadReturns
+	fake.recordInvocation("Load", []interface{}{})
+	fake.loadMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeSBConfigurator) LoadCallCount() int {
+	fake.loadMutex.RLock()
+	defer fake.loadMutex.RUnlock()
+	return len(fake.loadArgsForCall)
+}
+
+func (fake *FakeSBConfigurator) LoadCalls(stub func() (*config.SBConfiguration, error)) {
+	fake.loadMutex.Lock()
+	defer fake.loadMutex.Unlock()
+	fake.LoadStub = stub
+}
+
+func (fake *FakeSBConfigurator) LoadReturns(result1 *config.SBConfiguration, result2 error) {
+	fake.loadMutex.Lock()
+	defer fake.loadMutex.Unlock()
+	fake.LoadStub = nil
+	fake.loadReturns = struct {
 		result1 *config.SBConfiguration
 		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSBConfigurator) LoadReturnsOnCall(i int, result1 *config.SBConfiguration, result2 error) {
+	fake.loadMutex.Lock()
+	defer fake.loadMutex.Unlock()
+	fake.LoadStub = nil
+	if fake.loadReturnsOnCall == nil {
+		fake.loadReturnsOnCall = make(map[int]struct {
+			result1 *config.SBConfiguration
+			result2 error
+		})
 	}
-	loadReturnsOnCall map[int]struct {
+	fake.loadReturnsOnCall[i] = struct {
 		result1 *config.SBConfiguration
 		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSBConfigurator) LoadContexts() (*config.SBContexts, error) {
+	fake.loadContextsMutex.Lock()
+	ret, specificReturn := fake.loadContextsReturnsOnCall[len(fake.loadContextsArgsForCall)]
+	fake.loadContextsArgsForCall = append(fake.loadContextsArgsForCall, struct {
+	}{})
+	stub := fake.LoadContextsStub
+	fakeReturns := fake.loadContextsReturns
+	fake.recordInvocation("LoadContexts", []interface{}{})
+	fake.loadContextsMutex.Unlock()
+	if stub != nil {
+		return stub()
 	}
-	invocations      map[string][][]interface{}
-	invocationsMutex sync.RWMutex
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeSBConfigurator) LoadContextsCallCount() int {
+	fake.loadContextsMutex.RLock()
+	defer fake.loadContextsMutex.RUnlock()
+	return len(fake.loadContextsArgsForCall)
+}
+
+func (fake *FakeSBConfigurator) LoadContextsCalls(stub func() (*config.SBContexts, error)) {
+	fake.loadContextsMutex.Lock()
+	defer fake.loadContextsMutex.Unlock()
+	fake.LoadContextsStub = stub
+}
+
+func (fake *FakeSBConfigurator) LoadContextsReturns(result1 *config.SBContexts, result2 error) {
+	fake.loadContextsMutex.Lock()
+	defer fake.loadContextsMutex.Unlock()
+	fake.LoadContextsStub = nil
+	fake.loadContextsReturns = struct {
+		result1 *config.SBContexts
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSBConfigurator) LoadContextsReturnsOnCall(i int, result1 *config.SBContexts, result2 error) {
+	fake.loadContextsMutex.Lock()
+	defer fake.loadContextsMutex.Unlock()
+	fake.LoadContextsStub = nil
+	if fake.loadContextsReturnsOnCall == nil {
+		fake.loadContextsReturnsOnCall = make(map[int]struct {
+			result1 *config.SBContexts
+			result2 error
+		})
+	}
+	fake.loadContextsReturnsOnCall[i] = struct {
+		result1 *config.SBContexts
+		result2 error
+	}{result1, result2}
 }
 
 func (fake *FakeSBConfigurator) Save(arg1 *config.SBConfiguration) error {
@@ -40,15 +312,17 @@ func (fake *FakeSBConfigurator) Save(arg1 *config.SBConfiguration) error {
 	fake.saveArgsForCall = append(fake.saveArgsForCall, struct {
 		arg1 *config.SBConfiguration
 	}{arg1})
+	stub := fake.SaveStub
+	fakeReturns := fake.saveReturns
 	fake.recordInvocation("Save", []interface{}{arg1})
 	fake.saveMutex.Unlock()
-	if fake.SaveStub != nil {
-		return fake.SaveStub(arg1)
+	if stub != nil {
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	return fake.saveReturns.result1
+	return fakeReturns.result1
 }
 
 func (fake *FakeSBConfigurator) SaveCallCount() int {
@@ -57,13 +331,22 @@ func (fake *FakeSBConfigurator) SaveCallCount() int {
 	return len(fake.saveArgsForCall)
 }
 
+func (fake *FakeSBConfigurator) SaveCalls(stub func(*config.SBConfiguration) error) {
+	fake.saveMutex.Lock()
+	defer fake.saveMutex.Unlock()
+	fake.SaveStub = stub
+}
+
 func (fake *FakeSBConfigurator) SaveArgsForCall(i int) *config.SBConfiguration {
 	fake.saveMutex.RLock()
 	defer fake.saveMutex.RUnlock()
-	return fake.saveArgsForCall[i].arg1
+	argsForCall := fake.saveArgsForCall[i]
+	return argsForCall.arg1
 }
 
 func (fake *FakeSBConfigurator) SaveReturns(result1 error) {
+	fake.saveMutex.Lock()
+	defer fake.saveMutex.Unlock()
 	fake.SaveStub = nil
 	fake.saveReturns = struct {
 		result1 error
@@ -71,6 +354,8 @@ func (fake *FakeSBConfigurator) SaveReturns(result1 error) {
 }
 
 func (fake *FakeSBConfigurator) SaveReturnsOnCall(i int, result1 error) {
+	fake.saveMutex.Lock()
+	defer fake.saveMutex.Unlock()
 	fake.SaveStub = nil
 	if fake.saveReturnsOnCall == nil {
 		fake.saveReturnsOnCall = make(map[int]struct {
@@ -82,56 +367,82 @@ func (fake *FakeSBConfigurator) SaveReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeSBConfigurator) Load() (*config.SBConfiguration, error) {
-	fake.loadMutex.Lock()
-	ret, specificReturn := fake.loadReturnsOnCall[len(fake.loadArgsForCall)]
-	fake.loadArgsForCall = append(fake.loadArgsForCall, struct{}{})
-	fake.recordInvocation("Load", []interface{}{})
-	fake.loadMutex.Unlock()
-	if fake.LoadStub != nil {
-		return fake.LoadStub()
+func (fake *FakeSBConfigurator) SaveContexts(arg1 *config.SBContexts) error {
+	fake.saveContextsMutex.Lock()
+	ret, specificReturn := fake.saveContextsReturnsOnCall[len(fake.saveContextsArgsForCall)]
+	fake.saveContextsArgsForCall = append(fake.saveContextsArgsForCall, struct {
+		arg1 *config.SBContexts
+	}{arg1})
+	stub := fake.SaveContextsStub
+	fakeReturns := fake.saveContextsReturns
+	fake.recordInvocation("SaveContexts", []interface{}{arg1})
+	fake.saveContextsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
 	}
 	if specificReturn {
-		return ret.result1, ret.result2
+		return ret.result1
 	}
-	return fake.loadReturns.result1, fake.loadReturns.result2
+	return fakeReturns.result1
 }
 
-func (fake *FakeSBConfigurator) LoadCallCount() int {
-	fake.loadMutex.RLock()
-	defer fake.loadMutex.RUnlock()
-	return len(fake.loadArgsForCall)
+func (fake *FakeSBConfigurator) SaveContextsCallCount() int {
+	fake.saveContextsMutex.RLock()
+	defer fake.saveContextsMutex.RUnlock()
+	return len(fake.saveContextsArgsForCall)
 }
 
-func (fake *FakeSBConfigurator) LoadReturns(result1 *config.SBConfiguration, result2 error) {
-	fake.LoadStub = nil
-	fake.loadReturns = struct {
-		result1 *config.SBConfiguration
-		result2 error
-	}{result1, result2}
+func (fake *FakeSBConfigurator) SaveContextsCalls(stub func(*config.SBContexts) error) {
+	fake.saveContextsMutex.Lock()
+	defer fake.saveContextsMutex.Unlock()
+	fake.SaveContextsStub = stub
 }
 
-func (fake *FakeSBConfigurator) LoadReturnsOnCall(i int, result1 *config.SBConfiguration, result2 error) {
-	fake.LoadStub = nil
-	if fake.loadReturnsOnCall == nil {
-		fake.loadReturnsOnCall = make(map[int]struct {
-			result1 *config.SBConfiguration
-			result2 error
+func (fake *FakeSBConfigurator) SaveContextsArgsForCall(i int) *config.SBContexts {
+	fake.saveContextsMutex.RLock()
+	defer fake.saveContextsMutex.RUnlock()
+	argsForCall := fake.saveContextsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeSBConfigurator) SaveContextsReturns(result1 error) {
+	fake.saveContextsMutex.Lock()
+	defer fake.saveContextsMutex.Unlock()
+	fake.SaveContextsStub = nil
+	fake.saveContextsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeSBConfigurator) SaveContextsReturnsOnCall(i int, result1 error) {
+	fake.saveContextsMutex.Lock()
+	defer fake.saveContextsMutex.Unlock()
+	fake.SaveContextsStub = nil
+	if fake.saveContextsReturnsOnCall == nil {
+		fake.saveContextsReturnsOnCall = make(map[int]struct {
+			result1 error
 		})
 	}
-	fake.loadReturnsOnCall[i] = struct {
-		result1 *config.SBConfiguration
-		result2 error
-	}{result1, result2}
+	fake.saveContextsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
 }
 
 func (fake *FakeSBConfigurator) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
-	fake.saveMutex.RLock()
-	defer fake.saveMutex.RUnlock()
+	fake.activeContextMutex.RLock()
+	defer fake.activeContextMutex.RUnlock()
+	fake.insecureFilesMutex.RLock()
+	defer fake.insecureFilesMutex.RUnlock()
 	fake.loadMutex.RLock()
 	defer fake.loadMutex.RUnlock()
+	fake.loadContextsMutex.RLock()
+	defer fake.loadContextsMutex.RUnlock()
+	fake.saveMutex.RLock()
+	defer fake.saveMutex.RUnlock()
+	fake.saveContextsMutex.RLock()
+	defer fake.saveContextsMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -15,10 +15,19 @@
 package config
 
 // SBConfigurator is an objet that can save and load Story Builder Configuration objects.
+// Save and Load work with the configuration of the active context, while LoadContexts and SaveContexts work with all contexts at once.
+// Implementations must keep the authorizations of the contexts where only the current user can read them.
 //go:generate counterfeiter . SBConfigurator
 type SBConfigurator interface {
 	Save(*SBConfiguration) error
 	Load() (*SBConfiguration, error)
+
+	LoadContexts() (*SBContexts, error)
+	SaveContexts(*SBContexts) error
+	ActiveContext() (string, error)
+
+	// InsecureFiles returns the files of the configuration that users other than their owner can access.
+	InsecureFiles() ([]string, error)
 }
 
 // SBConfiguration contains the configuration of the Story Builder CLI.
@@ -26,4 +35,54 @@ type SBConfiguration struct {
 	URL           string `json:"url"`
 	Authorization string `json:"authorization,omitempty"`
 	Room          string `json:"room,omitempty"`
+
+	// CAFile is the path to a PEM file with the certificate authorities that the server's TLS certificate is verified against, in addition to the system ones.
+	CAFile string `json:"caFile,omitempty"`
+	// InsecureSkipVerify disables the verification of the server's TLS certificate. Use it only for testing.
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+}
+
+// DefaultContext is the name of the context that is created when the CLI is used without contexts, and that configurations from before contexts existed are migrated to.
+const DefaultContext = "default"
+
+// SBContext is a named server profile of the Story Builder CLI, with its own server, credentials and current room.
+type SBContext struct {
+	Name            string `json:"name"`
+	SBConfiguration `mapstructure:",squash"`
+}
+
+// SBContexts contains all contexts of the Story Builder CLI and the name of the current one.
+type SBContexts struct {
+	Current  string      `json:"currentContext,omitempty" mapstructure:"currentContext"`
+	Contexts []SBContext `json:"contexts"`
+}
+
+// Get returns the context with the provided name, or nil if there is no such context.
+func (contexts *SBContexts) Get(name string) *SBContext {
+	for index := range contexts.Contexts {
+		if contexts.Contexts[index].Name == name {
+			return &contexts.Contexts[index]
+		}
+	}
+	return nil
+}
+
+// Set replaces the configuration of the context with the provided name, adding the context if it doesn't exist.
+func (contexts *SBContexts) Set(name string, config SBConfiguration) {
+	if context := contexts.Get(name); context != nil {
+		context.SBConfiguration = config
+		return
+	}
+	contexts.Contexts = append(contexts.Contexts, SBContext{Name: name, SBConfiguration: config})
+}
+
+// Delete removes the context with the provided name. Returns false if there is no such context.
+func (contexts *SBContexts) Delete(name string) bool {
+	for index := range contexts.Contexts {
+		if contexts.Contexts[index].Name == name {
+			contexts.Contexts = append(contexts.Contexts[:index], contexts.Contexts[index+1:]...)
+			return true
+		}
+	}
+	return false
 }
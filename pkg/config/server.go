@@ -0,0 +1,230 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"net"
+	"net/mail"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pavelhadzhiev/story-builder/pkg/api/game"
+	"github.com/pavelhadzhiev/story-builder/pkg/api/logging"
+	"github.com/pavelhadzhiev/story-builder/pkg/api/ratelimit"
+	"github.com/pavelhadzhiev/story-builder/pkg/api/reminders"
+	"github.com/pavelhadzhiev/story-builder/pkg/api/webhooks"
+	"github.com/pavelhadzhiev/story-builder/pkg/db"
+)
+
+// ServerConfiguration contains the configuration of a Story Builder server, as read from a configuration file and the environment.
+type ServerConfiguration struct {
+	// Listen is the address the server listens at, e.g. ":8080" or "127.0.0.1:8080".
+	Listen   string      `json:"listen"`
+	Database db.Settings `json:"database"`
+	TLS      TLSSettings `json:"tls"`
+
+	// Game holds the settings of new games, used when the player starting a game doesn't provide them.
+	Game game.Settings `json:"game"`
+	// Votes holds the acceptance ratio and the time limit of each vote kind.
+	Votes      map[game.VoteKind]game.VoteSettings `json:"votes"`
+	RateLimits ratelimit.Settings                  `json:"rateLimits"`
+	// Reminders holds the SMTP relay that emails players when it's their turn.
+	Reminders reminders.Settings `json:"reminders"`
+	// Webhooks holds the networks that webhooks may deliver to, even though they are private.
+	Webhooks webhooks.Settings `json:"webhooks"`
+
+	// LogLevel is one of "debug", "info", "warn" and "error".
+	LogLevel string `json:"logLevel"`
+	// LogFormat is either "logfmt" or "json".
+	LogFormat    string           `json:"logFormat"`
+	Audit        AuditSettings    `json:"audit"`
+	ServerAdmins []string         `json:"serverAdmins,omitempty"`
+	Shutdown     ShutdownSettings `json:"shutdown"`
+}
+
+// AuditSettings configure the audit log, which records security-relevant actions, such as logins, bans and deleted rooms.
+type AuditSettings struct {
+	// File is the file the audit log is appended to.
+	File string `json:"file,omitempty"`
+	// Disabled turns off the audit log.
+	Disabled bool `json:"disabled,omitempty"`
+}
+
+// TLSSettings configure HTTPS. Either provide both a certificate and a key or ask for a self-signed certificate.
+type TLSSettings struct {
+	Cert       string `json:"cert,omitempty"`
+	Key        string `json:"key,omitempty"`
+	SelfSigned bool   `json:"selfSigned,omitempty"`
+}
+
+// Enabled returns true if the server should serve HTTPS.
+func (settings TLSSettings) Enabled() bool {
+	return settings.SelfSigned || settings.Cert != ""
+}
+
+// ShutdownSettings configure what the server does when it shuts down.
+type ShutdownSettings struct {
+	// DrainTimeout is how long the server waits for ongoing requests to finish.
+	DrainTimeout time.Duration `json:"drainTimeout"`
+	// SnapshotFile is the file in which the rooms and games are saved on shutdown and restored from on the next start.
+	SnapshotFile string `json:"snapshotFile,omitempty"`
+	// DisableSnapshot turns off saving and restoring snapshots.
+	DisableSnapshot bool `json:"disableSnapshot,omitempty"`
+}
+
+// DefaultServerConfiguration returns the configuration used for every setting that isn't provided in the configuration file or the environment.
+func DefaultServerConfiguration() *ServerConfiguration {
+	votes := make(map[game.VoteKind]game.VoteSettings)
+	for kind, settings := range game.DefaultVoteSettings {
+		votes[kind] = settings
+	}
+	return &ServerConfiguration{
+		Listen:     ":8080",
+		Database:   db.DefaultSettings,
+		Game:       game.DefaultSettings,
+		Votes:      votes,
+		RateLimits: ratelimit.DefaultSettings,
+		Reminders:  reminders.DefaultSettings,
+		Webhooks:   webhooks.DefaultSettings,
+		LogLevel:   "info",
+		LogFormat:  string(logging.Logfmt),
+		Shutdown: ShutdownSettings{
+			DrainTimeout: 30 * time.Second,
+		},
+	}
+}
+
+// Validate checks every setting of the configuration.
+// Returns an error listing all invalid settings by their keys in the configuration file, or nil if the configuration is valid.
+func (config *ServerConfiguration) Validate() error {
+	problems := make([]string, 0)
+	invalid := func(key, format string, args ...interface{}) {
+		problems = append(problems, fmt.Sprintf("%s: %s", key, fmt.Sprintf(format, args...)))
+	}
+
+	if _, port, err := net.SplitHostPort(config.Listen); err != nil {
+		invalid("listen", "\"%s\" is not a valid address, use \"host:port\" or \":port\"", config.Listen)
+	} else if portNumber, err := strconv.Atoi(port); err != nil || portNumber < 0 || portNumber > 65535 {
+		invalid("listen", "port \"%s\" must be a number between 0 and 65535", port)
+	}
+
+	if config.Database.DSN == "" {
+		if config.Database.Host == "" {
+			invalid("database.host", "must not be empty")
+		}
+		if config.Database.Port < 1 || config.Database.Port > 65535 {
+			invalid("database.port", "must be between 1 and 65535, got %d", config.Database.Port)
+		}
+		if config.Database.Username == "" {
+			invalid("database.username", "must not be empty")
+		}
+	}
+	if _, _, err := config.Database.MySQLConfig(); err != nil {
+		key := "database.name"
+		if strings.HasPrefix(err.Error(), "invalid DSN") {
+			key = "database.dsn"
+		}
+		invalid(key, "%v", err)
+	}
+
+	if config.TLS.Cert != "" && config.TLS.Key == "" {
+		invalid("tls.key", "must be provided together with tls.cert")
+	}
+	if config.TLS.Key != "" && config.TLS.Cert == "" {
+		invalid("tls.cert", "must be provided together with tls.key")
+	}
+
+	if config.Game.TimeLimit < 0 {
+		invalid("game.timeLimit", "must not be negative, got %d", config.Game.TimeLimit)
+	}
+	if config.Game.MaxLength < 0 {
+		invalid("game.maxLength", "must not be negative, got %d", config.Game.MaxLength)
+	}
+	if config.Game.EntriesCount < 0 {
+		invalid("game.entriesCount", "must not be negative, got %d", config.Game.EntriesCount)
+	}
+
+	kinds := make([]string, 0, len(config.Votes))
+	for kind := range config.Votes {
+		kinds = append(kinds, string(kind))
+	}
+	sort.Strings(kinds)
+	for _, kind := range kinds {
+		settings := config.Votes[game.VoteKind(kind)]
+		if _, err := game.ParseVoteKind(kind); err != nil {
+			invalid("votes."+kind, "%v, must be one of: kick, skip, end, revert", err)
+			continue
+		}
+		if settings.AcceptanceRatio <= 0 || settings.AcceptanceRatio > 1 {
+			invalid("votes."+kind+".acceptanceRatio", "must be greater than 0 and at most 1, got %v", settings.AcceptanceRatio)
+		}
+		if settings.TimeLimit <= 0 {
+			invalid("votes."+kind+".timeLimit", "must be positive, got %d", settings.TimeLimit)
+		}
+	}
+
+	if config.RateLimits.RequestsPerSecond < 0 {
+		invalid("rateLimits.requestsPerSecond", "must not be negative, got %v", config.RateLimits.RequestsPerSecond)
+	}
+	if config.RateLimits.RequestsPerSecond > 0 && config.RateLimits.Burst < 1 {
+		invalid("rateLimits.burst", "must be at least 1 while rate limiting is enabled, got %d", config.RateLimits.Burst)
+	}
+	if config.RateLimits.LockoutThreshold < 0 {
+		invalid("rateLimits.lockoutThreshold", "must not be negative, got %d", config.RateLimits.LockoutThreshold)
+	}
+	if config.RateLimits.LockoutThreshold > 0 {
+		if config.RateLimits.LockoutDuration <= 0 {
+			invalid("rateLimits.lockoutDuration", "must be positive while lockouts are enabled, got %v", config.RateLimits.LockoutDuration)
+		}
+		if config.RateLimits.MaxLockoutDuration < config.RateLimits.LockoutDuration {
+			invalid("rateLimits.maxLockoutDuration", "must not be shorter than rateLimits.lockoutDuration (%v), got %v", config.RateLimits.LockoutDuration, config.RateLimits.MaxLockoutDuration)
+		}
+	}
+
+	if config.Reminders.Enabled() {
+		if config.Reminders.Port < 1 || config.Reminders.Port > 65535 {
+			invalid("reminders.port", "must be between 1 and 65535, got %d", config.Reminders.Port)
+		}
+		if _, err := mail.ParseAddress(config.Reminders.From); err != nil {
+			invalid("reminders.from", "\"%s\" is not a valid email address", config.Reminders.From)
+		}
+	}
+	if config.Reminders.Interval < 0 {
+		invalid("reminders.interval", "must not be negative, got %v", config.Reminders.Interval)
+	}
+
+	if err := config.Webhooks.Validate(); err != nil {
+		invalid("webhooks.allowedNetworks", "%v", err)
+	}
+
+	if _, err := logging.ParseLevel(config.LogLevel); err != nil {
+		invalid("logLevel", "%v", err)
+	}
+	if _, err := logging.ParseFormat(config.LogFormat); err != nil {
+		invalid("logFormat", "%v", err)
+	}
+
+	if config.Shutdown.DrainTimeout < 0 {
+		invalid("shutdown.drainTimeout", "must not be negative, got %v", config.Shutdown.DrainTimeout)
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+	return fmt.Errorf("invalid server configuration:\n  %s", strings.Join(problems, "\n  "))
+}
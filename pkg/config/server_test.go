@@ -0,0 +1,144 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/pavelhadzhiev/story-builder/pkg/api/game"
+)
+
+func TestServerConfigurationValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		modify   func(config *ServerConfiguration)
+		problems string
+	}{
+		{
+			name:   "defaults",
+			modify: func(config *ServerConfiguration) {},
+		},
+		{
+			name: "several invalid settings",
+			modify: func(config *ServerConfiguration) {
+				config.Listen = "8080"
+				config.RateLimits.Burst = 0
+				config.LogLevel = "verbose"
+				config.Shutdown.DrainTimeout = -1
+			},
+			problems: "invalid server configuration:\n" +
+				"  listen: \"8080\" is not a valid address, use \"host:port\" or \":port\"\n" +
+				"  rateLimits.burst: must be at least 1 while rate limiting is enabled, got 0\n" +
+				"  logLevel: unknown log level \"verbose\", must be one of: debug, info, warn, error\n" +
+				"  shutdown.drainTimeout: must not be negative, got -1ns",
+		},
+		{
+			name: "port out of range",
+			modify: func(config *ServerConfiguration) {
+				config.Listen = ":70000"
+			},
+			problems: "invalid server configuration:\n" +
+				"  listen: port \"70000\" must be a number between 0 and 65535",
+		},
+		{
+			name: "database host and port",
+			modify: func(config *ServerConfiguration) {
+				config.Database.Host = ""
+				config.Database.Port = 0
+				config.Database.Username = ""
+			},
+			problems: "invalid server configuration:\n" +
+				"  database.host: must not be empty\n" +
+				"  database.port: must be between 1 and 65535, got 0\n" +
+				"  database.username: must not be empty",
+		},
+		{
+			name: "database DSN instead of host and port",
+			modify: func(config *ServerConfiguration) {
+				config.Database.DSN = "admin:secret@tcp(db.example.com:3306)/stories"
+				config.Database.Host = ""
+				config.Database.Port = 0
+				config.Database.Username = ""
+			},
+		},
+		{
+			name: "invalid database DSN",
+			modify: func(config *ServerConfiguration) {
+				config.Database.DSN = "admin@db.example.com"
+			},
+			problems: "invalid server configuration:\n" +
+				"  database.dsn: invalid DSN: invalid DSN: missing the slash separating the database name",
+		},
+		{
+			name: "invalid database name in DSN",
+			modify: func(config *ServerConfiguration) {
+				config.Database.DSN = "admin:secret@tcp(db.example.com:3306)/my-stories"
+			},
+			problems: "invalid server configuration:\n" +
+				"  database.name: invalid database name \"my-stories\" - it can contain only letters, digits, \"_\" and \"$\" and must be 1 to 64 symbols long",
+		},
+		{
+			name: "TLS key without certificate",
+			modify: func(config *ServerConfiguration) {
+				config.TLS.Key = "server.key"
+			},
+			problems: "invalid server configuration:\n" +
+				"  tls.cert: must be provided together with tls.key",
+		},
+		{
+			name: "votes",
+			modify: func(config *ServerConfiguration) {
+				config.Votes[game.VoteKind("ban")] = game.VoteSettings{AcceptanceRatio: 0.5, TimeLimit: 60}
+				config.Votes[game.KickVote] = game.VoteSettings{AcceptanceRatio: 1.5, TimeLimit: 0}
+			},
+			problems: "invalid server configuration:\n" +
+				"  votes.ban: unknown vote kind \"ban\", must be one of: kick, skip, end, revert\n" +
+				"  votes.kick.acceptanceRatio: must be greater than 0 and at most 1, got 1.5\n" +
+				"  votes.kick.timeLimit: must be positive, got 0",
+		},
+		{
+			name: "lockouts",
+			modify: func(config *ServerConfiguration) {
+				config.RateLimits.LockoutDuration = 0
+				config.RateLimits.MaxLockoutDuration = -1
+			},
+			problems: "invalid server configuration:\n" +
+				"  rateLimits.lockoutDuration: must be positive while lockouts are enabled, got 0s\n" +
+				"  rateLimits.maxLockoutDuration: must not be shorter than rateLimits.lockoutDuration (0s), got -1ns",
+		},
+		{
+			name: "reminders",
+			modify: func(config *ServerConfiguration) {
+				config.Reminders.Host = "smtp.example.com"
+				config.Reminders.Port = 0
+				config.Reminders.From = "noreply"
+			},
+			problems: "invalid server configuration:\n" +
+				"  reminders.port: must be between 1 and 65535, got 0\n" +
+				"  reminders.from: \"noreply\" is not a valid email address",
+		},
+		{
+			name: "webhooks",
+			modify: func(config *ServerConfiguration) {
+				config.Webhooks.AllowedNetworks = []string{"127.0.0.1", "localhost"}
+			},
+			problems: "invalid server configuration:\n" +
+				"  webhooks.allowedNetworks: \"localhost\" is not a valid IP address or CIDR range",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := DefaultServerConfiguration()
+			test.modify(config)
+
+			err := config.Validate()
+			if test.problems == "" {
+				if err != nil {
+					t.Errorf("the configuration should be valid, got %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != test.problems {
+				t.Errorf("expected problems:\n%s\ngot:\n%v", test.problems, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package viper
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/pavelhadzhiev/story-builder/pkg/config"
+)
+
+// credentialsFileSuffix is appended to the configuration file name, without its extension, to get the name of the credentials file.
+const credentialsFileSuffix = "-credentials.json"
+
+// privateFileMode allows only the owner of a file to read and write it.
+const privateFileMode os.FileMode = 0600
+
+// sharedPermissions are the permissions that let users other than the owner access a file.
+const sharedPermissions os.FileMode = 0077
+
+// credentials is the content of the credentials file - the authorization of every context that has a logged in user, by context name.
+type credentials struct {
+	Authorizations map[string]string `json:"authorizations"`
+}
+
+// credentialsPath returns the path of the credentials file that belongs to the configuration file with the provided path.
+func credentialsPath(configPath string) string {
+	return strings.TrimSuffix(configPath, filepath.Ext(configPath)) + credentialsFileSuffix
+}
+
+// readCredentials reads the credentials file. A missing file holds no credentials.
+func (viperConfig *Configurator) readCredentials() (*credentials, error) {
+	creds := &credentials{Authorizations: make(map[string]string)}
+	data, err := ioutil.ReadFile(credentialsPath(viperConfig.path))
+	if os.IsNotExist(err) {
+		return creds, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, creds); err != nil {
+		return nil, err
+	}
+	if creds.Authorizations == nil {
+		creds.Authorizations = make(map[string]string)
+	}
+	return creds, nil
+}
+
+// writeCredentials replaces the credentials file with the authorizations of the provided contexts. The file is removed if no context
+// has a logged in user. It's written to a temporary file first, which only the current user can access, and then renamed, so it's never
+// left half-written or readable by others.
+func (viperConfig *Configurator) writeCredentials(contexts *config.SBContexts) error {
+	path := credentialsPath(viperConfig.path)
+	creds := &credentials{Authorizations: make(map[string]string)}
+	for _, context := range contexts.Contexts {
+		if context.Authorization != "" {
+			creds.Authorizations[context.Name] = context.Authorization
+		}
+	}
+	if len(creds.Authorizations) == 0 {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+
+	data, err := json.MarshalIndent(creds, "", "  ")
+	if err != nil {
+		return err
+	}
+	file, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+	if err := file.Chmod(privateFileMode); err != nil {
+		file.Close()
+		return err
+	}
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), path)
+}
+
+// restrictPermissions makes sure that only the current user can access the file with the provided path, creating it if it's missing.
+func restrictPermissions(path string) error {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY, privateFileMode)
+	if err != nil {
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Chmod(path, privateFileMode)
+}
+
+// InsecureFiles returns the configuration and credentials files that users other than their owner can access.
+// File permissions are not checked on Windows, where they don't apply.
+func (viperConfig *Configurator) InsecureFiles() ([]string, error) {
+	insecureFiles := make([]string, 0)
+	if runtime.GOOS == "windows" {
+		return insecureFiles, nil
+	}
+	for _, path := range []string{viperConfig.path, credentialsPath(viperConfig.path)} {
+		info, err := os.Stat(path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if info.Mode().Perm()&sharedPermissions != 0 {
+			insecureFiles = append(insecureFiles, path)
+		}
+	}
+	return insecureFiles, nil
+}
@@ -0,0 +1,133 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package viper
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/pavelhadzhiev/story-builder/pkg/config"
+)
+
+// EnvPrefix is the prefix of the environment variables that override the server configuration, e.g. SB_DATABASE_HOST overrides database.host.
+const EnvPrefix = "SB"
+
+// LoadServerConfiguration reads the server configuration from the provided file (YAML, JSON or TOML, judging by the extension) and validates it.
+// Pass an empty path to use only the defaults and the environment. Every setting can be overridden with an environment variable, named after
+// its key in upper case, with EnvPrefix and with underscores instead of dots, e.g. SB_RATELIMITS_BURST. The provided flags, keyed by the setting
+// they override, take precedence over everything else, but only if they were set on the command line.
+// Returns error if the file can't be read, holds unknown settings or settings of the wrong type, or if the configuration is not valid.
+func LoadServerConfiguration(cfgFile string, flags map[string]*pflag.Flag) (*config.ServerConfiguration, error) {
+	viper := viper.New()
+	viper.SetEnvPrefix(EnvPrefix)
+	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	viper.AutomaticEnv()
+	setServerDefaults(viper, config.DefaultServerConfiguration())
+
+	if cfgFile != "" {
+		viper.SetConfigFile(cfgFile)
+		if err := viper.ReadInConfig(); err != nil {
+			return nil, fmt.Errorf("cannot read server configuration file \"%s\": %v", cfgFile, err)
+		}
+	}
+
+	for key, flag := range flags {
+		if flag != nil && flag.Changed {
+			if err := viper.BindPFlag(key, flag); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	serverConfig := &config.ServerConfiguration{}
+	if err := viper.UnmarshalExact(serverConfig); err != nil {
+		return nil, decodingError(err)
+	}
+	if err := serverConfig.Validate(); err != nil {
+		return nil, err
+	}
+	return serverConfig, nil
+}
+
+// setServerDefaults registers every setting with its default value. Viper only looks up environment variables for settings it knows about.
+func setServerDefaults(viper *viper.Viper, defaults *config.ServerConfiguration) {
+	viper.SetDefault("listen", defaults.Listen)
+
+	viper.SetDefault("database.dsn", defaults.Database.DSN)
+	viper.SetDefault("database.host", defaults.Database.Host)
+	viper.SetDefault("database.port", defaults.Database.Port)
+	viper.SetDefault("database.name", defaults.Database.Name)
+	viper.SetDefault("database.username", defaults.Database.Username)
+	viper.SetDefault("database.password", defaults.Database.Password)
+
+	viper.SetDefault("tls.cert", defaults.TLS.Cert)
+	viper.SetDefault("tls.key", defaults.TLS.Key)
+	viper.SetDefault("tls.selfSigned", defaults.TLS.SelfSigned)
+
+	viper.SetDefault("game.timeLimit", defaults.Game.TimeLimit)
+	viper.SetDefault("game.maxLength", defaults.Game.MaxLength)
+	viper.SetDefault("game.entriesCount", defaults.Game.EntriesCount)
+
+	for kind, settings := range defaults.Votes {
+		viper.SetDefault("votes."+string(kind)+".acceptanceRatio", settings.AcceptanceRatio)
+		viper.SetDefault("votes."+string(kind)+".timeLimit", settings.TimeLimit)
+	}
+
+	viper.SetDefault("rateLimits.requestsPerSecond", defaults.RateLimits.RequestsPerSecond)
+	viper.SetDefault("rateLimits.burst", defaults.RateLimits.Burst)
+	viper.SetDefault("rateLimits.lockoutThreshold", defaults.RateLimits.LockoutThreshold)
+	viper.SetDefault("rateLimits.lockoutDuration", defaults.RateLimits.LockoutDuration)
+	viper.SetDefault("rateLimits.maxLockoutDuration", defaults.RateLimits.MaxLockoutDuration)
+
+	viper.SetDefault("reminders.host", defaults.Reminders.Host)
+	viper.SetDefault("reminders.port", defaults.Reminders.Port)
+	viper.SetDefault("reminders.username", defaults.Reminders.Username)
+	viper.SetDefault("reminders.password", defaults.Reminders.Password)
+	viper.SetDefault("reminders.from", defaults.Reminders.From)
+	viper.SetDefault("reminders.interval", defaults.Reminders.Interval)
+
+	viper.SetDefault("webhooks.allowedNetworks", []string{})
+
+	viper.SetDefault("logLevel", defaults.LogLevel)
+	viper.SetDefault("logFormat", defaults.LogFormat)
+	viper.SetDefault("audit.file", defaults.Audit.File)
+	viper.SetDefault("audit.disabled", defaults.Audit.Disabled)
+	viper.SetDefault("serverAdmins", []string{})
+
+	viper.SetDefault("shutdown.drainTimeout", defaults.Shutdown.DrainTimeout)
+	viper.SetDefault("shutdown.snapshotFile", defaults.Shutdown.SnapshotFile)
+	viper.SetDefault("shutdown.disableSnapshot", defaults.Shutdown.DisableSnapshot)
+}
+
+// decodingError lists the problems in an error returned while unmarshalling the configuration in the format used by ServerConfiguration.Validate.
+func decodingError(err error) error {
+	problems := make([]string, 0)
+	for _, line := range strings.Split(err.Error(), "\n") {
+		if !strings.HasPrefix(line, "* ") {
+			continue
+		}
+		problem := strings.TrimPrefix(line, "* ")
+		problem = strings.Replace(problem, "'' has invalid keys: ", "unknown settings: ", 1)
+		problem = strings.Replace(problem, "' has invalid keys: ", "' has unknown settings: ", 1)
+		problems = append(problems, problem)
+	}
+	if len(problems) == 0 {
+		return fmt.Errorf("invalid server configuration: %v", err)
+	}
+	return fmt.Errorf("invalid server configuration:\n  %s", strings.Join(problems, "\n  "))
+}
@@ -0,0 +1,280 @@
+package viper
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+// writeConfigFile writes the content to a file with the provided name in a temporary directory and returns its path.
+func writeConfigFile(t *testing.T, name, content string) string {
+	dir, err := ioutil.TempDir("", "story-builder-config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	path := filepath.Join(dir, name)
+	if err := ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// setEnv sets the environment variable until the end of the test.
+func setEnv(t *testing.T, key, value string) {
+	previous, existed := os.LookupEnv(key)
+	if err := os.Setenv(key, value); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if existed {
+			os.Setenv(key, previous)
+		} else {
+			os.Unsetenv(key)
+		}
+	})
+}
+
+// hostFlags returns the flags of the host command that override the rate limits, parsed from the provided arguments.
+func hostFlags(t *testing.T, args ...string) map[string]*pflag.Flag {
+	flagSet := pflag.NewFlagSet("host", pflag.ContinueOnError)
+	flagSet.Float64("rate-limit", 10, "")
+	flagSet.Int("rate-burst", 30, "")
+	if err := flagSet.Parse(args); err != nil {
+		t.Fatal(err)
+	}
+	return map[string]*pflag.Flag{
+		"rateLimits.requestsPerSecond": flagSet.Lookup("rate-limit"),
+		"rateLimits.burst":             flagSet.Lookup("rate-burst"),
+	}
+}
+
+func TestLoadServerConfigurationFromFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{
+			name: "config.yaml",
+			content: `listen: 127.0.0.1:9090
+rateLimits:
+  burst: 50
+shutdown:
+  drainTimeout: 1m
+serverAdmins: [alice, bob]
+`,
+		},
+		{
+			name:    "config.json",
+			content: `{"listen": "127.0.0.1:9090", "rateLimits": {"burst": 50}, "shutdown": {"drainTimeout": "1m"}, "serverAdmins": ["alice", "bob"]}`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			serverConfig, err := LoadServerConfiguration(writeConfigFile(t, test.name, test.content), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if serverConfig.Listen != "127.0.0.1:9090" || serverConfig.RateLimits.Burst != 50 || serverConfig.Shutdown.DrainTimeout != time.Minute {
+				t.Errorf("settings should be read from the file, got %+v", serverConfig)
+			}
+			if len(serverConfig.ServerAdmins) != 2 || serverConfig.ServerAdmins[0] != "alice" || serverConfig.ServerAdmins[1] != "bob" {
+				t.Errorf("server admins should be read from the file, got %v", serverConfig.ServerAdmins)
+			}
+			if serverConfig.RateLimits.RequestsPerSecond != 10 || serverConfig.Database.Host != "localhost" {
+				t.Errorf("settings missing from the file should keep their defaults, got %+v", serverConfig)
+			}
+		})
+	}
+}
+
+func TestLoadServerConfigurationPrecedence(t *testing.T) {
+	cfgFile := writeConfigFile(t, "config.yaml", "rateLimits:\n  requestsPerSecond: 5\n  burst: 50\n")
+
+	tests := []struct {
+		name              string
+		env               map[string]string
+		args              []string
+		requestsPerSecond float64
+		burst             int
+	}{
+		{
+			name:              "file",
+			requestsPerSecond: 5,
+			burst:             50,
+		},
+		{
+			name:              "environment over file",
+			env:               map[string]string{"SB_RATELIMITS_BURST": "60"},
+			requestsPerSecond: 5,
+			burst:             60,
+		},
+		{
+			name:              "set flag over environment",
+			env:               map[string]string{"SB_RATELIMITS_BURST": "60"},
+			args:              []string{"--rate-burst", "70"},
+			requestsPerSecond: 5,
+			burst:             70,
+		},
+		{
+			name:              "unset flag keeps the environment",
+			env:               map[string]string{"SB_RATELIMITS_BURST": "60"},
+			args:              []string{"--rate-limit", "2"},
+			requestsPerSecond: 2,
+			burst:             60,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for key, value := range test.env {
+				setEnv(t, key, value)
+			}
+
+			serverConfig, err := LoadServerConfiguration(cfgFile, hostFlags(t, test.args...))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if serverConfig.RateLimits.RequestsPerSecond != test.requestsPerSecond || serverConfig.RateLimits.Burst != test.burst {
+				t.Errorf("expected %v requests per second and burst %d, got %v and %d",
+					test.requestsPerSecond, test.burst, serverConfig.RateLimits.RequestsPerSecond, serverConfig.RateLimits.Burst)
+			}
+		})
+	}
+}
+
+func TestLoadServerConfigurationWithoutFile(t *testing.T) {
+	setEnv(t, "SB_DATABASE_HOST", "db.example.com")
+	setEnv(t, "SB_SHUTDOWN_DRAINTIMEOUT", "5s")
+
+	serverConfig, err := LoadServerConfiguration("", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if serverConfig.Database.Host != "db.example.com" || serverConfig.Shutdown.DrainTimeout != 5*time.Second {
+		t.Errorf("settings should be read from the environment, got %+v", serverConfig)
+	}
+}
+
+func TestLoadServerConfigurationDatabase(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		addr     string
+		database string
+		problems string
+	}{
+		{
+			name:     "host and port",
+			content:  "database:\n  host: db.example.com\n  port: 3307\n  name: stories\n",
+			addr:     "db.example.com:3307",
+			database: "stories",
+		},
+		{
+			name:     "DSN instead of host and port",
+			content:  "database:\n  dsn: admin:secret@tcp(dsn.example.com:3306)/tales\n  host: \"\"\n  port: 0\n",
+			addr:     "dsn.example.com:3306",
+			database: "tales",
+		},
+		{
+			name:     "DSN without database name",
+			content:  "database:\n  dsn: admin:secret@tcp(dsn.example.com:3306)/\n  name: stories\n",
+			addr:     "dsn.example.com:3306",
+			database: "stories",
+		},
+		{
+			name:    "missing host and port",
+			content: "database:\n  host: \"\"\n  port: 0\n",
+			problems: "invalid server configuration:\n" +
+				"  database.host: must not be empty\n" +
+				"  database.port: must be between 1 and 65535, got 0",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			serverConfig, err := LoadServerConfiguration(writeConfigFile(t, "config.yaml", test.content), nil)
+			if test.problems != "" {
+				if err == nil || err.Error() != test.problems {
+					t.Errorf("expected problems:\n%s\ngot:\n%v", test.problems, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			mysqlConfig, name, err := serverConfig.Database.MySQLConfig()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if mysqlConfig.Addr != test.addr || name != test.database {
+				t.Errorf("expected database %s at %s, got %s at %s", test.database, test.addr, name, mysqlConfig.Addr)
+			}
+		})
+	}
+}
+
+func TestLoadServerConfigurationProblems(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		problems string
+	}{
+		{
+			name:    "unknown settings",
+			content: "listne: :9090\nrateLimits:\n  bursts: 50\n",
+			problems: "invalid server configuration:\n" +
+				"  unknown settings: listne\n" +
+				"  'RateLimits' has unknown settings: bursts",
+		},
+		{
+			name:    "wrong types",
+			content: "game:\n  timeLimit: long\n",
+			problems: "invalid server configuration:\n" +
+				"  cannot parse 'Game.TimeLimit' as int: strconv.ParseInt: parsing \"long\": invalid syntax",
+		},
+		{
+			name:    "invalid settings",
+			content: "listen: \"9090\"\nlogFormat: xml\n",
+			problems: "invalid server configuration:\n" +
+				"  listen: \"9090\" is not a valid address, use \"host:port\" or \":port\"\n" +
+				"  logFormat: unknown log format \"xml\", must be one of: logfmt, json",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := LoadServerConfiguration(writeConfigFile(t, "config.yaml", test.content), nil)
+			if err == nil || err.Error() != test.problems {
+				t.Errorf("expected problems:\n%s\ngot:\n%v", test.problems, err)
+			}
+		})
+	}
+}
+
+func TestDecodingError(t *testing.T) {
+	tests := []struct {
+		err      string
+		expected string
+	}{
+		{
+			err:      "2 error(s) decoding:\n\n* '' has invalid keys: listne\n* 'tls' has invalid keys: certificate",
+			expected: "invalid server configuration:\n  unknown settings: listne\n  'tls' has unknown settings: certificate",
+		},
+		{
+			err:      "1 error(s) decoding:\n\n* cannot parse 'listen' as string",
+			expected: "invalid server configuration:\n  cannot parse 'listen' as string",
+		},
+		{
+			err:      "unexpected error",
+			expected: "invalid server configuration: unexpected error",
+		},
+	}
+	for _, test := range tests {
+		if err := decodingError(errors.New(test.err)); err.Error() != test.expected {
+			t.Errorf("expected:\n%s\ngot:\n%v", test.expected, err)
+		}
+	}
+}
@@ -15,6 +15,8 @@
 package viper
 
 import (
+	"fmt"
+	"os"
 	"path/filepath"
 
 	homedir "github.com/mitchellh/go-homedir"
@@ -27,55 +29,180 @@ import (
 const DefaultConfigFileName = ".story-builder.json"
 
 // Configurator implements the SBConfigurator interface using viper and the file system.
+// The configuration file holds a list of named contexts and the name of the current one. The authorizations of the contexts are kept
+// in a separate credentials file next to it, e.g. ".story-builder-credentials.json". Only the current user can access either file.
 type Configurator struct {
-	viper *viper.Viper
+	path string
+	// context overrides the current context of the file, if it's not empty.
+	context string
 }
 
-// NewConfigurator creates a new ViperConfigurator, provided a configuration file path.
-func NewConfigurator(cfgFile string) (config.SBConfigurator, error) {
-	viper := viper.New()
-
+// NewConfigurator creates a new ViperConfigurator, provided a configuration file path and the name of the context to use instead of
+// the current one. Pass an empty context name to use the current context.
+// Configuration files from before contexts existed are migrated to a context named "default", and authorizations found in the
+// configuration file are moved to the credentials file.
+func NewConfigurator(cfgFile, context string) (config.SBConfigurator, error) {
 	absCfgFilePath, err := getConfigFileAbsPath(cfgFile)
 	if err != nil {
 		return nil, err
 	}
-	viper.SetConfigFile(absCfgFilePath)
 
-	configurator := &Configurator{viper: viper}
-	if err := viper.ReadInConfig(); err != nil {
-		configurator.Save(&config.SBConfiguration{})
+	configurator := &Configurator{path: absCfgFilePath, context: context}
+	contexts, migrated, err := configurator.read()
+	if err != nil {
+		return nil, err
+	}
+	if migrated {
+		if err := configurator.SaveContexts(contexts); err != nil {
+			return nil, err
+		}
+	}
+	if context != "" && contexts.Get(context) == nil {
+		return nil, fmt.Errorf("context \"%s\" doesn't exist", context)
 	}
 
 	return configurator, nil
 }
 
-// Save is filling the configuration file with the properties from the passed configuration object
+// Save stores the passed configuration in the active context. If there are no contexts yet, a context named "default" is created and
+// made current.
 func (viperConfig *Configurator) Save(sbConfig *config.SBConfiguration) error {
-	viperConfig.viper.Set("url", sbConfig.URL)
-	viperConfig.viper.Set("authorization", sbConfig.Authorization)
-	viperConfig.viper.Set("room", sbConfig.Room)
-
-	if err := viperConfig.viper.WriteConfig(); err != nil {
+	contexts, _, err := viperConfig.read()
+	if err != nil {
 		return err
 	}
 
-	return nil
+	name := viperConfig.activeContext(contexts)
+	if name == "" {
+		name = config.DefaultContext
+		contexts.Current = name
+	}
+	contexts.Set(name, *sbConfig)
+
+	return viperConfig.SaveContexts(contexts)
 }
 
-// Load returns a SBConfiguration pointer, storing the properties from the configuration file
+// Load returns a SBConfiguration pointer, storing the properties of the active context.
+// Returns an empty configuration if there is no active context.
 func (viperConfig *Configurator) Load() (*config.SBConfiguration, error) {
-	if err := viperConfig.viper.ReadInConfig(); err != nil {
+	contexts, _, err := viperConfig.read()
+	if err != nil {
 		return nil, err
 	}
 
 	sbConfig := &config.SBConfiguration{}
-	if err := viperConfig.viper.Unmarshal(sbConfig); err != nil {
-		return nil, err
+	if context := contexts.Get(viperConfig.activeContext(contexts)); context != nil {
+		*sbConfig = context.SBConfiguration
 	}
 
 	return sbConfig, nil
 }
 
+// LoadContexts returns all contexts in the configuration file.
+func (viperConfig *Configurator) LoadContexts() (*config.SBContexts, error) {
+	contexts, _, err := viperConfig.read()
+	return contexts, err
+}
+
+// SaveContexts replaces all contexts in the configuration and credentials files with the passed ones.
+func (viperConfig *Configurator) SaveContexts(contexts *config.SBContexts) error {
+	if err := viperConfig.writeCredentials(contexts); err != nil {
+		return err
+	}
+
+	viper := viper.New()
+	viper.SetConfigFile(viperConfig.path)
+
+	contextSettings := make([]map[string]interface{}, 0, len(contexts.Contexts))
+	for _, context := range contexts.Contexts {
+		contextSettings = append(contextSettings, map[string]interface{}{
+			"name":               context.Name,
+			"url":                context.URL,
+			"room":               context.Room,
+			"caFile":             context.CAFile,
+			"insecureSkipVerify": context.InsecureSkipVerify,
+		})
+	}
+	viper.Set("currentContext", contexts.Current)
+	viper.Set("contexts", contextSettings)
+
+	// viper creates missing files readable by everyone, so the file is created beforehand
+	if err := restrictPermissions(viperConfig.path); err != nil {
+		return err
+	}
+	if err := viper.WriteConfig(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ActiveContext returns the name of the context that Load and Save use - the one the configurator was created with, or the current one.
+// Returns an empty name if there are no contexts yet.
+func (viperConfig *Configurator) ActiveContext() (string, error) {
+	contexts, _, err := viperConfig.read()
+	if err != nil {
+		return "", err
+	}
+	return viperConfig.activeContext(contexts), nil
+}
+
+func (viperConfig *Configurator) activeContext(contexts *config.SBContexts) string {
+	if viperConfig.context != "" {
+		return viperConfig.context
+	}
+	return contexts.Current
+}
+
+// legacyConfiguration is the format of configuration files from before contexts existed, with the settings of a single server at the top level.
+type legacyConfiguration struct {
+	config.SBConfiguration `mapstructure:",squash"`
+	config.SBContexts      `mapstructure:",squash"`
+}
+
+// read reads all contexts from the configuration file, with their authorizations from the credentials file. A missing file holds no
+// contexts. If the file is in the format from before contexts existed, its settings are returned as the "default" context and migrated
+// is true. Migrated is also true if the configuration file has authorizations, which were kept there before the credentials file existed.
+func (viperConfig *Configurator) read() (contexts *config.SBContexts, migrated bool, err error) {
+	viper := viper.New()
+	viper.SetConfigFile(viperConfig.path)
+	if err := viper.ReadInConfig(); err != nil {
+		if _, statErr := os.Stat(viperConfig.path); os.IsNotExist(statErr) {
+			return &config.SBContexts{Contexts: make([]config.SBContext, 0)}, false, nil
+		}
+		return nil, false, err
+	}
+
+	fileConfig := &legacyConfiguration{}
+	if err := viper.Unmarshal(fileConfig); err != nil {
+		return nil, false, err
+	}
+	contexts = &fileConfig.SBContexts
+	if contexts.Contexts == nil {
+		contexts.Contexts = make([]config.SBContext, 0)
+	}
+	if len(contexts.Contexts) == 0 && fileConfig.SBConfiguration != (config.SBConfiguration{}) {
+		contexts.Current = config.DefaultContext
+		contexts.Set(config.DefaultContext, fileConfig.SBConfiguration)
+		migrated = true
+	}
+
+	creds, err := viperConfig.readCredentials()
+	if err != nil {
+		return nil, false, err
+	}
+	for index := range contexts.Contexts {
+		context := &contexts.Contexts[index]
+		if context.Authorization != "" {
+			migrated = true
+		}
+		if authorization, ok := creds.Authorizations[context.Name]; ok {
+			context.Authorization = authorization
+		}
+	}
+	return contexts, migrated, nil
+}
+
 func getConfigFileAbsPath(cfgFile string) (string, error) {
 	if cfgFile == "" {
 		home, err := homedir.Dir()
@@ -0,0 +1,97 @@
+package viper
+
+import (
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/pavelhadzhiev/story-builder/pkg/config"
+)
+
+func TestLegacyConfigurationMigrated(t *testing.T) {
+	cfgFile := writeConfigFile(t, "config.json",
+		`{"url": "http://localhost:8080", "authorization": "`+authorization+`", "room": "tavern", "insecureSkipVerify": true}`)
+
+	configurator, err := NewConfigurator(cfgFile, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &config.SBContexts{
+		Current: config.DefaultContext,
+		Contexts: []config.SBContext{{
+			Name: config.DefaultContext,
+			SBConfiguration: config.SBConfiguration{
+				URL:                "http://localhost:8080",
+				Authorization:      authorization,
+				Room:               "tavern",
+				InsecureSkipVerify: true,
+			},
+		}},
+	}
+	contexts, err := configurator.LoadContexts()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(contexts, expected) {
+		t.Errorf("the legacy configuration should be migrated to the default context, expected %+v, got %+v", expected, contexts)
+	}
+	if active, err := configurator.ActiveContext(); err != nil || active != config.DefaultContext {
+		t.Errorf("the default context should be active, got \"%s\" (%v)", active, err)
+	}
+
+	configData, err := ioutil.ReadFile(cfgFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(configData), `"contexts"`) || strings.Contains(string(configData), authorization) {
+		t.Errorf("the configuration file should be rewritten with contexts and without the authorization, got %s", configData)
+	}
+
+	// the migrated file is read as it is, without migrating it again
+	reopened, err := NewConfigurator(cfgFile, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	contexts, migrated, err := reopened.(*Configurator).read()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if migrated {
+		t.Error("the migrated configuration should not be migrated again")
+	}
+	if !reflect.DeepEqual(contexts, expected) {
+		t.Errorf("the migrated configuration should survive a round trip, expected %+v, got %+v", expected, contexts)
+	}
+}
+
+func TestContextsRoundTrip(t *testing.T) {
+	configurator := newTestConfigurator(t)
+	contexts := &config.SBContexts{
+		Current: "remote",
+		Contexts: []config.SBContext{
+			{Name: "local", SBConfiguration: config.SBConfiguration{URL: "http://localhost:8080"}},
+			{Name: "remote", SBConfiguration: config.SBConfiguration{URL: "https://stories.example.com", Authorization: authorization, CAFile: "/etc/ca.pem"}},
+		},
+	}
+
+	if err := configurator.SaveContexts(contexts); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := configurator.LoadContexts()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(loaded, contexts) {
+		t.Errorf("expected %+v, got %+v", contexts, loaded)
+	}
+
+	sbConfig, err := configurator.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *sbConfig != contexts.Contexts[1].SBConfiguration {
+		t.Errorf("the current context should be loaded, got %+v", sbConfig)
+	}
+}
@@ -15,9 +15,18 @@
 package db
 
 import (
+	"context"
 	"database/sql"
+	"errors"
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"time"
 
 	"github.com/go-sql-driver/mysql"
+	"github.com/pavelhadzhiev/story-builder/pkg/api/users"
+	"github.com/pavelhadzhiev/story-builder/pkg/metrics"
 )
 
 // UserDatabase represents an object that can be used to store users and their credentials
@@ -25,45 +34,118 @@ import (
 type UserDatabase interface {
 	InitializeDB() error
 	CloseDB()
+	Ping() error
 
 	LoginUser(username, password string) error
 	RegisterUser(username, password string) error
 	UserExists(username string) (bool, error)
+
+	GetUser(username string) (*users.User, error)
+	GetUsers() ([]users.User, error)
+	SetRole(username, role string) error
+	SetDisabled(username string, disabled bool) error
+	SetBanned(username string, banned bool) error
+	UpdatePassword(username, password string) error
+	DeleteUser(username string) error
+
+	GetProfile(username string) (*users.Profile, error)
+	UpdateProfile(profile users.Profile) error
+}
+
+// DefaultDatabaseName is the name of the database the server uses, unless it's configured otherwise.
+const DefaultDatabaseName = "storybuilder"
+
+// Settings configure the connection to the MySQL server. If DSN is provided, it's used instead of the other settings, but its database name
+// is used only if it has one - otherwise Name is used.
+type Settings struct {
+	DSN      string `json:"dsn,omitempty"`
+	Host     string `json:"host"`
+	Port     int    `json:"port"`
+	Name     string `json:"name"`
+	Username string `json:"username"`
+	Password string `json:"password,omitempty"`
+}
+
+// DefaultSettings are the settings used to connect to the MySQL server, unless the server is configured otherwise.
+var DefaultSettings = Settings{
+	Host:     "localhost",
+	Port:     3306,
+	Name:     DefaultDatabaseName,
+	Username: "admin",
+	Password: "Abcd1234",
+}
+
+var databaseNamePattern = regexp.MustCompile(`^[A-Za-z0-9_$]{1,64}$`)
+
+// MySQLConfig returns the configuration of the MySQL driver for the settings, without a database name, so the database can be created first.
+// The database name is returned separately.
+// Returns error if the DSN can't be parsed or the database name is not a valid MySQL identifier.
+func (settings Settings) MySQLConfig() (*mysql.Config, string, error) {
+	config := mysql.NewConfig()
+	name := settings.Name
+	if settings.DSN != "" {
+		var err error
+		if config, err = mysql.ParseDSN(settings.DSN); err != nil {
+			return nil, "", fmt.Errorf("invalid DSN: %v", err)
+		}
+		if config.DBName != "" {
+			name = config.DBName
+		}
+	} else {
+		config.User = settings.Username
+		config.Passwd = settings.Password
+		config.Net = "tcp"
+		config.Addr = net.JoinHostPort(settings.Host, strconv.Itoa(settings.Port))
+	}
+	if !databaseNamePattern.MatchString(name) {
+		return nil, "", fmt.Errorf("invalid database name \"%s\" - it can contain only letters, digits, \"_\" and \"$\" and must be 1 to 64 symbols long", name)
+	}
+	config.DBName = ""
+	return config, name, nil
 }
 
 // SBDatabase represents the database layer for the story builder server
 type SBDatabase struct {
 	database *sql.DB // a database variable to close on program exit
 
-	username string
-	password string
+	settings Settings
 }
 
-// NewSBDatabase returns a pointer to a new instance of SBDatabase, using the provided credentials.
+// NewSBDatabase returns a pointer to a new instance of SBDatabase, using the provided credentials to connect to the local MySQL server.
 func NewSBDatabase(username string, password string) *SBDatabase {
+	settings := DefaultSettings
+	settings.Username = username
+	settings.Password = password
+	return NewSBDatabaseWithSettings(settings)
+}
+
+// NewSBDatabaseWithSettings returns a pointer to a new instance of SBDatabase, using the provided connection settings.
+func NewSBDatabaseWithSettings(settings Settings) *SBDatabase {
 	return &SBDatabase{
-		username: username,
-		password: password,
+		settings: settings,
 	}
 }
 
-// InitializeDB connects to a local MySQL server using the configured user, creates a database named "storybuilder" and creates all necessary for the story builder API tables inside. Recourses are created only if they do not exist.
+// InitializeDB connects to the configured MySQL server, creates the configured database (named "storybuilder" by default) and creates all necessary for the story builder API tables inside. Recourses are created only if they do not exist.
 func (sbdb *SBDatabase) InitializeDB() error {
-	var config = mysql.Config{
-		User:   sbdb.username,
-		Passwd: sbdb.password,
-	}
-	var err error
-	sbdb.database, err = sql.Open("mysql", config.FormatDSN())
+	config, name, err := sbdb.settings.MySQLConfig()
 	if err != nil {
 		return err
 	}
 
-	if _, err := sbdb.database.Exec("create database if not exists storybuilder"); err != nil {
+	server, err := sql.Open("mysql", config.FormatDSN())
+	if err != nil {
+		return err
+	}
+	_, err = server.Exec(fmt.Sprintf("create database if not exists `%s`", name))
+	server.Close()
+	if err != nil {
 		return err
 	}
 
-	if _, err := sbdb.database.Exec("use storybuilder"); err != nil {
+	config.DBName = name
+	sbdb.database, err = sql.Open("mysql", config.FormatDSN())
+	if err != nil {
 		return err
 	}
 
@@ -74,9 +156,68 @@ func (sbdb *SBDatabase) InitializeDB() error {
 		return err
 	}
 
+	if err := sbdb.addColumnIfMissing("users", "role", "varchar(32) not null default 'user'"); err != nil {
+		return err
+	}
+	if err := sbdb.addColumnIfMissing("users", "disabled", "boolean not null default false"); err != nil {
+		return err
+	}
+	if err := sbdb.addColumnIfMissing("users", "banned", "boolean not null default false"); err != nil {
+		return err
+	}
+	if err := sbdb.addColumnIfMissing("users", "display_name", "varchar(255) not null default ''"); err != nil {
+		return err
+	}
+	if err := sbdb.addColumnIfMissing("users", "bio", "varchar(2000) not null default ''"); err != nil {
+		return err
+	}
+	if err := sbdb.addColumnIfMissing("users", "email", "varchar(255) not null default ''"); err != nil {
+		return err
+	}
+	if err := sbdb.addColumnIfMissing("users", "turn_reminders", "boolean not null default false"); err != nil {
+		return err
+	}
+
+	if _, err = sbdb.database.Exec(`create table if not exists prompts (
+		id int not null auto_increment primary key,
+		room varchar(255) not null default '',
+		text text not null,
+		author varchar(255) not null
+	)`); err != nil {
+		return err
+	}
+
 	return nil
 }
 
+// addColumnIfMissing adds the provided column to a table that was created by an older version of the server.
+func (sbdb *SBDatabase) addColumnIfMissing(table, column, definition string) error {
+	var count int
+	if err := sbdb.database.QueryRow("select count(*) from information_schema.columns where table_schema = database() and table_name = ? and column_name = ?", table, column).Scan(&count); err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+	_, err := sbdb.database.Exec(fmt.Sprintf("alter table %s add column %s %s", table, column, definition))
+	return err
+}
+
+// pingTimeout is how long Ping waits for the MySQL server to respond.
+const pingTimeout = 2 * time.Second
+
+// Ping checks that the MySQL server is reachable and the database can be used.
+// Returns error if the database is not initialized or the MySQL server doesn't respond in time.
+func (sbdb *SBDatabase) Ping() error {
+	defer metrics.ObserveSince(metrics.DBQueryDuration, time.Now(), "ping")
+	if sbdb.database == nil {
+		return errors.New("database is not initialized")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), pingTimeout)
+	defer cancel()
+	return sbdb.database.PingContext(ctx)
+}
+
 // CloseDB shuts down the connection to the database.
 func (sbdb *SBDatabase) CloseDB() {
 	sbdb.database.Close()
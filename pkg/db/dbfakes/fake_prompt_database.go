@@ -0,0 +1,423 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package dbfakes
+
+import (
+	"sync"
+
+	"github.com/pavelhadzhiev/story-builder/pkg/api/prompts"
+	"github.com/pavelhadzhiev/story-builder/pkg/db"
+)
+
+type FakePromptDatabase struct {
+	AddPromptStub        func(*prompts.Prompt) (int, error)
+	addPromptMutex       sync.RWMutex
+	addPromptArgsForCall []struct {
+		arg1 *prompts.Prompt
+	}
+	addPromptReturns struct {
+		result1 int
+		result2 error
+	}
+	addPromptReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
+	DeletePromptStub        func(int) error
+	deletePromptMutex       sync.RWMutex
+	deletePromptArgsForCall []struct {
+		arg1 int
+	}
+	deletePromptReturns struct {
+		result1 error
+	}
+	deletePromptReturnsOnCall map[int]struct {
+		result1 error
+	}
+	GetPromptStub        func(int) (*prompts.Prompt, error)
+	getPromptMutex       sync.RWMutex
+	getPromptArgsForCall []struct {
+		arg1 int
+	}
+	getPromptReturns struct {
+		result1 *prompts.Prompt
+		result2 error
+	}
+	getPromptReturnsOnCall map[int]struct {
+		result1 *prompts.Prompt
+		result2 error
+	}
+	GetPromptsStub        func(string) ([]prompts.Prompt, error)
+	getPromptsMutex       sync.RWMutex
+	getPromptsArgsForCall []struct {
+		arg1 string
+	}
+	getPromptsReturns struct {
+		result1 []prompts.Prompt
+		result2 error
+	}
+	getPromptsReturnsOnCall map[int]struct {
+		result1 []prompts.Prompt
+		result2 error
+	}
+	UpdatePromptStub        func(*prompts.Prompt) error
+	updatePromptMutex       sync.RWMutex
+	updatePromptArgsForCall []struct {
+		arg1 *prompts.Prompt
+	}
+	updatePromptReturns struct {
+		result1 error
+	}
+	updatePromptReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakePromptDatabase) AddPrompt(arg1 *prompts.Prompt) (int, error) {
+	fake.addPromptMutex.Lock()
+	ret, specificReturn := fake.addPromptReturnsOnCall[len(fake.addPromptArgsForCall)]
+	fake.addPromptArgsForCall = append(fake.addPromptArgsForCall, struct {
+		arg1 *prompts.Prompt
+	}{arg1})
+	stub := fake.AddPromptStub
+	fakeReturns := fake.addPromptReturns
+	fake.recordInvocation("AddPrompt", []interface{}{arg1})
+	fake.addPromptMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePromptDatabase) AddPromptCallCount() int {
+	fake.addPromptMutex.RLock()
+	defer fake.addPromptMutex.RUnlock()
+	return len(fake.addPromptArgsForCall)
+}
+
+func (fake *FakePromptDatabase) AddPromptCalls(stub func(*prompts.Prompt) (int, error)) {
+	fake.addPromptMutex.Lock()
+	defer fake.addPromptMutex.Unlock()
+	fake.AddPromptStub = stub
+}
+
+func (fake *FakePromptDatabase) AddPromptArgsForCall(i int) *prompts.Prompt {
+	fake.addPromptMutex.RLock()
+	defer fake.addPromptMutex.RUnlock()
+	argsForCall := fake.addPromptArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakePromptDatabase) AddPromptReturns(result1 int, result2 error) {
+	fake.addPromptMutex.Lock()
+	defer fake.addPromptMutex.Unlock()
+	fake.AddPromptStub = nil
+	fake.addPromptReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePromptDatabase) AddPromptReturnsOnCall(i int, result1 int, result2 error) {
+	fake.addPromptMutex.Lock()
+	defer fake.addPromptMutex.Unlock()
+	fake.AddPromptStub = nil
+	if fake.addPromptReturnsOnCall == nil {
+		fake.addPromptReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.addPromptReturnsOnCall[i] = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePromptDatabase) DeletePrompt(arg1 int) error {
+	fake.deletePromptMutex.Lock()
+	ret, specificReturn := fake.deletePromptReturnsOnCall[len(fake.deletePromptArgsForCall)]
+	fake.deletePromptArgsForCall = append(fake.deletePromptArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	stub := fake.DeletePromptStub
+	fakeReturns := fake.deletePromptReturns
+	fake.recordInvocation("DeletePrompt", []interface{}{arg1})
+	fake.deletePromptMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakePromptDatabase) DeletePromptCallCount() int {
+	fake.deletePromptMutex.RLock()
+	defer fake.deletePromptMutex.RUnlock()
+	return len(fake.deletePromptArgsForCall)
+}
+
+func (fake *FakePromptDatabase) DeletePromptCalls(stub func(int) error) {
+	fake.deletePromptMutex.Lock()
+	defer fake.deletePromptMutex.Unlock()
+	fake.DeletePromptStub = stub
+}
+
+func (fake *FakePromptDatabase) DeletePromptArgsForCall(i int) int {
+	fake.deletePromptMutex.RLock()
+	defer fake.deletePromptMutex.RUnlock()
+	argsForCall := fake.deletePromptArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakePromptDatabase) DeletePromptReturns(result1 error) {
+	fake.deletePromptMutex.Lock()
+	defer fake.deletePromptMutex.Unlock()
+	fake.DeletePromptStub = nil
+	fake.deletePromptReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePromptDatabase) DeletePromptReturnsOnCall(i int, result1 error) {
+	fake.deletePromptMutex.Lock()
+	defer fake.deletePromptMutex.Unlock()
+	fake.DeletePromptStub = nil
+	if fake.deletePromptReturnsOnCall == nil {
+		fake.deletePromptReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deletePromptReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePromptDatabase) GetPrompt(arg1 int) (*prompts.Prompt, error) {
+	fake.getPromptMutex.Lock()
+	ret, specificReturn := fake.getPromptReturnsOnCall[len(fake.getPromptArgsForCall)]
+	fake.getPromptArgsForCall = append(fake.getPromptArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	stub := fake.GetPromptStub
+	fakeReturns := fake.getPromptReturns
+	fake.recordInvocation("GetPrompt", []interface{}{arg1})
+	fake.getPromptMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePromptDatabase) GetPromptCallCount() int {
+	fake.getPromptMutex.RLock()
+	defer fake.getPromptMutex.RUnlock()
+	return len(fake.getPromptArgsForCall)
+}
+
+func (fake *FakePromptDatabase) GetPromptCalls(stub func(int) (*prompts.Prompt, error)) {
+	fake.getPromptMutex.Lock()
+	defer fake.getPromptMutex.Unlock()
+	fake.GetPromptStub = stub
+}
+
+func (fake *FakePromptDatabase) GetPromptArgsForCall(i int) int {
+	fake.getPromptMutex.RLock()
+	defer fake.getPromptMutex.RUnlock()
+	argsForCall := fake.getPromptArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakePromptDatabase) GetPromptReturns(result1 *prompts.Prompt, result2 error) {
+	fake.getPromptMutex.Lock()
+	defer fake.getPromptMutex.Unlock()
+	fake.GetPromptStub = nil
+	fake.getPromptReturns = struct {
+		result1 *prompts.Prompt
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePromptDatabase) GetPromptReturnsOnCall(i int, result1 *prompts.Prompt, result2 error) {
+	fake.getPromptMutex.Lock()
+	defer fake.getPromptMutex.Unlock()
+	fake.GetPromptStub = nil
+	if fake.getPromptReturnsOnCall == nil {
+		fake.getPromptReturnsOnCall = make(map[int]struct {
+			result1 *prompts.Prompt
+			result2 error
+		})
+	}
+	fake.getPromptReturnsOnCall[i] = struct {
+		result1 *prompts.Prompt
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePromptDatabase) GetPrompts(arg1 string) ([]prompts.Prompt, error) {
+	fake.getPromptsMutex.Lock()
+	ret, specificReturn := fake.getPromptsReturnsOnCall[len(fake.getPromptsArgsForCall)]
+	fake.getPromptsArgsForCall = append(fake.getPromptsArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.GetPromptsStub
+	fakeReturns := fake.getPromptsReturns
+	fake.recordInvocation("GetPrompts", []interface{}{arg1})
+	fake.getPromptsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePromptDatabase) GetPromptsCallCount() int {
+	fake.getPromptsMutex.RLock()
+	defer fake.getPromptsMutex.RUnlock()
+	return len(fake.getPromptsArgsForCall)
+}
+
+func (fake *FakePromptDatabase) GetPromptsCalls(stub func(string) ([]prompts.Prompt, error)) {
+	fake.getPromptsMutex.Lock()
+	defer fake.getPromptsMutex.Unlock()
+	fake.GetPromptsStub = stub
+}
+
+func (fake *FakePromptDatabase) GetPromptsArgsForCall(i int) string {
+	fake.getPromptsMutex.RLock()
+	defer fake.getPromptsMutex.RUnlock()
+	argsForCall := fake.getPromptsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakePromptDatabase) GetPromptsReturns(result1 []prompts.Prompt, result2 error) {
+	fake.getPromptsMutex.Lock()
+	defer fake.getPromptsMutex.Unlock()
+	fake.GetPromptsStub = nil
+	fake.getPromptsReturns = struct {
+		result1 []prompts.Prompt
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePromptDatabase) GetPromptsReturnsOnCall(i int, result1 []prompts.Prompt, result2 error) {
+	fake.getPromptsMutex.Lock()
+	defer fake.getPromptsMutex.Unlock()
+	fake.GetPromptsStub = nil
+	if fake.getPromptsReturnsOnCall == nil {
+		fake.getPromptsReturnsOnCall = make(map[int]struct {
+			result1 []prompts.Prompt
+			result2 error
+		})
+	}
+	fake.getPromptsReturnsOnCall[i] = struct {
+		result1 []prompts.Prompt
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePromptDatabase) UpdatePrompt(arg1 *prompts.Prompt) error {
+	fake.updatePromptMutex.Lock()
+	ret, specificReturn := fake.updatePromptReturnsOnCall[len(fake.updatePromptArgsForCall)]
+	fake.updatePromptArgsForCall = append(fake.updatePromptArgsForCall, struct {
+		arg1 *prompts.Prompt
+	}{arg1})
+	stub := fake.UpdatePromptStub
+	fakeReturns := fake.updatePromptReturns
+	fake.recordInvocation("UpdatePrompt", []interface{}{arg1})
+	fake.updatePromptMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakePromptDatabase) UpdatePromptCallCount() int {
+	fake.updatePromptMutex.RLock()
+	defer fake.updatePromptMutex.RUnlock()
+	return len(fake.updatePromptArgsForCall)
+}
+
+func (fake *FakePromptDatabase) UpdatePromptCalls(stub func(*prompts.Prompt) error) {
+	fake.updatePromptMutex.Lock()
+	defer fake.updatePromptMutex.Unlock()
+	fake.UpdatePromptStub = stub
+}
+
+func (fake *FakePromptDatabase) UpdatePromptArgsForCall(i int) *prompts.Prompt {
+	fake.updatePromptMutex.RLock()
+	defer fake.updatePromptMutex.RUnlock()
+	argsForCall := fake.updatePromptArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakePromptDatabase) UpdatePromptReturns(result1 error) {
+	fake.updatePromptMutex.Lock()
+	defer fake.updatePromptMutex.Unlock()
+	fake.UpdatePromptStub = nil
+	fake.updatePromptReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePromptDatabase) UpdatePromptReturnsOnCall(i int, result1 error) {
+	fake.updatePromptMutex.Lock()
+	defer fake.updatePromptMutex.Unlock()
+	fake.UpdatePromptStub = nil
+	if fake.updatePromptReturnsOnCall == nil {
+		fake.updatePromptReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updatePromptReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePromptDatabase) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.addPromptMutex.RLock()
+	defer fake.addPromptMutex.RUnlock()
+	fake.deletePromptMutex.RLock()
+	defer fake.deletePromptMutex.RUnlock()
+	fake.getPromptMutex.RLock()
+	defer fake.getPromptMutex.RUnlock()
+	fake.getPromptsMutex.RLock()
+	defer fake.getPromptsMutex.RUnlock()
+	fake.updatePromptMutex.RLock()
+	defer fake.updatePromptMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakePromptDatabase) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ db.PromptDatabase = new(FakePromptDatabase)
@@ -4,27 +4,79 @@ package dbfakes
 import (
 	"sync"
 
+	"github.com/pavelhadzhiev/story-builder/pkg/api/users"
 	"github.com/pavelhadzhiev/story-builder/pkg/db"
 )
 
 type FakeUserDatabase struct {
+	CloseDBStub        func()
+	closeDBMutex       sync.RWMutex
+	closeDBArgsForCall []struct {
+	}
+	DeleteUserStub        func(string) error
+	deleteUserMutex       sync.RWMutex
+	deleteUserArgsForCall []struct {
+		arg1 string
+	}
+	deleteUserReturns struct {
+		result1 error
+	}
+	deleteUserReturnsOnCall map[int]struct {
+		result1 error
+	}
+	GetProfileStub        func(string) (*users.Profile, error)
+	getProfileMutex       sync.RWMutex
+	getProfileArgsForCall []struct {
+		arg1 string
+	}
+	getProfileReturns struct {
+		result1 *users.Profile
+		result2 error
+	}
+	getProfileReturnsOnCall map[int]struct {
+		result1 *users.Profile
+		result2 error
+	}
+	GetUserStub        func(string) (*users.User, error)
+	getUserMutex       sync.RWMutex
+	getUserArgsForCall []struct {
+		arg1 string
+	}
+	getUserReturns struct {
+		result1 *users.User
+		result2 error
+	}
+	getUserReturnsOnCall map[int]struct {
+		result1 *users.User
+		result2 error
+	}
+	GetUsersStub        func() ([]users.User, error)
+	getUsersMutex       sync.RWMutex
+	getUsersArgsForCall []struct {
+	}
+	getUsersReturns struct {
+		result1 []users.User
+		result2 error
+	}
+	getUsersReturnsOnCall map[int]struct {
+		result1 []users.User
+		result2 error
+	}
 	InitializeDBStub        func() error
 	initializeDBMutex       sync.RWMutex
-	initializeDBArgsForCall []struct{}
-	initializeDBReturns     struct {
+	initializeDBArgsForCall []struct {
+	}
+	initializeDBReturns struct {
 		result1 error
 	}
 	initializeDBReturnsOnCall map[int]struct {
 		result1 error
 	}
-	CloseDBStub          func()
-	closeDBMutex         sync.RWMutex
-	closeDBArgsForCall   []struct{}
-	LoginUserStub        func(username, password string) error
+	LoginUserStub        func(string, string) error
 	loginUserMutex       sync.RWMutex
 	loginUserArgsForCall []struct {
-		username string
-		password string
+		arg1 string
+		arg2 string
 	}
 	loginUserReturns struct {
 		result1 error
@@ -32,11 +84,21 @@ type FakeUserDatabase struct {
 	loginUserReturnsOnCall map[int]struct {
 		result1 error
 	}
-	RegisterUserStub        func(username, password string) error
+	PingStub        func() error
+	pingMutex       sync.RWMutex
+	pingArgsForCall []struct {
+	}
+	pingReturns struct {
+		result1 error
+	}
+	pingReturnsOnCall map[int]struct {
+		result1 error
+	}
+	RegisterUserStub        func(string, string) error
 	registerUserMutex       sync.RWMutex
 	registerUserArgsForCall []struct {
-		username string
-		password string
+		arg1 string
+		arg2 string
 	}
 	registerUserReturns struct {
 		result1 error
@@ -44,10 +106,69 @@ type FakeUserDatabase struct {
 	registerUserReturnsOnCall map[int]struct {
 		result1 error
 	}
-	UserExistsStub        func(username string) (bool, error)
+	SetBannedStub        func(string, bool) error
+	setBannedMutex       sync.RWMutex
+	setBannedArgsForCall []struct {
+		arg1 string
+		arg2 bool
+	}
+	setBannedReturns struct {
+		result1 error
+	}
+	setBannedReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SetDisabledStub        func(string, bool) error
+	setDisabledMutex       sync.RWMutex
+	setDisabledArgsForCall []struct {
+		arg1 string
+		arg2 bool
+	}
+	setDisabledReturns struct {
+		result1 error
+	}
+	setDisabledReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SetRoleStub        func(string, string) error
+	setRoleMutex       sync.RWMutex
+	setRoleArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	setRoleReturns struct {
+		result1 error
+	}
+	setRoleReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UpdatePasswordStub        func(string, string) error
+	updatePasswordMutex       sync.RWMutex
+	updatePasswordArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	updatePasswordReturns struct {
+		result1 error
+	}
+	updatePasswordReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UpdateProfileStub        func(users.Profile) error
+	updateProfileMutex       sync.RWMutex
+	updateProfileArgsForCall []struct {
+		arg1 users.Profile
+	}
+	updateProfileReturns struct {
+		result1 error
+	}
+	updateProfileReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UserExistsStub        func(string) (bool, error)
 	userExistsMutex       sync.RWMutex
 	userExistsArgsForCall []struct {
-		username string
+		arg1 string
 	}
 	userExistsReturns struct {
 		result1 bool
@@ -61,19 +182,291 @@ type FakeUserDatabase struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeUserDatabase) CloseDB() {
+	fake.closeDBMutex.Lock()
+	fake.closeDBArgsForCall = append(fake.closeDBArgsForCall, struct {
+	}{})
+	stub := fake.CloseDBStub
+	fake.recordInvocation("CloseDB", []interface{}{})
+	fake.closeDBMutex.Unlock()
+	if stub != nil {
+		fake.CloseDBStub()
+	}
+}
+
+func (fake *FakeUserDatabase) CloseDBCallCount() int {
+	fake.closeDBMutex.RLock()
+	defer fake.closeDBMutex.RUnlock()
+	return len(fake.closeDBArgsForCall)
+}
+
+func (fake *FakeUserDatabase) CloseDBCalls(stub func()) {
+	fake.closeDBMutex.Lock()
+	defer fake.closeDBMutex.Unlock()
+	fake.CloseDBStub = stub
+}
+
+func (fake *FakeUserDatabase) DeleteUser(arg1 string) error {
+	fake.deleteUserMutex.Lock()
+	ret, specificReturn := fake.deleteUserReturnsOnCall[len(fake.deleteUserArgsForCall)]
+	fake.deleteUserArgsForCall = append(fake.deleteUserArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.DeleteUserStub
+	fakeReturns := fake.deleteUserReturns
+	fake.recordInvocation("DeleteUser", []interface{}{arg1})
+	fake.deleteUserMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeUserDatabase) DeleteUserCallCount() int {
+	fake.deleteUserMutex.RLock()
+	defer fake.deleteUserMutex.RUnlock()
+	return len(fake.deleteUserArgsForCall)
+}
+
+func (fake *FakeUserDatabase) DeleteUserCalls(stub func(string) error) {
+	fake.deleteUserMutex.Lock()
+	defer fake.deleteUserMutex.Unlock()
+	fake.DeleteUserStub = stub
+}
+
+func (fake *FakeUserDatabase) DeleteUserArgsForCall(i int) string {
+	fake.deleteUserMutex.RLock()
+	defer fake.deleteUserMutex.RUnlock()
+	argsForCall := fake.deleteUserArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeUserDatabase) DeleteUserReturns(result1 error) {
+	fake.deleteUserMutex.Lock()
+	defer fake.deleteUserMutex.Unlock()
+	fake.DeleteUserStub = nil
+	fake.deleteUserReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeUserDatabase) DeleteUserReturnsOnCall(i int, result1 error) {
+	fake.deleteUserMutex.Lock()
+	defer fake.deleteUserMutex.Unlock()
+	fake.DeleteUserStub = nil
+	if fake.deleteUserReturnsOnCall == nil {
+		fake.deleteUserReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteUserReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeUserDatabase) GetProfile(arg1 string) (*users.Profile, error) {
+	fake.getProfileMutex.Lock()
+	ret, specificReturn := fake.getProfileReturnsOnCall[len(fake.getProfileArgsForCall)]
+	fake.getProfileArgsForCall = append(fake.getProfileArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.GetProfileStub
+	fakeReturns := fake.getProfileReturns
+	fake.recordInvocation("GetProfile", []interface{}{arg1})
+	fake.getProfileMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeUserDatabase) GetProfileCallCount() int {
+	fake.getProfileMutex.RLock()
+	defer fake.getProfileMutex.RUnlock()
+	return len(fake.getProfileArgsForCall)
+}
+
+func (fake *FakeUserDatabase) GetProfileCalls(stub func(string) (*users.Profile, error)) {
+	fake.getProfileMutex.Lock()
+	defer fake.getProfileMutex.Unlock()
+	fake.GetProfileStub = stub
+}
+
+func (fake *FakeUserDatabase) GetProfileArgsForCall(i int) string {
+	fake.getProfileMutex.RLock()
+	defer fake.getProfileMutex.RUnlock()
+	argsForCall := fake.getProfileArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeUserDatabase) GetProfileReturns(result1 *users.Profile, result2 error) {
+	fake.getProfileMutex.Lock()
+	defer fake.getProfileMutex.Unlock()
+	fake.GetProfileStub = nil
+	fake.getProfileReturns = struct {
+		result1 *users.Profile
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeUserDatabase) GetProfileReturnsOnCall(i int, result1 *users.Profile, result2 error) {
+	fake.getProfileMutex.Lock()
+	defer fake.getProfileMutex.Unlock()
+	fake.GetProfileStub = nil
+	if fake.getProfileReturnsOnCall == nil {
+		fake.getProfileReturnsOnCall = make(map[int]struct {
+			result1 *users.Profile
+			result2 error
+		})
+	}
+	fake.getProfileReturnsOnCall[i] = struct {
+		result1 *users.Profile
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeUserDatabase) GetUser(arg1 string) (*users.User, error) {
+	fake.getUserMutex.Lock()
+	ret, specificReturn := fake.getUserReturnsOnCall[len(fake.getUserArgsForCall)]
+	fake.getUserArgsForCall = append(fake.getUserArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.GetUserStub
+	fakeReturns := fake.getUserReturns
+	fake.recordInvocation("GetUser", []interface{}{arg1})
+	fake.getUserMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeUserDatabase) GetUserCallCount() int {
+	fake.getUserMutex.RLock()
+	defer fake.getUserMutex.RUnlock()
+	return len(fake.getUserArgsForCall)
+}
+
+func (fake *FakeUserDatabase) GetUserCalls(stub func(string) (*users.User, error)) {
+	fake.getUserMutex.Lock()
+	defer fake.getUserMutex.Unlock()
+	fake.GetUserStub = stub
+}
+
+func (fake *FakeUserDatabase) GetUserArgsForCall(i int) string {
+	fake.getUserMutex.RLock()
+	defer fake.getUserMutex.RUnlock()
+	argsForCall := fake.getUserArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeUserDatabase) GetUserReturns(result1 *users.User, result2 error) {
+	fake.getUserMutex.Lock()
+	defer fake.getUserMutex.Unlock()
+	fake.GetUserStub = nil
+	fake.getUserReturns = struct {
+		result1 *users.User
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeUserDatabase) GetUserReturnsOnCall(i int, result1 *users.User, result2 error) {
+	fake.getUserMutex.Lock()
+	defer fake.getUserMutex.Unlock()
+	fake.GetUserStub = nil
+	if fake.getUserReturnsOnCall == nil {
+		fake.getUserReturnsOnCall = make(map[int]struct {
+			result1 *users.User
+			result2 error
+		})
+	}
+	fake.getUserReturnsOnCall[i] = struct {
+		result1 *users.User
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeUserDatabase) GetUsers() ([]users.User, error) {
+	fake.getUsersMutex.Lock()
+	ret, specificReturn := fake.getUsersReturnsOnCall[len(fake.getUsersArgsForCall)]
+	fake.getUsersArgsForCall = append(fake.getUsersArgsForCall, struct {
+	}{})
+	stub := fake.GetUsersStub
+	fakeReturns := fake.getUsersReturns
+	fake.recordInvocation("GetUsers", []interface{}{})
+	fake.getUsersMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeUserDatabase) GetUsersCallCount() int {
+	fake.getUsersMutex.RLock()
+	defer fake.getUsersMutex.RUnlock()
+	return len(fake.getUsersArgsForCall)
+}
+
+func (fake *FakeUserDatabase) GetUsersCalls(stub func() ([]users.User, error)) {
+	fake.getUsersMutex.Lock()
+	defer fake.getUsersMutex.Unlock()
+	fake.GetUsersStub = stub
+}
+
+func (fake *FakeUserDatabase) GetUsersReturns(result1 []users.User, result2 error) {
+	fake.getUsersMutex.Lock()
+	defer fake.getUsersMutex.Unlock()
+	fake.GetUsersStub = nil
+	fake.getUsersReturns = struct {
+		result1 []users.User
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeUserDatabase) GetUsersReturnsOnCall(i int, result1 []users.User, result2 error) {
+	fake.getUsersMutex.Lock()
+	defer fake.getUsersMutex.Unlock()
+	fake.GetUsersStub = nil
+	if fake.getUsersReturnsOnCall == nil {
+		fake.getUsersReturnsOnCall = make(map[int]struct {
+			result1 []users.User
+			result2 error
+		})
+	}
+	fake.getUsersReturnsOnCall[i] = struct {
+		result1 []users.User
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeUserDatabase) InitializeDB() error {
 	fake.initializeDBMutex.Lock()
 	ret, specificReturn := fake.initializeDBReturnsOnCall[len(fake.initializeDBArgsForCall)]
-	fake.initializeDBArgsForCall = append(fake.initializeDBArgsForCall, struct{}{})
+	fake.initializeDBArgsForCall = append(fake.initializeDBArgsForCall, struct {
+	}{})
+	stub := fake.InitializeDBStub
+	fakeReturns := fake.initializeDBReturns
 	fake.recordInvocation("InitializeDB", []interface{}{})
 	fake.initializeDBMutex.Unlock()
-	if fake.InitializeDBStub != nil {
-		return fake.InitializeDBStub()
+	if stub != nil {
+		return stub()
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	return fake.initializeDBReturns.result1
+	return fakeReturns.result1
 }
 
 func (fake *FakeUserDatabase) InitializeDBCallCount() int {
@@ -82,7 +475,15 @@ func (fake *FakeUserDatabase) InitializeDBCallCount() int {
 	return len(fake.initializeDBArgsForCall)
 }
 
+func (fake *FakeUserDatabase) InitializeDBCalls(stub func() error) {
+	fake.initializeDBMutex.Lock()
+	defer fake.initializeDBMutex.Unlock()
+	fake.InitializeDBStub = stub
+}
+
 func (fake *FakeUserDatabase) InitializeDBReturns(result1 error) {
+	fake.initializeDBMutex.Lock()
+	defer fake.initializeDBMutex.Unlock()
 	fake.InitializeDBStub = nil
 	fake.initializeDBReturns = struct {
 		result1 error
@@ -90,6 +491,8 @@ func (fake *FakeUserDatabase) InitializeDBReturns(result1 error) {
 }
 
 func (fake *FakeUserDatabase) InitializeDBReturnsOnCall(i int, result1 error) {
+	fake.initializeDBMutex.Lock()
+	defer fake.initializeDBMutex.Unlock()
 	fake.InitializeDBStub = nil
 	if fake.initializeDBReturnsOnCall == nil {
 		fake.initializeDBReturnsOnCall = make(map[int]struct {
@@ -101,38 +504,24 @@ func (fake *FakeUserDatabase) InitializeDBReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeUserDatabase) CloseDB() {
-	fake.closeDBMutex.Lock()
-	fake.closeDBArgsForCall = append(fake.closeDBArgsForCall, struct{}{})
-	fake.recordInvocation("CloseDB", []interface{}{})
-	fake.closeDBMutex.Unlock()
-	if fake.CloseDBStub != nil {
-		fake.CloseDBStub()
-	}
-}
-
-func (fake *FakeUserDatabase) CloseDBCallCount() int {
-	fake.closeDBMutex.RLock()
-	defer fake.closeDBMutex.RUnlock()
-	return len(fake.closeDBArgsForCall)
-}
-
-func (fake *FakeUserDatabase) LoginUser(username string, password string) error {
+func (fake *FakeUserDatabase) LoginUser(arg1 string, arg2 string) error {
 	fake.loginUserMutex.Lock()
 	ret, specificReturn := fake.loginUserReturnsOnCall[len(fake.loginUserArgsForCall)]
 	fake.loginUserArgsForCall = append(fake.loginUserArgsForCall, struct {
-		username string
-		password string
-	}{username, password})
-	fake.recordInvocation("LoginUser", []interface{}{username, password})
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.LoginUserStub
+	fakeReturns := fake.loginUserReturns
+	fake.recordInvocation("LoginUser", []interface{}{arg1, arg2})
 	fake.loginUserMutex.Unlock()
-	if fake.LoginUserStub != nil {
-		return fake.LoginUserStub(username, password)
+	if stub != nil {
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	return fake.loginUserReturns.result1
+	return fakeReturns.result1
 }
 
 func (fake *FakeUserDatabase) LoginUserCallCount() int {
@@ -141,13 +530,22 @@ func (fake *FakeUserDatabase) LoginUserCallCount() int {
 	return len(fake.loginUserArgsForCall)
 }
 
+func (fake *FakeUserDatabase) LoginUserCalls(stub func(string, string) error) {
+	fake.loginUserMutex.Lock()
+	defer fake.loginUserMutex.Unlock()
+	fake.LoginUserStub = stub
+}
+
 func (fake *FakeUserDatabase) LoginUserArgsForCall(i int) (string, string) {
 	fake.loginUserMutex.RLock()
 	defer fake.loginUserMutex.RUnlock()
-	return fake.loginUserArgsForCall[i].username, fake.loginUserArgsForCall[i].password
+	argsForCall := fake.loginUserArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeUserDatabase) LoginUserReturns(result1 error) {
+	fake.loginUserMutex.Lock()
+	defer fake.loginUserMutex.Unlock()
 	fake.LoginUserStub = nil
 	fake.loginUserReturns = struct {
 		result1 error
@@ -155,6 +553,8 @@ func (fake *FakeUserDatabase) LoginUserReturns(result1 error) {
 }
 
 func (fake *FakeUserDatabase) LoginUserReturnsOnCall(i int, result1 error) {
+	fake.loginUserMutex.Lock()
+	defer fake.loginUserMutex.Unlock()
 	fake.LoginUserStub = nil
 	if fake.loginUserReturnsOnCall == nil {
 		fake.loginUserReturnsOnCall = make(map[int]struct {
@@ -166,22 +566,77 @@ func (fake *FakeUserDatabase) LoginUserReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeUserDatabase) RegisterUser(username string, password string) error {
+func (fake *FakeUserDatabase) Ping() error {
+	fake.pingMutex.Lock()
+	ret, specificReturn := fake.pingReturnsOnCall[len(fake.pingArgsForCall)]
+	fake.pingArgsForCall = append(fake.pingArgsForCall, struct {
+	}{})
+	stub := fake.PingStub
+	fakeReturns := fake.pingReturns
+	fake.recordInvocation("Ping", []interface{}{})
+	fake.pingMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeUserDatabase) PingCallCount() int {
+	fake.pingMutex.RLock()
+	defer fake.pingMutex.RUnlock()
+	return len(fake.pingArgsForCall)
+}
+
+func (fake *FakeUserDatabase) PingCalls(stub func() error) {
+	fake.pingMutex.Lock()
+	defer fake.pingMutex.Unlock()
+	fake.PingStub = stub
+}
+
+func (fake *FakeUserDatabase) PingReturns(result1 error) {
+	fake.pingMutex.Lock()
+	defer fake.pingMutex.Unlock()
+	fake.PingStub = nil
+	fake.pingReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeUserDatabase) PingReturnsOnCall(i int, result1 error) {
+	fake.pingMutex.Lock()
+	defer fake.pingMutex.Unlock()
+	fake.PingStub = nil
+	if fake.pingReturnsOnCall == nil {
+		fake.pingReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.pingReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeUserDatabase) RegisterUser(arg1 string, arg2 string) error {
 	fake.registerUserMutex.Lock()
 	ret, specificReturn := fake.registerUserReturnsOnCall[len(fake.registerUserArgsForCall)]
 	fake.registerUserArgsForCall = append(fake.registerUserArgsForCall, struct {
-		username string
-		password string
-	}{username, password})
-	fake.recordInvocation("RegisterUser", []interface{}{username, password})
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.RegisterUserStub
+	fakeReturns := fake.registerUserReturns
+	fake.recordInvocation("RegisterUser", []interface{}{arg1, arg2})
 	fake.registerUserMutex.Unlock()
-	if fake.RegisterUserStub != nil {
-		return fake.RegisterUserStub(username, password)
+	if stub != nil {
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	return fake.registerUserReturns.result1
+	return fakeReturns.result1
 }
 
 func (fake *FakeUserDatabase) RegisterUserCallCount() int {
@@ -190,13 +645,22 @@ func (fake *FakeUserDatabase) RegisterUserCallCount() int {
 	return len(fake.registerUserArgsForCall)
 }
 
+func (fake *FakeUserDatabase) RegisterUserCalls(stub func(string, string) error) {
+	fake.registerUserMutex.Lock()
+	defer fake.registerUserMutex.Unlock()
+	fake.RegisterUserStub = stub
+}
+
 func (fake *FakeUserDatabase) RegisterUserArgsForCall(i int) (string, string) {
 	fake.registerUserMutex.RLock()
 	defer fake.registerUserMutex.RUnlock()
-	return fake.registerUserArgsForCall[i].username, fake.registerUserArgsForCall[i].password
+	argsForCall := fake.registerUserArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeUserDatabase) RegisterUserReturns(result1 error) {
+	fake.registerUserMutex.Lock()
+	defer fake.registerUserMutex.Unlock()
 	fake.RegisterUserStub = nil
 	fake.registerUserReturns = struct {
 		result1 error
@@ -204,6 +668,8 @@ func (fake *FakeUserDatabase) RegisterUserReturns(result1 error) {
 }
 
 func (fake *FakeUserDatabase) RegisterUserReturnsOnCall(i int, result1 error) {
+	fake.registerUserMutex.Lock()
+	defer fake.registerUserMutex.Unlock()
 	fake.RegisterUserStub = nil
 	if fake.registerUserReturnsOnCall == nil {
 		fake.registerUserReturnsOnCall = make(map[int]struct {
@@ -215,21 +681,332 @@ func (fake *FakeUserDatabase) RegisterUserReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeUserDatabase) UserExists(username string) (bool, error) {
+func (fake *FakeUserDatabase) SetBanned(arg1 string, arg2 bool) error {
+	fake.setBannedMutex.Lock()
+	ret, specificReturn := fake.setBannedReturnsOnCall[len(fake.setBannedArgsForCall)]
+	fake.setBannedArgsForCall = append(fake.setBannedArgsForCall, struct {
+		arg1 string
+		arg2 bool
+	}{arg1, arg2})
+	stub := fake.SetBannedStub
+	fakeReturns := fake.setBannedReturns
+	fake.recordInvocation("SetBanned", []interface{}{arg1, arg2})
+	fake.setBannedMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeUserDatabase) SetBannedCallCount() int {
+	fake.setBannedMutex.RLock()
+	defer fake.setBannedMutex.RUnlock()
+	return len(fake.setBannedArgsForCall)
+}
+
+func (fake *FakeUserDatabase) SetBannedCalls(stub func(string, bool) error) {
+	fake.setBannedMutex.Lock()
+	defer fake.setBannedMutex.Unlock()
+	fake.SetBannedStub = stub
+}
+
+func (fake *FakeUserDatabase) SetBannedArgsForCall(i int) (string, bool) {
+	fake.setBannedMutex.RLock()
+	defer fake.setBannedMutex.RUnlock()
+	argsForCall := fake.setBannedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeUserDatabase) SetBannedReturns(result1 error) {
+	fake.setBannedMutex.Lock()
+	defer fake.setBannedMutex.Unlock()
+	fake.SetBannedStub = nil
+	fake.setBannedReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeUserDatabase) SetBannedReturnsOnCall(i int, result1 error) {
+	fake.setBannedMutex.Lock()
+	defer fake.setBannedMutex.Unlock()
+	fake.SetBannedStub = nil
+	if fake.setBannedReturnsOnCall == nil {
+		fake.setBannedReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setBannedReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeUserDatabase) SetDisabled(arg1 string, arg2 bool) error {
+	fake.setDisabledMutex.Lock()
+	ret, specificReturn := fake.setDisabledReturnsOnCall[len(fake.setDisabledArgsForCall)]
+	fake.setDisabledArgsForCall = append(fake.setDisabledArgsForCall, struct {
+		arg1 string
+		arg2 bool
+	}{arg1, arg2})
+	stub := fake.SetDisabledStub
+	fakeReturns := fake.setDisabledReturns
+	fake.recordInvocation("SetDisabled", []interface{}{arg1, arg2})
+	fake.setDisabledMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeUserDatabase) SetDisabledCallCount() int {
+	fake.setDisabledMutex.RLock()
+	defer fake.setDisabledMutex.RUnlock()
+	return len(fake.setDisabledArgsForCall)
+}
+
+func (fake *FakeUserDatabase) SetDisabledCalls(stub func(string, bool) error) {
+	fake.setDisabledMutex.Lock()
+	defer fake.setDisabledMutex.Unlock()
+	fake.SetDisabledStub = stub
+}
+
+func (fake *FakeUserDatabase) SetDisabledArgsForCall(i int) (string, bool) {
+	fake.setDisabledMutex.RLock()
+	defer fake.setDisabledMutex.RUnlock()
+	argsForCall := fake.setDisabledArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeUserDatabase) SetDisabledReturns(result1 error) {
+	fake.setDisabledMutex.Lock()
+	defer fake.setDisabledMutex.Unlock()
+	fake.SetDisabledStub = nil
+	fake.setDisabledReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeUserDatabase) SetDisabledReturnsOnCall(i int, result1 error) {
+	fake.setDisabledMutex.Lock()
+	defer fake.setDisabledMutex.Unlock()
+	fake.SetDisabledStub = nil
+	if fake.setDisabledReturnsOnCall == nil {
+		fake.setDisabledReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setDisabledReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeUserDatabase) SetRole(arg1 string, arg2 string) error {
+	fake.setRoleMutex.Lock()
+	ret, specificReturn := fake.setRoleReturnsOnCall[len(fake.setRoleArgsForCall)]
+	fake.setRoleArgsForCall = append(fake.setRoleArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.SetRoleStub
+	fakeReturns := fake.setRoleReturns
+	fake.recordInvocation("SetRole", []interface{}{arg1, arg2})
+	fake.setRoleMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeUserDatabase) SetRoleCallCount() int {
+	fake.setRoleMutex.RLock()
+	defer fake.setRoleMutex.RUnlock()
+	return len(fake.setRoleArgsForCall)
+}
+
+func (fake *FakeUserDatabase) SetRoleCalls(stub func(string, string) error) {
+	fake.setRoleMutex.Lock()
+	defer fake.setRoleMutex.Unlock()
+	fake.SetRoleStub = stub
+}
+
+func (fake *FakeUserDatabase) SetRoleArgsForCall(i int) (string, string) {
+	fake.setRoleMutex.RLock()
+	defer fake.setRoleMutex.RUnlock()
+	argsForCall := fake.setRoleArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeUserDatabase) SetRoleReturns(result1 error) {
+	fake.setRoleMutex.Lock()
+	defer fake.setRoleMutex.Unlock()
+	fake.SetRoleStub = nil
+	fake.setRoleReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeUserDatabase) SetRoleReturnsOnCall(i int, result1 error) {
+	fake.setRoleMutex.Lock()
+	defer fake.setRoleMutex.Unlock()
+	fake.SetRoleStub = nil
+	if fake.setRoleReturnsOnCall == nil {
+		fake.setRoleReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setRoleReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeUserDatabase) UpdatePassword(arg1 string, arg2 string) error {
+	fake.updatePasswordMutex.Lock()
+	ret, specificReturn := fake.updatePasswordReturnsOnCall[len(fake.updatePasswordArgsForCall)]
+	fake.updatePasswordArgsForCall = append(fake.updatePasswordArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.UpdatePasswordStub
+	fakeReturns := fake.updatePasswordReturns
+	fake.recordInvocation("UpdatePassword", []interface{}{arg1, arg2})
+	fake.updatePasswordMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeUserDatabase) UpdatePasswordCallCount() int {
+	fake.updatePasswordMutex.RLock()
+	defer fake.updatePasswordMutex.RUnlock()
+	return len(fake.updatePasswordArgsForCall)
+}
+
+func (fake *FakeUserDatabase) UpdatePasswordCalls(stub func(string, string) error) {
+	fake.updatePasswordMutex.Lock()
+	defer fake.updatePasswordMutex.Unlock()
+	fake.UpdatePasswordStub = stub
+}
+
+func (fake *FakeUserDatabase) UpdatePasswordArgsForCall(i int) (string, string) {
+	fake.updatePasswordMutex.RLock()
+	defer fake.updatePasswordMutex.RUnlock()
+	argsForCall := fake.updatePasswordArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeUserDatabase) UpdatePasswordReturns(result1 error) {
+	fake.updatePasswordMutex.Lock()
+	defer fake.updatePasswordMutex.Unlock()
+	fake.UpdatePasswordStub = nil
+	fake.updatePasswordReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeUserDatabase) UpdatePasswordReturnsOnCall(i int, result1 error) {
+	fake.updatePasswordMutex.Lock()
+	defer fake.updatePasswordMutex.Unlock()
+	fake.UpdatePasswordStub = nil
+	if fake.updatePasswordReturnsOnCall == nil {
+		fake.updatePasswordReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updatePasswordReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeUserDatabase) UpdateProfile(arg1 users.Profile) error {
+	fake.updateProfileMutex.Lock()
+	ret, specificReturn := fake.updateProfileReturnsOnCall[len(fake.updateProfileArgsForCall)]
+	fake.updateProfileArgsForCall = append(fake.updateProfileArgsForCall, struct {
+		arg1 users.Profile
+	}{arg1})
+	stub := fake.UpdateProfileStub
+	fakeReturns := fake.updateProfileReturns
+	fake.recordInvocation("UpdateProfile", []interface{}{arg1})
+	fake.updateProfileMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeUserDatabase) UpdateProfileCallCount() int {
+	fake.updateProfileMutex.RLock()
+	defer fake.updateProfileMutex.RUnlock()
+	return len(fake.updateProfileArgsForCall)
+}
+
+func (fake *FakeUserDatabase) UpdateProfileCalls(stub func(users.Profile) error) {
+	fake.updateProfileMutex.Lock()
+	defer fake.updateProfileMutex.Unlock()
+	fake.UpdateProfileStub = stub
+}
+
+func (fake *FakeUserDatabase) UpdateProfileArgsForCall(i int) users.Profile {
+	fake.updateProfileMutex.RLock()
+	defer fake.updateProfileMutex.RUnlock()
+	argsForCall := fake.updateProfileArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeUserDatabase) UpdateProfileReturns(result1 error) {
+	fake.updateProfileMutex.Lock()
+	defer fake.updateProfileMutex.Unlock()
+	fake.UpdateProfileStub = nil
+	fake.updateProfileReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeUserDatabase) UpdateProfileReturnsOnCall(i int, result1 error) {
+	fake.updateProfileMutex.Lock()
+	defer fake.updateProfileMutex.Unlock()
+	fake.UpdateProfileStub = nil
+	if fake.updateProfileReturnsOnCall == nil {
+		fake.updateProfileReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateProfileReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeUserDatabase) UserExists(arg1 string) (bool, error) {
 	fake.userExistsMutex.Lock()
 	ret, specificReturn := fake.userExistsReturnsOnCall[len(fake.userExistsArgsForCall)]
 	fake.userExistsArgsForCall = append(fake.userExistsArgsForCall, struct {
-		username string
-	}{username})
-	fake.recordInvocation("UserExists", []interface{}{username})
+		arg1 string
+	}{arg1})
+	stub := fake.UserExistsStub
+	fakeReturns := fake.userExistsReturns
+	fake.recordInvocation("UserExists", []interface{}{arg1})
 	fake.userExistsMutex.Unlock()
-	if fake.UserExistsStub != nil {
-		return fake.UserExistsStub(username)
+	if stub != nil {
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	return fake.userExistsReturns.result1, fake.userExistsReturns.result2
+	return fakeReturns.result1, fakeReturns.result2
 }
 
 func (fake *FakeUserDatabase) UserExistsCallCount() int {
@@ -238,13 +1015,22 @@ func (fake *FakeUserDatabase) UserExistsCallCount() int {
 	return len(fake.userExistsArgsForCall)
 }
 
+func (fake *FakeUserDatabase) UserExistsCalls(stub func(string) (bool, error)) {
+	fake.userExistsMutex.Lock()
+	defer fake.userExistsMutex.Unlock()
+	fake.UserExistsStub = stub
+}
+
 func (fake *FakeUserDatabase) UserExistsArgsForCall(i int) string {
 	fake.userExistsMutex.RLock()
 	defer fake.userExistsMutex.RUnlock()
-	return fake.userExistsArgsForCall[i].username
+	argsForCall := fake.userExistsArgsForCall[i]
+	return argsForCall.arg1
 }
 
 func (fake *FakeUserDatabase) UserExistsReturns(result1 bool, result2 error) {
+	fake.userExistsMutex.Lock()
+	defer fake.userExistsMutex.Unlock()
 	fake.UserExistsStub = nil
 	fake.userExistsReturns = struct {
 		result1 bool
@@ -253,6 +1039,8 @@ func (fake *FakeUserDatabase) UserExistsReturns(result1 bool, result2 error) {
 }
 
 func (fake *FakeUserDatabase) UserExistsReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.userExistsMutex.Lock()
+	defer fake.userExistsMutex.Unlock()
 	fake.UserExistsStub = nil
 	if fake.userExistsReturnsOnCall == nil {
 		fake.userExistsReturnsOnCall = make(map[int]struct {
@@ -269,14 +1057,34 @@ func (fake *FakeUserDatabase) UserExistsReturnsOnCall(i int, result1 bool, resul
 func (fake *FakeUserDatabase) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
-	fake.initializeDBMutex.RLock()
-	defer fake.initializeDBMutex.RUnlock()
 	fake.closeDBMutex.RLock()
 	defer fake.closeDBMutex.RUnlock()
+	fake.deleteUserMutex.RLock()
+	defer fake.deleteUserMutex.RUnlock()
+	fake.getProfileMutex.RLock()
+	defer fake.getProfileMutex.RUnlock()
+	fake.getUserMutex.RLock()
+	defer fake.getUserMutex.RUnlock()
+	fake.getUsersMutex.RLock()
+	defer fake.getUsersMutex.RUnlock()
+	fake.initializeDBMutex.RLock()
+	defer fake.initializeDBMutex.RUnlock()
 	fake.loginUserMutex.RLock()
 	defer fake.loginUserMutex.RUnlock()
+	fake.pingMutex.RLock()
+	defer fake.pingMutex.RUnlock()
 	fake.registerUserMutex.RLock()
 	defer fake.registerUserMutex.RUnlock()
+	fake.setBannedMutex.RLock()
+	defer fake.setBannedMutex.RUnlock()
+	fake.setDisabledMutex.RLock()
+	defer fake.setDisabledMutex.RUnlock()
+	fake.setRoleMutex.RLock()
+	defer fake.setRoleMutex.RUnlock()
+	fake.updatePasswordMutex.RLock()
+	defer fake.updatePasswordMutex.RUnlock()
+	fake.updateProfileMutex.RLock()
+	defer fake.updateProfileMutex.RUnlock()
 	fake.userExistsMutex.RLock()
 	defer fake.userExistsMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
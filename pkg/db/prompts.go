@@ -0,0 +1,100 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package db
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/pavelhadzhiev/story-builder/pkg/api/prompts"
+	"github.com/pavelhadzhiev/story-builder/pkg/metrics"
+)
+
+// PromptDatabase represents an object that can be used to store story prompts
+//go:generate counterfeiter . PromptDatabase
+type PromptDatabase interface {
+	AddPrompt(prompt *prompts.Prompt) (int, error)
+	GetPrompt(id int) (*prompts.Prompt, error)
+	GetPrompts(room string) ([]prompts.Prompt, error)
+	UpdatePrompt(prompt *prompts.Prompt) error
+	DeletePrompt(id int) error
+}
+
+// AddPrompt saves the provided prompt and returns the ID it was given.
+func (sbdb *SBDatabase) AddPrompt(prompt *prompts.Prompt) (int, error) {
+	defer metrics.ObserveSince(metrics.DBQueryDuration, time.Now(), "add_prompt")
+	result, err := sbdb.database.Exec("insert into prompts(room, text, author) values(?, ?, ?)", prompt.Room, prompt.Text, prompt.Author)
+	if err != nil {
+		return 0, err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+	return int(id), nil
+}
+
+// GetPrompt returns the prompt with the provided ID or nil if there is no such prompt.
+func (sbdb *SBDatabase) GetPrompt(id int) (*prompts.Prompt, error) {
+	defer metrics.ObserveSince(metrics.DBQueryDuration, time.Now(), "get_prompt")
+	prompt := &prompts.Prompt{}
+	err := sbdb.database.QueryRow("select id, room, text, author from prompts where id = ?", id).Scan(&prompt.ID, &prompt.Room, &prompt.Text, &prompt.Author)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return prompt, nil
+}
+
+// GetPrompts returns all server-wide prompts, along with the prompts of the provided room. Pass an empty room to get the server-wide prompts only.
+func (sbdb *SBDatabase) GetPrompts(room string) ([]prompts.Prompt, error) {
+	defer metrics.ObserveSince(metrics.DBQueryDuration, time.Now(), "get_prompts")
+	stmt, err := sbdb.database.Prepare("select id, room, text, author from prompts where room = '' or room = ? order by id")
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+	rows, err := stmt.Query(room)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]prompts.Prompt, 0)
+	for rows.Next() {
+		var prompt prompts.Prompt
+		if err := rows.Scan(&prompt.ID, &prompt.Room, &prompt.Text, &prompt.Author); err != nil {
+			return nil, err
+		}
+		result = append(result, prompt)
+	}
+	return result, rows.Err()
+}
+
+// UpdatePrompt replaces the text of the prompt with the ID of the provided one.
+func (sbdb *SBDatabase) UpdatePrompt(prompt *prompts.Prompt) error {
+	defer metrics.ObserveSince(metrics.DBQueryDuration, time.Now(), "update_prompt")
+	_, err := sbdb.database.Exec("update prompts set text = ? where id = ?", prompt.Text, prompt.ID)
+	return err
+}
+
+// DeletePrompt removes the prompt with the provided ID.
+func (sbdb *SBDatabase) DeletePrompt(id int) error {
+	defer metrics.ObserveSince(metrics.DBQueryDuration, time.Now(), "delete_prompt")
+	_, err := sbdb.database.Exec("delete from prompts where id = ?", id)
+	return err
+}
@@ -14,13 +14,21 @@
 
 package db
 
-import "errors"
+import (
+	"database/sql"
+	"errors"
+	"time"
 
-const getUserByUsername = "select * from users where username = ?"
+	"github.com/pavelhadzhiev/story-builder/pkg/api/users"
+	"github.com/pavelhadzhiev/story-builder/pkg/metrics"
+)
 
-// UserExists returns true if the provided username is already taken according to the server database.
+const getUserByUsername = "select username, password from users where username = ?"
+
+// UserExists returns true if the provided username is already taken according to the server database. Usernames that differ only in case are considered the same.
 func (sbdb *SBDatabase) UserExists(username string) (bool, error) {
-	stmt, err := sbdb.database.Prepare(getUserByUsername)
+	defer metrics.ObserveSince(metrics.DBQueryDuration, time.Now(), "user_exists")
+	stmt, err := sbdb.database.Prepare("select username from users where lower(username) = lower(?)")
 	if err != nil {
 		return false, err
 	}
@@ -41,6 +49,7 @@ func (sbdb *SBDatabase) UserExists(username string) (bool, error) {
 
 // LoginUser returns true if the provided user exists and the password matches the one that is saved for that username in the server database.
 func (sbdb *SBDatabase) LoginUser(username, password string) error {
+	defer metrics.ObserveSince(metrics.DBQueryDuration, time.Now(), "login_user")
 	stmt, err := sbdb.database.Prepare(getUserByUsername)
 	if err != nil {
 		return err
@@ -67,9 +76,131 @@ func (sbdb *SBDatabase) LoginUser(username, password string) error {
 
 // RegisterUser registers a new user to the server with the provided username and password.
 func (sbdb *SBDatabase) RegisterUser(username, password string) error {
+	defer metrics.ObserveSince(metrics.DBQueryDuration, time.Now(), "register_user")
 	_, err := sbdb.database.Exec("insert into users(username, password) values(?, ?)", username, password)
 	if err != nil {
 		return err
 	}
 	return nil
 }
+
+// GetUser returns the account of the user with the provided username or nil if there is no such user.
+func (sbdb *SBDatabase) GetUser(username string) (*users.User, error) {
+	defer metrics.ObserveSince(metrics.DBQueryDuration, time.Now(), "get_user")
+	user := &users.User{}
+	err := sbdb.database.QueryRow("select username, role, disabled, banned from users where username = ?", username).Scan(&user.Username, &user.Role, &user.Disabled, &user.Banned)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// GetUsers returns the accounts of all users of the server, ordered by username.
+func (sbdb *SBDatabase) GetUsers() ([]users.User, error) {
+	defer metrics.ObserveSince(metrics.DBQueryDuration, time.Now(), "get_users")
+	rows, err := sbdb.database.Query("select username, role, disabled, banned from users order by username")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]users.User, 0)
+	for rows.Next() {
+		var user users.User
+		if err := rows.Scan(&user.Username, &user.Role, &user.Disabled, &user.Banned); err != nil {
+			return nil, err
+		}
+		result = append(result, user)
+	}
+	return result, rows.Err()
+}
+
+// SetRole gives the user with the provided username the provided role.
+func (sbdb *SBDatabase) SetRole(username, role string) error {
+	defer metrics.ObserveSince(metrics.DBQueryDuration, time.Now(), "set_role")
+	return sbdb.updateUser(username, "update users set role = ? where username = ?", role)
+}
+
+// SetDisabled disables or enables the account of the user with the provided username.
+func (sbdb *SBDatabase) SetDisabled(username string, disabled bool) error {
+	defer metrics.ObserveSince(metrics.DBQueryDuration, time.Now(), "set_disabled")
+	return sbdb.updateUser(username, "update users set disabled = ? where username = ?", disabled)
+}
+
+// SetBanned bans the user with the provided username from the server or lifts the ban.
+func (sbdb *SBDatabase) SetBanned(username string, banned bool) error {
+	defer metrics.ObserveSince(metrics.DBQueryDuration, time.Now(), "set_banned")
+	return sbdb.updateUser(username, "update users set banned = ? where username = ?", banned)
+}
+
+// UpdatePassword replaces the password of the user with the provided username.
+func (sbdb *SBDatabase) UpdatePassword(username, password string) error {
+	defer metrics.ObserveSince(metrics.DBQueryDuration, time.Now(), "update_password")
+	return sbdb.updateUser(username, "update users set password = ? where username = ?", password)
+}
+
+// DeleteUser removes the user with the provided username from the server database.
+// Returns error if there is no such user.
+func (sbdb *SBDatabase) DeleteUser(username string) error {
+	defer metrics.ObserveSince(metrics.DBQueryDuration, time.Now(), "delete_user")
+	result, err := sbdb.database.Exec("delete from users where username = ?", username)
+	if err != nil {
+		return err
+	}
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return errors.New("user not found")
+	}
+	return nil
+}
+
+// GetProfile returns the profile of the user with the provided username or nil if there is no such user.
+func (sbdb *SBDatabase) GetProfile(username string) (*users.Profile, error) {
+	defer metrics.ObserveSince(metrics.DBQueryDuration, time.Now(), "get_profile")
+	profile := &users.Profile{}
+	err := sbdb.database.QueryRow("select username, display_name, bio, email, turn_reminders from users where username = ?", username).Scan(&profile.Username, &profile.DisplayName, &profile.Bio, &profile.Email, &profile.TurnReminders)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return profile, nil
+}
+
+// UpdateProfile replaces the display name, the bio, the email address and the turn reminders setting of the user the provided profile belongs to.
+// Returns error if there is no such user.
+func (sbdb *SBDatabase) UpdateProfile(profile users.Profile) error {
+	defer metrics.ObserveSince(metrics.DBQueryDuration, time.Now(), "update_profile")
+	result, err := sbdb.database.Exec("update users set display_name = ?, bio = ?, email = ?, turn_reminders = ? where username = ?", profile.DisplayName, profile.Bio, profile.Email, profile.TurnReminders, profile.Username)
+	if err != nil {
+		return err
+	}
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 { // unchanged rows are not counted as affected
+		if exists, err := sbdb.UserExists(profile.Username); err != nil {
+			return err
+		} else if !exists {
+			return errors.New("user not found")
+		}
+	}
+	return nil
+}
+
+// updateUser sets a column of the user with the provided username, using the provided update statement that takes the new value and the username.
+// Returns error if there is no such user.
+func (sbdb *SBDatabase) updateUser(username, query string, value interface{}) error {
+	result, err := sbdb.database.Exec(query, value, username)
+	if err != nil {
+		return err
+	}
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 { // unchanged rows are not counted as affected
+		if exists, err := sbdb.UserExists(username); err != nil {
+			return err
+		} else if !exists {
+			return errors.New("user not found")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,241 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"sync"
+)
+
+// CounterVec is a counter, partitioned by labels. A counter only goes up.
+type CounterVec struct {
+	name       string
+	help       string
+	labelNames []string
+
+	mutex  sync.Mutex
+	series map[string]*counterSeries
+}
+
+type counterSeries struct {
+	labelValues []string
+	value       float64
+}
+
+// NewCounterVec creates a counter with the provided name, help text and label names.
+func NewCounterVec(name, help string, labelNames ...string) *CounterVec {
+	return &CounterVec{
+		name:       name,
+		help:       help,
+		labelNames: labelNames,
+		series:     make(map[string]*counterSeries),
+	}
+}
+
+// Name returns the name of the counter.
+func (counter *CounterVec) Name() string {
+	return counter.name
+}
+
+// Inc increments the counter with the provided label values by one.
+func (counter *CounterVec) Inc(labelValues ...string) {
+	counter.Add(1, labelValues...)
+}
+
+// Add increases the counter with the provided label values by the provided amount.
+// Panics if the number of label values doesn't match the label names or the amount is negative, as these are programming errors.
+func (counter *CounterVec) Add(amount float64, labelValues ...string) {
+	if len(labelValues) != len(counter.labelNames) {
+		panic(fmt.Sprintf("metric \"%s\" expects %d label values, got %d", counter.name, len(counter.labelNames), len(labelValues)))
+	}
+	if amount < 0 {
+		panic(fmt.Sprintf("counter \"%s\" can't be decreased", counter.name))
+	}
+	counter.mutex.Lock()
+	defer counter.mutex.Unlock()
+	key := labelKey(labelValues)
+	series, ok := counter.series[key]
+	if !ok {
+		series = &counterSeries{labelValues: append([]string(nil), labelValues...)}
+		counter.series[key] = series
+	}
+	series.value += amount
+}
+
+// Value returns the current value of the counter with the provided label values.
+func (counter *CounterVec) Value(labelValues ...string) float64 {
+	counter.mutex.Lock()
+	defer counter.mutex.Unlock()
+	if series, ok := counter.series[labelKey(labelValues)]; ok {
+		return series.value
+	}
+	return 0
+}
+
+// Write writes the counter in the Prometheus text exposition format. A counter without labels is written even if it was never incremented.
+func (counter *CounterVec) Write(w io.Writer) error {
+	if err := writeHeader(w, counter.name, counter.help, "counter"); err != nil {
+		return err
+	}
+	counter.mutex.Lock()
+	defer counter.mutex.Unlock()
+	if len(counter.labelNames) == 0 && len(counter.series) == 0 {
+		return writeSample(w, counter.name, nil, nil, 0)
+	}
+	keys := make([]string, 0, len(counter.series))
+	for key := range counter.series {
+		keys = append(keys, key)
+	}
+	for _, key := range sortedKeys(keys) {
+		series := counter.series[key]
+		if err := writeSample(w, counter.name, counter.labelNames, series.labelValues, series.value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GaugeFunc is a gauge whose value is computed by a function every time it's written.
+type GaugeFunc struct {
+	name  string
+	help  string
+	value func() float64
+}
+
+// NewGaugeFunc creates a gauge with the provided name and help text, whose value is returned by the provided function.
+func NewGaugeFunc(name, help string, value func() float64) *GaugeFunc {
+	return &GaugeFunc{name: name, help: help, value: value}
+}
+
+// Name returns the name of the gauge.
+func (gauge *GaugeFunc) Name() string {
+	return gauge.name
+}
+
+// Write writes the gauge in the Prometheus text exposition format.
+func (gauge *GaugeFunc) Write(w io.Writer) error {
+	if err := writeHeader(w, gauge.name, gauge.help, "gauge"); err != nil {
+		return err
+	}
+	return writeSample(w, gauge.name, nil, nil, gauge.value())
+}
+
+// HistogramVec is a histogram, partitioned by labels. It counts observations in cumulative buckets and keeps their sum and count.
+type HistogramVec struct {
+	name       string
+	help       string
+	labelNames []string
+	buckets    []float64
+
+	mutex  sync.Mutex
+	series map[string]*histogramSeries
+}
+
+type histogramSeries struct {
+	labelValues []string
+	counts      []uint64 // not cumulative, one per bucket
+	count       uint64
+	sum         float64
+}
+
+// DefaultBuckets are the upper bounds (in seconds) of the buckets of latency histograms.
+var DefaultBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// NewHistogramVec creates a histogram with the provided name, help text, bucket upper bounds (in increasing order) and label names.
+func NewHistogramVec(name, help string, buckets []float64, labelNames ...string) *HistogramVec {
+	return &HistogramVec{
+		name:       name,
+		help:       help,
+		labelNames: labelNames,
+		buckets:    buckets,
+		series:     make(map[string]*histogramSeries),
+	}
+}
+
+// Name returns the name of the histogram.
+func (histogram *HistogramVec) Name() string {
+	return histogram.name
+}
+
+// Observe adds an observation to the histogram with the provided label values.
+// Panics if the number of label values doesn't match the label names, as that's a programming error.
+func (histogram *HistogramVec) Observe(value float64, labelValues ...string) {
+	if len(labelValues) != len(histogram.labelNames) {
+		panic(fmt.Sprintf("metric \"%s\" expects %d label values, got %d", histogram.name, len(histogram.labelNames), len(labelValues)))
+	}
+	histogram.mutex.Lock()
+	defer histogram.mutex.Unlock()
+	key := labelKey(labelValues)
+	series, ok := histogram.series[key]
+	if !ok {
+		series = &histogramSeries{labelValues: append([]string(nil), labelValues...), counts: make([]uint64, len(histogram.buckets))}
+		histogram.series[key] = series
+	}
+	for index, upperBound := range histogram.buckets {
+		if value <= upperBound {
+			series.counts[index]++
+			break
+		}
+	}
+	series.count++
+	series.sum += value
+}
+
+// Count returns the number of observations of the histogram with the provided label values.
+func (histogram *HistogramVec) Count(labelValues ...string) uint64 {
+	histogram.mutex.Lock()
+	defer histogram.mutex.Unlock()
+	if series, ok := histogram.series[labelKey(labelValues)]; ok {
+		return series.count
+	}
+	return 0
+}
+
+// Write writes the histogram in the Prometheus text exposition format, with cumulative buckets, including the +Inf one.
+func (histogram *HistogramVec) Write(w io.Writer) error {
+	if err := writeHeader(w, histogram.name, histogram.help, "histogram"); err != nil {
+		return err
+	}
+	histogram.mutex.Lock()
+	defer histogram.mutex.Unlock()
+	keys := make([]string, 0, len(histogram.series))
+	for key := range histogram.series {
+		keys = append(keys, key)
+	}
+	bucketLabels := append(append([]string(nil), histogram.labelNames...), "le")
+	for _, key := range sortedKeys(keys) {
+		series := histogram.series[key]
+		cumulative := uint64(0)
+		for index, upperBound := range histogram.buckets {
+			cumulative += series.counts[index]
+			labelValues := append(append([]string(nil), series.labelValues...), formatValue(upperBound))
+			if err := writeSample(w, histogram.name+"_bucket", bucketLabels, labelValues, float64(cumulative)); err != nil {
+				return err
+			}
+		}
+		labelValues := append(append([]string(nil), series.labelValues...), "+Inf")
+		if err := writeSample(w, histogram.name+"_bucket", bucketLabels, labelValues, float64(series.count)); err != nil {
+			return err
+		}
+		if err := writeSample(w, histogram.name+"_sum", histogram.labelNames, series.labelValues, series.sum); err != nil {
+			return err
+		}
+		if err := writeSample(w, histogram.name+"_count", histogram.labelNames, series.labelValues, float64(series.count)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import "time"
+
+// DefaultRegistry holds the metrics of the story builder server, which are updated by the API, the games and the database.
+var DefaultRegistry = NewRegistry()
+
+var (
+	// HTTPRequests counts the handled HTTP requests by route, method and status code.
+	HTTPRequests = NewCounterVec("storybuilder_http_requests_total", "Number of handled HTTP requests by route, method and status code.", "route", "method", "status")
+	// HTTPRequestDuration observes the time (in seconds) it takes to handle HTTP requests by route.
+	HTTPRequestDuration = NewHistogramVec("storybuilder_http_request_duration_seconds", "Time it takes to handle HTTP requests by route, in seconds.", DefaultBuckets, "route")
+
+	// EntriesSubmitted counts the entries added to stories, including the ones in team games.
+	EntriesSubmitted = NewCounterVec("storybuilder_entries_submitted_total", "Number of entries added to stories.")
+	// TurnTimeouts counts the turns that passed because the player ran out of time.
+	TurnTimeouts = NewCounterVec("storybuilder_turn_timeouts_total", "Number of turns that passed because the player ran out of time.")
+	// VoteKicksTriggered counts the votes to kick a player that were started.
+	VoteKicksTriggered = NewCounterVec("storybuilder_vote_kicks_triggered_total", "Number of votes to kick a player that were started.")
+	// VoteKicksPassed counts the votes to kick a player that passed.
+	VoteKicksPassed = NewCounterVec("storybuilder_vote_kicks_passed_total", "Number of votes to kick a player that passed.")
+
+	// TurnReminders counts the turn reminder emails by result - "sent", "throttled" or "failed".
+	TurnReminders = NewCounterVec("storybuilder_turn_reminders_total", "Number of turn reminder emails by result.", "result")
+
+	// DBQueryDuration observes the time (in seconds) database operations take by operation.
+	DBQueryDuration = NewHistogramVec("storybuilder_db_query_duration_seconds", "Time database operations take by operation, in seconds.", DefaultBuckets, "operation")
+)
+
+func init() {
+	DefaultRegistry.Register(HTTPRequests)
+	DefaultRegistry.Register(HTTPRequestDuration)
+	DefaultRegistry.Register(EntriesSubmitted)
+	DefaultRegistry.Register(TurnTimeouts)
+	DefaultRegistry.Register(VoteKicksTriggered)
+	DefaultRegistry.Register(VoteKicksPassed)
+	DefaultRegistry.Register(TurnReminders)
+	DefaultRegistry.Register(DBQueryDuration)
+}
+
+// ObserveSince observes the time passed since the provided start (in seconds) in the histogram with the provided label values.
+// It's meant to be deferred at the start of the operation to measure.
+func ObserveSince(histogram *HistogramVec, start time.Time, labelValues ...string) {
+	histogram.Observe(time.Since(start).Seconds(), labelValues...)
+}
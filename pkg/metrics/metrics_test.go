@@ -0,0 +1,96 @@
+package metrics
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func write(t *testing.T, collectors ...Collector) string {
+	registry := NewRegistry()
+	for _, collector := range collectors {
+		registry.Register(collector)
+	}
+	buffer := &bytes.Buffer{}
+	if err := registry.Write(buffer); err != nil {
+		t.Fatal(err)
+	}
+	return buffer.String()
+}
+
+func TestCounterVec(t *testing.T) {
+	counter := NewCounterVec("requests_total", "Number of requests.", "route", "status")
+	counter.Inc("/rooms/", "200")
+	counter.Inc("/rooms/", "200")
+	counter.Add(3, "/login/", "401")
+
+	expected := `# HELP requests_total Number of requests.
+# TYPE requests_total counter
+requests_total{route="/login/",status="401"} 3
+requests_total{route="/rooms/",status="200"} 2
+`
+	if output := write(t, counter); output != expected {
+		t.Errorf("got:\n%s\nwant:\n%s", output, expected)
+	}
+	if counter.Value("/rooms/", "200") != 2 {
+		t.Error("value of the counter is not right")
+	}
+}
+
+func TestCounterWithoutLabelsIsWrittenBeforeFirstIncrement(t *testing.T) {
+	counter := NewCounterVec("entries_total", "Number of entries.")
+	if output := write(t, counter); !strings.Contains(output, "\nentries_total 0\n") {
+		t.Errorf("counter without labels should start at 0, got:\n%s", output)
+	}
+}
+
+func TestHistogramVec(t *testing.T) {
+	histogram := NewHistogramVec("latency_seconds", "Latency.", []float64{0.1, 1}, "route")
+	histogram.Observe(0.05, "/rooms/")
+	histogram.Observe(0.5, "/rooms/")
+	histogram.Observe(2, "/rooms/")
+
+	expected := `# HELP latency_seconds Latency.
+# TYPE latency_seconds histogram
+latency_seconds_bucket{route="/rooms/",le="0.1"} 1
+latency_seconds_bucket{route="/rooms/",le="1"} 2
+latency_seconds_bucket{route="/rooms/",le="+Inf"} 3
+latency_seconds_sum{route="/rooms/"} 2.55
+latency_seconds_count{route="/rooms/"} 3
+`
+	if output := write(t, histogram); output != expected {
+		t.Errorf("got:\n%s\nwant:\n%s", output, expected)
+	}
+}
+
+func TestGaugeFunc(t *testing.T) {
+	value := 3.0
+	gauge := NewGaugeFunc("rooms", "Number of rooms.", func() float64 { return value })
+	value = 5
+	if output := write(t, gauge); !strings.HasSuffix(output, "# TYPE rooms gauge\nrooms 5\n") {
+		t.Errorf("gauge should be computed when written, got:\n%s", output)
+	}
+}
+
+func TestLabelValuesAreEscaped(t *testing.T) {
+	counter := NewCounterVec("escaped_total", "Help with \\ and\nnewline.", "value")
+	counter.Inc("quote \" backslash \\ newline \n")
+	output := write(t, counter)
+	if !strings.Contains(output, `escaped_total{value="quote \" backslash \\ newline \n"} 1`) {
+		t.Errorf("label values should be escaped, got:\n%s", output)
+	}
+	if !strings.Contains(output, `# HELP escaped_total Help with \\ and\nnewline.`) {
+		t.Errorf("help should be escaped, got:\n%s", output)
+	}
+}
+
+func TestRegisterTwicePanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("registering two metrics with the same name should panic")
+		}
+	}()
+	registry := NewRegistry()
+	registry.Register(NewCounterVec("twice_total", "Twice."))
+	registry.Register(NewCounterVec("twice_total", "Twice."))
+}
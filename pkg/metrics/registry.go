@@ -0,0 +1,131 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Collector is a metric that can write its samples in the Prometheus text exposition format.
+type Collector interface {
+	// Name returns the name of the metric.
+	Name() string
+	// Write writes the HELP and TYPE lines of the metric, followed by its samples.
+	Write(w io.Writer) error
+}
+
+// Registry holds collectors and writes all of them at once.
+type Registry struct {
+	mutex      sync.Mutex
+	collectors []Collector
+}
+
+// NewRegistry returns an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{collectors: make([]Collector, 0)}
+}
+
+// Register adds the collector to the registry. Collectors are written in the order they were registered.
+// Panics if a collector with the same name is already registered, as that's a programming error.
+func (registry *Registry) Register(collector Collector) {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+	for _, registered := range registry.collectors {
+		if registered.Name() == collector.Name() {
+			panic(fmt.Sprintf("metric \"%s\" is already registered", collector.Name()))
+		}
+	}
+	registry.collectors = append(registry.collectors, collector)
+}
+
+// Write writes all registered collectors in the Prometheus text exposition format.
+func (registry *Registry) Write(w io.Writer) error {
+	registry.mutex.Lock()
+	collectors := append(make([]Collector, 0, len(registry.collectors)), registry.collectors...)
+	registry.mutex.Unlock()
+
+	buffered := bufio.NewWriter(w)
+	for _, collector := range collectors {
+		if err := collector.Write(buffered); err != nil {
+			return err
+		}
+	}
+	return buffered.Flush()
+}
+
+// writeHeader writes the HELP and TYPE lines of a metric.
+func writeHeader(w io.Writer, name, help, kind string) error {
+	_, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, escapeHelp(help), name, kind)
+	return err
+}
+
+// writeSample writes a single sample line with the provided labels.
+func writeSample(w io.Writer, name string, labelNames, labelValues []string, value float64) error {
+	_, err := fmt.Fprintf(w, "%s%s %s\n", name, formatLabels(labelNames, labelValues), formatValue(value))
+	return err
+}
+
+func formatLabels(names, values []string) string {
+	if len(names) == 0 {
+		return ""
+	}
+	pairs := make([]string, len(names))
+	for index, name := range names {
+		pairs[index] = fmt.Sprintf("%s=\"%s\"", name, escapeLabelValue(values[index]))
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func formatValue(value float64) string {
+	switch {
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	case math.IsNaN(value):
+		return "NaN"
+	default:
+		return strconv.FormatFloat(value, 'g', -1, 64)
+	}
+}
+
+var helpEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+
+func escapeHelp(help string) string {
+	return helpEscaper.Replace(help)
+}
+
+func escapeLabelValue(value string) string {
+	return labelValueEscaper.Replace(value)
+}
+
+// labelKey joins label values into a map key. The separator can't appear in valid UTF-8 label values.
+func labelKey(values []string) string {
+	return strings.Join(values, "\xff")
+}
+
+// sortedKeys returns the keys of a map of series, so samples are written in a stable order.
+func sortedKeys(keys []string) []string {
+	sort.Strings(keys)
+	return keys
+}
@@ -50,7 +50,10 @@ func ExtractCredentialsFromAuthorizationHeader(authorizationHeader string) (stri
 		return "", "", errors.New("invalid authorization header")
 	}
 
-	split := strings.Split(string(credentials), ":")
+	split := strings.SplitN(string(credentials), ":", 2)
+	if len(split) != 2 {
+		return "", "", errors.New("invalid authorization header")
+	}
 	username, password := split[0], split[1]
 	return username, password, nil
 }
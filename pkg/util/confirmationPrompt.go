@@ -22,9 +22,10 @@ import (
 )
 
 // ConfirmationPrompt is a utility function, used to prompt the user for confirmation on potentially dangerous commands.
+// The prompt is written to the standard error, so it doesn't get mixed with the output of the command.
 // Returns true if the user types yes and false if they don't.
 func ConfirmationPrompt(action string) bool {
-	fmt.Printf("Are you sure you want to %s? (yes/no)\n", action)
+	fmt.Fprintf(os.Stderr, "Are you sure you want to %s? (yes/no)\n", action)
 	reader := bufio.NewReader(os.Stdin)
 	if input, err := reader.ReadString('\n'); err != nil || strings.Compare(strings.ToLower(input), "yes\n") != 0 {
 		return false
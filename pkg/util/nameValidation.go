@@ -0,0 +1,95 @@
+// Copyright © 2019 Pavel Hadzhiev <p.hadzhiev96@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// MinUsernameLength is the minimum number of symbols in a username.
+const MinUsernameLength = 3
+
+// MaxUsernameLength is the maximum number of symbols in a username.
+const MaxUsernameLength = 32
+
+// MaxRoomNameLength is the maximum number of symbols in a room name.
+const MaxRoomNameLength = 64
+
+// ReservedNames can't be used as usernames or room names, regardless of case, as they are used by the server itself.
+var ReservedNames = []string{"system", "deleted", "server", "admin", "root", "anonymous"}
+
+// ValidateUsername returns error if the provided username breaks the naming policy of the server.
+// Usernames are between 3 and 32 symbols long and contain only letters, digits, "-", "_" and ".", but not only dots. Reserved names are not allowed.
+func ValidateUsername(username string) error {
+	if length := len([]rune(username)); length < MinUsernameLength || length > MaxUsernameLength {
+		return fmt.Errorf("username must be between %d and %d symbols long", MinUsernameLength, MaxUsernameLength)
+	}
+	for _, symbol := range username {
+		if !isNameSymbol(symbol) {
+			return fmt.Errorf(`username can only contain letters, digits, "-", "_" and ".", but contains %q`, symbol)
+		}
+	}
+	if err := checkDots("username", username); err != nil {
+		return err
+	}
+	return checkReserved("username", username)
+}
+
+// ValidateRoomName returns error if the provided room name breaks the naming policy of the server.
+// Room names are up to 64 symbols long and contain only letters, digits, spaces, "-", "_" and ".", but not only dots, and can't start or end with a space.
+// Reserved names are not allowed.
+func ValidateRoomName(roomName string) error {
+	if roomName == "" {
+		return fmt.Errorf("room name can't be empty")
+	}
+	if len([]rune(roomName)) > MaxRoomNameLength {
+		return fmt.Errorf("room name can't be longer than %d symbols", MaxRoomNameLength)
+	}
+	for _, symbol := range roomName {
+		if !isNameSymbol(symbol) && symbol != ' ' {
+			return fmt.Errorf(`room name can only contain letters, digits, spaces, "-", "_" and ".", but contains %q`, symbol)
+		}
+	}
+	if strings.TrimSpace(roomName) != roomName {
+		return fmt.Errorf("room name can't start or end with a space")
+	}
+	if err := checkDots("room name", roomName); err != nil {
+		return err
+	}
+	return checkReserved("room name", roomName)
+}
+
+func isNameSymbol(symbol rune) bool {
+	return unicode.IsLetter(symbol) || unicode.IsDigit(symbol) || symbol == '-' || symbol == '_' || symbol == '.'
+}
+
+// checkDots rejects names made only of dots, such as "." and "..", which are special in URL paths.
+func checkDots(kind, name string) error {
+	if strings.Trim(name, ".") == "" {
+		return fmt.Errorf("%s can't consist of dots only", kind)
+	}
+	return nil
+}
+
+func checkReserved(kind, name string) error {
+	for _, reserved := range ReservedNames {
+		if strings.EqualFold(name, reserved) {
+			return fmt.Errorf("%s \"%s\" is reserved", kind, name)
+		}
+	}
+	return nil
+}
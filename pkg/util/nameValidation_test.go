@@ -0,0 +1,58 @@
+package util
+
+import "testing"
+
+func TestValidateUsername(t *testing.T) {
+	tests := []struct {
+		username string
+		err      string
+	}{
+		{username: "alice"},
+		{username: "j.r.r.tolkien"},
+		{username: "bot-1_2"},
+		{username: "al", err: "username must be between 3 and 32 symbols long"},
+		{username: "a-very-long-username-of-33-symbol", err: "username must be between 3 and 32 symbols long"},
+		{username: "alice smith", err: `username can only contain letters, digits, "-", "_" and ".", but contains ' '`},
+		{username: "alice/bob", err: `username can only contain letters, digits, "-", "_" and ".", but contains '/'`},
+		{username: "...", err: "username can't consist of dots only"},
+		{username: "....", err: "username can't consist of dots only"},
+		{username: "Admin", err: `username "Admin" is reserved`},
+	}
+	for _, test := range tests {
+		err := ValidateUsername(test.username)
+		if test.err == "" && err != nil {
+			t.Errorf("username %q should be valid, got %v", test.username, err)
+		}
+		if test.err != "" && (err == nil || err.Error() != test.err) {
+			t.Errorf("username %q should be rejected with %q, got %v", test.username, test.err, err)
+		}
+	}
+}
+
+func TestValidateRoomName(t *testing.T) {
+	tests := []struct {
+		roomName string
+		err      string
+	}{
+		{roomName: "Tavern"},
+		{roomName: "The Prancing Pony"},
+		{roomName: "v1.0"},
+		{roomName: ".hidden"},
+		{roomName: "", err: "room name can't be empty"},
+		{roomName: "A room with a name that is way too long for the server to accept it", err: "room name can't be longer than 64 symbols"},
+		{roomName: "rooms/tavern", err: `room name can only contain letters, digits, spaces, "-", "_" and ".", but contains '/'`},
+		{roomName: " Tavern", err: "room name can't start or end with a space"},
+		{roomName: ".", err: "room name can't consist of dots only"},
+		{roomName: "..", err: "room name can't consist of dots only"},
+		{roomName: "System", err: `room name "System" is reserved`},
+	}
+	for _, test := range tests {
+		err := ValidateRoomName(test.roomName)
+		if test.err == "" && err != nil {
+			t.Errorf("room name %q should be valid, got %v", test.roomName, err)
+		}
+		if test.err != "" && (err == nil || err.Error() != test.err) {
+			t.Errorf("room name %q should be rejected with %q, got %v", test.roomName, test.err, err)
+		}
+	}
+}
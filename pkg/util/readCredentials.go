@@ -26,7 +26,7 @@ import (
 
 // ReadUsername is a utility function, used to read usernames from the terminal.
 func ReadUsername() (string, error) {
-	fmt.Print("Username: ")
+	fmt.Fprint(os.Stderr, "Username: ")
 	reader := bufio.NewReader(os.Stdin)
 	username, err := reader.ReadString('\n')
 	if err != nil {
@@ -37,11 +37,11 @@ func ReadUsername() (string, error) {
 
 // ReadPassword is a utility function, used to read passwords securely from the terminal.
 func ReadPassword() (string, error) {
-	fmt.Print("Password: ")
+	fmt.Fprint(os.Stderr, "Password: ")
 	password, err := terminal.ReadPassword((int)(syscall.Stdin))
 	if err != nil {
 		return "", err
 	}
-	fmt.Println()
+	fmt.Fprintln(os.Stderr)
 	return string(password), nil
 }
@@ -18,8 +18,13 @@ import (
 	"github.com/pavelhadzhiev/story-builder/cmd"
 	"github.com/pavelhadzhiev/story-builder/cmd/client"
 	"github.com/pavelhadzhiev/story-builder/cmd/client/admin"
+	"github.com/pavelhadzhiev/story-builder/cmd/client/bot"
+	"github.com/pavelhadzhiev/story-builder/cmd/client/chat"
 	"github.com/pavelhadzhiev/story-builder/cmd/client/game"
+	"github.com/pavelhadzhiev/story-builder/cmd/client/prompt"
 	"github.com/pavelhadzhiev/story-builder/cmd/client/room"
+	"github.com/pavelhadzhiev/story-builder/cmd/client/serveradmin"
+	"github.com/pavelhadzhiev/story-builder/cmd/client/webhook"
 	"github.com/pavelhadzhiev/story-builder/cmd/server"
 )
 
@@ -31,10 +36,15 @@ func main() {
 		&server.HostCmd{},
 		&server.ConnectCmd{Context: ctx},
 		&server.DisconnectCmd{Context: ctx},
+		&server.StatusCmd{Context: ctx},
+		&server.ContextCmd{Context: ctx},
 		&cmd.InfoCmd{Context: ctx},
 		&client.LoginCmd{Context: ctx},
 		&client.LogoutCmd{Context: ctx},
 		&client.RegisterCmd{Context: ctx},
+		&client.PasswdCmd{Context: ctx},
+		&client.DeleteAccountCmd{Context: ctx},
+		&client.ProfileCmd{Context: ctx},
 		&room.CreateRoomCmd{Context: ctx},
 		&room.DeleteRoomCmd{Context: ctx},
 		&room.JoinRoomCmd{Context: ctx},
@@ -42,13 +52,30 @@ func main() {
 		&room.ListRoomsCmd{Context: ctx},
 		&game.StartGameCmd{Context: ctx},
 		&game.EndGameCmd{Context: ctx},
+		&game.PauseGameCmd{Context: ctx},
+		&game.ResumeGameCmd{Context: ctx},
+		&game.ReadyCmd{Context: ctx},
+		&game.CloseLobbyCmd{Context: ctx},
 		&game.AddEntryCmd{Context: ctx},
 		&game.GetGameCmd{Context: ctx},
+		&game.ForkGameCmd{Context: ctx},
+		&game.StoryTreeCmd{Context: ctx},
 		&game.TriggerVoteCmd{Context: ctx},
 		&game.VoteCmd{Context: ctx},
+		&chat.SayCmd{Context: ctx},
+		&chat.ChatCmd{Context: ctx},
+		&prompt.ListPromptsCmd{Context: ctx},
+		&prompt.AddPromptCmd{Context: ctx},
+		&prompt.EditPromptCmd{Context: ctx},
+		&prompt.DeletePromptCmd{Context: ctx},
 		&admin.BanCmd{Context: ctx},
 		&admin.KickCmd{Context: ctx},
+		&admin.MuteCmd{Context: ctx},
+		&admin.UnmuteCmd{Context: ctx},
 		&admin.PromoteCmd{Context: ctx},
+		&webhook.WebhookCmd{Context: ctx},
+		&bot.BotCmd{Context: ctx},
+		&serveradmin.ServerAdminCmd{Context: ctx},
 	}
 	for _, command := range commands {
 		rootCmd.AddCommand(command.Command())